@@ -21,10 +21,14 @@
 package archive
 
 import (
+	"archive/zip"
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"strings"
+
+	"github.com/bodgit/sevenzip"
 )
 
 // FileInfo contains information about a file in an archive.
@@ -35,7 +39,9 @@ type FileInfo struct {
 
 // Archive provides read access to files within an archive.
 type Archive interface {
-	// List returns all files in the archive.
+	// List returns all files in the archive. Members whose names are unsafe
+	// to extract, being absolute or climbing out with "..", are left out so
+	// the rest stay usable; Open rejects them with UnsafePathError.
 	List() ([]FileInfo, error)
 
 	// Open opens a file within the archive for reading.
@@ -43,8 +49,9 @@ type Archive interface {
 	Open(internalPath string) (io.ReadCloser, int64, error)
 
 	// OpenReaderAt opens a file and returns an io.ReaderAt interface.
-	// The file contents are buffered in memory to support random access.
-	// The returned Closer must be called to release resources.
+	// Small files are buffered in memory to support random access; 7z and RAR
+	// archives stream files above their spill threshold through a temporary
+	// file. The returned Closer must be called to release resources.
 	OpenReaderAt(internalPath string) (io.ReaderAt, int64, io.Closer, error)
 
 	// Close closes the archive.
@@ -53,47 +60,67 @@ type Archive interface {
 
 // Open opens an archive file based on its extension.
 // Supported formats: .zip, .7z, .rar
-func Open(path string) (Archive, error) {
+func Open(path string, opts ...Option) (Archive, error) {
 	ext := strings.ToLower(filepath.Ext(path))
 
 	switch ext {
 	case ".zip":
-		return OpenZIP(path)
+		return OpenZIP(path, opts...)
 	case ".7z":
-		return OpenSevenZip(path)
+		return OpenSevenZip(path, opts...)
 	case ".rar":
-		return OpenRAR(path)
+		return OpenRAR(path, opts...)
 	default:
 		return nil, FormatError{Format: ext}
 	}
 }
 
-// IsArchiveExtension checks if an extension is a supported archive format.
-func IsArchiveExtension(ext string) bool {
-	ext = strings.ToLower(ext)
+// OpenReader opens an archive held by reader, such as one stored inside
+// another archive. The format comes from the extension of name, which is
+// also used in errors. Closing the returned archive does not close reader.
+func OpenReader(reader io.ReaderAt, size int64, name string, opts ...Option) (Archive, error) {
+	ext := strings.ToLower(filepath.Ext(name))
+
 	switch ext {
-	case ".zip", ".7z", ".rar":
-		return true
+	case ".zip":
+		zipReader, err := zip.NewReader(reader, size)
+		if err != nil {
+			return nil, fmt.Errorf("open ZIP archive: %w", err)
+		}
+		return &ZIPArchive{reader: zipReader, path: name, opts: newOptions(opts)}, nil
+	case ".7z":
+		szReader, err := sevenzip.NewReader(reader, size)
+		if err != nil {
+			return nil, fmt.Errorf("open 7z archive: %w", err)
+		}
+		return &SevenZipArchive{reader: szReader, path: name, opts: newOptions(opts)}, nil
+	case ".rar":
+		return &RARArchive{reader: reader, path: name, size: size, opts: newOptions(opts)}, nil
 	default:
-		return false
+		return nil, FormatError{Format: ext}
 	}
 }
 
+// IsArchiveExtension checks if an extension is a supported archive format.
+func IsArchiveExtension(ext string) bool {
+	return slices.Contains(archiveExtensions, strings.ToLower(ext))
+}
+
+// Extensions returns the supported archive extensions, such as ".zip". The
+// returned slice is a copy and may be modified.
+func Extensions() []string {
+	return slices.Clone(archiveExtensions)
+}
+
 // nopCloser wraps a value that doesn't need closing.
 type nopCloser struct{}
 
 func (nopCloser) Close() error { return nil }
 
-// bufferFile reads the entire file into memory and returns a ReaderAt.
+// bufferReader reads size bytes from reader into memory.
 //
 //nolint:revive // 4 return values is necessary for this interface pattern
-func bufferFile(arc Archive, internalPath string) (io.ReaderAt, int64, io.Closer, error) {
-	reader, size, err := arc.Open(internalPath)
-	if err != nil {
-		return nil, 0, nil, fmt.Errorf("open file in archive: %w", err)
-	}
-	defer func() { _ = reader.Close() }()
-
+func bufferReader(reader io.Reader, size int64) (io.ReaderAt, int64, io.Closer, error) {
 	data := make([]byte, size)
 	bytesRead, err := io.ReadFull(reader, data)
 	if err != nil {
@@ -25,9 +25,11 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ZaparooProject/go-gameid/archive"
+	"github.com/ZaparooProject/go-gameid/iso9660"
 )
 
 // createTestZIP creates a ZIP archive in tmpDir with the given files.
@@ -561,3 +563,201 @@ func TestOpenReaderAt_NonExistent(t *testing.T) {
 		t.Error("expected error for non-existent file in OpenReaderAt")
 	}
 }
+
+func TestOpenReaderAt_SpillsLargeDiscImage(t *testing.T) {
+	t.Parallel()
+
+	// disc.7z stores a 43008-byte ISO9660 image, well above this threshold
+	arc, err := archive.Open("../testdata/archive/disc.7z", archive.WithSpillThreshold(16<<10))
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer func() { _ = arc.Close() }()
+
+	readerAt, size, closer, err := arc.OpenReaderAt("disc.iso")
+	if err != nil {
+		t.Fatalf("open reader at: %v", err)
+	}
+	defer func() { _ = closer.Close() }()
+
+	if size != 43008 {
+		t.Fatalf("got size %d, want 43008", size)
+	}
+
+	iso, err := iso9660.OpenReader(readerAt, size)
+	if err != nil {
+		t.Fatalf("parse archived ISO: %v", err)
+	}
+	if got := strings.TrimRight(iso.GetVolumeID(), "\x00 "); got != "ARCHIVED_DISC" {
+		t.Errorf("got volume ID %q, want %q", got, "ARCHIVED_DISC")
+	}
+	data, err := iso.ReadFileByPath("SYSTEM.CNF")
+	if err != nil {
+		t.Fatalf("read SYSTEM.CNF: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("BOOT = cdrom:")) {
+		t.Errorf("got SYSTEM.CNF %q, want a BOOT line", data)
+	}
+}
+
+func TestOpenReaderAt_SpillMatchesBuffered(t *testing.T) {
+	t.Parallel()
+
+	archivePaths := []string{
+		"../testdata/archive/snes.7z", "../testdata/archive/snes.rar", "../testdata/archive/snes.zip",
+	}
+
+	for _, archivePath := range archivePaths {
+		t.Run(filepath.Ext(archivePath), func(t *testing.T) {
+			t.Parallel()
+
+			read := func(threshold int64) []byte {
+				t.Helper()
+
+				arc, err := archive.Open(archivePath, archive.WithSpillThreshold(threshold))
+				if err != nil {
+					t.Fatalf("open archive: %v", err)
+				}
+				defer func() { _ = arc.Close() }()
+
+				readerAt, size, closer, err := arc.OpenReaderAt("240pSuite.sfc")
+				if err != nil {
+					t.Fatalf("open reader at: %v", err)
+				}
+				defer func() { _ = closer.Close() }()
+
+				// Read the SNES header first, then the start, as an identifier would
+				buf := make([]byte, size)
+				if _, err := readerAt.ReadAt(buf[0x7FC0:0x8000], 0x7FC0); err != nil {
+					t.Fatalf("read header: %v", err)
+				}
+				if _, err := readerAt.ReadAt(buf[:0x7FC0], 0); err != nil {
+					t.Fatalf("read start: %v", err)
+				}
+				if _, err := readerAt.ReadAt(buf[0x8000:], 0x8000); err != nil {
+					t.Fatalf("read rest: %v", err)
+				}
+				if n, err := readerAt.ReadAt(make([]byte, 16), size-8); n != 8 || !errors.Is(err, io.EOF) {
+					t.Errorf("ReadAt past end = %d, %v; want 8, io.EOF", n, err)
+				}
+				return buf
+			}
+
+			if !bytes.Equal(read(64<<10), read(0)) {
+				t.Error("spilled contents differ from buffered contents")
+			}
+		})
+	}
+}
+
+// TestOpenReaderAt_SpillsLargeZIPMember checks that ZIP members above the
+// spill threshold are decompressed into a temporary file, not memory.
+//
+//nolint:paralleltest // t.Setenv redirects the spill file
+func TestOpenReaderAt_SpillsLargeZIPMember(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+
+	arc, err := archive.Open("../testdata/archive/snes.zip", archive.WithSpillThreshold(64<<10))
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer func() { _ = arc.Close() }()
+
+	_, _, closer, err := arc.OpenReaderAt("240pSuite.sfc")
+	if err != nil {
+		t.Fatalf("open reader at: %v", err)
+	}
+	spills, err := filepath.Glob(filepath.Join(tmpDir, "gameid-archive-*"))
+	if err != nil {
+		t.Fatalf("glob spill files: %v", err)
+	}
+	if len(spills) != 1 {
+		t.Errorf("got %d spill files, want 1", len(spills))
+	}
+
+	if err := closer.Close(); err != nil {
+		t.Fatalf("close reader: %v", err)
+	}
+	if spills, _ = filepath.Glob(filepath.Join(tmpDir, "gameid-archive-*")); len(spills) != 0 {
+		t.Errorf("spill files left after Close: %v", spills)
+	}
+}
+
+func TestOpen_MaxFileSize(t *testing.T) {
+	t.Parallel()
+
+	zipPath := createTestZIP(t, t.TempDir(), "bomb.zip", map[string][]byte{
+		"big.gba":   make([]byte, 4096),
+		"small.gba": make([]byte, 512),
+	})
+
+	arc, err := archive.Open(zipPath, archive.WithMaxFileSize(1024))
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer func() { _ = arc.Close() }()
+
+	var tooLarge archive.FileTooLargeError
+	if _, _, err := arc.Open("big.gba"); !errors.As(err, &tooLarge) {
+		t.Fatalf("Open() error = %v, want FileTooLargeError", err)
+	}
+	if tooLarge.Size != 4096 || tooLarge.Max != 1024 {
+		t.Errorf("FileTooLargeError = %+v, want Size 4096 and Max 1024", tooLarge)
+	}
+	if _, _, _, err := arc.OpenReaderAt("big.gba"); !errors.As(err, &tooLarge) {
+		t.Errorf("OpenReaderAt() error = %v, want FileTooLargeError", err)
+	}
+
+	reader, size, err := arc.Open("small.gba")
+	if err != nil {
+		t.Fatalf("Open() under the limit error = %v", err)
+	}
+	defer func() { _ = reader.Close() }()
+	data, err := io.ReadAll(reader)
+	if err != nil || int64(len(data)) != size {
+		t.Errorf("ReadAll() = %d bytes, %v; want %d bytes", len(data), err, size)
+	}
+}
+
+func TestList_SkipsUnsafeNames(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"../../etc/passwd", "/abs/game.gba", `roms\..\..\game.gba`, `C:\game.gba`}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			zipPath := createTestZIP(t, t.TempDir(), "unsafe.zip", map[string][]byte{
+				"game.gba": make([]byte, 100),
+				name:       []byte("payload"),
+			})
+
+			arc, err := archive.Open(zipPath)
+			if err != nil {
+				t.Fatalf("open archive: %v", err)
+			}
+			defer func() { _ = arc.Close() }()
+
+			files, err := arc.List()
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if len(files) != 1 || files[0].Name != "game.gba" {
+				t.Errorf("List() = %v, want only game.gba", files)
+			}
+			if game, err := archive.DetectGameFile(arc); err != nil || game != "game.gba" {
+				t.Errorf("DetectGameFile() = %q, %v, want game.gba", game, err)
+			}
+
+			var unsafeErr archive.UnsafePathError
+			if _, _, err := arc.Open(name); !errors.As(err, &unsafeErr) {
+				t.Fatalf("Open() error = %v, want UnsafePathError", err)
+			}
+			if unsafeErr.Name != name {
+				t.Errorf("UnsafePathError.Name = %q, want %q", unsafeErr.Name, name)
+			}
+		})
+	}
+}
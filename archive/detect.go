@@ -24,45 +24,53 @@ import (
 	"strings"
 )
 
-// gameExtensions are file extensions that indicate cartridge-based game files.
-// This only includes unambiguous extensions that can be identified without header analysis.
-var gameExtensions = map[string]bool{
+// gameExtensions maps file extensions that indicate cartridge-based game
+// files to the console they belong to. Console names match the root
+// package's Console values. This only includes unambiguous extensions that
+// can be identified without header analysis.
+var gameExtensions = map[string]string{
 	// Game Boy / Game Boy Color
-	".gb":  true,
-	".gbc": true,
+	".gb":  "GB",
+	".gbc": "GBC",
 
 	// Game Boy Advance
-	".gba": true,
-	".srl": true,
+	".gba": "GBA",
+	".srl": "GBA",
 
 	// Nintendo 64
-	".n64": true,
-	".z64": true,
-	".v64": true,
-	".ndd": true,
+	".n64": "N64",
+	".z64": "N64",
+	".v64": "N64",
+	".ndd": "N64",
 
 	// NES
-	".nes": true,
-	".fds": true,
-	".unf": true,
-	".nez": true,
+	".nes": "NES",
+	".fds": "FDS",
+	".unf": "NES",
+	".nez": "NES",
 
 	// SNES
-	".sfc": true,
-	".smc": true,
-	".swc": true,
+	".sfc": "SNES",
+	".smc": "SNES",
+	".swc": "SNES",
 
 	// Genesis / Mega Drive
-	".gen": true,
-	".md":  true,
-	".smd": true,
+	".gen": "Genesis",
+	".md":  "Genesis",
+	".smd": "Genesis",
+}
+
+// GameFile is a candidate game file found in an archive.
+type GameFile struct {
+	Name    string // Full path within archive
+	Console string // Console implied by the file extension
 }
 
 // IsGameFile checks if a filename has a recognized game file extension.
 // This only returns true for cartridge-based game extensions.
 func IsGameFile(filename string) bool {
 	ext := strings.ToLower(filepath.Ext(filename))
-	return gameExtensions[ext]
+	return gameExtensions[ext] != ""
 }
 
 // DetectGameFile finds the first game file in an archive.
@@ -82,3 +90,25 @@ func DetectGameFile(arc Archive) (string, error) {
 
 	return "", NoGameFilesError{Archive: "archive"}
 }
+
+// DetectGameFiles returns every game file in an archive, in archive order,
+// along with the console its extension implies. Callers can use it to choose
+// between games for different consoles instead of taking the first match.
+func DetectGameFiles(arc Archive) ([]GameFile, error) {
+	files, err := arc.List()
+	if err != nil {
+		return nil, fmt.Errorf("list archive files: %w", err)
+	}
+
+	var games []GameFile
+	for _, file := range files {
+		if console := gameExtensions[strings.ToLower(filepath.Ext(file.Name))]; console != "" {
+			games = append(games, GameFile{Name: file.Name, Console: console})
+		}
+	}
+	if len(games) == 0 {
+		return nil, NoGameFilesError{Archive: "archive"}
+	}
+
+	return games, nil
+}
@@ -20,6 +20,8 @@ package archive_test
 
 import (
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/ZaparooProject/go-gameid/archive"
@@ -167,3 +169,54 @@ func TestDetectGameFile_MultipleGames(t *testing.T) {
 		t.Errorf("returned path %q is not a game file", gamePath)
 	}
 }
+
+func TestDetectGameFiles_MixedConsoles(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	files := map[string][]byte{
+		"readme.txt":      []byte("readme"),
+		"snes/game.sfc":   make([]byte, 200),
+		"gameboy/game.gb": make([]byte, 100),
+	}
+	zipPath := createTestZIP(t, tmpDir, "mixed.zip", files)
+
+	arc, err := archive.Open(zipPath)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer func() { _ = arc.Close() }()
+
+	games, err := archive.DetectGameFiles(arc)
+	if err != nil {
+		t.Fatalf("detect game files: %v", err)
+	}
+
+	// ZIP iteration order follows the map, so compare by name
+	slices.SortFunc(games, func(a, b archive.GameFile) int { return strings.Compare(a.Name, b.Name) })
+	want := []archive.GameFile{
+		{Name: "gameboy/game.gb", Console: "GB"},
+		{Name: "snes/game.sfc", Console: "SNES"},
+	}
+	if !slices.Equal(games, want) {
+		t.Errorf("got %+v, want %+v", games, want)
+	}
+}
+
+func TestDetectGameFiles_NoGames(t *testing.T) {
+	t.Parallel()
+
+	zipPath := createTestZIP(t, t.TempDir(), "nogames.zip", map[string][]byte{"readme.txt": []byte("readme")})
+
+	arc, err := archive.Open(zipPath)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer func() { _ = arc.Close() }()
+
+	var noGamesErr archive.NoGameFilesError
+	if _, err := archive.DetectGameFiles(arc); !errors.As(err, &noGamesErr) {
+		t.Errorf("expected NoGameFilesError, got %v", err)
+	}
+}
@@ -18,7 +18,10 @@
 
 package archive
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // FormatError indicates an unsupported or invalid archive format.
 type FormatError struct {
@@ -43,6 +46,34 @@ func (e FileNotFoundError) Error() string {
 	return fmt.Sprintf("file %q not found in archive %q", e.InternalPath, e.Archive)
 }
 
+// FileTooLargeError indicates an archive member exceeds the configured
+// maximum uncompressed size. Size is -1 when the member's header understated
+// its size and the overrun was found while decompressing.
+type FileTooLargeError struct {
+	Archive      string
+	InternalPath string
+	Size         int64
+	Max          int64
+}
+
+func (e FileTooLargeError) Error() string {
+	if e.Size < 0 {
+		return fmt.Sprintf("file %q in archive %q decompresses to more than %d bytes", e.InternalPath, e.Archive, e.Max)
+	}
+	return fmt.Sprintf("file %q in archive %q is %d bytes (max %d)", e.InternalPath, e.Archive, e.Size, e.Max)
+}
+
+// UnsafePathError indicates an archive member name is absolute or climbs out
+// of the archive root with "..".
+type UnsafePathError struct {
+	Archive string
+	Name    string
+}
+
+func (e UnsafePathError) Error() string {
+	return fmt.Sprintf("unsafe file name %q in archive %q", e.Name, e.Archive)
+}
+
 // NoGameFilesError indicates no game files were found in the archive.
 type NoGameFilesError struct {
 	Archive string
@@ -52,6 +83,21 @@ func (e NoGameFilesError) Error() string {
 	return fmt.Sprintf("no game files found in archive %q", e.Archive)
 }
 
+// AmbiguousGameFilesError indicates an archive holds games for more than one
+// console, so no single file can be chosen automatically.
+type AmbiguousGameFilesError struct {
+	Archive string
+	Files   []GameFile
+}
+
+func (e AmbiguousGameFilesError) Error() string {
+	choices := make([]string, len(e.Files))
+	for idx, file := range e.Files {
+		choices[idx] = fmt.Sprintf("%s (%s)", file.Name, file.Console)
+	}
+	return fmt.Sprintf("archive %q contains games for multiple consoles: %s", e.Archive, strings.Join(choices, ", "))
+}
+
 // DiscNotSupportedError indicates disc-based games in archives are not supported.
 type DiscNotSupportedError struct {
 	Console string
@@ -60,3 +106,13 @@ type DiscNotSupportedError struct {
 func (e DiscNotSupportedError) Error() string {
 	return fmt.Sprintf("disc-based games (%s) in archives are not supported", e.Console)
 }
+
+// NestingDepthError indicates a path nests archives deeper than allowed.
+type NestingDepthError struct {
+	Path string
+	Max  int
+}
+
+func (e NestingDepthError) Error() string {
+	return fmt.Sprintf("archive path %q nests more than %d archives", e.Path, e.Max)
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package archive
+
+import (
+	"io"
+	"path"
+	"strings"
+)
+
+// checkMemberName rejects member names that would escape a destination
+// directory if written out: absolute paths, drive letters, and ".." segments.
+// Both slash styles are checked since archives created on Windows may use
+// backslashes.
+func checkMemberName(archivePath, name string) error {
+	cleaned := strings.ReplaceAll(name, `\`, "/")
+	unsafe := path.IsAbs(cleaned) || (len(cleaned) >= 2 && cleaned[1] == ':')
+	for _, segment := range strings.Split(cleaned, "/") {
+		if segment == ".." {
+			unsafe = true
+		}
+	}
+	if unsafe {
+		return UnsafePathError{Archive: archivePath, Name: name}
+	}
+	return nil
+}
+
+// guardMember applies the size limit to an opened member. The declared size
+// is checked up front and the reader fails once it yields more than the
+// limit, in case the header understates the real size.
+func (o options) guardMember(
+	archivePath, name string, size int64, reader io.ReadCloser,
+) (io.ReadCloser, error) {
+	if o.maxFileSize <= 0 {
+		return reader, nil
+	}
+	if size > o.maxFileSize {
+		_ = reader.Close()
+		return nil, FileTooLargeError{Archive: archivePath, InternalPath: name, Size: size, Max: o.maxFileSize}
+	}
+	return &limitedReader{
+		ReadCloser: reader,
+		err:        FileTooLargeError{Archive: archivePath, InternalPath: name, Size: -1, Max: o.maxFileSize},
+		remaining:  o.maxFileSize,
+	}, nil
+}
+
+// limitedReader fails with err once more than the limit has been read.
+type limitedReader struct {
+	io.ReadCloser
+
+	err       error
+	remaining int64
+}
+
+func (lr *limitedReader) Read(buf []byte) (int, error) {
+	if lr.remaining < 0 {
+		return 0, lr.err
+	}
+	// Read one byte past the limit so an oversized member is detected
+	if int64(len(buf)) > lr.remaining+1 {
+		buf = buf[:lr.remaining+1]
+	}
+	n, err := lr.ReadCloser.Read(buf)
+	lr.remaining -= int64(n)
+	if lr.remaining < 0 {
+		return n + int(lr.remaining), lr.err
+	}
+	return n, err //nolint:wrapcheck // Read errors, including io.EOF, pass through unchanged
+}
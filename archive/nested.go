@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// OpenPath opens the archive a parsed path refers to. Nested archives are
+// opened in turn from their parent's OpenReaderAt, so an inner archive larger
+// than the spill threshold is read from a temporary file rather than memory,
+// whatever the format of its parent.
+// Closing the returned archive closes every layer.
+func OpenPath(path *Path, opts ...Option) (Archive, error) {
+	if len(path.Nested)+1 > MaxNestingDepth {
+		return nil, NestingDepthError{Path: path.ArchivePath, Max: MaxNestingDepth}
+	}
+
+	arc, err := Open(path.ArchivePath, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(path.Nested) == 0 {
+		return arc, nil
+	}
+
+	chain := &nestedArchive{closers: []io.Closer{arc}}
+	name := path.ArchivePath
+	for _, inner := range path.Nested {
+		reader, size, closer, openErr := arc.OpenReaderAt(inner)
+		if openErr != nil {
+			_ = chain.Close()
+			return nil, fmt.Errorf("open nested archive %s: %w", inner, openErr)
+		}
+		chain.closers = append(chain.closers, closer)
+
+		name += "/" + inner
+		if arc, err = OpenReader(reader, size, name, opts...); err != nil {
+			_ = chain.Close()
+			return nil, fmt.Errorf("open nested archive %s: %w", inner, err)
+		}
+		chain.closers = append(chain.closers, arc)
+	}
+	chain.Archive = arc
+
+	return chain, nil
+}
+
+// nestedArchive is the innermost archive of a chain, holding the outer layers
+// open until it is closed.
+type nestedArchive struct {
+	Archive
+
+	closers []io.Closer // Outermost first
+}
+
+// Close closes every layer, innermost first.
+func (na *nestedArchive) Close() error {
+	var errs []error
+	for _, closer := range slices.Backward(na.closers) {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
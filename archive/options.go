@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package archive
+
+// DefaultMaxFileSize is the largest uncompressed member Open and OpenReaderAt
+// will read. It leaves room for dual-layer DVD images while stopping a member
+// that claims or inflates to far more.
+const DefaultMaxFileSize int64 = 16 << 30
+
+// Option configures how an archive is opened.
+type Option func(*options)
+
+type options struct {
+	spillThreshold int64
+	maxFileSize    int64
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		spillThreshold: DefaultSpillThreshold,
+		maxFileSize:    DefaultMaxFileSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithSpillThreshold sets the size above which OpenReaderAt streams a file
+// through a temporary file instead of buffering it in memory. A threshold of
+// 0 or less always buffers in memory.
+func WithSpillThreshold(n int64) Option {
+	return func(o *options) {
+		o.spillThreshold = n
+	}
+}
+
+// WithMaxFileSize sets the largest uncompressed size a member may declare or
+// decompress to before reads fail with FileTooLargeError. A limit of 0 or
+// less disables the check.
+func WithMaxFileSize(n int64) Option {
+	return func(o *options) {
+		o.maxFileSize = n
+	}
+}
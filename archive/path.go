@@ -27,10 +27,16 @@ import (
 
 // Path represents a parsed archive path with optional internal path.
 type Path struct {
-	ArchivePath  string // Path to the archive file
-	InternalPath string // Path inside the archive (empty means auto-detect)
+	ArchivePath  string   // Path to the archive file
+	InternalPath string   // Path inside the innermost archive (empty means auto-detect)
+	Nested       []string // Archives opened in turn inside ArchivePath, outermost first
 }
 
+// MaxNestingDepth is the most archive layers, counting the outermost file,
+// that a path may traverse. It keeps a crafted archive-in-archive chain from
+// unpacking without bound.
+const MaxNestingDepth = 3
+
 // archiveExtensions are the supported archive extensions.
 var archiveExtensions = []string{".zip", ".7z", ".rar"}
 
@@ -66,9 +72,15 @@ func ParsePath(path string) (*Path, error) {
 				return nil, fmt.Errorf("stat archive %s: %w", archivePath, err)
 			}
 
+			nested, innerPath := splitNested(internalPath)
+			if len(nested)+1 > MaxNestingDepth {
+				return nil, NestingDepthError{Path: path, Max: MaxNestingDepth}
+			}
+
 			return &Path{
 				ArchivePath:  archivePath,
-				InternalPath: internalPath,
+				InternalPath: innerPath,
+				Nested:       nested,
 			}, nil
 		}
 	}
@@ -93,6 +105,21 @@ func ParsePath(path string) (*Path, error) {
 	return nil, nil // Not an archive path
 }
 
+// splitNested splits a path inside an archive at each segment naming another
+// archive. "roms.7z/game.sfc" yields ["roms.7z"] and "game.sfc"; a trailing
+// archive segment leaves the innermost path empty for auto-detection.
+func splitNested(internalPath string) (nested []string, innerPath string) {
+	segments := strings.Split(filepath.ToSlash(internalPath), "/")
+	start := 0
+	for idx, segment := range segments {
+		if IsArchiveExtension(filepath.Ext(segment)) {
+			nested = append(nested, strings.Join(segments[start:idx+1], "/"))
+			start = idx + 1
+		}
+	}
+	return nested, strings.Join(segments[start:], "/")
+}
+
 // IsArchivePath checks if a path references an archive.
 // This is a quick check that doesn't verify file existence.
 func IsArchivePath(path string) bool {
@@ -20,8 +20,10 @@ package archive_test
 
 import (
 	"archive/zip"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/ZaparooProject/go-gameid/archive"
@@ -75,6 +77,51 @@ func TestParsePath_ArchiveWithInternalPath(t *testing.T) {
 	}
 }
 
+func TestParsePath_Nested(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	zipPath := filepath.Join(tmpDir, "games.zip")
+	createSimpleTestZIP(t, zipPath)
+
+	tests := []struct {
+		name         string
+		path         string
+		wantInternal string
+		wantNested   []string
+	}{
+		{"inner file", zipPath + "/roms.7z/game.sfc", "game.sfc", []string{"roms.7z"}},
+		{"inner auto-detect", zipPath + "/sets/roms.7z", "", []string{"sets/roms.7z"}},
+		{"two layers", zipPath + "/a.rar/b.zip/gba/game.gba", "gba/game.gba", []string{"a.rar", "b.zip"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := archive.ParsePath(tt.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.ArchivePath != zipPath {
+				t.Errorf("ArchivePath = %q, want %q", result.ArchivePath, zipPath)
+			}
+			if result.InternalPath != tt.wantInternal {
+				t.Errorf("InternalPath = %q, want %q", result.InternalPath, tt.wantInternal)
+			}
+			if !slices.Equal(result.Nested, tt.wantNested) {
+				t.Errorf("Nested = %q, want %q", result.Nested, tt.wantNested)
+			}
+		})
+	}
+
+	_, err := archive.ParsePath(zipPath + "/a.zip/b.7z/c.rar/game.sfc")
+	var depthErr archive.NestingDepthError
+	if !errors.As(err, &depthErr) {
+		t.Errorf("ParsePath() error = %v, want NestingDepthError", err)
+	}
+}
+
 func TestParsePath_ArchiveOnly(t *testing.T) {
 	t.Parallel()
 
@@ -31,33 +31,50 @@ import (
 
 // RARArchive provides access to files in a RAR archive.
 type RARArchive struct {
-	file *os.File
-	path string
+	reader io.ReaderAt
+	closer io.Closer // Underlying file, if the archive owns one
+	path   string
+	size   int64
+	opts   options
 }
 
 // OpenRAR opens a RAR archive for reading.
-func OpenRAR(path string) (*RARArchive, error) {
+func OpenRAR(path string, opts ...Option) (*RARArchive, error) {
 	file, err := os.Open(path) //nolint:gosec // User-provided path is expected
 	if err != nil {
 		return nil, fmt.Errorf("open RAR archive: %w", err)
 	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("stat RAR archive: %w", err)
+	}
 
 	return &RARArchive{
-		file: file,
-		path: path,
+		reader: file,
+		closer: file,
+		path:   path,
+		size:   info.Size(),
+		opts:   newOptions(opts),
 	}, nil
 }
 
-// List returns all files in the RAR archive.
-func (ra *RARArchive) List() ([]FileInfo, error) {
-	// Seek to beginning
-	if _, err := ra.file.Seek(0, io.SeekStart); err != nil {
-		return nil, fmt.Errorf("seek RAR archive: %w", err)
+// newRARReader starts a fresh sequential pass over the archive. Each pass
+// reads through its own section, so open files don't share a position.
+func (ra *RARArchive) newRARReader() (*rardecode.Reader, error) {
+	reader, err := rardecode.NewReader(io.NewSectionReader(ra.reader, 0, ra.size))
+	if err != nil {
+		return nil, fmt.Errorf("create RAR reader: %w", err)
 	}
+	return reader, nil
+}
 
-	reader, err := rardecode.NewReader(ra.file)
+// List returns all files in the RAR archive, leaving out members whose
+// names are unsafe to extract.
+func (ra *RARArchive) List() ([]FileInfo, error) {
+	reader, err := ra.newRARReader()
 	if err != nil {
-		return nil, fmt.Errorf("create RAR reader: %w", err)
+		return nil, err
 	}
 
 	var files []FileInfo
@@ -74,6 +91,9 @@ func (ra *RARArchive) List() ([]FileInfo, error) {
 		if header.IsDir {
 			continue
 		}
+		if checkMemberName(ra.path, header.Name) != nil {
+			continue
+		}
 
 		files = append(files, FileInfo{
 			Name: header.Name,
@@ -85,19 +105,14 @@ func (ra *RARArchive) List() ([]FileInfo, error) {
 }
 
 // Open opens a file within the RAR archive.
-// Note: RAR archives require sequential reading, so this seeks through the archive.
+// Note: RAR archives require sequential reading, so this reads through the archive headers.
 func (ra *RARArchive) Open(internalPath string) (io.ReadCloser, int64, error) {
 	// Normalize path separators
 	internalPath = filepath.ToSlash(internalPath)
 
-	// Seek to beginning
-	if _, err := ra.file.Seek(0, io.SeekStart); err != nil {
-		return nil, 0, fmt.Errorf("seek RAR archive: %w", err)
-	}
-
-	reader, err := rardecode.NewReader(ra.file)
+	reader, err := ra.newRARReader()
 	if err != nil {
-		return nil, 0, fmt.Errorf("create RAR reader: %w", err)
+		return nil, 0, err
 	}
 
 	for {
@@ -110,8 +125,15 @@ func (ra *RARArchive) Open(internalPath string) (io.ReadCloser, int64, error) {
 		}
 
 		if strings.EqualFold(header.Name, internalPath) {
+			if err := checkMemberName(ra.path, header.Name); err != nil {
+				return nil, 0, err
+			}
 			// Wrap the reader since rardecode doesn't provide a closer
-			return &rarFileReader{reader: reader}, header.UnPackedSize, nil
+			guarded, err := ra.opts.guardMember(ra.path, header.Name, header.UnPackedSize, &rarFileReader{reader: reader})
+			if err != nil {
+				return nil, 0, err
+			}
+			return guarded, header.UnPackedSize, nil
 		}
 	}
 
@@ -122,16 +144,20 @@ func (ra *RARArchive) Open(internalPath string) (io.ReadCloser, int64, error) {
 }
 
 // OpenReaderAt opens a file and returns an io.ReaderAt interface.
-// The file contents are buffered in memory.
+// Files up to the spill threshold are buffered in memory; larger ones are
+// decompressed into a temporary file as reads reach them.
 //
 //nolint:revive // 4 return values is necessary for this interface pattern
 func (ra *RARArchive) OpenReaderAt(internalPath string) (io.ReaderAt, int64, io.Closer, error) {
-	return bufferFile(ra, internalPath)
+	return spillOrBuffer(ra.Open, internalPath, ra.opts.spillThreshold)
 }
 
 // Close closes the RAR archive.
 func (ra *RARArchive) Close() error {
-	return ra.file.Close() //nolint:wrapcheck // Close error passthrough is intentional
+	if ra.closer == nil {
+		return nil
+	}
+	return ra.closer.Close() //nolint:wrapcheck // Close error passthrough is intentional
 }
 
 // rarFileReader wraps a rardecode reader to provide io.ReadCloser.
@@ -30,24 +30,29 @@ import (
 
 // SevenZipArchive provides access to files in a 7z archive.
 type SevenZipArchive struct {
-	reader *sevenzip.ReadCloser
+	reader *sevenzip.Reader
+	closer io.Closer // Underlying files, if the archive owns them
 	path   string
+	opts   options
 }
 
 // OpenSevenZip opens a 7z archive for reading.
-func OpenSevenZip(path string) (*SevenZipArchive, error) {
+func OpenSevenZip(path string, opts ...Option) (*SevenZipArchive, error) {
 	reader, err := sevenzip.OpenReader(path)
 	if err != nil {
 		return nil, fmt.Errorf("open 7z archive: %w", err)
 	}
 
 	return &SevenZipArchive{
-		reader: reader,
+		reader: &reader.Reader,
+		closer: reader,
 		path:   path,
+		opts:   newOptions(opts),
 	}, nil
 }
 
-// List returns all files in the 7z archive.
+// List returns all files in the 7z archive, leaving out members whose
+// names are unsafe to extract.
 func (sza *SevenZipArchive) List() ([]FileInfo, error) {
 	files := make([]FileInfo, 0, len(sza.reader.File))
 
@@ -56,6 +61,9 @@ func (sza *SevenZipArchive) List() ([]FileInfo, error) {
 		if file.FileInfo().IsDir() {
 			continue
 		}
+		if checkMemberName(sza.path, file.Name) != nil {
+			continue
+		}
 
 		files = append(files, FileInfo{
 			Name: file.Name,
@@ -73,12 +81,19 @@ func (sza *SevenZipArchive) Open(internalPath string) (io.ReadCloser, int64, err
 
 	for _, file := range sza.reader.File {
 		if strings.EqualFold(file.Name, internalPath) {
+			if err := checkMemberName(sza.path, file.Name); err != nil {
+				return nil, 0, err
+			}
 			reader, err := file.Open()
 			if err != nil {
 				return nil, 0, fmt.Errorf("open file in 7z: %w", err)
 			}
-			//nolint:gosec // Safe: file sizes don't exceed int64
-			return reader, int64(file.UncompressedSize), nil
+			size := int64(file.UncompressedSize) //nolint:gosec // Safe: file sizes don't exceed int64
+			guarded, err := sza.opts.guardMember(sza.path, file.Name, size, reader)
+			if err != nil {
+				return nil, 0, err
+			}
+			return guarded, size, nil
 		}
 	}
 
@@ -89,14 +104,19 @@ func (sza *SevenZipArchive) Open(internalPath string) (io.ReadCloser, int64, err
 }
 
 // OpenReaderAt opens a file and returns an io.ReaderAt interface.
-// The file contents are buffered in memory.
+// Files up to the spill threshold are buffered in memory; larger ones are
+// decompressed into a temporary file on demand, since solid blocks can only
+// be read sequentially.
 //
 //nolint:revive // 4 return values is necessary for this interface pattern
 func (sza *SevenZipArchive) OpenReaderAt(internalPath string) (io.ReaderAt, int64, io.Closer, error) {
-	return bufferFile(sza, internalPath)
+	return spillOrBuffer(sza.Open, internalPath, sza.opts.spillThreshold)
 }
 
 // Close closes the 7z archive.
 func (sza *SevenZipArchive) Close() error {
-	return sza.reader.Close() //nolint:wrapcheck // Close error passthrough is intentional
+	if sza.closer == nil {
+		return nil
+	}
+	return sza.closer.Close() //nolint:wrapcheck // Close error passthrough is intentional
 }
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+)
+
+// DefaultSpillThreshold is the largest file OpenReaderAt buffers in memory.
+// Larger files are decompressed into a temporary file as reads reach them.
+const DefaultSpillThreshold int64 = 64 << 20
+
+// spillChunk is the minimum amount decompressed into the spill file at once,
+// so small scattered reads don't each pay for a copy.
+const spillChunk = 1 << 20
+
+// openFunc opens a file in an archive for sequential reading.
+type openFunc func(internalPath string) (io.ReadCloser, int64, error)
+
+// spillOrBuffer opens a file as an io.ReaderAt, buffering it in memory if it
+// fits within threshold and spilling it to a temporary file otherwise. Files
+// too large for a byte slice on this platform are always spilled.
+//
+//nolint:revive // 4 return values is necessary for this interface pattern
+func spillOrBuffer(open openFunc, internalPath string, threshold int64) (io.ReaderAt, int64, io.Closer, error) {
+	reader, size, err := open(internalPath)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("open file in archive: %w", err)
+	}
+	if (threshold <= 0 || size <= threshold) && size <= math.MaxInt {
+		defer func() { _ = reader.Close() }()
+		return bufferReader(reader, size)
+	}
+
+	spill, err := newSpillReaderAt(reader, size)
+	if err != nil {
+		_ = reader.Close()
+		return nil, 0, nil, err
+	}
+	return spill, size, spill, nil
+}
+
+// spillReaderAt provides random access over a sequential decompressor by
+// copying its output into a temporary file up to the furthest offset read.
+type spillReaderAt struct {
+	src    io.ReadCloser
+	tmp    *os.File
+	err    error // Sticky decompression error
+	size   int64
+	filled int64
+	mu     sync.Mutex
+}
+
+func newSpillReaderAt(src io.ReadCloser, size int64) (*spillReaderAt, error) {
+	tmp, err := os.CreateTemp("", "gameid-archive-*")
+	if err != nil {
+		return nil, fmt.Errorf("create archive spill file: %w", err)
+	}
+	return &spillReaderAt{src: src, tmp: tmp, size: size}, nil
+}
+
+func (sr *spillReaderAt) ReadAt(buf []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset: %d", off)
+	}
+	if off >= sr.size {
+		return 0, io.EOF
+	}
+	end := min(off+int64(len(buf)), sr.size)
+
+	if err := sr.fill(end); err != nil {
+		return 0, err
+	}
+
+	bytesRead, err := sr.tmp.ReadAt(buf[:end-off], off)
+	if err != nil {
+		return bytesRead, fmt.Errorf("read archive spill file: %w", err)
+	}
+	if end-off < int64(len(buf)) {
+		return bytesRead, io.EOF
+	}
+	return bytesRead, nil
+}
+
+// fill decompresses into the spill file until at least end bytes are present.
+func (sr *spillReaderAt) fill(end int64) error {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if end <= sr.filled {
+		return nil
+	}
+	if sr.err != nil {
+		return sr.err
+	}
+
+	target := min(sr.size, max(end, sr.filled+spillChunk))
+	copied, err := io.CopyN(sr.tmp, sr.src, target-sr.filled)
+	sr.filled += copied
+	if err != nil {
+		sr.err = fmt.Errorf("decompress file from archive: %w", err)
+		return sr.err
+	}
+	return nil
+}
+
+// Close releases the decompressor and removes the spill file.
+func (sr *spillReaderAt) Close() error {
+	srcErr := sr.src.Close()
+	tmpErr := sr.tmp.Close()
+	removeErr := os.Remove(sr.tmp.Name())
+	return errors.Join(srcErr, tmpErr, removeErr)
+}
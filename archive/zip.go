@@ -29,24 +29,29 @@ import (
 
 // ZIPArchive provides access to files in a ZIP archive.
 type ZIPArchive struct {
-	reader *zip.ReadCloser
+	reader *zip.Reader
+	closer io.Closer // Underlying file, if the archive owns one
 	path   string
+	opts   options
 }
 
 // OpenZIP opens a ZIP archive for reading.
-func OpenZIP(path string) (*ZIPArchive, error) {
+func OpenZIP(path string, opts ...Option) (*ZIPArchive, error) {
 	reader, err := zip.OpenReader(path)
 	if err != nil {
 		return nil, fmt.Errorf("open ZIP archive: %w", err)
 	}
 
 	return &ZIPArchive{
-		reader: reader,
+		reader: &reader.Reader,
+		closer: reader,
 		path:   path,
+		opts:   newOptions(opts),
 	}, nil
 }
 
-// List returns all files in the ZIP archive.
+// List returns all files in the ZIP archive, leaving out members whose
+// names are unsafe to extract.
 func (za *ZIPArchive) List() ([]FileInfo, error) {
 	files := make([]FileInfo, 0, len(za.reader.File))
 
@@ -55,6 +60,9 @@ func (za *ZIPArchive) List() ([]FileInfo, error) {
 		if file.FileInfo().IsDir() {
 			continue
 		}
+		if checkMemberName(za.path, file.Name) != nil {
+			continue
+		}
 
 		files = append(files, FileInfo{
 			Name: file.Name,
@@ -72,12 +80,19 @@ func (za *ZIPArchive) Open(internalPath string) (io.ReadCloser, int64, error) {
 
 	for _, file := range za.reader.File {
 		if strings.EqualFold(file.Name, internalPath) {
+			if err := checkMemberName(za.path, file.Name); err != nil {
+				return nil, 0, err
+			}
 			reader, err := file.Open()
 			if err != nil {
 				return nil, 0, fmt.Errorf("open file in ZIP: %w", err)
 			}
-			//nolint:gosec // Safe: file sizes don't exceed int64
-			return reader, int64(file.UncompressedSize64), nil
+			size := int64(file.UncompressedSize64) //nolint:gosec // Safe: file sizes don't exceed int64
+			guarded, err := za.opts.guardMember(za.path, file.Name, size, reader)
+			if err != nil {
+				return nil, 0, err
+			}
+			return guarded, size, nil
 		}
 	}
 
@@ -88,14 +103,18 @@ func (za *ZIPArchive) Open(internalPath string) (io.ReadCloser, int64, error) {
 }
 
 // OpenReaderAt opens a file and returns an io.ReaderAt interface.
-// The file contents are buffered in memory.
+// Files up to the spill threshold are buffered in memory; larger ones are
+// decompressed into a temporary file on demand.
 //
 //nolint:revive // 4 return values is necessary for this interface pattern
 func (za *ZIPArchive) OpenReaderAt(internalPath string) (io.ReaderAt, int64, io.Closer, error) {
-	return bufferFile(za, internalPath)
+	return spillOrBuffer(za.Open, internalPath, za.opts.spillThreshold)
 }
 
 // Close closes the ZIP archive.
 func (za *ZIPArchive) Close() error {
-	return za.reader.Close() //nolint:wrapcheck // Close error passthrough is intentional
+	if za.closer == nil {
+		return nil
+	}
+	return za.closer.Close() //nolint:wrapcheck // Close error passthrough is intentional
 }
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/ZaparooProject/go-gameid/archive"
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// ArchiveResult is the outcome of identifying one game file in an archive.
+// Exactly one of Result and Err is set.
+type ArchiveResult struct {
+	Result *Result
+	Err    error
+}
+
+// IdentifyArchives identifies every game file in each of the given archives,
+// using up to workers goroutines (GOMAXPROCS when workers is zero or less).
+// Each archive is handled by a single worker, which opens it once, identifies
+// its members in turn and closes it, so solid 7z archives are decompressed by
+// one goroutine while different archives proceed in parallel.
+//
+// Results are keyed by archive path plus member name, in the form Identify
+// accepts ("roms/games.zip/game.gba"). An archive that can't be opened or
+// holds no game files is reported under its own path.
+func IdentifyArchives(archivePaths []string, db *GameDatabase, workers int) map[string]ArchiveResult {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(archivePaths))
+
+	jobs := make(chan string)
+	results := make(map[string]ArchiveResult, len(archivePaths))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				found := identifyArchiveMembers(path, db)
+				mu.Lock()
+				for key, result := range found {
+					results[key] = result
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, path := range archivePaths {
+		jobs <- path
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// identifyArchiveMembers opens one archive and identifies each game file in it.
+func identifyArchiveMembers(path string, db *GameDatabase) map[string]ArchiveResult {
+	arc, err := archive.Open(path)
+	if err != nil {
+		return map[string]ArchiveResult{path: {Err: fmt.Errorf("open archive: %w", err)}}
+	}
+	defer func() { _ = arc.Close() }()
+
+	games, err := archive.DetectGameFiles(arc)
+	if err != nil {
+		return map[string]ArchiveResult{path: {Err: fmt.Errorf("detect game files: %w", err)}}
+	}
+
+	results := make(map[string]ArchiveResult, len(games))
+	for _, game := range games {
+		result, idErr := IdentifyFromArchive(arc, game.Name, identifier.Console(game.Console), db)
+		if idErr != nil {
+			idErr = fmt.Errorf("identify %s: %w", game.Name, idErr)
+		}
+		results[path+"/"+game.Name] = ArchiveResult{Result: result, Err: idErr}
+	}
+	return results
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ZaparooProject/go-gameid/archive"
+)
+
+func TestIdentifyArchives(t *testing.T) {
+	t.Parallel()
+
+	gba, err := os.ReadFile(createTestGBAFile(t, t.TempDir()))
+	if err != nil {
+		t.Fatalf("read GBA file: %v", err)
+	}
+
+	var paths []string
+	want := make(map[string]bool)
+	for i := range 5 {
+		files := map[string][]byte{fmt.Sprintf("game%d.gba", i): gba}
+		if i == 0 {
+			files["extra.gba"] = gba
+		}
+		path := writeTestZIP(t, files)
+		paths = append(paths, path)
+		for name := range files {
+			want[path+"/"+name] = true
+		}
+	}
+
+	results := IdentifyArchives(paths, nil, 2)
+	if len(results) != len(want) {
+		t.Errorf("got %d results, want %d", len(results), len(want))
+	}
+	for key := range want {
+		got, ok := results[key]
+		switch {
+		case !ok:
+			t.Errorf("missing result for %s", key)
+		case got.Err != nil:
+			t.Errorf("%s: error = %v", key, got.Err)
+		case got.Result.ID != "ATST":
+			t.Errorf("%s: ID = %q, want %q", key, got.Result.ID, "ATST")
+		}
+	}
+}
+
+func TestIdentifyArchives_ArchiveErrors(t *testing.T) {
+	t.Parallel()
+
+	broken := filepath.Join(t.TempDir(), "broken.zip")
+	if err := os.WriteFile(broken, []byte("not a zip"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	empty := writeTestZIP(t, map[string][]byte{"readme.txt": []byte("hello")})
+
+	results := IdentifyArchives([]string{broken, empty}, nil, 0)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[broken].Err == nil {
+		t.Error("broken archive: error = nil, want an open error")
+	}
+	var noGames archive.NoGameFilesError
+	if !errors.As(results[empty].Err, &noGames) {
+		t.Errorf("empty archive: error = %v, want NoGameFilesError", results[empty].Err)
+	}
+}
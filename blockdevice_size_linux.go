@@ -0,0 +1,41 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:build linux && !(mips || mipsle || mips64 || mips64le || ppc64 || ppc64le)
+
+package gameid
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// blkGetSize64 is BLKGETSIZE64, _IOR(0x12, 114, size_t), in the generic ioctl
+// encoding used by x86, ARM, RISC-V and LoongArch.
+const blkGetSize64 = 0x80001272 | uintptr(unsafe.Sizeof(uintptr(0)))<<16
+
+// blockDeviceSize returns the size in bytes of an open block device.
+func blockDeviceSize(file *os.File) (int64, error) {
+	var size uint64
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), blkGetSize64, uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0, errno
+	}
+	return int64(size), nil //nolint:gosec // Device sizes fit in int64
+}
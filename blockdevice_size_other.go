@@ -0,0 +1,32 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:build (!linux && !windows) || mips || mipsle || mips64 || mips64le || ppc64 || ppc64le
+
+package gameid
+
+import (
+	"errors"
+	"os"
+)
+
+// blockDeviceSize reports that the device size can't be queried here, so
+// callers use defaultBlockDeviceSize.
+func blockDeviceSize(*os.File) (int64, error) {
+	return 0, errors.ErrUnsupported
+}
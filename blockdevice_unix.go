@@ -21,11 +21,19 @@
 package gameid
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"strings"
 	"syscall"
+
+	"github.com/ZaparooProject/go-gameid/iso9660"
 )
 
+// identifyDevicesByPath is true on Unix, where the path-based identifiers can
+// open /dev/ nodes directly.
+const identifyDevicesByPath = true
+
 // isBlockDevice checks if the given path is a block device (e.g., /dev/sr0).
 func isBlockDevice(path string) bool {
 	// On Unix, block devices are typically in /dev/
@@ -44,3 +52,18 @@ func isBlockDevice(path string) bool {
 	// S_IFBLK = block device (0x6000 = 0o60000)
 	return stat.Mode&syscall.S_IFMT == syscall.S_IFBLK
 }
+
+// deviceReaderAt returns the reader used to identify an open device. Unix
+// device nodes accept unaligned reads, so the file is used directly.
+func deviceReaderAt(file *os.File) io.ReaderAt {
+	return file
+}
+
+// openDeviceISO parses the ISO9660 filesystem of a device.
+func openDeviceISO(path string) (*iso9660.ISO9660, error) {
+	iso, err := iso9660.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open ISO on block device: %w", err)
+	}
+	return iso, nil
+}
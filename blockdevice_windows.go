@@ -1,16 +1,136 @@
 // Copyright (c) 2025 Niema Moshiri and The Zaparoo Project.
 // SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
 
 //go:build windows
 
 package gameid
 
-// isBlockDevice checks if the given path is a block device.
-// On Windows, block devices (like CD/DVD drives) are accessed differently,
-// typically via drive letters (e.g., D:\) rather than /dev/ paths.
-// This function returns false on Windows as the /dev/ path check doesn't apply.
-func isBlockDevice(_ string) bool {
-	// Windows doesn't use /dev/ paths for block devices
-	// Physical drives are accessed via \\.\PhysicalDriveN or drive letters
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/ZaparooProject/go-gameid/iso9660"
+)
+
+// identifyDevicesByPath is false on Windows: the path-based identifiers stat
+// and open files by name, which doesn't work for \\.\ device paths, so
+// devices are always identified through a reader.
+const identifyDevicesByPath = false
+
+// ioctlDiskGetLengthInfo is IOCTL_DISK_GET_LENGTH_INFO, which fills a
+// GET_LENGTH_INFORMATION (a single LARGE_INTEGER) with the volume size.
+const ioctlDiskGetLengthInfo = 0x7405C
+
+// deviceSectorSize is the read alignment required for raw optical volumes.
+const deviceSectorSize = 2048
+
+// isBlockDevice checks if the given path names a drive in the Win32 device
+// namespace, such as \\.\D: or \\.\CdRom0. Physical drives must be opened
+// this way; a plain drive letter (D:\) is the mounted filesystem instead.
+func isBlockDevice(path string) bool {
+	const prefix = `\\.\`
+	if len(path) <= len(prefix) || !strings.EqualFold(path[:len(prefix)], prefix) {
+		return false
+	}
+	name := strings.TrimSuffix(path[len(prefix):], `\`)
+
+	if len(name) == 2 && name[1] == ':' {
+		letter := name[0] | 0x20
+		return letter >= 'a' && letter <= 'z'
+	}
+	upper := strings.ToUpper(name)
+	for _, device := range []string{"CDROM", "PHYSICALDRIVE"} {
+		if digits, ok := strings.CutPrefix(upper, device); ok && digits != "" && strings.Trim(digits, "0123456789") == "" {
+			return true
+		}
+	}
 	return false
 }
+
+// queryDiskLength asks the driver for the device size. It is a variable so
+// tests can stub the query without a physical drive.
+var queryDiskLength = func(handle syscall.Handle) (int64, error) {
+	var length int64
+	var returned uint32
+	err := syscall.DeviceIoControl(handle, ioctlDiskGetLengthInfo, nil, 0,
+		(*byte)(unsafe.Pointer(&length)), uint32(unsafe.Sizeof(length)), &returned, nil)
+	if err != nil {
+		return 0, fmt.Errorf("IOCTL_DISK_GET_LENGTH_INFO: %w", err)
+	}
+	return length, nil
+}
+
+// blockDeviceSize returns the size in bytes of an open device.
+func blockDeviceSize(file *os.File) (int64, error) {
+	return queryDiskLength(syscall.Handle(file.Fd()))
+}
+
+// deviceReaderAt wraps an open device so reads are sector aligned, as raw
+// Windows volumes reject unaligned offsets and lengths.
+func deviceReaderAt(file *os.File) io.ReaderAt {
+	return &alignedReaderAt{reader: file, align: deviceSectorSize}
+}
+
+// openDeviceISO parses the ISO9660 filesystem of a device through an aligned
+// reader.
+func openDeviceISO(path string) (*iso9660.ISO9660, error) {
+	file, err := os.Open(path) //nolint:gosec // Path from user input is expected for block device
+	if err != nil {
+		return nil, fmt.Errorf("open block device: %w", err)
+	}
+	iso, err := iso9660.OpenReaderWithCloser(deviceReaderAt(file), blockDeviceSizeOrDefault(file), file)
+	if err != nil {
+		return nil, fmt.Errorf("open ISO on block device: %w", err)
+	}
+	return iso, nil
+}
+
+// alignedReaderAt serves arbitrary reads by reading whole aligned blocks.
+type alignedReaderAt struct {
+	reader io.ReaderAt
+	align  int64
+}
+
+func (ar *alignedReaderAt) ReadAt(buf []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset: %d", off)
+	}
+	start := off - off%ar.align
+	end := off + int64(len(buf))
+	if rem := end % ar.align; rem != 0 {
+		end += ar.align - rem
+	}
+
+	block := make([]byte, end-start)
+	bytesRead, err := ar.reader.ReadAt(block, start)
+	copied := 0
+	if int64(bytesRead) > off-start {
+		copied = copy(buf, block[off-start:bytesRead])
+	}
+	if copied < len(buf) {
+		if err == nil {
+			err = io.EOF
+		}
+		return copied, err //nolint:wrapcheck // Device errors, including io.EOF, pass through unchanged
+	}
+	return copied, nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+//go:build windows
+
+package gameid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestIsBlockDevice_Windows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{`\\.\D:`, true},
+		{`\\.\e:\`, true},
+		{`\\.\CdRom0`, true},
+		{`\\.\PhysicalDrive12`, true},
+		{`D:\`, false},
+		{`\\.\`, false},
+		{`\\.\CdRom`, false},
+		{`\\.\1:`, false},
+		{`C:\games\game.iso`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isBlockDevice(tt.path); got != tt.want {
+				t.Errorf("isBlockDevice(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestIdentifyFromBlockDevice_StubbedSize verifies the size reported by the
+// device query reaches the identifier. It swaps the package-level query, so it
+// can't run in parallel.
+//
+//nolint:paralleltest // Replaces queryDiskLength for the duration of the test
+func TestIdentifyFromBlockDevice_StubbedSize(t *testing.T) {
+	original := queryDiskLength
+	t.Cleanup(func() { queryDiskLength = original })
+
+	path := filepath.Join(t.TempDir(), "drive")
+	if err := os.WriteFile(path, make([]byte, 4096), 0o600); err != nil {
+		t.Fatalf("write device stand-in: %v", err)
+	}
+
+	const dvdSize = 4_700_372_992
+	queryDiskLength = func(syscall.Handle) (int64, error) { return dvdSize, nil }
+	recorder := &sizeRecorder{}
+	if _, err := identifyFromBlockDevice(path, ConsolePS2, recorder, nil); err != nil {
+		t.Fatalf("identifyFromBlockDevice() error = %v", err)
+	}
+	if recorder.size != dvdSize {
+		t.Errorf("Identify() size = %d, want %d", recorder.size, dvdSize)
+	}
+
+	queryDiskLength = func(syscall.Handle) (int64, error) { return 0, errors.New("not a disk") }
+	if _, err := identifyFromBlockDevice(path, ConsolePS2, recorder, nil); err != nil {
+		t.Fatalf("identifyFromBlockDevice() error = %v", err)
+	}
+	if recorder.size != defaultBlockDeviceSize {
+		t.Errorf("Identify() size = %d, want fallback %d", recorder.size, defaultBlockDeviceSize)
+	}
+}
+
+func TestAlignedReaderAt(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 3*deviceSectorSize)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	reader := &alignedReaderAt{reader: bytes.NewReader(data), align: deviceSectorSize}
+
+	tests := []struct {
+		name   string
+		offset int64
+		length int
+	}{
+		{"within a sector", 10, 100},
+		{"across a boundary", 2040, 20},
+		{"whole sector", 2048, 2048},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := make([]byte, tt.length)
+			if _, err := reader.ReadAt(buf, tt.offset); err != nil {
+				t.Fatalf("ReadAt() error = %v", err)
+			}
+			if !bytes.Equal(buf, data[tt.offset:tt.offset+int64(tt.length)]) {
+				t.Error("ReadAt() returned wrong data")
+			}
+		})
+	}
+
+	n, err := reader.ReadAt(make([]byte, 20), int64(len(data))-10)
+	if n != 10 || !errors.Is(err, io.EOF) {
+		t.Errorf("ReadAt() at end = %d, %v; want 10, io.EOF", n, err)
+	}
+}
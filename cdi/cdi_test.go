@@ -0,0 +1,274 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package cdi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+// testTrack describes a track for buildImage.
+type testTrack struct {
+	data       []byte // Cooked 2048-byte sectors, pregap excluded
+	mode       int
+	sectorCode uint32
+	pregap     uint32
+}
+
+// sectorSizes maps sector size codes to stored sector sizes.
+var sectorSizes = map[uint32]int{0: 2048, 1: 2336, 2: 2352, 4: 2448}
+
+// layOutSector stores 2048 bytes of user data in a sector of the given size
+// and mode, leaving sync, header and EDC/ECC bytes as zero apart from the
+// sync pattern.
+func layOutSector(user []byte, size, mode int) []byte {
+	sector := make([]byte, size)
+	offset := 0
+	switch size {
+	case 2336:
+		offset = 8
+	case 2352, 2448:
+		copy(sector, []byte{0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00})
+		sector[15] = byte(mode)
+		offset = 16
+		if mode == Mode2 {
+			offset = 24
+		}
+	}
+	copy(sector[offset:], user)
+	return sector
+}
+
+// appendTrackDescriptor writes a track descriptor in the layout parseTrack
+// reads for version.
+func appendTrackDescriptor(buf *bytes.Buffer, version uint32, track testTrack, startLBA uint32) {
+	length := uint32(len(track.data) / 2048) //nolint:gosec // Small test track
+	le := func(value uint32) { _ = binary.Write(buf, binary.LittleEndian, value) }
+
+	le(0) // No extra data
+	buf.Write(trackStartMark)
+	buf.Write(trackStartMark)
+	buf.Write(make([]byte, 4))
+	name := "track.iso"
+	buf.WriteByte(byte(len(name)))
+	buf.WriteString(name)
+	buf.Write(make([]byte, 11+4+4))
+	le(0) // Not DiscJuggler 4
+	buf.Write(make([]byte, 2))
+	le(track.pregap)
+	le(length)
+	buf.Write(make([]byte, 6))
+	le(uint32(track.mode)) //nolint:gosec // Track mode constant
+	buf.Write(make([]byte, 12))
+	le(startLBA)
+	le(track.pregap + length)
+	buf.Write(make([]byte, 16))
+	le(track.sectorCode)
+	buf.Write(make([]byte, 29))
+	if version != Version2 {
+		buf.Write(make([]byte, 5))
+		le(0xFFFFFFFF)
+		buf.Write(make([]byte, 78))
+	}
+}
+
+// buildImage returns a CDI image holding one session per entry of sessions.
+func buildImage(t *testing.T, version uint32, sessions [][]testTrack) []byte {
+	t.Helper()
+
+	var image, desc bytes.Buffer
+	_ = binary.Write(&desc, binary.LittleEndian, uint16(len(sessions))) //nolint:gosec // Few sessions
+	lba := uint32(0)
+	for _, session := range sessions {
+		_ = binary.Write(&desc, binary.LittleEndian, uint16(len(session))) //nolint:gosec // Few tracks
+		for _, track := range session {
+			size := sectorSizes[track.sectorCode]
+			image.Write(make([]byte, int(track.pregap)*size))
+			for sector := 0; sector < len(track.data); sector += 2048 {
+				image.Write(layOutSector(track.data[sector:sector+2048], size, track.mode))
+			}
+			appendTrackDescriptor(&desc, version, track, lba+track.pregap)
+			lba += track.pregap + uint32(len(track.data)/2048) //nolint:gosec // Small test track
+		}
+		desc.Write(make([]byte, 4+8))
+		if version != Version2 {
+			desc.WriteByte(0)
+		}
+	}
+
+	headerOffset := uint32(image.Len()) //nolint:gosec // Small test image
+	image.Write(desc.Bytes())
+	if version == Version35 {
+		headerOffset = uint32(image.Len()+8) - headerOffset //nolint:gosec // Small test image
+	}
+	_ = binary.Write(&image, binary.LittleEndian, version)
+	_ = binary.Write(&image, binary.LittleEndian, headerOffset)
+	return image.Bytes()
+}
+
+// sectors returns n cooked sectors, each filled with its index plus seed.
+func sectors(n int, seed byte) []byte {
+	data := make([]byte, n*2048)
+	for i := range data {
+		data[i] = byte(i/2048) + seed
+	}
+	return data
+}
+
+func TestNewImage(t *testing.T) {
+	t.Parallel()
+
+	audio := testTrack{data: sectors(4, 0xA0), mode: ModeAudio, sectorCode: 2, pregap: 150}
+	data := sectors(20, 1)
+
+	tests := []struct {
+		name    string
+		data    testTrack
+		version uint32
+	}{
+		{name: "v2 mode1 2352", version: Version2, data: testTrack{data: data, mode: Mode1, sectorCode: 2, pregap: 150}},
+		{name: "v3 mode2 2352", version: Version3, data: testTrack{data: data, mode: Mode2, sectorCode: 2, pregap: 150}},
+		{name: "v3.5 mode2 2336", version: Version35, data: testTrack{data: data, mode: Mode2, sectorCode: 1, pregap: 2}},
+		{name: "v3.5 mode1 2048", version: Version35, data: testTrack{data: data, mode: Mode1, sectorCode: 0}},
+		{name: "v3.5 mode1 2448", version: Version35, data: testTrack{data: data, mode: Mode1, sectorCode: 4, pregap: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			raw := buildImage(t, tt.version, [][]testTrack{{audio}, {tt.data}})
+			img, err := NewImage(bytes.NewReader(raw), int64(len(raw)))
+			if err != nil {
+				t.Fatalf("NewImage() error = %v", err)
+			}
+			if img.Version() != tt.version {
+				t.Errorf("Version() = %#x, want %#x", img.Version(), tt.version)
+			}
+			if len(img.Tracks()) != 2 {
+				t.Fatalf("Tracks() = %d tracks, want 2", len(img.Tracks()))
+			}
+
+			track, err := img.DataTrack()
+			if err != nil {
+				t.Fatalf("DataTrack() error = %v", err)
+			}
+			if track.Session != 2 || track.Number != 2 || track.Mode != tt.data.mode {
+				t.Errorf("DataTrack() = session %d track %d mode %d, want session 2 track 2 mode %d",
+					track.Session, track.Number, track.Mode, tt.data.mode)
+			}
+			if want := 154 + tt.data.pregap; track.StartLBA != want {
+				t.Errorf("StartLBA = %d, want %d", track.StartLBA, want)
+			}
+
+			reader := img.SectorReader(track)
+			if reader.Size() != int64(len(data)) {
+				t.Errorf("Size() = %d, want %d", reader.Size(), len(data))
+			}
+			got, err := io.ReadAll(io.NewSectionReader(reader, 0, reader.Size()))
+			if err != nil {
+				t.Fatalf("read track: %v", err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Error("track user data does not match")
+			}
+
+			buf := make([]byte, 100)
+			if _, err := reader.ReadAt(buf, 16*2048-50); err != nil {
+				t.Fatalf("ReadAt() across sectors error = %v", err)
+			}
+			if !bytes.Equal(buf, data[16*2048-50:16*2048+50]) {
+				t.Error("ReadAt() across sectors returned the wrong bytes")
+			}
+			if n, err := reader.ReadAt(buf, reader.Size()-10); n != 10 || !errors.Is(err, io.EOF) {
+				t.Errorf("ReadAt() past end = %d, %v; want 10, io.EOF", n, err)
+			}
+		})
+	}
+}
+
+func TestDataTrack_FirstOfLastSession(t *testing.T) {
+	t.Parallel()
+
+	raw := buildImage(t, Version3, [][]testTrack{
+		{{data: sectors(2, 0), mode: Mode1, sectorCode: 0}},
+		{{data: sectors(2, 0), mode: Mode2, sectorCode: 2}, {data: sectors(2, 0), mode: Mode2, sectorCode: 2}},
+	})
+	img, err := NewImage(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		t.Fatalf("NewImage() error = %v", err)
+	}
+	track, err := img.DataTrack()
+	if err != nil {
+		t.Fatalf("DataTrack() error = %v", err)
+	}
+	if track.Number != 2 {
+		t.Errorf("DataTrack() = track %d, want 2", track.Number)
+	}
+}
+
+func TestNewImage_Invalid(t *testing.T) {
+	t.Parallel()
+
+	valid := buildImage(t, Version3, [][]testTrack{{{data: sectors(2, 0), mode: Mode1, sectorCode: 0}}})
+	footer := len(valid) - 8
+	withUint32 := func(offset int, value uint32) []byte {
+		raw := bytes.Clone(valid)
+		binary.LittleEndian.PutUint32(raw[offset:], value)
+		return raw
+	}
+	audioOnly := buildImage(t, Version3, [][]testTrack{{{data: sectors(2, 0), mode: ModeAudio, sectorCode: 2}}})
+
+	tests := []struct {
+		want error
+		name string
+		raw  []byte
+	}{
+		{name: "too small", raw: []byte{1, 2, 3}, want: ErrInvalidFooter},
+		{name: "unknown version", raw: withUint32(footer, 0x12345678), want: ErrInvalidFooter},
+		{name: "offset past end", raw: withUint32(footer+4, uint32(len(valid))), want: ErrInvalidFooter}, //nolint:gosec // Small
+		{name: "bad start mark", raw: func() []byte {
+			raw := bytes.Clone(valid)
+			raw[2*2048+2+2+4] = 0x55
+			return raw
+		}(), want: ErrInvalidDescriptor},
+		{name: "misplaced descriptor offset", raw: withUint32(footer+4, uint32(footer-20)), want: ErrInvalidDescriptor}, //nolint:gosec // Small
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := NewImage(bytes.NewReader(tt.raw), int64(len(tt.raw))); !errors.Is(err, tt.want) {
+				t.Errorf("NewImage() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	img, err := NewImage(bytes.NewReader(audioOnly), int64(len(audioOnly)))
+	if err != nil {
+		t.Fatalf("NewImage() error = %v", err)
+	}
+	if _, err := img.DataTrack(); !errors.Is(err, ErrNoDataTrack) {
+		t.Errorf("DataTrack() error = %v, want ErrNoDataTrack", err)
+	}
+}
@@ -18,10 +18,20 @@
 
 package chd
 
-// bitReader reads bits from a byte slice.
+import (
+	"errors"
+	"fmt"
+)
+
+// errBitstreamOverrun is reported when more bits are consumed than the
+// stream holds.
+var errBitstreamOverrun = errors.New("bitstream read past end of data")
+
+// bitReader reads bits from a byte slice. Reads past the end return zero
+// bits; callers check err once decoding is done.
 type bitReader struct {
 	data   []byte
-	offset int  // bit offset
+	offset int  // bit offset, including zero padding past the end
 	bits   uint // accumulated bits
 	avail  int  // bits available in accumulator
 }
@@ -39,6 +49,7 @@ func (br *bitReader) read(count int) uint32 {
 		if byteOff >= len(br.data) {
 			br.bits <<= 8
 			br.avail += 8
+			br.offset += 8
 			continue
 		}
 		br.bits = (br.bits << 8) | uint(br.data[byteOff])
@@ -53,6 +64,15 @@ func (br *bitReader) read(count int) uint32 {
 	return result
 }
 
+// err reports errBitstreamOverrun if bits beyond the end of the data have
+// been consumed. Bits peeked and then put back do not count.
+func (br *bitReader) err() error {
+	if consumed := br.offset - br.avail; consumed > len(br.data)*8 {
+		return fmt.Errorf("%w: consumed %d of %d bits", errBitstreamOverrun, consumed, len(br.data)*8)
+	}
+	return nil
+}
+
 // huffmanDecoder decodes Huffman-encoded data for CHD V5 maps.
 type huffmanDecoder struct {
 	lookup   []uint32
@@ -106,6 +126,9 @@ func (hd *huffmanDecoder) importTreeRLE(br *bitReader) error {
 		//nolint:gosec // Safe: nodeBits from Huffman tree is bounded to 0-32
 		curNode = hd.fillNodeBits(curNode, uint8(nodeBits), repCount)
 	}
+	if err := br.err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
 
 	// Build lookup table
 	return hd.buildLookup()
@@ -126,19 +149,29 @@ func (hd *huffmanDecoder) buildLookup() error {
 	// Build histogram of bit lengths
 	bithisto := make([]uint32, 33)
 	for i := range hd.numCodes {
-		if hd.nodeBits[i] <= 32 {
-			bithisto[hd.nodeBits[i]]++
+		if int(hd.nodeBits[i]) > hd.maxBits {
+			return fmt.Errorf("%w: code %d has %d bits, max %d", ErrInvalidHeader, i, hd.nodeBits[i], hd.maxBits)
 		}
+		bithisto[hd.nodeBits[i]]++
 	}
 
 	// For each code length, determine the starting code number
 	// Process from highest to lowest bit length (MAME convention)
 	var curstart uint32
 	for codelen := 32; codelen > 0; codelen-- {
-		nextstart := (curstart + bithisto[codelen]) >> 1
+		// Codes of one length must pair up into nodes of the next shorter
+		// length, or the lengths don't describe a prefix tree
+		total := curstart + bithisto[codelen]
+		if codelen != 1 && total%2 != 0 {
+			return fmt.Errorf("%w: huffman code lengths do not form a prefix tree", ErrInvalidHeader)
+		}
+		nextstart := total >> 1
 		bithisto[codelen] = curstart
 		curstart = nextstart
 	}
+	if curstart > 1 {
+		return fmt.Errorf("%w: huffman code lengths oversubscribe the tree", ErrInvalidHeader)
+	}
 
 	// Now assign canonical codes and build lookup table
 	// nodeBits stores the assigned code for each symbol
@@ -163,6 +196,9 @@ func (hd *huffmanDecoder) buildLookup() error {
 			shift := hd.maxBits - bits
 			base := int(nodeCodes[i]) << shift
 			end := int(nodeCodes[i]+1)<<shift - 1
+			if end >= len(hd.lookup) {
+				return fmt.Errorf("%w: huffman code %d overflows %d-bit table", ErrInvalidHeader, i, hd.maxBits)
+			}
 			for j := base; j <= end; j++ {
 				hd.lookup[j] = value
 			}
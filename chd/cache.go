@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package chd
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DefaultCacheBytes is the hunk cache budget used when Open is not given
+// WithCacheBytes. It holds a couple of hundred CD hunks, enough for the
+// header and filesystem reads identification performs.
+const DefaultCacheBytes = 4 << 20
+
+// hunkCache is a least-recently-used cache of decompressed hunks bounded by
+// the total number of bytes held rather than the number of hunks, since hunk
+// sizes vary widely between CD and DVD images.
+type hunkCache struct {
+	items  map[uint32]*list.Element
+	order  *list.List // Front is most recently used
+	budget int64
+	used   int64
+	mu     sync.Mutex
+}
+
+type hunkCacheEntry struct {
+	data  []byte
+	index uint32
+}
+
+func newHunkCache(budget int64) *hunkCache {
+	return &hunkCache{
+		items:  make(map[uint32]*list.Element),
+		order:  list.New(),
+		budget: budget,
+	}
+}
+
+// get returns the cached hunk and marks it as most recently used.
+func (hc *hunkCache) get(index uint32) ([]byte, bool) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	elem, ok := hc.items[index]
+	if !ok {
+		return nil, false
+	}
+	hc.order.MoveToFront(elem)
+	entry, _ := elem.Value.(*hunkCacheEntry)
+	return entry.data, true
+}
+
+// contains reports whether a hunk is cached without affecting its recency.
+func (hc *hunkCache) contains(index uint32) bool {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	_, ok := hc.items[index]
+	return ok
+}
+
+// put stores a hunk, evicting least recently used hunks until the cache fits
+// its budget. Hunks larger than the whole budget are not cached.
+func (hc *hunkCache) put(index uint32, data []byte) {
+	size := int64(len(data))
+	if hc.budget <= 0 || size > hc.budget {
+		return
+	}
+
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	if elem, ok := hc.items[index]; ok {
+		hc.order.MoveToFront(elem)
+		return
+	}
+
+	for hc.used+size > hc.budget {
+		hc.evictOldest()
+	}
+	hc.items[index] = hc.order.PushFront(&hunkCacheEntry{index: index, data: data})
+	hc.used += size
+}
+
+// evictOldest drops the least recently used hunk. The caller holds mu.
+func (hc *hunkCache) evictOldest() {
+	elem := hc.order.Back()
+	if elem == nil {
+		return
+	}
+	entry, _ := hc.order.Remove(elem).(*hunkCacheEntry)
+	delete(hc.items, entry.index)
+	hc.used -= int64(len(entry.data))
+}
+
+// usage reports the bytes and hunk count currently held.
+func (hc *hunkCache) usage() (bytes int64, hunks int) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	return hc.used, hc.order.Len()
+}
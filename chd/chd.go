@@ -24,24 +24,60 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
+	"sync"
 )
 
 // CHD represents a CHD (Compressed Hunks of Data) disc image.
 type CHD struct {
-	file    *os.File
-	header  *Header
-	hunkMap *HunkMap
-	tracks  []Track
+	file     *os.File
+	header   *Header
+	hunkMap  *HunkMap
+	tracks   []Track
+	metadata []MetadataEntry
+	opts     options
+
+	logicalOnce sync.Once
+	logical     io.ReaderAt // Data track reader backing ReadLogicalAt
+}
+
+// Option configures how Open reads a CHD file.
+type Option func(*options)
+
+type options struct {
+	cacheBytes    int64
+	prefetchHunks int
+}
+
+// WithCacheBytes bounds the memory held by the decompressed hunk cache.
+// Least recently used hunks are evicted once the budget is exceeded; a
+// budget of zero or less disables caching.
+func WithCacheBytes(n int64) Option {
+	return func(o *options) {
+		o.cacheBytes = n
+	}
+}
+
+// WithPrefetch sets how many hunks sector readers decompress ahead, in
+// parallel, once they see sequential reads. The window is limited by the
+// cache budget; zero disables prefetching.
+func WithPrefetch(hunks int) Option {
+	return func(o *options) {
+		o.prefetchHunks = hunks
+	}
 }
 
 // Open opens a CHD file and parses its header and metadata.
-func Open(path string) (*CHD, error) {
+func Open(path string, opts ...Option) (*CHD, error) {
 	file, err := os.Open(path) //nolint:gosec // Path from user input is expected
 	if err != nil {
 		return nil, fmt.Errorf("open CHD file: %w", err)
 	}
 
-	chd := &CHD{file: file}
+	chd := &CHD{file: file, opts: options{cacheBytes: DefaultCacheBytes, prefetchHunks: DefaultPrefetchHunks}}
+	for _, opt := range opts {
+		opt(&chd.opts)
+	}
 
 	if err := chd.init(); err != nil {
 		_ = file.Close()
@@ -61,7 +97,7 @@ func (c *CHD) init() error {
 	c.header = header
 
 	// Create hunk map
-	hunkMap, err := NewHunkMap(c.file, header)
+	hunkMap, err := newHunkMap(c.file, header, c.opts)
 	if err != nil {
 		return fmt.Errorf("create hunk map: %w", err)
 	}
@@ -70,6 +106,10 @@ func (c *CHD) init() error {
 	// Parse metadata for track information
 	if header.MetaOffset > 0 {
 		entries, parseErr := parseMetadata(c.file, header.MetaOffset)
+		c.metadata = make([]MetadataEntry, 0, len(entries))
+		for _, entry := range entries {
+			c.metadata = append(c.metadata, MetadataEntry{Data: entry.Data, Tag: entry.Tag, Flags: entry.Flags})
+		}
 		if parseErr != nil {
 			// Metadata parsing failure is not fatal, continue without track info
 			c.tracks = nil
@@ -88,8 +128,11 @@ func (c *CHD) init() error {
 	return nil
 }
 
-// Close closes the CHD file.
+// Close waits for background hunk prefetches and closes the CHD file.
 func (c *CHD) Close() error {
+	if c.hunkMap != nil {
+		c.hunkMap.waitPrefetch()
+	}
 	if c.file != nil {
 		if err := c.file.Close(); err != nil {
 			return fmt.Errorf("close CHD file: %w", err)
@@ -103,11 +146,84 @@ func (c *CHD) Header() *Header {
 	return c.header
 }
 
+// RequiredCodecs returns the tags of the codecs this CHD's hunks may be
+// compressed with ("cdlz", "cdzl", ...), in header order. V1-V4 files name a
+// single legacy compression type, reported as "zlib" or, for types this
+// package does not know, "legacy-N". Uncompressed files need none.
+func (c *CHD) RequiredCodecs() []string {
+	if c.header.Version < 5 {
+		switch c.header.Compression {
+		case legacyCompressionNone:
+			return nil
+		case legacyCompressionZlib, legacyCompressionZlibP:
+			return []string{codecTagToString(CodecZlib)}
+		default:
+			return []string{fmt.Sprintf("legacy-%d", c.header.Compression)}
+		}
+	}
+
+	var codecs []string
+	for _, tag := range c.header.Compressors {
+		if tag != CodecNone {
+			codecs = append(codecs, codecTagToString(tag))
+		}
+	}
+	return codecs
+}
+
+// MissingCodecs returns the entries of RequiredCodecs that had no registered
+// codec when the CHD was opened. Reading a hunk compressed with one of them
+// fails with ErrUnsupportedCodec, so a non-empty result means the CHD may not
+// be fully decodable.
+func (c *CHD) MissingCodecs() []string {
+	if c.header.Version < 5 {
+		if c.header.Compression != legacyCompressionNone && len(c.hunkMap.codecs) == 0 {
+			return c.RequiredCodecs()
+		}
+		return nil
+	}
+
+	var missing []string
+	for i, tag := range c.header.Compressors {
+		if tag != CodecNone && (i >= len(c.hunkMap.codecs) || c.hunkMap.codecs[i] == nil) {
+			missing = append(missing, codecTagToString(tag))
+		}
+	}
+	return missing
+}
+
+// VerifyHunk checks a hunk's decompressed data against the checksum stored
+// in the hunk map, returning an error wrapping ErrCorruptData on mismatch.
+func (c *CHD) VerifyHunk(index uint32) error {
+	return c.hunkMap.VerifyHunk(index)
+}
+
+// Verify checks every hunk in the file and returns the first failure.
+func (c *CHD) Verify() error {
+	for index := range c.hunkMap.NumHunks() {
+		if err := c.hunkMap.VerifyHunk(index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Tracks returns the parsed track information.
 func (c *CHD) Tracks() []Track {
 	return c.tracks
 }
 
+// Metadata returns the entries of the metadata chain in file order. If the
+// chain is damaged, the entries read before the damage are returned. The
+// slice and the entries' data are copies the caller may modify.
+func (c *CHD) Metadata() []MetadataEntry {
+	entries := make([]MetadataEntry, len(c.metadata))
+	for i, entry := range c.metadata {
+		entries[i] = MetadataEntry{Data: slices.Clone(entry.Data), Tag: entry.Tag, Flags: entry.Flags}
+	}
+	return entries
+}
+
 // Size returns the total logical size (uncompressed) of the CHD data.
 func (c *CHD) Size() int64 {
 	return int64(c.header.LogicalBytes) //nolint:gosec // LogicalBytes is bounded by file size
@@ -137,6 +253,34 @@ func (c *CHD) DataTrackSectorReader() io.ReaderAt {
 	}
 }
 
+// ReadLogicalAt reads len(p) bytes from the first data track's 2048-byte
+// logical sector space starting at off, crossing sector and hunk boundaries
+// as needed. It follows io.ReaderAt semantics: reads that run past the end of
+// the track return the bytes available along with io.EOF.
+func (c *CHD) ReadLogicalAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("%w: negative offset %d", ErrInvalidHunk, off)
+	}
+	size := c.DataTrackSize()
+	if off >= size {
+		return 0, io.EOF
+	}
+
+	c.logicalOnce.Do(func() {
+		c.logical = c.DataTrackSectorReader()
+	})
+
+	want := min(int64(len(p)), size-off)
+	n, err := c.logical.ReadAt(p[:want], off)
+	if err != nil {
+		return n, err
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
 // DataTrackSize returns the logical size of the first data track in bytes.
 // For ISO9660 parsing, this is the size in 2048-byte sectors.
 func (c *CHD) DataTrackSize() int64 {
@@ -259,12 +403,94 @@ func (c *CHD) RawSectorReader() io.ReaderAt {
 	}
 }
 
+// RawReader returns an io.ReaderAt over the CHD's logical bytes exactly as
+// stored, hunk after hunk, with no CD sector interpretation. This is the
+// view to use for hard disk CHDs; see IsHardDisk. Reads are bounded by
+// Size, and a read that runs past it returns io.EOF with the bytes before it.
+func (c *CHD) RawReader() io.ReaderAt {
+	return &rawReader{chd: c}
+}
+
+// IsHardDisk reports whether the CHD wraps a hard disk image, which MAME
+// marks with a GDDD geometry metadata entry.
+func (c *CHD) IsHardDisk() bool {
+	for _, entry := range c.metadata {
+		if entry.Tag == MetaTagGDDD {
+			return true
+		}
+	}
+	return false
+}
+
+// rawReader implements io.ReaderAt over the flat logical bytes of a CHD.
+type rawReader struct {
+	chd *CHD
+}
+
+// ReadAt copies logical bytes starting at off, crossing hunk boundaries as
+// needed.
+func (rr *rawReader) ReadAt(dest []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("%w: negative offset %d", ErrInvalidHunk, off)
+	}
+	size := rr.chd.Size()
+	if off >= size {
+		return 0, io.EOF
+	}
+
+	hunkBytes := int64(rr.chd.hunkMap.HunkBytes())
+	want := min(int64(len(dest)), size-off)
+	totalRead := int64(0)
+	for totalRead < want {
+		pos := off + totalRead
+		hunkIdx := uint32(pos / hunkBytes) //nolint:gosec // Hunk index bounded by LogicalBytes
+		hunkData, err := rr.chd.hunkMap.ReadHunk(hunkIdx)
+		if err != nil {
+			return int(totalRead), fmt.Errorf("read hunk %d: %w", hunkIdx, err)
+		}
+		start := pos % hunkBytes
+		if start >= int64(len(hunkData)) {
+			return int(totalRead), io.ErrUnexpectedEOF
+		}
+		totalRead += int64(copy(dest[totalRead:want], hunkData[start:]))
+	}
+
+	if totalRead < int64(len(dest)) {
+		return int(totalRead), io.EOF
+	}
+	return int(totalRead), nil
+}
+
+// subchannelSize is the size of the subchannel data (P-W) stored after each
+// raw sector in a 2448-byte CD unit.
+const subchannelSize = 96
+
+// SubchannelReader returns an io.ReaderAt over the 96-byte subchannel block
+// stored after each raw sector, packed back to back: offset n*96 is the
+// subchannel of sector n. Reads fail with ErrNoSubchannel if the CHD's units
+// are too small to hold subchannel data.
+func (c *CHD) SubchannelReader() io.ReaderAt {
+	return &sectorReader{
+		chd:        c,
+		sectorSize: subchannelSize,
+		rawMode:    true,
+		unitOffset: rawSectorSize,
+	}
+}
+
 // sectorReader implements io.ReaderAt for CHD sector data.
 type sectorReader struct {
 	chd            *CHD
 	sectorSize     int
-	rawMode        bool  // If true, read raw 2352-byte sectors; if false, extract 2048-byte data
+	rawMode        bool  // If true, read sectorSize bytes per unit at unitOffset; if false, extract 2048-byte data
+	unitOffset     int64 // Start of the raw region within each unit (2352 for subchannel data)
 	dataTrackStart int64 // Sector offset to the first data track (for multi-track CDs)
+
+	// Sequential access detection for read-ahead
+	seqMu      sync.Mutex
+	lastHunk   uint32
+	seqRun     int
+	seqStarted bool
 }
 
 // sectorLocation holds the computed location of a sector within CHD hunks.
@@ -282,11 +508,12 @@ func (sr *sectorReader) computeSectorLocation(offset, hunkBytes, unitBytes int64
 	sectorsPerHunk := hunkBytes / unitBytes
 
 	if sr.rawMode {
-		sector := offset / rawSectorSize
+		sectorSize := int64(sr.sectorSize)
+		sector := offset / sectorSize
 		return sectorLocation{
 			hunkIdx:        uint32(sector / sectorsPerHunk), //nolint:gosec // Sector index bounded by file size
 			sectorInHunk:   sector % sectorsPerHunk,
-			offsetInSector: offset % rawSectorSize,
+			offsetInSector: offset % sectorSize,
 		}
 	}
 
@@ -305,7 +532,7 @@ func (sr *sectorReader) extractSectorData(hunkData []byte, loc sectorLocation, u
 	sectorOffset := loc.sectorInHunk * unitBytes
 
 	if sr.rawMode {
-		return sectorOffset + loc.offsetInSector, rawSectorSize - loc.offsetInSector
+		return sectorOffset + sr.unitOffset + loc.offsetInSector, int64(sr.sectorSize) - loc.offsetInSector
 	}
 
 	// For CD CHD files, the codec returns data at a consistent offset within each unit.
@@ -335,8 +562,8 @@ func (sr *sectorReader) clampDataLength(dataStart, dataLen int64, hunkLen int, l
 	if dataStart+dataLen > int64(hunkLen) {
 		dataLen = int64(hunkLen) - dataStart
 	}
-	if sr.rawMode && dataLen > rawSectorSize-loc.offsetInSector {
-		dataLen = rawSectorSize - loc.offsetInSector
+	if sr.rawMode && dataLen > int64(sr.sectorSize)-loc.offsetInSector {
+		dataLen = int64(sr.sectorSize) - loc.offsetInSector
 	}
 	return dataLen
 }
@@ -354,6 +581,9 @@ func (sr *sectorReader) ReadAt(dest []byte, off int64) (int, error) {
 	if unitBytes == 0 {
 		unitBytes = 2448 // Default CD sector + subchannel
 	}
+	if sr.unitOffset > 0 && sr.unitOffset+int64(sr.sectorSize) > unitBytes {
+		return 0, fmt.Errorf("%w: %d-byte units", ErrNoSubchannel, unitBytes)
+	}
 
 	totalRead := 0
 	remaining := len(dest)
@@ -362,6 +592,10 @@ func (sr *sectorReader) ReadAt(dest []byte, off int64) (int, error) {
 	for remaining > 0 {
 		loc := sr.computeSectorLocation(currentOff, hunkBytes, unitBytes)
 
+		if sr.trackSequential(loc.hunkIdx) {
+			sr.chd.hunkMap.prefetch(loc.hunkIdx + 1)
+		}
+
 		hunkData, err := sr.chd.hunkMap.ReadHunk(loc.hunkIdx)
 		if err != nil {
 			if totalRead > 0 {
@@ -23,9 +23,14 @@ import (
 	"compress/flate"
 	"encoding/binary"
 	"errors"
+	"io"
 	"os"
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/ZaparooProject/go-gameid/internal/crc16"
+	"github.com/ulikunitz/xz"
 )
 
 func TestOpenSegaCDCHD(t *testing.T) {
@@ -121,6 +126,39 @@ func TestCHDSize(t *testing.T) {
 	}
 }
 
+// TestCHDMetadata verifies the Sega CD image exposes its track metadata entry.
+func TestCHDMetadata(t *testing.T) {
+	t.Parallel()
+
+	chdFile, err := Open("../testdata/SegaCD/240pSuite_USA.chd")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = chdFile.Close() }()
+
+	entries := chdFile.Metadata()
+	if len(entries) != 1 {
+		t.Fatalf("Metadata() returned %d entries, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Tag != MetaTagCHT2 || entry.TagString() != "CHT2" {
+		t.Errorf("Tag = %#x (%q), want CHT2", entry.Tag, entry.TagString())
+	}
+	track, err := parseCHT2(entry.Data)
+	if err != nil {
+		t.Fatalf("parseCHT2() error = %v", err)
+	}
+	if track.Type != "MODE1" || track.Frames != 512 {
+		t.Errorf("track = %+v, want MODE1 with 512 frames", track)
+	}
+
+	// Callers get copies, not the CHD's own buffers
+	entries[0].Data[0] = 'X'
+	if chdFile.Metadata()[0].Data[0] == 'X' {
+		t.Error("Metadata() data alias the CHD's entries")
+	}
+}
+
 // TestSectorReader verifies SectorReader returns 2048-byte sectors.
 func TestSectorReader(t *testing.T) {
 	t.Parallel()
@@ -142,6 +180,52 @@ func TestSectorReader(t *testing.T) {
 	}
 }
 
+// TestReadLogicalAt verifies that a range crossing a hunk boundary matches
+// the same bytes assembled from whole-sector reads.
+func TestReadLogicalAt(t *testing.T) {
+	t.Parallel()
+
+	chdFile, err := Open("../testdata/SegaCD/240pSuite_USA.chd")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = chdFile.Close() }()
+
+	sectorsPerHunk := int64(chdFile.header.HunkBytes / chdFile.header.UnitBytes)
+	start := chdFile.firstDataTrackSector()
+	boundary := sectorsPerHunk - start%sectorsPerHunk // First logical sector of the next hunk
+	off := boundary*2048 - 700
+	got := make([]byte, 2048+1400)
+
+	n, err := chdFile.ReadLogicalAt(got, off)
+	if err != nil || n != len(got) {
+		t.Fatalf("ReadLogicalAt() = %d, %v; want %d, nil", n, err, len(got))
+	}
+
+	sectors := chdFile.DataTrackSectorReader()
+	var want []byte
+	for sector := boundary - 1; sector <= boundary+1; sector++ {
+		buf := make([]byte, 2048)
+		if _, err := sectors.ReadAt(buf, sector*2048); err != nil {
+			t.Fatalf("sector %d ReadAt failed: %v", sector, err)
+		}
+		want = append(want, buf...)
+	}
+	want = want[2048-700 : 2048-700+len(got)]
+	if !bytes.Equal(got, want) {
+		t.Error("ReadLogicalAt() across hunk boundary does not match sector reads")
+	}
+
+	size := chdFile.DataTrackSize()
+	n, err = chdFile.ReadLogicalAt(make([]byte, 4096), size-1024)
+	if n != 1024 || !errors.Is(err, io.EOF) {
+		t.Errorf("ReadLogicalAt() at end = %d, %v; want 1024, EOF", n, err)
+	}
+	if _, err := chdFile.ReadLogicalAt(got, -1); err == nil {
+		t.Error("ReadLogicalAt() with negative offset should fail")
+	}
+}
+
 // TestFirstDataTrackOffset verifies track offset calculation.
 func TestFirstDataTrackOffset(t *testing.T) {
 	t.Parallel()
@@ -449,6 +533,21 @@ func TestGetCodecUnknown(t *testing.T) {
 	}
 }
 
+// TestRegisteredCodecs verifies the built-in codecs are listed in order.
+func TestRegisteredCodecs(t *testing.T) {
+	t.Parallel()
+
+	tags := RegisteredCodecs()
+	if !slices.IsSorted(tags) {
+		t.Errorf("RegisteredCodecs() = %v, want ascending order", tags)
+	}
+	for _, tag := range []uint32{CodecZlib, CodecLZMA, CodecZstd, CodecCDZlib} {
+		if !slices.Contains(tags, tag) {
+			t.Errorf("RegisteredCodecs() is missing %s", CodecName(tag))
+		}
+	}
+}
+
 // TestReadAtEmptyBuffer verifies ReadAt with empty buffer.
 func TestReadAtEmptyBuffer(t *testing.T) {
 	t.Parallel()
@@ -628,6 +727,580 @@ func TestLZMACodecEmptySource(t *testing.T) {
 	}
 }
 
+// compressXZ returns data compressed as an XZ stream.
+func compressXZ(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer, err := xz.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("xz.NewWriter() error = %v", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		t.Fatalf("xz write error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("xz close error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// TestXZCodecDecompress verifies XZ hunks decode directly and via the LZMA tags.
+func TestXZCodecDecompress(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte("CHD XZ hunk payload "), 200)
+	compressed := compressXZ(t, payload)
+
+	tests := []struct {
+		codec Codec
+		name  string
+	}{
+		{name: "xz", codec: &xzCodec{}},
+		{name: "lzma tag", codec: &lzmaCodec{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dst := make([]byte, len(payload))
+			n, err := tt.codec.Decompress(dst, compressed)
+			if err != nil {
+				t.Fatalf("Decompress() error = %v", err)
+			}
+			if n != len(payload) || !bytes.Equal(dst, payload) {
+				t.Errorf("Decompress() = %d bytes, want %d matching bytes", n, len(payload))
+			}
+		})
+	}
+}
+
+// TestCDLZMACodecXZSectors verifies XZ-framed sector data inside a cdlz hunk.
+func TestCDLZMACodecXZSectors(t *testing.T) {
+	t.Parallel()
+
+	const frames = 2
+	sectors := make([]byte, frames*2352)
+	for i := range sectors {
+		sectors[i] = byte(i % 251)
+	}
+	base := compressXZ(t, sectors)
+
+	// ECC bitmap (no frames flagged), 2-byte base length, base data, no subchannel
+	src := make([]byte, 1, 1+2+len(base))
+	src = binary.BigEndian.AppendUint16(src, uint16(len(base))) //nolint:gosec // Test payload is small
+	src = append(src, base...)
+
+	dst := make([]byte, frames*2448)
+	if _, err := (&cdLZMACodec{}).DecompressCD(dst, src, len(dst), frames); err != nil {
+		t.Fatalf("DecompressCD() error = %v", err)
+	}
+	for i := range frames {
+		got := dst[i*2448 : i*2448+2352]
+		want := sectors[i*2352 : (i+1)*2352]
+		if !bytes.Equal(got, want) {
+			t.Errorf("frame %d sector data mismatch", i)
+		}
+	}
+}
+
+// TestXZCodecCorrupt verifies a damaged XZ stream reports an error.
+func TestXZCodecCorrupt(t *testing.T) {
+	t.Parallel()
+
+	compressed := compressXZ(t, bytes.Repeat([]byte{0xAB}, 4096))
+	compressed[len(compressed)/2] ^= 0xFF
+
+	_, err := (&xzCodec{}).Decompress(make([]byte, 4096), compressed)
+	if !errors.Is(err, ErrDecompressFailed) {
+		t.Errorf("Decompress() error = %v, want ErrDecompressFailed", err)
+	}
+}
+
+// TestHunkCacheLRU reads more hunks than the cache budget allows and checks
+// that the cache stays bounded while returning the same data as an uncached
+// read.
+func TestHunkCacheLRU(t *testing.T) {
+	t.Parallel()
+
+	const path = "../testdata/SegaCD/240pSuite_USA.chd"
+	uncached, err := Open(path, WithCacheBytes(0))
+	if err != nil {
+		t.Fatalf("Open uncached failed: %v", err)
+	}
+	defer func() { _ = uncached.Close() }()
+
+	hunkBytes := int64(uncached.hunkMap.HunkBytes())
+	budget := 3 * hunkBytes
+	chdFile, err := Open(path, WithCacheBytes(budget))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = chdFile.Close() }()
+
+	numHunks := min(chdFile.hunkMap.NumHunks(), 12)
+	if numHunks < 4 {
+		t.Fatalf("fixture has %d hunks, need at least 4", numHunks)
+	}
+	for index := range numHunks {
+		got, err := chdFile.hunkMap.ReadHunk(index)
+		if err != nil {
+			t.Fatalf("ReadHunk(%d) failed: %v", index, err)
+		}
+		want, err := uncached.hunkMap.ReadHunk(index)
+		if err != nil {
+			t.Fatalf("uncached ReadHunk(%d) failed: %v", index, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("ReadHunk(%d) data differs from uncached read", index)
+		}
+		if used, _ := chdFile.hunkMap.cache.usage(); used > budget {
+			t.Fatalf("cache holds %d bytes after hunk %d, budget %d", used, index, budget)
+		}
+	}
+
+	used, hunks := chdFile.hunkMap.cache.usage()
+	if hunks != 3 || used != budget {
+		t.Errorf("cache usage = %d bytes / %d hunks, want %d / 3", used, hunks, budget)
+	}
+	// The most recent hunks survive; the oldest were evicted.
+	if _, ok := chdFile.hunkMap.cache.get(numHunks - 1); !ok {
+		t.Errorf("hunk %d missing from cache", numHunks-1)
+	}
+	if _, ok := chdFile.hunkMap.cache.get(0); ok {
+		t.Error("hunk 0 still cached, want evicted")
+	}
+	if _, hunks := uncached.hunkMap.cache.usage(); hunks != 0 {
+		t.Errorf("uncached CHD holds %d hunks, want 0", hunks)
+	}
+}
+
+// TestHunkCacheRecency verifies that a cache hit protects a hunk from eviction.
+func TestHunkCacheRecency(t *testing.T) {
+	t.Parallel()
+
+	cache := newHunkCache(20)
+	cache.put(1, make([]byte, 10))
+	cache.put(2, make([]byte, 10))
+	if _, ok := cache.get(1); !ok {
+		t.Fatal("hunk 1 missing")
+	}
+	cache.put(3, make([]byte, 10))
+
+	if _, ok := cache.get(2); ok {
+		t.Error("hunk 2 cached, want evicted as least recently used")
+	}
+	if _, ok := cache.get(1); !ok {
+		t.Error("hunk 1 evicted, want retained after recent use")
+	}
+	cache.put(4, make([]byte, 30))
+	if used, hunks := cache.usage(); used != 20 || hunks != 2 {
+		t.Errorf("usage = %d / %d after oversized put, want 20 / 2", used, hunks)
+	}
+}
+
+// readAllSectors reads the whole data track sequentially in fixed chunks.
+func readAllSectors(tb testing.TB, chdFile *CHD) []byte {
+	tb.Helper()
+
+	reader := chdFile.SectorReader()
+	out := make([]byte, 0, chdFile.DataTrackSize())
+	buf := make([]byte, 32*1024)
+	for off := int64(0); off < chdFile.DataTrackSize(); {
+		n, err := reader.ReadAt(buf, off)
+		out = append(out, buf[:n]...)
+		off += int64(n)
+		if err != nil || n == 0 {
+			break
+		}
+	}
+	return out
+}
+
+// TestSectorReaderPrefetch verifies that sequential reads with read-ahead
+// return the same data as reads without it, and that hunks past the read
+// position end up cached.
+func TestSectorReaderPrefetch(t *testing.T) {
+	t.Parallel()
+
+	const path = "../testdata/GC/GameCube-240pSuite-1.17.chd"
+	plain, err := Open(path, WithPrefetch(0))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = plain.Close() }()
+
+	prefetched, err := Open(path, WithPrefetch(4))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = prefetched.Close() }()
+
+	want := readAllSectors(t, plain)
+	got := readAllSectors(t, prefetched)
+	if !bytes.Equal(got, want) {
+		t.Fatalf("prefetched read differs: got %d bytes, want %d", len(got), len(want))
+	}
+	if _, hunks := plain.hunkMap.cache.usage(); hunks == 0 {
+		t.Fatal("plain reader cached nothing")
+	}
+
+	// A short sequential read from a fresh reader should pull hunks ahead.
+	fresh, err := Open(path, WithPrefetch(4))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = fresh.Close() }()
+
+	sectorsPerHunk := int64(fresh.hunkMap.HunkBytes() / fresh.header.UnitBytes)
+	buf := make([]byte, 2048)
+	reader := fresh.SectorReader()
+	for hunk := range int64(sequentialRunHunks + 1) {
+		if _, err := reader.ReadAt(buf, hunk*sectorsPerHunk*2048); err != nil {
+			t.Fatalf("ReadAt hunk %d failed: %v", hunk, err)
+		}
+	}
+	fresh.hunkMap.waitPrefetch()
+	if !fresh.hunkMap.cache.contains(sequentialRunHunks + 1) {
+		t.Errorf("hunk %d not prefetched after sequential reads", sequentialRunHunks+1)
+	}
+}
+
+// BenchmarkSectorReaderSequential compares reading the whole GameCube data
+// track with and without parallel read-ahead.
+func BenchmarkSectorReaderSequential(b *testing.B) {
+	const path = "../testdata/GC/GameCube-240pSuite-1.17.chd"
+
+	for _, bench := range []struct {
+		name     string
+		prefetch int
+	}{
+		{name: "NoPrefetch", prefetch: 0},
+		{name: "Prefetch", prefetch: DefaultPrefetchHunks},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			var size int64
+			for b.Loop() {
+				chdFile, err := Open(path, WithPrefetch(bench.prefetch))
+				if err != nil {
+					b.Fatalf("Open failed: %v", err)
+				}
+				size = int64(len(readAllSectors(b, chdFile)))
+				_ = chdFile.Close()
+			}
+			b.SetBytes(size)
+		})
+	}
+}
+
+// TestExtractISO extracts the Sega CD data track and checks the image size,
+// the PVD at sector 16 and the progress reports.
+func TestExtractISO(t *testing.T) {
+	t.Parallel()
+
+	const path = "../testdata/SegaCD/240pSuite_USA.chd"
+	outPath := t.TempDir() + "/out.iso"
+
+	var calls int
+	var lastWritten, lastTotal int64
+	progress := func(written, total int64) {
+		if written < lastWritten {
+			t.Errorf("progress went backwards: %d after %d", written, lastWritten)
+		}
+		calls++
+		lastWritten, lastTotal = written, total
+	}
+	if err := ExtractISO(path, outPath, progress); err != nil {
+		t.Fatalf("ExtractISO failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath) //nolint:gosec // Test output path
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if len(data)%2048 != 0 {
+		t.Errorf("output size %d is not a whole number of sectors", len(data))
+	}
+	if int64(len(data)) != lastTotal || lastWritten != lastTotal {
+		t.Errorf("progress ended at %d/%d, output is %d bytes", lastWritten, lastTotal, len(data))
+	}
+	if calls < 2 {
+		t.Errorf("progress called %d times, want one call per chunk", calls)
+	}
+
+	const pvdOffset = 16 * 2048
+	if len(data) < pvdOffset+6 {
+		t.Fatalf("output too small: %d bytes", len(data))
+	}
+	if data[pvdOffset] != 0x01 || string(data[pvdOffset+1:pvdOffset+6]) != "CD001" {
+		t.Errorf("sector 16 = % x, want PVD", data[pvdOffset:pvdOffset+6])
+	}
+}
+
+// TestExtractISOErrors verifies missing inputs fail without leaving output behind.
+func TestExtractISOErrors(t *testing.T) {
+	t.Parallel()
+
+	outPath := t.TempDir() + "/out.iso"
+	if err := ExtractISO("../testdata/SegaCD/missing.chd", outPath); err == nil {
+		t.Error("ExtractISO() error = nil for missing CHD")
+	}
+	if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+		t.Errorf("output exists after failed extraction: %v", err)
+	}
+}
+
+// TestSubchannelReader verifies that each sector's 96 subchannel bytes are
+// read from just past its 2352 raw bytes within the 2448-byte unit.
+func TestSubchannelReader(t *testing.T) {
+	t.Parallel()
+
+	chdFile, err := Open("../testdata/SegaCD/240pSuite_USA.chd")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = chdFile.Close() }()
+
+	unitBytes := int(chdFile.header.UnitBytes)
+	if unitBytes != 2448 {
+		t.Fatalf("UnitBytes = %d, want 2448", unitBytes)
+	}
+	sectorsPerHunk := int(chdFile.hunkMap.HunkBytes()) / unitBytes
+	hunk1, err := chdFile.hunkMap.ReadHunk(1)
+	if err != nil {
+		t.Fatalf("ReadHunk(1) failed: %v", err)
+	}
+	// Mark the cached hunk so the reader is provably reading this region
+	for sector := range sectorsPerHunk {
+		sub := hunk1[sector*unitBytes+rawSectorSize : (sector+1)*unitBytes]
+		for i := range sub {
+			sub[i] = byte(sector + i)
+		}
+	}
+
+	reader := chdFile.SubchannelReader()
+	for sector := range sectorsPerHunk {
+		buf := make([]byte, subchannelSize)
+		off := int64(sectorsPerHunk+sector) * subchannelSize
+		n, err := reader.ReadAt(buf, off)
+		if err != nil || n != subchannelSize {
+			t.Fatalf("ReadAt(sector %d) = %d, %v; want %d bytes", sectorsPerHunk+sector, n, err, subchannelSize)
+		}
+		want := hunk1[sector*unitBytes+rawSectorSize : (sector+1)*unitBytes]
+		if !bytes.Equal(buf, want) {
+			t.Errorf("sector %d subchannel = % x, want % x", sectorsPerHunk+sector, buf[:8], want[:8])
+		}
+	}
+
+	// A read spanning two sectors continues with the next sector's block
+	buf := make([]byte, 2*subchannelSize)
+	if _, err := reader.ReadAt(buf, int64(sectorsPerHunk)*subchannelSize+10); err != nil {
+		t.Fatalf("spanning ReadAt failed: %v", err)
+	}
+	if buf[subchannelSize-10] != 1 || buf[0] != 10 {
+		t.Errorf("spanning read = % x..., want sector 0 offset 10 then sector 1", buf[:4])
+	}
+}
+
+// TestSubchannelReaderNoSubchannel verifies CHDs with small units are rejected.
+func TestSubchannelReaderNoSubchannel(t *testing.T) {
+	t.Parallel()
+
+	file := buildLegacyHeader(2, 0, 1, 1, 512)
+	file = binary.BigEndian.AppendUint64(file, 512<<44|uint64(len(file)+8))
+	file = append(file, make([]byte, 512)...)
+	path := t.TempDir() + "/hd.chd"
+	if err := os.WriteFile(path, file, 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	chdFile, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = chdFile.Close() }()
+
+	_, err = chdFile.SubchannelReader().ReadAt(make([]byte, subchannelSize), 0)
+	if !errors.Is(err, ErrNoSubchannel) {
+		t.Errorf("ReadAt() error = %v, want ErrNoSubchannel", err)
+	}
+}
+
+// TestVerifyFixtures verifies every hunk of the intact test CHDs.
+func TestVerifyFixtures(t *testing.T) {
+	t.Parallel()
+
+	paths := []string{
+		"../testdata/SegaCD/240pSuite_USA.chd",
+		"../testdata/GC/GameCube-240pSuite-1.17.chd",
+		"../testdata/NeoGeoCD/240pTestSuite.chd",
+	}
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+
+			chdFile, err := Open(path)
+			if err != nil {
+				t.Fatalf("Open failed: %v", err)
+			}
+			defer func() { _ = chdFile.Close() }()
+
+			if err := chdFile.Verify(); err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+		})
+	}
+}
+
+// TestVerifyHunkCorrupt flips a byte inside a compressed hunk and checks
+// that verification rejects it.
+func TestVerifyHunkCorrupt(t *testing.T) {
+	t.Parallel()
+
+	const srcPath = "../testdata/SegaCD/240pSuite_USA.chd"
+	chdFile, err := Open(srcPath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	var target uint32
+	var entry HunkMapEntry
+	for i, e := range chdFile.hunkMap.entries {
+		if e.CompType <= HunkCompTypeCodec3 && e.CompLength > 16 {
+			target, entry = uint32(i), e //nolint:gosec // Fixture hunk count is small
+			break
+		}
+	}
+	_ = chdFile.Close()
+	if entry.CompLength == 0 {
+		t.Fatal("no compressed hunk found in fixture")
+	}
+
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	data[entry.Offset+uint64(entry.CompLength)/2] ^= 0x55
+
+	corruptPath := t.TempDir() + "/corrupt.chd"
+	if err := os.WriteFile(corruptPath, data, 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	corrupt, err := Open(corruptPath)
+	if err != nil {
+		t.Fatalf("Open corrupt copy failed: %v", err)
+	}
+	defer func() { _ = corrupt.Close() }()
+
+	err = corrupt.VerifyHunk(target)
+	if !errors.Is(err, ErrCorruptData) && !errors.Is(err, ErrDecompressFailed) {
+		t.Errorf("VerifyHunk(%d) error = %v, want ErrCorruptData or ErrDecompressFailed", target, err)
+	}
+}
+
+// TestNewHunkMapInvalidHuffmanTree checks that code lengths which don't
+// form a prefix tree are rejected instead of building a decoder that
+// silently mis-decodes the map.
+func TestNewHunkMapInvalidHuffmanTree(t *testing.T) {
+	t.Parallel()
+
+	// 16 four-bit code lengths follow the 16-byte map header
+	trees := map[string][]byte{
+		"three 2-bit codes":   {0x22, 0x20, 0, 0, 0, 0, 0, 0},
+		"sixteen 2-bit codes": {0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22},
+		"nine 3-bit codes":    {0x33, 0x33, 0x33, 0x33, 0x30, 0, 0, 0},
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			reader, header := v5MapImage(tree, [3]byte{8, 8, 8}, 4)
+			_, err := NewHunkMap(reader, header)
+			if !errors.Is(err, ErrInvalidHeader) {
+				t.Fatalf("NewHunkMap() error = %v, want ErrInvalidHeader", err)
+			}
+			if !strings.Contains(err.Error(), "huffman") {
+				t.Errorf("NewHunkMap() error = %v, want a huffman tree error", err)
+			}
+		})
+	}
+}
+
+// TestParseMapV5Malformed checks that truncated and garbage compressed maps
+// are rejected with ErrInvalidHeader instead of panicking or decoding zeros.
+func TestParseMapV5Malformed(t *testing.T) {
+	t.Parallel()
+
+	compMap, bits, numHunks := fixtureV5Map(t)
+
+	reader, header := v5MapImage(compMap, bits, numHunks)
+	hm := &HunkMap{reader: reader, header: header, entries: make([]HunkMapEntry, numHunks)}
+	if err := hm.parseMapV5(); err != nil {
+		t.Fatalf("parseMapV5() on intact map error = %v", err)
+	}
+
+	for _, n := range []int{0, 1, 4, len(compMap) / 2, len(compMap) - 1} {
+		reader, header := v5MapImage(compMap[:n], bits, numHunks)
+		hm := &HunkMap{reader: reader, header: header, entries: make([]HunkMapEntry, numHunks)}
+		if err := hm.parseMapV5(); !errors.Is(err, ErrInvalidHeader) {
+			t.Errorf("parseMapV5() truncated to %d bytes error = %v, want ErrInvalidHeader", n, err)
+		}
+	}
+
+	garbage := map[string][]byte{
+		"all ones":        bytes.Repeat([]byte{0xFF}, 64),
+		"long codes":      {0xEE, 0xEE, 0xEE, 0xEE, 0xEE, 0xEE, 0xEE, 0xEE},
+		"oversubscribed":  {0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22},
+		"tree only, zero": make([]byte, 8),
+	}
+	for name, data := range garbage {
+		reader, header := v5MapImage(data, bits, numHunks)
+		hm := &HunkMap{reader: reader, header: header, entries: make([]HunkMapEntry, numHunks)}
+		if err := hm.parseMapV5(); !errors.Is(err, ErrInvalidHeader) {
+			t.Errorf("parseMapV5(%s) error = %v, want ErrInvalidHeader", name, err)
+		}
+	}
+
+	reader, header = v5MapImage(compMap, [3]byte{64, bits[1], bits[2]}, numHunks)
+	hm = &HunkMap{reader: reader, header: header, entries: make([]HunkMapEntry, numHunks)}
+	if err := hm.parseMapV5(); !errors.Is(err, ErrInvalidHeader) {
+		t.Errorf("parseMapV5() with 64-bit lengths error = %v, want ErrInvalidHeader", err)
+	}
+}
+
+// TestCheckHunkCRCMismatch verifies checksum mismatches for both map versions.
+func TestCheckHunkCRCMismatch(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte{0x42}, 64)
+	tests := []struct {
+		name    string
+		entry   HunkMapEntry
+		version uint32
+		wantErr bool
+	}{
+		{name: "v5 match", version: 5, entry: HunkMapEntry{CRC16: crc16.CCITT(data), HasCRC: true}},
+		{name: "v5 mismatch", version: 5, entry: HunkMapEntry{CRC16: crc16.CCITT(data) ^ 1, HasCRC: true}, wantErr: true},
+		{name: "v4 mismatch", version: 4, entry: HunkMapEntry{CRC32: 0xDEADBEEF, HasCRC: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hm := &HunkMap{header: &Header{Version: tt.version, HunkBytes: uint32(len(data))}}
+			err := hm.checkHunkCRC(0, tt.entry, data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkHunkCRC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrCorruptData) {
+				t.Errorf("checkHunkCRC() error = %v, want ErrCorruptData", err)
+			}
+		})
+	}
+}
+
 // TestCDLZMACodecSourceTooSmall verifies error for truncated source.
 func TestCDLZMACodecSourceTooSmall(t *testing.T) {
 	t.Parallel()
@@ -746,6 +1419,280 @@ func TestHeaderV3Parsing(t *testing.T) {
 	}
 }
 
+// buildLegacyHeader returns a complete V1 or V2 header with the given
+// geometry. V2 headers carry an explicit sector length.
+func buildLegacyHeader(version, compression, hunkSectors, totalHunks, sectorLen uint32) []byte {
+	size := headerSizeV1
+	if version == 2 {
+		size = headerSizeV2
+	}
+	buf := make([]byte, size)
+	copy(buf[0:8], chdMagic[:])
+	binary.BigEndian.PutUint32(buf[8:12], uint32(size)) //nolint:gosec // Constant header size
+	binary.BigEndian.PutUint32(buf[12:16], version)
+	binary.BigEndian.PutUint32(buf[20:24], compression)
+	binary.BigEndian.PutUint32(buf[24:28], hunkSectors)
+	binary.BigEndian.PutUint32(buf[28:32], totalHunks)
+	binary.BigEndian.PutUint32(buf[32:36], 10) // Cylinders
+	binary.BigEndian.PutUint32(buf[36:40], 4)  // Heads
+	binary.BigEndian.PutUint32(buf[40:44], 16) // Sectors per track
+	if version == 2 {
+		binary.BigEndian.PutUint32(buf[76:80], sectorLen)
+	}
+	return buf
+}
+
+// TestHeaderV1V2Parsing verifies hunk size and geometry from legacy headers.
+func TestHeaderV1V2Parsing(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		header        []byte
+		wantHunkBytes uint32
+		wantUnitBytes uint32
+		wantHunks     uint32
+		wantLogical   uint64
+		wantMapOffset uint64
+	}{
+		{
+			name:          "v1 fixed 512-byte sectors",
+			header:        buildLegacyHeader(1, 1, 8, 80, 0),
+			wantHunkBytes: 4096,
+			wantUnitBytes: 512,
+			wantHunks:     80,
+			wantLogical:   10 * 4 * 16 * 512,
+			wantMapOffset: headerSizeV1,
+		},
+		{
+			name:          "v2 explicit sector length",
+			header:        buildLegacyHeader(2, 0, 4, 40, 2048),
+			wantHunkBytes: 8192,
+			wantUnitBytes: 2048,
+			wantHunks:     40,
+			wantLogical:   10 * 4 * 16 * 2048,
+			wantMapOffset: headerSizeV2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			header, err := parseHeader(bytes.NewReader(tt.header))
+			if err != nil {
+				t.Fatalf("parseHeader failed: %v", err)
+			}
+			if header.HunkBytes != tt.wantHunkBytes {
+				t.Errorf("HunkBytes = %d, want %d", header.HunkBytes, tt.wantHunkBytes)
+			}
+			if header.UnitBytes != tt.wantUnitBytes {
+				t.Errorf("UnitBytes = %d, want %d", header.UnitBytes, tt.wantUnitBytes)
+			}
+			if header.TotalHunks != tt.wantHunks || header.NumHunks() != tt.wantHunks {
+				t.Errorf("TotalHunks = %d, NumHunks() = %d, want %d", header.TotalHunks, header.NumHunks(), tt.wantHunks)
+			}
+			if header.LogicalBytes != tt.wantLogical {
+				t.Errorf("LogicalBytes = %d, want %d", header.LogicalBytes, tt.wantLogical)
+			}
+			if header.MapOffset != tt.wantMapOffset {
+				t.Errorf("MapOffset = %d, want %d", header.MapOffset, tt.wantMapOffset)
+			}
+			if header.Cylinders != 10 || header.Heads != 4 || header.Sectors != 16 {
+				t.Errorf("geometry = %d/%d/%d, want 10/4/16", header.Cylinders, header.Heads, header.Sectors)
+			}
+		})
+	}
+}
+
+// TestHeaderV1V2Invalid verifies truncated and zero-sized legacy headers are rejected.
+func TestHeaderV1V2Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header []byte
+	}{
+		{name: "v2 truncated to v1 size", header: func() []byte {
+			buf := buildLegacyHeader(2, 0, 4, 40, 2048)[:headerSizeV1]
+			binary.BigEndian.PutUint32(buf[8:12], headerSizeV1)
+			return buf
+		}()},
+		{name: "v2 zero sector length", header: buildLegacyHeader(2, 0, 4, 40, 0)},
+		{name: "v1 zero hunk size", header: buildLegacyHeader(1, 0, 0, 40, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := parseHeader(bytes.NewReader(tt.header)); !errors.Is(err, ErrInvalidHeader) {
+				t.Errorf("parseHeader() error = %v, want ErrInvalidHeader", err)
+			}
+		})
+	}
+}
+
+// TestOpenV2 builds a small V2 file with one compressed and one stored hunk
+// and reads both back through Open.
+func TestOpenV2(t *testing.T) {
+	t.Parallel()
+
+	const sectorLen, hunkSectors = 512, 2
+	hunkBytes := sectorLen * hunkSectors
+	hunk0 := bytes.Repeat([]byte("CHDV2"), hunkBytes/5+1)[:hunkBytes]
+	hunk1 := make([]byte, hunkBytes)
+	for i := range hunk1 {
+		hunk1[i] = byte(i * 7)
+	}
+
+	var compressed bytes.Buffer
+	fw, err := flate.NewWriter(&compressed, flate.BestCompression)
+	if err != nil {
+		t.Fatalf("flate.NewWriter failed: %v", err)
+	}
+	_, _ = fw.Write(hunk0)
+	_ = fw.Close()
+
+	file := buildLegacyHeader(2, 1, hunkSectors, 2, sectorLen)
+	dataStart := uint64(len(file) + 2*8)
+	entry0 := uint64(compressed.Len())<<44 | dataStart
+	entry1 := uint64(hunkBytes)<<44 | (dataStart + uint64(compressed.Len()))
+	file = binary.BigEndian.AppendUint64(file, entry0)
+	file = binary.BigEndian.AppendUint64(file, entry1)
+	file = append(file, compressed.Bytes()...)
+	file = append(file, hunk1...)
+
+	path := t.TempDir() + "/v2.chd"
+	if err := os.WriteFile(path, file, 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	chdFile, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = chdFile.Close() }()
+
+	if chdFile.Header().Version != 2 {
+		t.Errorf("Version = %d, want 2", chdFile.Header().Version)
+	}
+	if len(chdFile.Tracks()) != 0 {
+		t.Errorf("Tracks() = %v, want none for V2", chdFile.Tracks())
+	}
+	for index, want := range [][]byte{hunk0, hunk1} {
+		got, err := chdFile.hunkMap.ReadHunk(uint32(index)) //nolint:gosec // Two hunks
+		if err != nil {
+			t.Fatalf("ReadHunk(%d) failed: %v", index, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("ReadHunk(%d) data mismatch", index)
+		}
+	}
+	if err := chdFile.Verify(); err != nil {
+		t.Errorf("Verify() error = %v, want nil for checksum-less hunks", err)
+	}
+}
+
+// buildHardDiskV4 returns an uncompressed V4 CHD holding data in hunks of
+// hunkBytes, with a GDDD geometry metadata entry.
+func buildHardDiskV4(data []byte, hunkBytes int) []byte {
+	numHunks := (len(data) + hunkBytes - 1) / hunkBytes
+	gddd := []byte("CYLS:1,HEADS:1,SECS:8,BPS:512\x00")
+	metaOffset := headerSizeV4 + numHunks*16
+	dataStart := metaOffset + 16 + len(gddd)
+
+	file := make([]byte, headerSizeV4)
+	copy(file[0:8], chdMagic[:])
+	binary.BigEndian.PutUint32(file[8:12], headerSizeV4)
+	binary.BigEndian.PutUint32(file[12:16], 4)
+	binary.BigEndian.PutUint32(file[24:28], uint32(numHunks))   //nolint:gosec // Small test image
+	binary.BigEndian.PutUint64(file[28:36], uint64(len(data)))  //nolint:gosec // Small test image
+	binary.BigEndian.PutUint64(file[36:44], uint64(metaOffset)) //nolint:gosec // Small test image
+	binary.BigEndian.PutUint32(file[44:48], uint32(hunkBytes))  //nolint:gosec // Small test image
+	for hunk := range numHunks {
+		entry := make([]byte, 16)
+		binary.BigEndian.PutUint64(entry[0:8], uint64(dataStart+hunk*hunkBytes)) //nolint:gosec // Small test image
+		file = append(file, entry...)
+	}
+
+	meta := make([]byte, 16)
+	binary.BigEndian.PutUint32(meta[0:4], MetaTagGDDD)
+	meta[4] = 1
+	meta[7] = byte(len(gddd))
+	file = append(file, meta...)
+	file = append(file, gddd...)
+
+	padded := make([]byte, numHunks*hunkBytes)
+	copy(padded, data)
+	return append(file, padded...)
+}
+
+// TestRawReaderHardDisk reads a hard disk CHD linearly across hunk boundaries.
+func TestRawReaderHardDisk(t *testing.T) {
+	t.Parallel()
+
+	const hunkBytes = 4096
+	data := make([]byte, 3*hunkBytes-100)
+	for i := range data {
+		data[i] = byte(i * 31 / 7)
+	}
+	path := t.TempDir() + "/hd.chd"
+	if err := os.WriteFile(path, buildHardDiskV4(data, hunkBytes), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	chdFile, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = chdFile.Close() }()
+
+	if !chdFile.IsHardDisk() {
+		t.Error("IsHardDisk() = false, want true")
+	}
+	if len(chdFile.Tracks()) != 0 {
+		t.Errorf("Tracks() = %v, want none", chdFile.Tracks())
+	}
+
+	reader := chdFile.RawReader()
+	buf := make([]byte, hunkBytes+200)
+	bytesRead, err := reader.ReadAt(buf, hunkBytes-100)
+	if err != nil {
+		t.Fatalf("ReadAt across hunks failed: %v", err)
+	}
+	if bytesRead != len(buf) || !bytes.Equal(buf, data[hunkBytes-100:2*hunkBytes+100]) {
+		t.Error("ReadAt across hunks returned the wrong bytes")
+	}
+
+	// Reads stop at the logical size, not the padded end of the last hunk
+	bytesRead, err = reader.ReadAt(buf[:200], int64(len(data)-50))
+	if !errors.Is(err, io.EOF) || bytesRead != 50 {
+		t.Errorf("ReadAt past end = %d, %v; want 50, io.EOF", bytesRead, err)
+	}
+	if !bytes.Equal(buf[:50], data[len(data)-50:]) {
+		t.Error("ReadAt past end returned the wrong bytes")
+	}
+	if _, err := reader.ReadAt(buf, int64(len(data))); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadAt at end error = %v, want io.EOF", err)
+	}
+}
+
+// TestIsHardDiskCD verifies CD images aren't reported as hard disks.
+func TestIsHardDiskCD(t *testing.T) {
+	t.Parallel()
+
+	chdFile, err := Open("../testdata/SegaCD/240pSuite_USA.chd")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer func() { _ = chdFile.Close() }()
+
+	if chdFile.IsHardDisk() {
+		t.Error("IsHardDisk() = true for a CD image")
+	}
+}
+
 // TestHeaderV3TooSmall verifies error for truncated V3 buffer.
 func TestHeaderV3TooSmall(t *testing.T) {
 	t.Parallel()
@@ -990,6 +1937,84 @@ func TestRegisterAndGetCodec(t *testing.T) {
 	}
 }
 
+// TestMissingCodecs declares an unregistered codec in an unused compressor
+// slot of a fixture and checks that it is reported as missing.
+func TestMissingCodecs(t *testing.T) {
+	t.Parallel()
+
+	const srcPath = "../testdata/SegaCD/240pSuite_USA.chd"
+	chdFile, err := Open(srcPath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	required := chdFile.RequiredCodecs()
+	missing := chdFile.MissingCodecs()
+	slot := slices.Index(chdFile.Header().Compressors[:], CodecNone)
+	_ = chdFile.Close()
+	if len(required) == 0 {
+		t.Fatal("RequiredCodecs() is empty for a compressed fixture")
+	}
+	if len(missing) != 0 {
+		t.Errorf("MissingCodecs() = %v, want none", missing)
+	}
+	if slot < 0 {
+		t.Fatal("fixture has no unused compressor slot")
+	}
+
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	binary.BigEndian.PutUint32(data[0x10+4*slot:], 0x71717171) // "qqqq"
+	patched := t.TempDir() + "/unknown-codec.chd"
+	if err := os.WriteFile(patched, data, 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	chdFile, err = Open(patched)
+	if err != nil {
+		t.Fatalf("Open patched copy failed: %v", err)
+	}
+	defer func() { _ = chdFile.Close() }()
+
+	if got := chdFile.RequiredCodecs(); !slices.Contains(got, "qqqq") || len(got) != len(required)+1 {
+		t.Errorf("RequiredCodecs() = %v, want %v plus qqqq", got, required)
+	}
+	if got := chdFile.MissingCodecs(); !slices.Equal(got, []string{"qqqq"}) {
+		t.Errorf("MissingCodecs() = %v, want [qqqq]", got)
+	}
+}
+
+// TestRequiredCodecsLegacy covers the V1-V4 compression types.
+func TestRequiredCodecsLegacy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		compression  uint32
+		wantRequired []string
+		wantMissing  []string
+	}{
+		{legacyCompressionNone, nil, nil},
+		{legacyCompressionZlib, []string{"zlib"}, nil},
+		{legacyCompressionZlibP, []string{"zlib"}, nil},
+		{3, []string{"legacy-3"}, []string{"legacy-3"}},
+	}
+
+	for _, tt := range tests {
+		header := &Header{Version: 4, Compression: tt.compression}
+		hunkMap := &HunkMap{header: header}
+		hunkMap.initLegacyCodec()
+		chdFile := &CHD{header: header, hunkMap: hunkMap}
+
+		if got := chdFile.RequiredCodecs(); !slices.Equal(got, tt.wantRequired) {
+			t.Errorf("compression %d: RequiredCodecs() = %v, want %v", tt.compression, got, tt.wantRequired)
+		}
+		if got := chdFile.MissingCodecs(); !slices.Equal(got, tt.wantMissing) {
+			t.Errorf("compression %d: MissingCodecs() = %v, want %v", tt.compression, got, tt.wantMissing)
+		}
+	}
+}
+
 //nolint:gocognit,gocyclo,cyclop,funlen,nestif,revive,govet // Debug test with extensive diagnostic output
 func TestNeoGeoCDCHD(t *testing.T) {
 	t.Parallel()
@@ -20,6 +20,7 @@ package chd
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 )
 
@@ -106,6 +107,25 @@ func GetCodec(tag uint32) (Codec, error) {
 	return factory(), nil
 }
 
+// RegisteredCodecs returns the tags of all registered codecs in ascending
+// order. Builds that leave out a codec's file do not register it.
+func RegisteredCodecs() []uint32 {
+	codecRegistryMu.RLock()
+	defer codecRegistryMu.RUnlock()
+
+	tags := make([]uint32, 0, len(codecRegistry))
+	for tag := range codecRegistry {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// CodecName returns the four-character name of a codec tag, such as "zlib".
+func CodecName(tag uint32) string {
+	return codecTagToString(tag)
+}
+
 // codecTagToString converts a codec tag to its ASCII representation.
 func codecTagToString(tag uint32) string {
 	if tag == 0 {
@@ -66,6 +66,11 @@ func (c *lzmaCodec) Decompress(dst, src []byte) (int, error) {
 		return 0, fmt.Errorf("%w: lzma: empty source", ErrDecompressFailed)
 	}
 
+	// XZ-framed data carries its own properties; decode it as such
+	if isXZStream(src) {
+		return (&xzCodec{}).Decompress(dst, src)
+	}
+
 	// Compute properties like MAME does
 	// MAME's configure_properties uses level=8, reduceSize=hunkbytes
 	// After normalization: lc=3, lp=0, pb=2, dictSize computed from reduceSize
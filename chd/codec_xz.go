@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package chd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ulikunitz/xz"
+)
+
+// xzMagic is the stream header that starts every XZ container.
+var xzMagic = []byte{0xFD, '7', 'z', 'X', 'Z', 0x00}
+
+// xzCodec implements XZ (LZMA2) decompression for CHD hunks.
+// There is no dedicated CHD tag for XZ; some tools write XZ-framed hunks
+// under the "lzma" and "cdlz" tags instead of MAME's headerless raw LZMA,
+// so the LZMA codecs hand such hunks to this codec based on the stream header.
+type xzCodec struct{}
+
+// isXZStream reports whether src starts with an XZ stream header.
+func isXZStream(src []byte) bool {
+	return bytes.HasPrefix(src, xzMagic)
+}
+
+// Decompress decompresses an XZ stream into dst.
+func (*xzCodec) Decompress(dst, src []byte) (int, error) {
+	if len(src) == 0 {
+		return 0, fmt.Errorf("%w: xz: empty source", ErrDecompressFailed)
+	}
+
+	reader, err := xz.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return 0, fmt.Errorf("%w: xz init: %w", ErrDecompressFailed, err)
+	}
+
+	n, err := io.ReadFull(reader, dst)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return n, fmt.Errorf("%w: xz read: %w", ErrDecompressFailed, err)
+	}
+
+	// Run the stream to its end so the XZ integrity check is verified
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return n, fmt.Errorf("%w: xz check: %w", ErrDecompressFailed, err)
+	}
+
+	return n, nil
+}
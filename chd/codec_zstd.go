@@ -25,31 +25,33 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/klauspost/compress/zstd"
 )
 
+// sharedZstdDecoder is created on first use and shared by every zstd codec.
+// DecodeAll is safe for concurrent use, which hunk prefetching relies on.
+var sharedZstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+	return zstd.NewReader(nil)
+})
+
 func init() {
 	RegisterCodec(CodecZstd, func() Codec { return &zstdCodec{} })
 	RegisterCodec(CodecCDZstd, func() Codec { return &cdZstdCodec{} })
 }
 
 // zstdCodec implements Zstandard decompression for CHD hunks.
-type zstdCodec struct {
-	decoder *zstd.Decoder
-}
+type zstdCodec struct{}
 
 // Decompress decompresses Zstandard compressed data.
-func (z *zstdCodec) Decompress(dst, src []byte) (int, error) {
-	if z.decoder == nil {
-		decoder, err := zstd.NewReader(nil)
-		if err != nil {
-			return 0, fmt.Errorf("%w: zstd init: %w", ErrDecompressFailed, err)
-		}
-		z.decoder = decoder
+func (*zstdCodec) Decompress(dst, src []byte) (int, error) {
+	decoder, err := sharedZstdDecoder()
+	if err != nil {
+		return 0, fmt.Errorf("%w: zstd init: %w", ErrDecompressFailed, err)
 	}
 
-	result, err := z.decoder.DecodeAll(src, dst[:0])
+	result, err := decoder.DecodeAll(src, dst[:0])
 	if err != nil {
 		return 0, fmt.Errorf("%w: zstd: %w", ErrDecompressFailed, err)
 	}
@@ -67,9 +69,7 @@ func (z *zstdCodec) Decompress(dst, src []byte) (int, error) {
 
 // cdZstdCodec implements CD-ROM Zstandard decompression.
 // CD Zstd compresses sector data with Zstandard and subchannel data with zlib.
-type cdZstdCodec struct {
-	decoder *zstd.Decoder
-}
+type cdZstdCodec struct{}
 
 // Decompress implements basic decompression.
 func (c *cdZstdCodec) Decompress(dst, src []byte) (int, error) {
@@ -83,7 +83,7 @@ func (c *cdZstdCodec) Decompress(dst, src []byte) (int, error) {
 //   - Remaining bytes: zlib-compressed subchannel data
 //
 //nolint:gocognit,revive // CD Zstd decompression requires complex sector/subchannel interleaving
-func (c *cdZstdCodec) DecompressCD(dst, src []byte, _, frames int) (int, error) {
+func (*cdZstdCodec) DecompressCD(dst, src []byte, _, frames int) (int, error) {
 	if len(src) < 4 {
 		return 0, fmt.Errorf("%w: cdzs: source too small", ErrDecompressFailed)
 	}
@@ -103,17 +103,13 @@ func (c *cdZstdCodec) DecompressCD(dst, src []byte, _, frames int) (int, error)
 	totalSectorBytes := frames * sectorSize
 	totalSubBytes := frames * subSize
 
-	// Initialize decoder if needed
-	if c.decoder == nil {
-		decoder, err := zstd.NewReader(nil)
-		if err != nil {
-			return 0, fmt.Errorf("%w: cdzs init: %w", ErrDecompressFailed, err)
-		}
-		c.decoder = decoder
+	decoder, err := sharedZstdDecoder()
+	if err != nil {
+		return 0, fmt.Errorf("%w: cdzs init: %w", ErrDecompressFailed, err)
 	}
 
 	// Decompress sector data with Zstandard
-	sectorDst, err := c.decoder.DecodeAll(sectorData, make([]byte, 0, totalSectorBytes))
+	sectorDst, err := decoder.DecodeAll(sectorData, make([]byte, 0, totalSectorBytes))
 	if err != nil {
 		return 0, fmt.Errorf("%w: cdzs sector: %w", ErrDecompressFailed, err)
 	}
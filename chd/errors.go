@@ -64,6 +64,9 @@ var (
 	// ErrNoTracks indicates no track metadata was found.
 	ErrNoTracks = errors.New("no track metadata found")
 
+	// ErrNoSubchannel indicates the CHD's units do not store subchannel data.
+	ErrNoSubchannel = errors.New("no subchannel data in CHD units")
+
 	// ErrInvalidMetadata indicates invalid metadata format.
 	ErrInvalidMetadata = errors.New("invalid metadata format")
 )
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package chd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ProgressFunc receives the number of bytes written so far and the total
+// expected size during a long-running operation such as ExtractISO.
+type ProgressFunc func(written, total int64)
+
+// ExtractISO writes the first data track of a CHD as a plain ISO image with
+// 2048-byte sectors. Data is streamed one hunk's worth of sectors at a time,
+// so memory use does not grow with the image size. If progress is given it
+// is called after every chunk. On failure the partial output is removed.
+func ExtractISO(chdPath, outPath string, progress ...ProgressFunc) (err error) {
+	chdFile, err := Open(chdPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = chdFile.Close() }()
+
+	out, err := os.Create(outPath) //nolint:gosec // Output path from caller is expected
+	if err != nil {
+		return fmt.Errorf("create ISO file: %w", err)
+	}
+	defer func() {
+		if closeErr := out.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close ISO file: %w", closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(outPath)
+		}
+	}()
+
+	return chdFile.writeISO(out, progress)
+}
+
+// writeISO streams the data track to w in hunk-sized chunks.
+func (c *CHD) writeISO(w io.Writer, progress []ProgressFunc) error {
+	total := c.DataTrackSize()
+	src := io.NewSectionReader(c.DataTrackSectorReader(), 0, total)
+	dst := &progressWriter{w: w, total: total, progress: progress}
+
+	chunk := int64(c.hunkMap.HunkBytes())
+	if unitBytes := int64(c.header.UnitBytes); unitBytes > 0 && chunk >= unitBytes {
+		chunk = chunk / unitBytes * 2048
+	}
+	if chunk <= 0 {
+		chunk = 2048
+	}
+
+	written, err := io.CopyBuffer(dst, src, make([]byte, chunk))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("extract data track: %w", err)
+	}
+	if written != total {
+		return fmt.Errorf("%w: extracted %d of %d bytes", ErrCorruptData, written, total)
+	}
+	return nil
+}
+
+// progressWriter forwards writes and reports the running total.
+type progressWriter struct {
+	w        io.Writer
+	progress []ProgressFunc
+	written  int64
+	total    int64
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.written += int64(n)
+	for _, fn := range pw.progress {
+		fn(pw.written, pw.total)
+	}
+	if err != nil {
+		return n, fmt.Errorf("write ISO data: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package chd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"testing"
+)
+
+// v5MapImage builds a reader holding a V5 map header followed by compMap,
+// along with a header describing numHunks hunks.
+func v5MapImage(compMap []byte, bits [3]byte, numHunks uint32) (*bytes.Reader, *Header) {
+	buf := make([]byte, 16+len(compMap))
+	//nolint:gosec // Test inputs are far below 4GB
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(compMap)))
+	copy(buf[12:15], bits[:])
+	copy(buf[16:], compMap)
+	header := &Header{
+		Version:    5,
+		HunkBytes:  2448 * 8,
+		UnitBytes:  2448,
+		TotalHunks: numHunks,
+	}
+	return bytes.NewReader(buf), header
+}
+
+// fixtureV5Map returns the compressed map and field widths of a V5 fixture.
+func fixtureV5Map(tb testing.TB) (compMap []byte, bits [3]byte, numHunks uint32) {
+	tb.Helper()
+
+	data, err := os.ReadFile("../testdata/SegaCD/240pSuite_USA.chd")
+	if err != nil {
+		tb.Fatalf("ReadFile failed: %v", err)
+	}
+	header, err := parseHeader(bytes.NewReader(data))
+	if err != nil {
+		tb.Fatalf("parseHeader failed: %v", err)
+	}
+	mapHeader := data[header.MapOffset : header.MapOffset+16]
+	compMapLen := binary.BigEndian.Uint32(mapHeader[0:4])
+	copy(bits[:], mapHeader[12:15])
+	start := header.MapOffset + 16
+	return data[start : start+uint64(compMapLen)], bits, header.NumHunks()
+}
+
+// FuzzParseMapV5 fuzzes V5 compressed map decoding. Malformed maps must be
+// rejected with ErrInvalidHeader rather than panicking.
+func FuzzParseMapV5(f *testing.F) {
+	compMap, bits, numHunks := fixtureV5Map(f)
+	f.Add(compMap, bits[0], bits[1], bits[2], numHunks)
+	f.Add(compMap[:len(compMap)/2], bits[0], bits[1], bits[2], numHunks)
+	f.Add(compMap[:4], bits[0], bits[1], bits[2], numHunks)
+	f.Add([]byte{}, bits[0], bits[1], bits[2], numHunks)
+	f.Add(bytes.Repeat([]byte{0xFF}, 64), byte(32), byte(32), byte(32), uint32(1000))
+	f.Add([]byte{0x11, 0x11, 0x11, 0x11}, byte(255), byte(0), byte(0), uint32(1))
+
+	f.Fuzz(func(t *testing.T, data []byte, lengthBits, selfBits, parentBits byte, hunks uint32) {
+		if len(data) > 1024*1024 || hunks > 100_000 {
+			return
+		}
+		reader, header := v5MapImage(data, [3]byte{lengthBits, selfBits, parentBits}, hunks)
+		hm := &HunkMap{reader: reader, header: header, entries: make([]HunkMapEntry, hunks)}
+		if err := hm.parseMapV5(); err != nil && !errors.Is(err, ErrInvalidHeader) {
+			t.Errorf("parseMapV5() error = %v, want nil or ErrInvalidHeader", err)
+		}
+	})
+}
@@ -30,6 +30,8 @@ var chdMagic = [8]byte{'M', 'C', 'o', 'm', 'p', 'r', 'H', 'D'}
 
 // Header sizes for different CHD versions
 const (
+	headerSizeV1 = 76
+	headerSizeV2 = 80
 	headerSizeV3 = 120
 	headerSizeV4 = 108
 	headerSizeV5 = 124
@@ -40,7 +42,7 @@ const (
 type Header struct {
 	Magic        [8]byte   // "MComprHD"
 	HeaderSize   uint32    // Header length in bytes
-	Version      uint32    // CHD version (3, 4, or 5)
+	Version      uint32    // CHD version (1 through 5)
 	Compressors  [4]uint32 // Compression codec tags (V5)
 	LogicalBytes uint64    // Total uncompressed size
 	MapOffset    uint64    // Offset to hunk map
@@ -51,12 +53,27 @@ type Header struct {
 	SHA1         [20]byte  // SHA1 of raw + metadata
 	ParentSHA1   [20]byte  // Parent SHA1 (for delta CHDs)
 
-	// V3/V4 specific fields
-	Flags       uint32 // V3/V4 flags
-	Compression uint32 // V3/V4 compression type
-	TotalHunks  uint32 // V3/V4 total number of hunks
+	// V1-V4 specific fields
+	Flags       uint32 // V1-V4 flags
+	Compression uint32 // V1-V4 compression type
+	TotalHunks  uint32 // V1-V4 total number of hunks
+
+	// V1/V2 hard disk geometry
+	Cylinders uint32
+	Heads     uint32
+	Sectors   uint32 // Sectors per track
 }
 
+// Legacy (V1-V4) compression types.
+const (
+	legacyCompressionNone  = 0
+	legacyCompressionZlib  = 1
+	legacyCompressionZlibP = 2 // zlib+, same stream format with a different encoder setup
+)
+
+// sectorBytesV1 is the fixed sector size of V1 files; V2 stores it in the header.
+const sectorBytesV1 = 512
+
 // parseHeader reads and parses a CHD header from the given reader.
 func parseHeader(reader io.Reader) (*Header, error) {
 	// Read magic and header size first
@@ -102,6 +119,10 @@ func parseHeader(reader io.Reader) (*Header, error) {
 		if err := parseHeaderV3(&header, headerBuf); err != nil {
 			return nil, err
 		}
+	case 2, 1:
+		if err := parseHeaderV1(&header, headerBuf); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("%w: version %d", ErrUnsupportedVersion, header.Version)
 	}
@@ -277,6 +298,77 @@ func parseHeaderV3(header *Header, buf []byte) error {
 	return nil
 }
 
+// parseHeaderV1 parses a V1 or V2 CHD header. Both describe hard disks by
+// their geometry and size hunks in sectors rather than bytes; V2 adds an
+// explicit sector length, which V1 fixes at 512 bytes. Neither version has
+// metadata, so these files carry no track list.
+// V1 header layout (76 bytes total, V2 is 80 bytes):
+//
+//	Offset 0x00: Magic (8 bytes)
+//	Offset 0x08: Header size (4 bytes)
+//	Offset 0x0C: Version (4 bytes)
+//	Offset 0x10: Flags (4 bytes)
+//	Offset 0x14: Compression (4 bytes)
+//	Offset 0x18: Hunk size in sectors (4 bytes)
+//	Offset 0x1C: Total hunks (4 bytes)
+//	Offset 0x20: Cylinders (4 bytes)
+//	Offset 0x24: Heads (4 bytes)
+//	Offset 0x28: Sectors per track (4 bytes)
+//	Offset 0x2C: MD5 (16 bytes)
+//	Offset 0x3C: Parent MD5 (16 bytes)
+//	Offset 0x4C: Sector length in bytes (4 bytes, V2 only)
+func parseHeaderV1(header *Header, buf []byte) error {
+	minSize := headerSizeV1
+	if header.Version == 2 {
+		minSize = headerSizeV2
+	}
+	if len(buf) < minSize-12 {
+		return fmt.Errorf("%w: buffer too small for V%d", ErrInvalidHeader, header.Version)
+	}
+
+	// Flags (4 bytes at offset 4 in buf)
+	header.Flags = binary.BigEndian.Uint32(buf[4:8])
+
+	// Compression (4 bytes at offset 8 in buf)
+	header.Compression = binary.BigEndian.Uint32(buf[8:12])
+
+	// Hunk size in sectors (4 bytes at offset 12 in buf)
+	hunkSectors := binary.BigEndian.Uint32(buf[12:16])
+
+	// Total hunks (4 bytes at offset 16 in buf)
+	header.TotalHunks = binary.BigEndian.Uint32(buf[16:20])
+
+	// Geometry (3 x 4 bytes at offset 20 in buf)
+	header.Cylinders = binary.BigEndian.Uint32(buf[20:24])
+	header.Heads = binary.BigEndian.Uint32(buf[24:28])
+	header.Sectors = binary.BigEndian.Uint32(buf[28:32])
+
+	// MD5 hashes skipped (16 + 16 = 32 bytes at offset 32)
+
+	// Sector length (4 bytes at offset 64 in buf, V2 only)
+	header.UnitBytes = sectorBytesV1
+	if header.Version == 2 {
+		header.UnitBytes = binary.BigEndian.Uint32(buf[64:68])
+	}
+	if header.UnitBytes == 0 || hunkSectors == 0 {
+		return fmt.Errorf("%w: zero hunk or sector size", ErrInvalidHeader)
+	}
+
+	hunkBytes := uint64(hunkSectors) * uint64(header.UnitBytes)
+	if hunkBytes > 1<<31 {
+		return fmt.Errorf("%w: hunk size %d too large", ErrInvalidHeader, hunkBytes)
+	}
+	header.HunkBytes = uint32(hunkBytes)
+
+	header.LogicalBytes = uint64(header.Cylinders) * uint64(header.Heads) *
+		uint64(header.Sectors) * uint64(header.UnitBytes)
+
+	// Map for V1/V2 is right after header
+	header.MapOffset = uint64(header.HeaderSize)
+
+	return nil
+}
+
 // NumHunks returns the total number of hunks in the CHD file.
 func (h *Header) NumHunks() uint32 {
 	if h.TotalHunks > 0 {
@@ -21,8 +21,11 @@ package chd
 import (
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"sync"
+
+	"github.com/ZaparooProject/go-gameid/internal/crc16"
 )
 
 // Hunk compression types (V5 map entry types).
@@ -47,30 +50,40 @@ const (
 type HunkMapEntry struct {
 	Offset     uint64
 	CompLength uint32
-	CRC16      uint16
+	CRC32      uint32 // V3/V4 checksum of the decompressed hunk
+	CRC16      uint16 // V5 checksum of the decompressed hunk
 	CompType   uint8
+	HasCRC     bool // False for V5 self/parent references, which carry no checksum
 }
 
 // HunkMap manages the hunk map and caching for a CHD file.
 type HunkMap struct {
-	reader    io.ReaderAt
-	header    *Header
-	cache     map[uint32][]byte
-	entries   []HunkMapEntry
-	codecs    []Codec
-	cacheSize int
-	maxCache  int
-	cacheMu   sync.RWMutex
+	reader      io.ReaderAt
+	header      *Header
+	cache       *hunkCache
+	inflight    map[uint32]chan struct{} // Hunks being decompressed by prefetch
+	entries     []HunkMapEntry
+	codecs      []Codec
+	prefetchSem chan struct{} // Bounds concurrent prefetch goroutines
+	readAhead   int
+	prefetchWG  sync.WaitGroup
+	inflightMu  sync.Mutex
 }
 
-// NewHunkMap creates a new hunk map from the CHD header and reader.
+// NewHunkMap creates a new hunk map from the CHD header and reader, caching
+// up to DefaultCacheBytes of decompressed hunks.
 func NewHunkMap(reader io.ReaderAt, header *Header) (*HunkMap, error) {
+	return newHunkMap(reader, header, options{cacheBytes: DefaultCacheBytes, prefetchHunks: DefaultPrefetchHunks})
+}
+
+func newHunkMap(reader io.ReaderAt, header *Header, opts options) (*HunkMap, error) {
 	hm := &HunkMap{
 		reader:   reader,
 		header:   header,
-		cache:    make(map[uint32][]byte),
-		maxCache: 16, // Cache up to 16 hunks
+		cache:    newHunkCache(opts.cacheBytes),
+		inflight: make(map[uint32]chan struct{}),
 	}
+	hm.setPrefetch(opts.prefetchHunks)
 
 	// Initialize codecs for V5
 	if header.Version == 5 {
@@ -90,6 +103,11 @@ func NewHunkMap(reader io.ReaderAt, header *Header) (*HunkMap, error) {
 		}
 	}
 
+	// Legacy versions name a single file-wide compression type
+	if header.Version < 5 {
+		hm.initLegacyCodec()
+	}
+
 	// Parse hunk map
 	if err := hm.parseMap(); err != nil {
 		return nil, fmt.Errorf("parse hunk map: %w", err)
@@ -111,6 +129,8 @@ func (hm *HunkMap) parseMap() error {
 		return hm.parseMapV5()
 	case 4, 3:
 		return hm.parseMapV4()
+	case 2, 1:
+		return hm.parseMapV1()
 	default:
 		return fmt.Errorf("%w: version %d", ErrUnsupportedVersion, hm.header.Version)
 	}
@@ -139,12 +159,22 @@ func (hm *HunkMap) parseMapV5() error {
 	if compMapLen > MaxCompMapLen {
 		return fmt.Errorf("%w: compressed map too large (%d > %d)", ErrInvalidHeader, compMapLen, MaxCompMapLen)
 	}
+	if compMapLen == 0 {
+		if hm.header.NumHunks() == 0 {
+			return nil
+		}
+		return fmt.Errorf("%w: empty compressed map", ErrInvalidHeader)
+	}
 	firstOffs := uint64(mapHeader[4])<<40 | uint64(mapHeader[5])<<32 |
 		uint64(mapHeader[6])<<24 | uint64(mapHeader[7])<<16 |
 		uint64(mapHeader[8])<<8 | uint64(mapHeader[9])
 	lengthBits := int(mapHeader[12])
 	selfBits := int(mapHeader[13])
 	parentBits := int(mapHeader[14])
+	if lengthBits > 32 || selfBits > 32 || parentBits > 32 {
+		return fmt.Errorf("%w: map field widths %d/%d/%d exceed 32 bits",
+			ErrInvalidHeader, lengthBits, selfBits, parentBits)
+	}
 
 	// Read compressed map data
 	compMap := make([]byte, compMapLen)
@@ -197,18 +227,22 @@ func (hm *HunkMap) parseMapV5() error {
 		compType := compTypes[hunkNum]
 		var length uint32
 		var offset uint64
+		var crc uint16
+		hasCRC := false
 
 		switch compType {
 		case HunkCompTypeCodec0, HunkCompTypeCodec1, HunkCompTypeCodec2, HunkCompTypeCodec3:
 			length = br.read(lengthBits)
 			offset = curOffset
 			curOffset += uint64(length)
-			br.read(16) // CRC16
+			crc = uint16(br.read(16))
+			hasCRC = true
 		case HunkCompTypeNone:
 			length = hm.header.HunkBytes
 			offset = curOffset
 			curOffset += uint64(length)
-			br.read(16) // CRC16
+			crc = uint16(br.read(16))
+			hasCRC = true
 		case HunkCompTypeSelf:
 			lastSelf = br.read(selfBits)
 			offset = uint64(lastSelf)
@@ -239,9 +273,15 @@ func (hm *HunkMap) parseMapV5() error {
 			CompType:   compType,
 			CompLength: length,
 			Offset:     offset,
+			CRC16:      crc,
+			HasCRC:     hasCRC,
 		}
 	}
 
+	if err := br.err(); err != nil {
+		return fmt.Errorf("%w: compressed map: %w", ErrInvalidHeader, err)
+	}
+
 	return nil
 }
 
@@ -265,7 +305,7 @@ func (hm *HunkMap) parseMapV4() error {
 		offset := int(i) * entrySize
 
 		entryOffset := binary.BigEndian.Uint64(mapData[offset : offset+8])
-		// CRC32 at offset+8 (skipped)
+		crc := binary.BigEndian.Uint32(mapData[offset+8 : offset+12])
 		length := binary.BigEndian.Uint16(mapData[offset+12 : offset+14])
 		flags := binary.BigEndian.Uint16(mapData[offset+14 : offset+16])
 
@@ -278,12 +318,60 @@ func (hm *HunkMap) parseMapV4() error {
 			CompType:   compType,
 			CompLength: uint32(length),
 			Offset:     entryOffset,
+			CRC32:      crc,
+			HasCRC:     true,
+		}
+	}
+
+	return nil
+}
+
+// parseMapV1 parses a V1/V2 hunk map. Each 8-byte big-endian entry packs
+// the hunk's file offset in the low 44 bits and its stored length in the
+// high 20 bits. A hunk stored at full size is uncompressed; anything shorter
+// is compressed with the file's codec. These versions carry no checksums.
+func (hm *HunkMap) parseMapV1() error {
+	numHunks := hm.header.NumHunks()
+	entrySize := 8
+	mapData := make([]byte, int(numHunks)*entrySize)
+
+	//nolint:gosec // Safe: MapOffset validated during header parsing, int64 conversion safe for valid CHD files
+	if _, err := hm.reader.ReadAt(mapData, int64(hm.header.MapOffset)); err != nil {
+		return fmt.Errorf("read V%d map: %w", hm.header.Version, err)
+	}
+
+	for i := range numHunks {
+		raw := binary.BigEndian.Uint64(mapData[int(i)*entrySize:])
+		length := uint32(raw >> 44)
+
+		compType := uint8(HunkCompTypeCodec0)
+		if length == hm.header.HunkBytes {
+			compType = HunkCompTypeNone
+		}
+
+		hm.entries[i] = HunkMapEntry{
+			CompType:   compType,
+			CompLength: length,
+			Offset:     raw & (1<<44 - 1),
 		}
 	}
 
 	return nil
 }
 
+// initLegacyCodec maps a V1-V4 compression type onto codec slot 0.
+// Both zlib variants store raw deflate streams.
+func (hm *HunkMap) initLegacyCodec() {
+	switch hm.header.Compression {
+	case legacyCompressionZlib, legacyCompressionZlibP:
+		hm.codecs = []Codec{&zlibCodec{}}
+	case legacyCompressionNone:
+	default:
+		// Other legacy codecs (e.g. A/V) are unsupported; hunks that need
+		// them fail in decompressWithCodec.
+	}
+}
+
 // ReadHunk reads and decompresses a hunk by index.
 func (hm *HunkMap) ReadHunk(index uint32) ([]byte, error) {
 	//nolint:gosec // Safe: len(entries) bounded by NumHunks which fits in uint32
@@ -291,13 +379,15 @@ func (hm *HunkMap) ReadHunk(index uint32) ([]byte, error) {
 		return nil, fmt.Errorf("%w: %d >= %d", ErrInvalidHunk, index, len(hm.entries))
 	}
 
-	// Check cache
-	hm.cacheMu.RLock()
-	if data, ok := hm.cache[index]; ok {
-		hm.cacheMu.RUnlock()
+	if data, ok := hm.cache.get(index); ok {
 		return data, nil
 	}
-	hm.cacheMu.RUnlock()
+	// A prefetch may be decompressing this hunk already
+	if hm.waitInflight(index) {
+		if data, ok := hm.cache.get(index); ok {
+			return data, nil
+		}
+	}
 
 	// Read and decompress
 	entry := hm.entries[index]
@@ -306,20 +396,65 @@ func (hm *HunkMap) ReadHunk(index uint32) ([]byte, error) {
 		return nil, fmt.Errorf("decompress hunk %d: %w", index, err)
 	}
 
-	// Update cache
-	hm.cacheMu.Lock()
-	if hm.cacheSize >= hm.maxCache {
-		// Simple cache eviction: clear all
-		hm.cache = make(map[uint32][]byte)
-		hm.cacheSize = 0
-	}
-	hm.cache[index] = data
-	hm.cacheSize++
-	hm.cacheMu.Unlock()
+	hm.cache.put(index, data)
 
 	return data, nil
 }
 
+// VerifyHunk decompresses a hunk and checks it against the checksum stored
+// in the hunk map: CRC16 for V5 files, CRC32 for V3/V4. Self-referencing
+// hunks are verified through the hunk they point at. The hunk cache is
+// bypassed so the data on disk is always what gets checked.
+func (hm *HunkMap) VerifyHunk(index uint32) error {
+	//nolint:gosec // Safe: len(entries) bounded by NumHunks which fits in uint32
+	if index >= uint32(len(hm.entries)) {
+		return fmt.Errorf("%w: %d >= %d", ErrInvalidHunk, index, len(hm.entries))
+	}
+
+	entry := hm.entries[index]
+	if entry.CompType == HunkCompTypeSelf {
+		//nolint:gosec // Safe: entry.Offset used as hunk index, validated by the recursive call
+		ref := uint32(entry.Offset)
+		// References always point backwards, which also rules out cycles
+		if ref >= index {
+			return fmt.Errorf("%w: hunk %d references hunk %d", ErrCorruptData, index, ref)
+		}
+		return hm.VerifyHunk(ref)
+	}
+	if !entry.HasCRC {
+		return nil
+	}
+
+	data, err := hm.decompressHunk(entry)
+	if err != nil {
+		return fmt.Errorf("decompress hunk %d: %w", index, err)
+	}
+	return hm.checkHunkCRC(index, entry, data)
+}
+
+// checkHunkCRC compares decompressed hunk data with its stored checksum.
+// Short hunks are treated as zero-padded to the full hunk size.
+func (hm *HunkMap) checkHunkCRC(index uint32, entry HunkMapEntry, data []byte) error {
+	var padding []byte
+	if hunkBytes := int(hm.header.HunkBytes); len(data) < hunkBytes {
+		padding = make([]byte, hunkBytes-len(data))
+	}
+
+	if hm.header.Version == 5 {
+		got := crc16.UpdateCCITT(crc16.CCITT(data), padding)
+		if got != entry.CRC16 {
+			return fmt.Errorf("%w: hunk %d crc16 0x%04x, want 0x%04x", ErrCorruptData, index, got, entry.CRC16)
+		}
+		return nil
+	}
+
+	got := crc32.Update(crc32.ChecksumIEEE(data), crc32.IEEETable, padding)
+	if got != entry.CRC32 {
+		return fmt.Errorf("%w: hunk %d crc32 0x%08x, want 0x%08x", ErrCorruptData, index, got, entry.CRC32)
+	}
+	return nil
+}
+
 // decompressHunk decompresses a single hunk.
 func (hm *HunkMap) decompressHunk(entry HunkMapEntry) ([]byte, error) {
 	hunkSize := int(hm.header.HunkBytes)
@@ -39,6 +39,9 @@ const (
 
 	// MetaTagGDTR is the GD-ROM track metadata tag ("CHGD")
 	MetaTagGDTR = 0x43484744
+
+	// MetaTagGDDD is the hard disk geometry metadata tag ("GDDD")
+	MetaTagGDDD = 0x47444444
 )
 
 // Track represents a CD track in the CHD file.
@@ -54,6 +57,20 @@ type Track struct {
 	StartFrame int
 }
 
+// MetadataEntry is one entry of a CHD's metadata chain, such as a CD track
+// (CHT2, CHTR, CHCD), GD-ROM track (CHGD) or hard disk geometry (GDDD)
+// entry. Data is the entry's payload, undecoded.
+type MetadataEntry struct {
+	Data  []byte
+	Tag   uint32
+	Flags uint8
+}
+
+// TagString returns the entry's tag as its four ASCII characters.
+func (e MetadataEntry) TagString() string {
+	return string([]byte{byte(e.Tag >> 24), byte(e.Tag >> 16), byte(e.Tag >> 8), byte(e.Tag)})
+}
+
 // metadataEntry represents a raw metadata entry from the CHD file.
 type metadataEntry struct {
 	Data  []byte
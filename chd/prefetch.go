@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package chd
+
+import "runtime"
+
+// DefaultPrefetchHunks is how many hunks a sector reader decompresses ahead
+// of a sequential read when Open is not given WithPrefetch.
+const DefaultPrefetchHunks = 8
+
+// sequentialRunHunks is how many consecutive hunk reads mark an access
+// pattern as sequential. A single boundary crossing, such as a directory
+// record straddling two hunks, should not start read-ahead.
+const sequentialRunHunks = 2
+
+// setPrefetch sizes the read-ahead window and its worker pool. The window is
+// clamped so prefetched hunks are not evicted from the cache before use.
+func (hm *HunkMap) setPrefetch(hunks int) {
+	if hunkBytes := int64(hm.header.HunkBytes); hunkBytes > 0 {
+		hunks = int(min(int64(hunks), hm.cache.budget/hunkBytes-1))
+	}
+	if hunks <= 0 {
+		hm.readAhead = 0
+		return
+	}
+	hm.readAhead = hunks
+	hm.prefetchSem = make(chan struct{}, min(hunks, runtime.GOMAXPROCS(0)))
+}
+
+// prefetch decompresses up to readAhead hunks starting at start in
+// background goroutines, feeding the hunk cache. Hunks that are cached or
+// already being decompressed are skipped, and no more goroutines are started
+// than the worker pool allows.
+func (hm *HunkMap) prefetch(start uint32) {
+	if hm.readAhead == 0 {
+		return
+	}
+	end := min(uint64(start)+uint64(hm.readAhead), uint64(hm.NumHunks()))
+
+	for index := start; uint64(index) < end; index++ {
+		// Self references resolve through ReadHunk and are cheap once their
+		// target is cached; decompressing them here could wait on themselves.
+		if hm.entries[index].CompType == HunkCompTypeSelf || hm.cache.contains(index) {
+			continue
+		}
+		select {
+		case hm.prefetchSem <- struct{}{}:
+		default:
+			return // All workers busy
+		}
+		done, claimed := hm.claim(index)
+		if !claimed {
+			<-hm.prefetchSem
+			continue
+		}
+
+		hm.prefetchWG.Add(1)
+		go func() {
+			defer hm.prefetchWG.Done()
+			defer func() { <-hm.prefetchSem }()
+
+			// Errors are left for the foreground read to report
+			if data, err := hm.decompressHunk(hm.entries[index]); err == nil {
+				hm.cache.put(index, data)
+			}
+			hm.release(index, done)
+		}()
+	}
+}
+
+// claim marks a hunk as being decompressed in the background. It returns
+// false if another goroutine already holds it.
+func (hm *HunkMap) claim(index uint32) (chan struct{}, bool) {
+	hm.inflightMu.Lock()
+	defer hm.inflightMu.Unlock()
+
+	if _, busy := hm.inflight[index]; busy {
+		return nil, false
+	}
+	done := make(chan struct{})
+	hm.inflight[index] = done
+	return done, true
+}
+
+// release clears a claim and wakes any readers waiting on it.
+func (hm *HunkMap) release(index uint32, done chan struct{}) {
+	hm.inflightMu.Lock()
+	delete(hm.inflight, index)
+	hm.inflightMu.Unlock()
+	close(done)
+}
+
+// waitInflight blocks until a background decompression of the hunk finishes.
+// It reports whether there was one to wait for.
+func (hm *HunkMap) waitInflight(index uint32) bool {
+	hm.inflightMu.Lock()
+	done, busy := hm.inflight[index]
+	hm.inflightMu.Unlock()
+
+	if !busy {
+		return false
+	}
+	<-done
+	return true
+}
+
+// waitPrefetch blocks until every background decompression has finished.
+func (hm *HunkMap) waitPrefetch() {
+	hm.prefetchWG.Wait()
+}
+
+// trackSequential records a hunk read and reports whether the recent reads
+// form a sequential run long enough to start read-ahead.
+func (sr *sectorReader) trackSequential(hunkIdx uint32) bool {
+	sr.seqMu.Lock()
+	defer sr.seqMu.Unlock()
+
+	switch {
+	case sr.seqStarted && hunkIdx == sr.lastHunk:
+		return false
+	case sr.seqStarted && hunkIdx == sr.lastHunk+1:
+		sr.seqRun++
+	default:
+		sr.seqRun = 0
+	}
+	sr.seqStarted = true
+	sr.lastHunk = hunkIdx
+	return sr.seqRun >= sequentialRunHunks
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package ciso reads CISO (.cso) and ZISO (.zso) compressed disc images, the
+// block-compressed ISO formats used for PSP and PS2 games. CISO blocks are
+// raw deflate streams; ZISO blocks are LZ4 blocks.
+package ciso
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// Allocation limits to prevent DoS from malicious images.
+const (
+	// MaxBlockSize is the largest accepted block size (1MB).
+	MaxBlockSize = 1 << 20
+
+	// MaxBlocks is the largest accepted block count (32GB of 2048-byte blocks).
+	MaxBlocks = 1 << 24
+)
+
+// headerSize is the size of the fixed CISO/ZISO header.
+const headerSize = 24
+
+// plainFlag marks an index entry whose block is stored uncompressed.
+const plainFlag = 0x80000000
+
+var (
+	magicCISO = [4]byte{'C', 'I', 'S', 'O'}
+	magicZISO = [4]byte{'Z', 'I', 'S', 'O'}
+)
+
+// Common errors for CISO parsing.
+var (
+	// ErrInvalidMagic indicates the file is neither a CISO nor a ZISO image.
+	ErrInvalidMagic = errors.New("invalid CISO magic: expected CISO or ZISO")
+
+	// ErrInvalidHeader indicates the header or block index is invalid.
+	ErrInvalidHeader = errors.New("invalid CISO header")
+
+	// ErrUnsupportedVersion indicates a format version other than 0 or 1.
+	ErrUnsupportedVersion = errors.New("unsupported CISO version")
+
+	// ErrDecompressFailed indicates a block failed to decompress.
+	ErrDecompressFailed = errors.New("CISO decompression failed")
+)
+
+// Header is the fixed header at the start of a CISO or ZISO file.
+//
+// Layout (little-endian):
+//
+//	Offset 0x00: Magic "CISO" or "ZISO" (4 bytes)
+//	Offset 0x04: Header size (4 bytes)
+//	Offset 0x08: Uncompressed size in bytes (8 bytes)
+//	Offset 0x10: Block size (4 bytes)
+//	Offset 0x14: Version (1 byte)
+//	Offset 0x15: Index alignment shift (1 byte)
+//	Offset 0x16: Reserved (2 bytes)
+type Header struct {
+	Magic      [4]byte
+	HeaderSize uint32
+	TotalBytes uint64
+	BlockSize  uint32
+	Version    uint8
+	Align      uint8
+}
+
+// IsZISO reports whether blocks are LZ4 rather than deflate compressed.
+func (h Header) IsZISO() bool {
+	return h.Magic == magicZISO
+}
+
+// HasMagic reports whether data starts with a CISO or ZISO magic word.
+func HasMagic(data []byte) bool {
+	if len(data) < 4 {
+		return false
+	}
+	magic := [4]byte(data[:4])
+	return magic == magicCISO || magic == magicZISO
+}
+
+// Reader provides random access to the decompressed contents of a CISO or
+// ZISO image. It is safe for concurrent use.
+type Reader struct {
+	reader io.ReaderAt
+	closer io.Closer
+	index  []uint32
+	header Header
+
+	mu         sync.Mutex
+	cached     []byte
+	cachedIdx  int64
+	compressed []byte
+}
+
+// Open opens a CISO or ZISO file.
+func Open(path string) (*Reader, error) {
+	file, err := os.Open(path) //nolint:gosec // Path from user input is expected
+	if err != nil {
+		return nil, fmt.Errorf("open CISO file: %w", err)
+	}
+
+	reader, err := NewReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	reader.closer = file
+	return reader, nil
+}
+
+// NewReader parses the header and block index of a CISO or ZISO image read
+// from reader. The caller keeps ownership of reader.
+func NewReader(reader io.ReaderAt) (*Reader, error) {
+	header, err := parseHeader(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	numBlocks := (header.TotalBytes + uint64(header.BlockSize) - 1) / uint64(header.BlockSize)
+	if numBlocks > MaxBlocks {
+		return nil, fmt.Errorf("%w: too many blocks (%d > %d)", ErrInvalidHeader, numBlocks, MaxBlocks)
+	}
+
+	// The index holds one entry per block plus one marking the end of the last
+	indexData := make([]byte, (numBlocks+1)*4)
+	if _, err := reader.ReadAt(indexData, headerSize); err != nil {
+		return nil, fmt.Errorf("read block index: %w", err)
+	}
+	index := make([]uint32, numBlocks+1)
+	for i := range index {
+		index[i] = binary.LittleEndian.Uint32(indexData[i*4:])
+	}
+	for i := range numBlocks {
+		if index[i]&^plainFlag > index[i+1]&^plainFlag {
+			return nil, fmt.Errorf("%w: block %d index entries out of order", ErrInvalidHeader, i)
+		}
+	}
+
+	return &Reader{reader: reader, header: header, index: index, cachedIdx: -1}, nil
+}
+
+// parseHeader reads and validates the fixed header.
+func parseHeader(reader io.ReaderAt) (Header, error) {
+	buf := make([]byte, headerSize)
+	if _, err := reader.ReadAt(buf, 0); err != nil {
+		return Header{}, fmt.Errorf("read CISO header: %w", err)
+	}
+	if !HasMagic(buf) {
+		return Header{}, ErrInvalidMagic
+	}
+
+	header := Header{
+		Magic:      [4]byte(buf[0:4]),
+		HeaderSize: binary.LittleEndian.Uint32(buf[4:8]),
+		TotalBytes: binary.LittleEndian.Uint64(buf[8:16]),
+		BlockSize:  binary.LittleEndian.Uint32(buf[16:20]),
+		Version:    buf[20],
+		Align:      buf[21],
+	}
+
+	// Version 2 CISO files reuse the plain flag to mean LZ4; only the
+	// original layout is supported.
+	if header.Version > 1 {
+		return Header{}, fmt.Errorf("%w: %d", ErrUnsupportedVersion, header.Version)
+	}
+	if header.BlockSize == 0 || header.BlockSize > MaxBlockSize {
+		return Header{}, fmt.Errorf("%w: block size %d", ErrInvalidHeader, header.BlockSize)
+	}
+	if header.TotalBytes == 0 {
+		return Header{}, fmt.Errorf("%w: empty image", ErrInvalidHeader)
+	}
+	if header.Align > 31 {
+		return Header{}, fmt.Errorf("%w: alignment shift %d", ErrInvalidHeader, header.Align)
+	}
+	return header, nil
+}
+
+// Header returns the parsed file header.
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Size returns the uncompressed size of the image.
+func (r *Reader) Size() int64 {
+	return int64(r.header.TotalBytes) //nolint:gosec // Bounded by MaxBlocks * MaxBlockSize
+}
+
+// ReadAt reads decompressed image data starting at off.
+func (r *Reader) ReadAt(dest []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("%w: negative offset %d", ErrInvalidHeader, off)
+	}
+	size := r.Size()
+	if off >= size {
+		return 0, io.EOF
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	blockSize := int64(r.header.BlockSize)
+	want := min(int64(len(dest)), size-off)
+	totalRead := int64(0)
+	for totalRead < want {
+		pos := off + totalRead
+		block, err := r.readBlock(pos / blockSize)
+		if err != nil {
+			return int(totalRead), err
+		}
+		totalRead += int64(copy(dest[totalRead:want], block[pos%blockSize:]))
+	}
+
+	if totalRead < int64(len(dest)) {
+		return int(totalRead), io.EOF
+	}
+	return int(totalRead), nil
+}
+
+// readBlock returns the decompressed contents of block index, reusing the
+// most recently read block. The caller holds r.mu.
+func (r *Reader) readBlock(index int64) ([]byte, error) {
+	if index == r.cachedIdx {
+		return r.cached, nil
+	}
+
+	start := int64(r.index[index]&^plainFlag) << r.header.Align
+	end := int64(r.index[index+1]&^plainFlag) << r.header.Align
+	plain := r.index[index]&plainFlag != 0
+	blockSize := int(r.header.BlockSize)
+
+	stored := end - start
+	if plain {
+		// Alignment padding may follow a plain block; only the block itself counts
+		stored = int64(blockSize)
+	}
+	if stored < 0 || stored > 2*MaxBlockSize {
+		return nil, fmt.Errorf("%w: block %d stored size %d", ErrInvalidHeader, index, stored)
+	}
+	if cap(r.compressed) < int(stored) {
+		r.compressed = make([]byte, stored)
+	}
+	src := r.compressed[:stored]
+	bytesRead, err := r.reader.ReadAt(src, start)
+	if err != nil && !(errors.Is(err, io.EOF) && bytesRead > 0) {
+		return nil, fmt.Errorf("read block %d: %w", index, err)
+	}
+	src = src[:bytesRead]
+	if plain && len(src) < blockSize && index != int64(len(r.index))-2 {
+		return nil, fmt.Errorf("%w: block %d: short plain block", ErrDecompressFailed, index)
+	}
+
+	if r.cached == nil {
+		r.cached = make([]byte, blockSize)
+	}
+	r.cachedIdx = -1
+	if err := r.decodeBlock(r.cached, src, plain); err != nil {
+		return nil, fmt.Errorf("block %d: %w", index, err)
+	}
+	r.cachedIdx = index
+	return r.cached, nil
+}
+
+// decodeBlock fills dst with the block stored in src.
+func (r *Reader) decodeBlock(dst, src []byte, plain bool) error {
+	if plain {
+		clear(dst[copy(dst, src):])
+		return nil
+	}
+
+	if r.header.IsZISO() {
+		decoded, err := lz4.UncompressBlock(src, dst)
+		if err != nil {
+			return fmt.Errorf("%w: lz4: %w", ErrDecompressFailed, err)
+		}
+		clear(dst[decoded:])
+		return nil
+	}
+
+	decoded, err := io.ReadFull(flate.NewReader(bytes.NewReader(src)), dst)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: deflate: %w", ErrDecompressFailed, err)
+	}
+	clear(dst[decoded:])
+	return nil
+}
+
+// Close closes the underlying file if the Reader was created by Open.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	if err := r.closer.Close(); err != nil {
+		return fmt.Errorf("close CISO file: %w", err)
+	}
+	return nil
+}
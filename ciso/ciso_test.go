@@ -0,0 +1,246 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package ciso
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// encodeImage compresses data into a CISO image, or a ZISO image when zso
+// is set. Blocks listed in plain are stored uncompressed.
+func encodeImage(t *testing.T, data []byte, blockSize int, zso bool, plain map[int]bool) []byte {
+	t.Helper()
+
+	numBlocks := (len(data) + blockSize - 1) / blockSize
+	header := make([]byte, headerSize)
+	copy(header, "CISO")
+	if zso {
+		copy(header, "ZISO")
+	}
+	binary.LittleEndian.PutUint32(header[4:8], headerSize)
+	binary.LittleEndian.PutUint64(header[8:16], uint64(len(data)))
+	binary.LittleEndian.PutUint32(header[16:20], uint32(blockSize)) //nolint:gosec // Small test block size
+	header[20] = 1
+
+	index := make([]byte, (numBlocks+1)*4)
+	var body bytes.Buffer
+	pos := len(header) + len(index)
+	for block := range numBlocks {
+		chunk := data[block*blockSize : min((block+1)*blockSize, len(data))]
+		entry := uint32(pos + body.Len()) //nolint:gosec // Small test image
+		switch {
+		case plain[block]:
+			entry |= plainFlag
+			body.Write(chunk)
+		case zso:
+			compressed := make([]byte, lz4.CompressBlockBound(len(chunk)))
+			n, err := lz4.CompressBlock(chunk, compressed, nil)
+			if err != nil || n == 0 {
+				t.Fatalf("lz4.CompressBlock() = %d, %v", n, err)
+			}
+			body.Write(compressed[:n])
+		default:
+			writer, err := flate.NewWriter(&body, flate.BestCompression)
+			if err != nil {
+				t.Fatalf("flate.NewWriter() error = %v", err)
+			}
+			_, _ = writer.Write(chunk)
+			_ = writer.Close()
+		}
+		binary.LittleEndian.PutUint32(index[block*4:], entry)
+	}
+	binary.LittleEndian.PutUint32(index[numBlocks*4:], uint32(pos+body.Len())) //nolint:gosec // Small test image
+
+	return append(append(header, index...), body.Bytes()...)
+}
+
+// testData returns n bytes that compress but don't repeat per block.
+func testData(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i / 3 * 7)
+	}
+	return data
+}
+
+func TestReader(t *testing.T) {
+	t.Parallel()
+
+	data := testData(5*2048 + 300)
+	tests := []struct {
+		plain map[int]bool
+		name  string
+		zso   bool
+	}{
+		{name: "cso deflate"},
+		{name: "cso mixed plain blocks", plain: map[int]bool{1: true, 5: true}},
+		{name: "zso lz4", zso: true},
+		{name: "zso mixed plain blocks", zso: true, plain: map[int]bool{0: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader, err := NewReader(bytes.NewReader(encodeImage(t, data, 2048, tt.zso, tt.plain)))
+			if err != nil {
+				t.Fatalf("NewReader() error = %v", err)
+			}
+			if reader.Size() != int64(len(data)) {
+				t.Errorf("Size() = %d, want %d", reader.Size(), len(data))
+			}
+			if reader.Header().IsZISO() != tt.zso {
+				t.Errorf("IsZISO() = %v, want %v", reader.Header().IsZISO(), tt.zso)
+			}
+
+			got, err := io.ReadAll(io.NewSectionReader(reader, 0, reader.Size()))
+			if err != nil {
+				t.Fatalf("read image: %v", err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Error("decompressed image does not match the original")
+			}
+
+			// A read spanning a block boundary
+			buf := make([]byte, 100)
+			if _, err := reader.ReadAt(buf, 2048-50); err != nil {
+				t.Fatalf("ReadAt() error = %v", err)
+			}
+			if !bytes.Equal(buf, data[2048-50:2048+50]) {
+				t.Error("ReadAt() across blocks returned the wrong bytes")
+			}
+
+			// A read running off the end
+			bytesRead, err := reader.ReadAt(buf, int64(len(data)-10))
+			if bytesRead != 10 || !errors.Is(err, io.EOF) {
+				t.Errorf("ReadAt() past end = %d, %v; want 10, io.EOF", bytesRead, err)
+			}
+		})
+	}
+}
+
+func TestNewReader_Invalid(t *testing.T) {
+	t.Parallel()
+
+	valid := encodeImage(t, testData(4096), 2048, false, nil)
+	withByte := func(offset int, value byte) []byte {
+		image := bytes.Clone(valid)
+		image[offset] = value
+		return image
+	}
+
+	tests := []struct {
+		want  error
+		name  string
+		image []byte
+	}{
+		{name: "bad magic", image: withByte(0, 'X'), want: ErrInvalidMagic},
+		{name: "version 2", image: withByte(20, 2), want: ErrUnsupportedVersion},
+		{name: "zero block size", image: func() []byte {
+			image := bytes.Clone(valid)
+			binary.LittleEndian.PutUint32(image[16:20], 0)
+			return image
+		}(), want: ErrInvalidHeader},
+		{name: "index out of order", image: func() []byte {
+			image := bytes.Clone(valid)
+			binary.LittleEndian.PutUint32(image[headerSize+4:], 1)
+			return image
+		}(), want: ErrInvalidHeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := NewReader(bytes.NewReader(tt.image)); !errors.Is(err, tt.want) {
+				t.Errorf("NewReader() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReader_CorruptBlock(t *testing.T) {
+	t.Parallel()
+
+	image := encodeImage(t, testData(4096), 2048, false, nil)
+	// Overwrite the first compressed block with an invalid deflate block type
+	start := binary.LittleEndian.Uint32(image[headerSize:])
+	image[start] = 0xFF
+
+	reader, err := NewReader(bytes.NewReader(image))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	if _, err := reader.ReadAt(make([]byte, 16), 0); !errors.Is(err, ErrDecompressFailed) {
+		t.Errorf("ReadAt() error = %v, want ErrDecompressFailed", err)
+	}
+}
+
+func TestHasMagic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		data []byte
+		want bool
+	}{
+		{data: []byte("CISO\x18\x00"), want: true},
+		{data: []byte("ZISO"), want: true},
+		{data: []byte("CIS"), want: false},
+		{data: []byte("MComprHD"), want: false},
+	}
+	for _, tt := range tests {
+		if got := HasMagic(tt.data); got != tt.want {
+			t.Errorf("HasMagic(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestOpen_Fixture(t *testing.T) {
+	t.Parallel()
+
+	reader, err := Open("../testdata/PSP/minimal.cso")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	pvd := make([]byte, 6)
+	if _, err := reader.ReadAt(pvd, 16*2048); err != nil {
+		t.Fatalf("ReadAt() error = %v", err)
+	}
+	if !bytes.Equal(pvd, []byte("\x01CD001")) {
+		t.Errorf("PVD signature = %q, want \\x01CD001", pvd)
+	}
+}
+
+func TestOpen_NonExistent(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Open("../testdata/PSP/missing.cso"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Open() error = %v, want os.ErrNotExist", err)
+	}
+}
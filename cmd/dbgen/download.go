@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// minRows is the fewest data rows each GameDB TSV is expected to have, set
+// to roughly half of each release's size. A download far below its floor is
+// almost always truncated or an error page rather than a real shrink.
+var minRows = map[string]int{
+	"GB": 500, "GBA": 1400, "GBC": 500, "GC": 850, "Genesis": 1600, "N64": 350, "NeoGeoCD": 90,
+	"NES": 3200, "PSP": 3900, "PSX": 5900, "PS2": 6500, "Saturn": 1100, "SegaCD": 160, "SNES": 1700,
+}
+
+// tsvSource says where GameDB TSVs come from: the GitHub releases, or a
+// local directory of previously downloaded files for offline, reproducible
+// builds.
+type tsvSource struct {
+	dir        string
+	retries    int
+	retryDelay time.Duration
+	checkRows  bool
+}
+
+// read returns the TSV for console.
+func (s tsvSource) read(console string) ([]byte, error) {
+	if s.dir != "" {
+		path := filepath.Join(s.dir, fmt.Sprintf(gameDBFileTemplate, console))
+		data, err := os.ReadFile(path) //nolint:gosec // Directory comes from command line arguments
+		if err != nil {
+			return nil, fmt.Errorf("read TSV: %w", err)
+		}
+		return data, nil
+	}
+	url := fmt.Sprintf(gameDBURLTemplate, console, console)
+	return fetchTSV(http.DefaultClient, url, s.retries, s.retryDelay)
+}
+
+// fetchTSV downloads url, retrying up to retries more times with a delay that
+// doubles after every failed attempt. The body is read in full so a
+// connection dropped mid-transfer is retried rather than parsed.
+func fetchTSV(client *http.Client, url string, retries int, delay time.Duration) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "Retrying %s in %s (attempt %d/%d): %v\n",
+				url, delay, attempt+1, retries+1, lastErr)
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		data, err := fetchOnce(client, url)
+		if err == nil {
+			return data, nil
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("failed to download after %d attempts: %w", retries+1, lastErr)
+}
+
+func fetchOnce(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url) //nolint:noctx // CLI tool without cancellation
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+	return data, nil
+}
+
+// checkRowCount fails when a console's TSV has fewer rows than its floor.
+// Consoles without a floor are only required to have at least one row.
+func checkRowCount(console string, rows int) error {
+	floor := max(minRows[console], 1)
+	if rows < floor {
+		return fmt.Errorf("only %d rows, expected at least %d (truncated download?)", rows, floor)
+	}
+	return nil
+}
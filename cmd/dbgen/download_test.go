@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ZaparooProject/go-gameid"
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+func TestFetchTSV_RetriesUntilSuccess(t *testing.T) {
+	t.Parallel()
+
+	const body = "ID\ttitle\nAXVE\tPokemon Ruby\n"
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if requests.Add(1) <= 2 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	data, err := fetchTSV(server.Client(), server.URL, 3, time.Millisecond)
+	if err != nil {
+		t.Fatalf("fetchTSV() error = %v", err)
+	}
+	if string(data) != body {
+		t.Errorf("fetchTSV() = %q, want %q", data, body)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+}
+
+func TestFetchTSV_GivesUp(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := fetchTSV(server.Client(), server.URL, 2, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 404") {
+		t.Errorf("fetchTSV() error = %v, want HTTP 404 after retries", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+}
+
+func TestParseConsoleTSV_RowFloor(t *testing.T) {
+	t.Parallel()
+
+	tsv := "ID\ttitle\nAXVE\tPokemon Ruby\nBPEE\tPokemon Emerald\n\tno ID\n"
+	db := newDatabase()
+	rows, err := parseConsoleTSV(db, "GBA", bytes.NewReader([]byte(tsv)))
+	if err != nil {
+		t.Fatalf("parseConsoleTSV() error = %v", err)
+	}
+	if rows != 3 {
+		t.Errorf("rows = %d, want 3", rows)
+	}
+	if len(db.GBA) != 2 {
+		t.Errorf("len(GBA) = %d, want 2", len(db.GBA))
+	}
+
+	if err := checkRowCount("GBA", rows); err == nil {
+		t.Error("checkRowCount() should reject a GBA TSV of 3 rows")
+	}
+	if err := checkRowCount("GBA", minRows["GBA"]); err != nil {
+		t.Errorf("checkRowCount() at the floor error = %v", err)
+	}
+	if err := checkRowCount("Unknown", 0); err == nil {
+		t.Error("checkRowCount() should reject an empty TSV")
+	}
+}
+
+func TestParseConsoleTSV_DuplicateIDs(t *testing.T) {
+	t.Parallel()
+
+	tsv := "ID\ttitle\tregion\nT-12345\tTest Game\tUSA\nT-12345\tTest Game\tEurope\n"
+	db := newDatabase()
+	if _, err := parseConsoleTSV(db, "Saturn", bytes.NewReader([]byte(tsv))); err != nil {
+		t.Fatalf("parseConsoleTSV() error = %v", err)
+	}
+	gameDB, err := toGameDatabase(db)
+	if err != nil {
+		t.Fatalf("toGameDatabase() error = %v", err)
+	}
+	entries, found := gameDB.LookupAll(identifier.ConsoleSaturn, "T12345")
+	if !found || len(entries) != 2 || entries[0]["region"] != "USA" || entries[1]["region"] != "Europe" {
+		t.Errorf("LookupAll() = %v, %v; want both regional rows in order", entries, found)
+	}
+}
+
+func TestSelectConsoles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		list    string
+		want    []string
+		wantErr string
+	}{
+		{name: "all", list: "", want: consoles},
+		{name: "case and spaces", list: "gba, SNES", want: []string{"GBA", "SNES"}},
+		{name: "trailing comma", list: "GBA,", want: []string{"GBA"}},
+		{name: "typo", list: "snse", wantErr: "unknown consoles snse"},
+		{name: "typos among valid names", list: "GBA,snse,xbox", wantErr: "unknown consoles snse, xbox"},
+		{name: "only commas", list: ",", wantErr: "no consoles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := selectConsoles(tt.list)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("selectConsoles(%q) error = %v, want one containing %q", tt.list, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectConsoles(%q) error = %v", tt.list, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("selectConsoles(%q) = %v, want %v", tt.list, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildDatabase_SourceDir(t *testing.T) {
+	t.Parallel()
+
+	src := tsvSource{dir: "testdata"}
+	path := filepath.Join(t.TempDir(), "games.gob.gz")
+	if err := writeSingle(src, []string{"GBA", "PSX"}, path); err != nil {
+		t.Fatalf("writeSingle() error = %v", err)
+	}
+
+	db, err := gameid.LoadDatabase(path)
+	if err != nil {
+		t.Fatalf("LoadDatabase() error = %v", err)
+	}
+	tests := []struct {
+		console identifier.Console
+		key     string
+		title   string
+	}{
+		{console: identifier.ConsoleGBA, key: "BPEE", title: "Pokemon Emerald Version"},
+		{console: identifier.ConsolePSX, key: "SLUS_00594", title: "Metal Gear Solid (Disc 1)"},
+		{console: identifier.ConsolePSX, key: "Metal Gear Solid (USA) (Disc 1)", title: "Metal Gear Solid (Disc 1)"},
+		{console: identifier.ConsolePSX, key: "SCUS_94163", title: "Final Fantasy VII (Disc 1)"},
+	}
+	for _, tt := range tests {
+		entry, found := db.LookupByString(tt.console, tt.key)
+		if !found {
+			t.Errorf("LookupByString(%s, %q) not found", tt.console, tt.key)
+			continue
+		}
+		if entry["title"] != tt.title {
+			t.Errorf("LookupByString(%s, %q) title = %q, want %q", tt.console, tt.key, entry["title"], tt.title)
+		}
+	}
+	prefixes := db.GetIDPrefixes(identifier.ConsolePSX)
+	if !slices.Contains(prefixes, "SLUS") || !slices.Contains(prefixes, "SCUS") {
+		t.Errorf("PSX prefixes = %v, want SLUS and SCUS", prefixes)
+	}
+}
+
+func TestBuildDatabase_SourceDirErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := buildDatabase(tsvSource{dir: "testdata"}, []string{"N64"}); err == nil {
+		t.Error("buildDatabase() should fail for a console without a TSV file")
+	}
+	if _, err := buildDatabase(tsvSource{dir: "testdata", checkRows: true}, []string{"GBA"}); err == nil {
+		t.Error("buildDatabase() should fail when a TSV is below its row floor")
+	}
+}
@@ -21,20 +21,30 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"compress/gzip"
 	"encoding/gob"
+	"errors"
+	"flag"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/ZaparooProject/go-gameid"
 	"github.com/ZaparooProject/go-gameid/identifier"
 )
 
 // GameDB TSV URL template
 const gameDBURLTemplate = "https://github.com/niemasd/GameDB-%s/releases/latest/download/%s.data.tsv"
 
+// GameDB TSV file name template, matching the release asset name
+const gameDBFileTemplate = "%s.data.tsv"
+
 // Consoles to download
 var consoles = []string{
 	"GB", "GBA", "GBC", "GC", "Genesis", "N64", "NeoGeoCD", "NES", "PSP", "PSX", "PS2", "Saturn", "SegaCD", "SNES",
@@ -62,6 +72,7 @@ type neogeoCDKey struct {
 
 // Database structure matching the main package
 type Database struct {
+	Version    int
 	GB         map[gbKey]map[string]string
 	GBA        map[string]map[string]string
 	GC         map[string]map[string]string
@@ -76,17 +87,81 @@ type Database struct {
 	SNES       map[snesKey]map[string]string
 	NeoGeoCD   map[neogeoCDKey]map[string]string
 	IDPrefixes map[identifier.Console][]string
+	Variants   map[identifier.Console]map[string][]map[string]string
 }
 
+var (
+	jsonOutput  = flag.Bool("json", false, "write the database as JSON instead of gob.gz")
+	shardOutput = flag.Bool("shards", false, "write one database per console into the output directory")
+	zstdOutput  = flag.Bool("zstd", false, "write -shards as gob.zst instead of gob.gz")
+	consoleList = flag.String("consoles", "", "comma-separated consoles to download (default: all)")
+	retries     = flag.Int("retries", 3, "times to retry a failed download")
+	retryDelay  = flag.Duration("retry-delay", 2*time.Second, "delay before the first retry, doubled after each")
+
+	skipRowCheck = flag.Bool("skip-row-check", false, "accept consoles with fewer rows than expected")
+	sourceDir    = flag.String("source-dir", "", "read <console>.data.tsv files from this directory instead of downloading")
+)
+
 func main() {
-	if len(os.Args) != 2 {
-		_, _ = fmt.Fprintf(os.Stderr, "Usage: %s <output.gob.gz>\n", os.Args[0])
+	if len(os.Args) > 1 && os.Args[1] == "merge" {
+		if err := mergeShards(os.Args[2:]); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		_, _ = fmt.Println("Done!")
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "stats" {
+		if err := showStats(os.Args[2:]); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage: %s [-json] [-consoles GBA,PS2] [-source-dir dir] <output.gob.gz|output.gob.zst|output.json>\n", os.Args[0])
+		_, _ = fmt.Fprintf(os.Stderr, "       %s -shards [-json|-zstd] [-consoles GBA,PS2] <output-dir>\n", os.Args[0])
+		_, _ = fmt.Fprintf(os.Stderr, "       %s merge <output.gob.gz|output.gob.zst|output.json> <shard> [shard ...]\n", os.Args[0])
+		_, _ = fmt.Fprintf(os.Stderr, "       %s stats <database>\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	outputPath := os.Args[1]
+	outputPath := flag.Arg(0)
+	selected, err := selectConsoles(*consoleList)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	src := tsvSource{
+		dir:        *sourceDir,
+		retries:    *retries,
+		retryDelay: *retryDelay,
+		checkRows:  !*skipRowCheck,
+	}
+
+	if *shardOutput {
+		err = writeShards(src, selected, outputPath)
+	} else {
+		err = writeSingle(src, selected, outputPath)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
-	db := &Database{
+	_, _ = fmt.Println("Done!")
+}
+
+func newDatabase() *Database {
+	return &Database{
+		Version:    gameid.DatabaseVersion,
 		GB:         make(map[gbKey]map[string]string),
 		GBA:        make(map[string]map[string]string),
 		GC:         make(map[string]map[string]string),
@@ -101,47 +176,252 @@ func main() {
 		SNES:       make(map[snesKey]map[string]string),
 		NeoGeoCD:   make(map[neogeoCDKey]map[string]string),
 		IDPrefixes: make(map[identifier.Console][]string),
+		Variants:   make(map[identifier.Console]map[string][]map[string]string),
 	}
+}
 
-	for _, console := range consoles {
+// addByID stores metadata under id in the string-keyed table of console. A
+// later row with the same ID, typically another regional release, is kept as
+// a variant rather than overwriting the first.
+func addByID(db *Database, console identifier.Console, table map[string]map[string]string, id string,
+	metadata map[string]string,
+) {
+	if _, exists := table[id]; !exists {
+		table[id] = metadata
+		return
+	}
+	if db.Variants[console] == nil {
+		db.Variants[console] = make(map[string][]map[string]string)
+	}
+	db.Variants[console][id] = append(db.Variants[console][id], metadata)
+}
+
+// selectConsoles returns the consoles named in list, or all consoles if list
+// is empty. Names it doesn't recognize are an error, so a typo can't silently
+// produce a database missing that console.
+func selectConsoles(list string) ([]string, error) {
+	if list == "" {
+		return consoles, nil
+	}
+	var selected, unknown []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		index := slices.IndexFunc(consoles, func(console string) bool { return strings.EqualFold(name, console) })
+		if index < 0 {
+			unknown = append(unknown, name)
+			continue
+		}
+		selected = append(selected, consoles[index])
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown consoles %s (known: %s)",
+			strings.Join(unknown, ", "), strings.Join(consoles, ", "))
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no consoles in %q", list)
+	}
+	return selected, nil
+}
+
+// buildDatabase loads the given consoles from src into a single database.
+// Any console that fails to load or comes back short is an error, so a
+// partial database is never written.
+func buildDatabase(src tsvSource, selected []string) (*Database, error) {
+	db := newDatabase()
+	for _, console := range selected {
 		_, _ = fmt.Printf("Loading GameDB-%s...\n", console)
-		if err := loadConsole(db, console); err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Warning: failed to load %s: %v\n", console, err)
+		if err := loadConsole(db, src, console); err != nil {
+			return nil, fmt.Errorf("load %s: %w", console, err)
 		}
 	}
 
-	// Apply fixups
 	applyFixups(db)
+	return db, nil
+}
 
-	// Save database
-	_, _ = fmt.Printf("Writing database to %s...\n", outputPath)
-	if err := saveDatabase(db, outputPath); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+// writeSingle loads the given consoles and writes them to one database.
+func writeSingle(src tsvSource, selected []string, path string) error {
+	db, err := buildDatabase(src, selected)
+	if err != nil {
+		return err
 	}
+	_, _ = fmt.Printf("Writing database to %s...\n", path)
+	if err := writeDatabase(db, path); err != nil {
+		return err
+	}
+	reportStats(db)
+	return nil
+}
 
-	_, _ = fmt.Println("Done!")
+// writeShards downloads each console into its own database file in dir,
+// so a single console can be refreshed without refetching the others.
+func writeShards(src tsvSource, selected []string, dir string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("create shard directory: %w", err)
+	}
+
+	ext := ".gob.gz"
+	switch {
+	case *jsonOutput:
+		ext = ".json"
+	case *zstdOutput:
+		ext = ".gob.zst"
+	}
+	for _, console := range selected {
+		db, err := buildDatabase(src, []string{console})
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, console+ext)
+		_, _ = fmt.Printf("Writing %s shard to %s...\n", console, path)
+		if err := writeDatabase(db, path); err != nil {
+			return fmt.Errorf("write %s shard: %w", console, err)
+		}
+		reportStats(db)
+	}
+	return nil
 }
 
-//nolint:gocognit,gocyclo,revive,cyclop,funlen // CLI tool complexity and switch statement required
-func loadConsole(db *Database, console string) error {
-	url := fmt.Sprintf(gameDBURLTemplate, console, console)
+// reportStats prints the entry counts of a generated database. A console
+// whose count drops sharply between runs usually failed to download.
+func reportStats(db *Database) {
+	gameDB, err := toGameDatabase(db)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: failed to compute statistics: %v\n", err)
+		return
+	}
+	printStats(gameDB.Stats())
+}
 
-	resp, err := http.Get(url) //nolint:gosec,noctx // URL is constructed from trusted template
+// showStats prints the statistics of an existing database.
+// Usage: stats <database>. The database may be gob.gz, gob.zst or JSON.
+func showStats(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: stats <database>")
+	}
+	db, err := loadShard(args[0])
+	if err != nil {
+		return err
+	}
+	printStats(db.Stats())
+	return nil
+}
+
+// printStats writes per-console entry counts, skipping empty tables.
+func printStats(stats gameid.DatabaseStats) {
+	consoles := make([]identifier.Console, 0, len(stats.Entries))
+	for console, count := range stats.Entries {
+		if count > 0 {
+			consoles = append(consoles, console)
+		}
+	}
+	slices.Sort(consoles)
+
+	_, _ = fmt.Println("Entries:")
+	for _, console := range consoles {
+		_, _ = fmt.Printf("  %-10s %7d\n", console, stats.Entries[console])
+	}
+	_, _ = fmt.Printf("  %-10s %7d\n", "Total", stats.Total)
+	_, _ = fmt.Printf("ID prefixes: %d\n", stats.IDPrefixes)
+}
+
+// writeDatabase saves db as JSON if requested, otherwise as compressed gob.
+func writeDatabase(db *Database, path string) error {
+	if *jsonOutput {
+		return saveDatabaseJSON(db, path)
+	}
+	return saveDatabase(db, path)
+}
+
+// mergeShards combines database shards into a single database.
+// Usage: merge <output> <shard>... The output and shards may be gob.gz, gob.zst or JSON,
+// chosen by file extension.
+func mergeShards(args []string) error {
+	if len(args) < 2 {
+		return errors.New("usage: merge <output.gob.gz|output.gob.zst|output.json> <shard> [shard ...]")
+	}
+
+	merged := gameid.NewDatabase()
+	for _, path := range args[1:] {
+		_, _ = fmt.Printf("Merging %s...\n", path)
+		shard, err := loadShard(path)
+		if err != nil {
+			return err
+		}
+		merged.Merge(shard)
+	}
+
+	outputPath := args[0]
+	_, _ = fmt.Printf("Writing database to %s...\n", outputPath)
+	if strings.HasSuffix(strings.ToLower(outputPath), ".json") {
+		file, err := os.Create(outputPath) //nolint:gosec // Path comes from command line arguments
+		if err != nil {
+			return fmt.Errorf("create database file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		if err := merged.ExportJSON(file); err != nil {
+			return fmt.Errorf("write database JSON: %w", err)
+		}
+		return nil
+	}
+	if err := merged.SaveDatabase(outputPath); err != nil {
+		return fmt.Errorf("save database: %w", err)
+	}
+	return nil
+}
+
+// loadShard loads a gob.gz, gob.zst or JSON database shard.
+func loadShard(path string) (*gameid.GameDatabase, error) {
+	if !strings.HasSuffix(strings.ToLower(path), ".json") {
+		db, err := gameid.LoadDatabase(path)
+		if err != nil {
+			return nil, fmt.Errorf("load shard %s: %w", path, err)
+		}
+		return db, nil
+	}
+
+	file, err := os.Open(path) //nolint:gosec // Path comes from command line arguments
+	if err != nil {
+		return nil, fmt.Errorf("open shard %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	db, err := gameid.LoadDatabaseJSON(file)
 	if err != nil {
-		return fmt.Errorf("failed to download: %w", err)
+		return nil, fmt.Errorf("load shard %s: %w", path, err)
 	}
-	defer func() { _ = resp.Body.Close() }()
+	return db, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP %d", resp.StatusCode)
+// loadConsole reads a console's GameDB TSV from src into db.
+func loadConsole(db *Database, src tsvSource, console string) error {
+	data, err := src.read(console)
+	if err != nil {
+		return err
 	}
+	rows, err := parseConsoleTSV(db, console, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if !src.checkRows {
+		return nil
+	}
+	return checkRowCount(console, rows)
+}
 
-	scanner := bufio.NewScanner(resp.Body)
+// parseConsoleTSV adds the rows of a GameDB TSV to db and returns the number
+// of data rows read.
+//
+//nolint:gocognit,gocyclo,revive,cyclop,funlen // CLI tool complexity and switch statement required
+func parseConsoleTSV(db *Database, console string, r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
 
 	// Read header
 	if !scanner.Scan() {
-		return fmt.Errorf("empty TSV")
+		return 0, fmt.Errorf("empty TSV")
 	}
 	header := strings.Split(scanner.Text(), "\t")
 	fieldIndex := make(map[string]int)
@@ -150,7 +430,9 @@ func loadConsole(db *Database, console string) error {
 	}
 
 	// Read data rows
+	rows := 0
 	for scanner.Scan() {
+		rows++
 		fields := strings.Split(scanner.Text(), "\t")
 
 		// Build metadata map (all fields except ID)
@@ -179,7 +461,7 @@ func loadConsole(db *Database, console string) error {
 		case "GB", "GBC":
 			addGB(db, id, metadata)
 		case "GBA":
-			db.GBA[id] = metadata
+			addByID(db, identifier.ConsoleGBA, db.GBA, id, metadata)
 		case "GC":
 			addGC(db, id, metadata)
 		case "Genesis":
@@ -191,7 +473,7 @@ func loadConsole(db *Database, console string) error {
 		case "NES":
 			addNES(db, id, metadata)
 		case "PSP":
-			db.PSP[id] = metadata
+			addByID(db, identifier.ConsolePSP, db.PSP, id, metadata)
 		case "PSX":
 			addPSXPS2(db, id, metadata, identifier.ConsolePSX)
 		case "PS2":
@@ -205,7 +487,10 @@ func loadConsole(db *Database, console string) error {
 		}
 	}
 
-	return scanner.Err()
+	if err := scanner.Err(); err != nil {
+		return rows, fmt.Errorf("read TSV: %w", err)
+	}
+	return rows, nil
 }
 
 func addGB(db *Database, _ string, metadata map[string]string) {
@@ -233,7 +518,7 @@ func addGC(db *Database, id string, metadata map[string]string) {
 	if len(parts) >= 2 {
 		id = strings.TrimSpace(parts[1])
 	}
-	db.GC[id] = metadata
+	addByID(db, identifier.ConsoleGC, db.GC, id, metadata)
 }
 
 func addGenesis(db *Database, id string, metadata map[string]string) {
@@ -242,7 +527,7 @@ func addGenesis(db *Database, id string, metadata map[string]string) {
 	id = strings.ReplaceAll(parts[0], "-", "")
 	id = strings.ReplaceAll(id, " ", "")
 	id = strings.TrimSpace(id)
-	db.Genesis[id] = metadata
+	addByID(db, identifier.ConsoleGenesis, db.Genesis, id, metadata)
 }
 
 func addN64(db *Database, id string, metadata map[string]string) {
@@ -254,7 +539,7 @@ func addN64(db *Database, id string, metadata map[string]string) {
 			id = cartID[1:4] // Skip first char, take next 3
 		}
 	}
-	db.N64[id] = metadata
+	addByID(db, identifier.ConsoleN64, db.N64, id, metadata)
 }
 
 func addNeoGeoCD(db *Database, metadata map[string]string) {
@@ -292,15 +577,15 @@ func addPSXPS2(db *Database, id string, metadata map[string]string, console iden
 	id = strings.ReplaceAll(id, "-", "_")
 
 	if console == identifier.ConsolePSX {
-		db.PSX[id] = metadata
+		addByID(db, console, db.PSX, id, metadata)
 		// Also add by redump_name if present
 		if redumpName, ok := metadata["redump_name"]; ok && redumpName != "" {
-			db.PSX[redumpName] = metadata
+			addByID(db, console, db.PSX, redumpName, metadata)
 		}
 	} else {
-		db.PS2[id] = metadata
+		addByID(db, console, db.PS2, id, metadata)
 		if redumpName, ok := metadata["redump_name"]; ok && redumpName != "" {
-			db.PS2[redumpName] = metadata
+			addByID(db, console, db.PS2, redumpName, metadata)
 		}
 	}
 }
@@ -311,7 +596,7 @@ func addSaturn(db *Database, id string, metadata map[string]string) {
 	id = strings.ReplaceAll(parts[0], "-", "")
 	id = strings.ReplaceAll(id, " ", "")
 	id = strings.TrimSpace(id)
-	db.Saturn[id] = metadata
+	addByID(db, identifier.ConsoleSaturn, db.Saturn, id, metadata)
 }
 
 func addSegaCD(db *Database, id string, metadata map[string]string) {
@@ -319,7 +604,7 @@ func addSegaCD(db *Database, id string, metadata map[string]string) {
 	id = strings.ReplaceAll(id, "-", "")
 	id = strings.ReplaceAll(id, " ", "")
 	id = strings.TrimSpace(id)
-	db.SegaCD[id] = metadata
+	addByID(db, identifier.ConsoleSegaCD, db.SegaCD, id, metadata)
 }
 
 func addSNES(db *Database, _ string, metadata map[string]string) {
@@ -391,7 +676,20 @@ func buildIDPrefixes(games map[string]map[string]string) []string {
 	return result
 }
 
+// saveDatabase writes the database as gob, compressed with zstd when the
+// path ends in .zst and gzip otherwise.
 func saveDatabase(db *Database, path string) error {
+	if strings.EqualFold(filepath.Ext(path), ".zst") {
+		gameDB, err := toGameDatabase(db)
+		if err != nil {
+			return err
+		}
+		if err := gameDB.SaveDatabase(path); err != nil {
+			return fmt.Errorf("save database: %w", err)
+		}
+		return nil
+	}
+
 	file, err := os.Create(path) //nolint:gosec // Path comes from command line arguments
 	if err != nil {
 		return fmt.Errorf("create database file: %w", err)
@@ -407,3 +705,36 @@ func saveDatabase(db *Database, path string) error {
 	}
 	return nil
 }
+
+// toGameDatabase converts the database to the library's type via gob, which
+// matches fields by name, so every output format describes the same data.
+func toGameDatabase(db *Database) (*gameid.GameDatabase, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(db); err != nil {
+		return nil, fmt.Errorf("encode database: %w", err)
+	}
+	gameDB := gameid.NewDatabase()
+	if err := gob.NewDecoder(&buf).Decode(gameDB); err != nil {
+		return nil, fmt.Errorf("convert database: %w", err)
+	}
+	return gameDB, nil
+}
+
+// saveDatabaseJSON writes the database as JSON using the library's schema.
+func saveDatabaseJSON(db *Database, path string) error {
+	gameDB, err := toGameDatabase(db)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path) //nolint:gosec // Path comes from command line arguments
+	if err != nil {
+		return fmt.Errorf("create database file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := gameDB.ExportJSON(file); err != nil {
+		return fmt.Errorf("write database JSON: %w", err)
+	}
+	return nil
+}
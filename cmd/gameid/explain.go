@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/ZaparooProject/go-gameid"
+)
+
+// explainSteps describes the diagnostic events reported through
+// gameid.SetLogger. Events missing here are printed by name.
+var explainSteps = map[string]string{
+	"detect.extension":  "extension decided the console",
+	"detect.registered": "registered detector consulted",
+	"detect.magic":      "header magic matched",
+	"detect.boot":       "disc boot header matched",
+	"detect.iso":        "ISO9660 filesystem decided the console",
+	"iso.pvd":           "ISO9660 primary volume descriptor found",
+	"gba.header":        "GBA header read",
+	"db.lookup":         "database lookup",
+}
+
+// startExplain installs a logger that prints each detection and
+// identification step to w. The returned function removes it.
+func startExplain(w io.Writer) func() {
+	var mu sync.Mutex
+	gameid.SetLogger(func(event string, kv ...any) {
+		line := formatExplainStep(event, kv)
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = fmt.Fprintln(w, line)
+	})
+	return func() { gameid.SetLogger(nil) }
+}
+
+// formatExplainStep renders one event as "  <step>: key=value ...".
+// db.lookup reports its found flag as hit or miss.
+func formatExplainStep(event string, kv []any) string {
+	var sb strings.Builder
+	step, ok := explainSteps[event]
+	if !ok {
+		step = event
+	}
+	_, _ = sb.WriteString("  " + step + ":")
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, value := fmt.Sprint(kv[i]), kv[i+1]
+		if event == "db.lookup" && key == "found" {
+			if found, isBool := value.(bool); isBool {
+				_, _ = sb.WriteString(map[bool]string{true: " hit", false: " miss"}[found])
+				continue
+			}
+		}
+		if value == nil {
+			continue
+		}
+		_, _ = fmt.Fprintf(&sb, " %s=%v", key, value)
+	}
+	return sb.String()
+}
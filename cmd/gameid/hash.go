@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"crypto/md5"  //nolint:gosec // MD5 is used to match DAT files, not for security
+	"crypto/sha1" //nolint:gosec // SHA-1 is used to match DAT files, not for security
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ZaparooProject/go-gameid"
+	"github.com/ZaparooProject/go-gameid/archive"
+)
+
+// hashAlgorithms maps -hash names to their constructors, in output order.
+var hashAlgorithms = []struct {
+	newHash func() hash.Hash
+	name    string
+}{
+	{name: "crc32", newHash: func() hash.Hash { return crc32.NewIEEE() }},
+	{name: "md5", newHash: md5.New},
+	{name: "sha1", newHash: sha1.New},
+}
+
+// parseHashList parses the comma-separated -hash value.
+func parseHashList(list string) ([]string, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	requested := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !isHashAlgorithm(name) {
+			return nil, fmt.Errorf("unknown hash algorithm %q (supported: crc32, md5, sha1)", name)
+		}
+		requested[name] = true
+	}
+
+	var names []string
+	for _, algo := range hashAlgorithms {
+		if requested[algo.name] {
+			names = append(names, algo.name)
+		}
+	}
+	return names, nil
+}
+
+func isHashAlgorithm(name string) bool {
+	for _, algo := range hashAlgorithms {
+		if algo.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addHashes computes the requested digests over the whole game file and
+// stores them in the result's metadata under the algorithm name.
+// Disc images are skipped: their files are containers (CUE/BIN, CHD, ISO)
+// whose raw digests do not match the per-track hashes used in DAT files.
+func addHashes(result *gameid.Result, path string, names []string, stderr io.Writer) error {
+	if len(names) == 0 {
+		return nil
+	}
+	if gameid.IsDiscBased(result.Console) {
+		_, _ = fmt.Fprintf(stderr, "Note: skipping -hash for %s: full-file hashing is not meaningful for disc images\n",
+			path)
+		return nil
+	}
+
+	reader, err := openGameFile(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+
+	hashes := make([]hash.Hash, len(names))
+	writers := make([]io.Writer, len(names))
+	for idx, name := range names {
+		for _, algo := range hashAlgorithms {
+			if algo.name == name {
+				hashes[idx] = algo.newHash()
+			}
+		}
+		writers[idx] = hashes[idx]
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), reader); err != nil {
+		return fmt.Errorf("hash %s: %w", path, err)
+	}
+
+	for idx, name := range names {
+		result.Metadata[name] = hex.EncodeToString(hashes[idx].Sum(nil))
+	}
+	return nil
+}
+
+// openGameFile opens path for reading, resolving archive paths
+// ("game.zip/inner.gba") to the file inside the archive.
+func openGameFile(path string) (io.ReadCloser, error) {
+	arcPath, err := archive.ParsePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("parse archive path: %w", err)
+	}
+	if arcPath == nil {
+		file, err := os.Open(path) //nolint:gosec // Path from user input is expected
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", path, err)
+		}
+		return file, nil
+	}
+
+	arc, err := archive.OpenPath(arcPath)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	internalPath := arcPath.InternalPath
+	if internalPath == "" {
+		if internalPath, err = archive.DetectGameFile(arc); err != nil {
+			_ = arc.Close()
+			return nil, fmt.Errorf("detect game file: %w", err)
+		}
+	}
+	reader, _, err := arc.Open(internalPath)
+	if err != nil {
+		_ = arc.Close()
+		return nil, fmt.Errorf("open %s in archive: %w", internalPath, err)
+	}
+	return archiveFile{ReadCloser: reader, archive: arc}, nil
+}
+
+// archiveFile closes the owning archive along with the file.
+type archiveFile struct {
+	io.ReadCloser
+
+	archive archive.Archive
+}
+
+func (f archiveFile) Close() error {
+	fileErr := f.ReadCloser.Close()
+	if err := f.archive.Close(); err != nil {
+		return fmt.Errorf("close archive: %w", err)
+	}
+	if fileErr != nil {
+		return fmt.Errorf("close archive file: %w", fileErr)
+	}
+	return nil
+}
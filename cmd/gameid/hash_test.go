@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // Matching the CLI's DAT-style digests
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_HashText(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "game.gba")
+	rom := writeGBAROM(t, path, "ATST")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-hash", "crc32", path}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+
+	want := fmt.Sprintf("Crc32: %08x", crc32.ChecksumIEEE(rom))
+	if !strings.Contains(stdout.String(), want) {
+		t.Errorf("stdout = %q, want line %q", stdout.String(), want)
+	}
+	if strings.Contains(stdout.String(), "Sha1") {
+		t.Error("only the requested hash should be printed")
+	}
+}
+
+func TestRun_HashJSON(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "game.gba")
+	rom := writeGBAROM(t, path, "ATST")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-json", "-hash", "SHA1, crc32", path}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+
+	var result struct {
+		Metadata map[string]string `json:"Metadata"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	sum := sha1.Sum(rom) //nolint:gosec // Matching the CLI's DAT-style digests
+	if got, want := result.Metadata["sha1"], hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("sha1 = %q, want %q", got, want)
+	}
+	if got, want := result.Metadata["crc32"], fmt.Sprintf("%08x", crc32.ChecksumIEEE(rom)); got != want {
+		t.Errorf("crc32 = %q, want %q", got, want)
+	}
+	if _, ok := result.Metadata["md5"]; ok {
+		t.Error("md5 was not requested")
+	}
+}
+
+func TestRun_HashArchiveEntry(t *testing.T) {
+	t.Parallel()
+
+	root := createScanTree(t)
+	path := filepath.Join(root, "packed.zip") + "/inner.gba"
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-hash", "md5", path}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Md5: ") {
+		t.Errorf("stdout = %q, want md5 line", stdout.String())
+	}
+}
+
+func TestRun_HashSkipsDiscs(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-hash", "crc32", "../../testdata/GC/GameCube-240pSuite-1.17.iso"}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "skipping -hash") {
+		t.Errorf("stderr = %q, want a note about skipped hashing", stderr.String())
+	}
+	if strings.Contains(stdout.String(), "Crc32") {
+		t.Error("disc images should not be hashed")
+	}
+}
+
+func TestRun_HashInvalidAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-hash", "crc32,sha256", snesFixture}, &stdout, &stderr); code != exitUsage {
+		t.Errorf("run() = %d, want %d", code, exitUsage)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/ZaparooProject/go-gameid/iso9660"
+)
+
+// listFiles prints the ISO9660 file listing of each input for -list-files,
+// one "size path" line per file sorted by path. With several inputs each
+// listing is headed by the input name.
+func listFiles(paths []string, stdout, stderr io.Writer) int {
+	exitCode := exitOK
+	for i, path := range paths {
+		if len(paths) > 1 {
+			if i > 0 {
+				_, _ = fmt.Fprintln(stdout)
+			}
+			_, _ = fmt.Fprintln(stdout, path+":")
+		}
+		if err := listDiscFiles(path, stdout); err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error listing %s: %v\n", path, err)
+			exitCode = exitFailure
+		}
+	}
+	return exitCode
+}
+
+// listDiscFiles writes the file listing of the disc image at path.
+func listDiscFiles(path string, w io.Writer) error {
+	iso, err := openDiscFilesystem(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = iso.Close() }()
+
+	files, err := iso.IterFilesSorted(false)
+	if err != nil {
+		return fmt.Errorf("list files: %w", err)
+	}
+	for _, file := range files {
+		_, _ = fmt.Fprintf(w, "%10d  %s\n", file.Size, file.Path)
+	}
+	return nil
+}
+
+// openDiscFilesystem opens the ISO9660 filesystem of a CUE sheet, CHD,
+// compressed ISO or plain disc image, choosing the reader by extension.
+func openDiscFilesystem(path string) (*iso9660.ISO9660, error) {
+	var iso *iso9660.ISO9660
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".cue":
+		iso, err = iso9660.OpenCue(path)
+	case ".chd":
+		iso, err = iso9660.OpenCHD(path)
+	case ".cso", ".zso":
+		iso, err = iso9660.OpenCISO(path)
+	default:
+		iso, err = iso9660.Open(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open disc filesystem: %w", err)
+	}
+	return iso, nil
+}
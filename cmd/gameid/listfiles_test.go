@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun_ListFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, disc := range []string{
+		"../../testdata/NeoGeoCD/240pTestSuite.chd",
+		"../../testdata/NeoGeoCD/240pTestSuite.cue",
+	} {
+		t.Run(disc, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			if code := run([]string{"-list-files", "-i", disc}, &stdout, &stderr); code != exitOK {
+				t.Fatalf("run() = %d, want %d; stderr = %q", code, exitOK, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), "        71  /IPL.TXT\n") {
+				t.Errorf("stdout = %q, want a 71-byte /IPL.TXT", stdout.String())
+			}
+		})
+	}
+}
+
+func TestRun_ListFilesNotADisc(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-list-files", snesFixture, "../../testdata/NeoGeoCD/240pTestSuite.iso"}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Errorf("run() = %d, want %d", code, exitFailure)
+	}
+	if !strings.Contains(stderr.String(), "Error listing "+snesFixture) {
+		t.Errorf("stderr = %q, want an error for the cartridge", stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "/IPL.TXT") {
+		t.Errorf("stdout = %q, want the disc listed despite the failure", stdout.String())
+	}
+}
@@ -21,109 +21,327 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/ZaparooProject/go-gameid"
+	"github.com/ZaparooProject/go-gameid/archive"
 )
 
-var (
-	inputFile    = flag.String("i", "", "input file path (required)")
-	console      = flag.String("c", "", "console type (auto-detect if omitted)")
-	dbPath       = flag.String("db", "", "path to game database (gob.gz file)")
-	jsonOutput   = flag.Bool("json", false, "output as JSON")
-	listConsoles = flag.Bool("list-consoles", false, "list supported consoles and exit")
-	version      = flag.Bool("version", false, "print version and exit")
+const appVersion = "0.1.0"
+
+// Exit codes
+const (
+	exitOK      = 0 // All inputs were identified
+	exitFailure = 1 // At least one input could not be identified
+	exitUsage   = 2 // Invalid command line
 )
 
-const appVersion = "0.1.0"
+// errUsage signals that the command line was invalid and usage was printed.
+var errUsage = errors.New("invalid usage")
+
+// stringList is a flag.Value that collects repeated string flags.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// config holds the parsed command line.
+type config struct {
+	consoleFilter map[gameid.Console]bool
+	console       string
+	dbPath        string
+	consoles      string
+	hashes        string
+	inputs        []string
+	hashNames     []string
+	jsonOutput    bool
+	ndjsonOutput  bool
+	csvOutput     bool
+	summary       bool
+	recursive     bool
+	rawMetadata   bool
+	archives      bool
+	explain       bool
+	listConsoles  bool
+	listFiles     bool
+	selfTest      bool
+	version       bool
+}
 
-//nolint:gocognit,revive // Main entry point handles all CLI logic
 func main() {
-	flag.Usage = func() {
-		_, _ = fmt.Fprint(os.Stderr, "Usage: "+os.Args[0]+" -i <file> [options]\n\n")
-		_, _ = fmt.Fprint(os.Stderr, "Identifies video game files and returns metadata.\n\n")
-		_, _ = fmt.Fprint(os.Stderr, "Options:\n")
-		flag.PrintDefaults()
-		_, _ = fmt.Fprint(os.Stderr, "\nExamples:\n")
-		_, _ = fmt.Fprint(os.Stderr, "  "+os.Args[0]+" -i game.gba\n")
-		_, _ = fmt.Fprint(os.Stderr, "  "+os.Args[0]+" -i game.iso -c PSX\n")
-		_, _ = fmt.Fprint(os.Stderr, "  "+os.Args[0]+" -i game.n64 -db gamedb.gob.gz -json\n")
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the CLI with the given arguments and returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	cfg, err := parseFlags(args, stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
 	}
-	flag.Parse()
 
-	if *version {
-		fmt.Println("gameid version " + appVersion) //nolint:revive // Output for CLI
-		os.Exit(0)
+	if cfg.version {
+		_, _ = fmt.Fprintln(stdout, "gameid version "+appVersion)
+		return exitOK
 	}
 
-	if *listConsoles {
-		fmt.Println("Supported consoles:") //nolint:revive // Output for CLI
+	if cfg.selfTest {
+		return selfTest(stdout)
+	}
+
+	if cfg.listConsoles {
+		_, _ = fmt.Fprintln(stdout, "Supported consoles:")
 		for _, c := range gameid.AllConsoles {
-			fmt.Println("  " + string(c)) //nolint:revive // Output for CLI
+			_, _ = fmt.Fprintf(stdout, "  %-10s %s\n", c, strings.Join(gameid.ConsoleExtensions(c), " "))
 		}
-		os.Exit(0)
+		return exitOK
+	}
+
+	if cfg.listFiles {
+		return listFiles(cfg.inputs, stdout, stderr)
 	}
 
-	if *inputFile == "" {
-		_, _ = fmt.Fprint(os.Stderr, "Error: input file required (-i)\n")
-		flag.Usage()
-		os.Exit(1)
+	if cfg.explain {
+		defer startExplain(stderr)()
 	}
 
 	// Load database if specified
 	var db *gameid.GameDatabase
-	if *dbPath != "" {
-		var loadErr error
-		db, loadErr = gameid.LoadDatabase(*dbPath)
-		if loadErr != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error loading database: %v\n", loadErr)
-			os.Exit(1)
+	if cfg.dbPath != "" {
+		db, err = gameid.LoadDatabase(cfg.dbPath)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error loading database: %v\n", err)
+			return exitFailure
 		}
 	}
 
-	// Identify the game
-	var result *gameid.Result
-	var err error
+	var console gameid.Console
+	if cfg.console != "" {
+		console, err = gameid.ParseConsole(cfg.console)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: unknown console '%s'\n", cfg.console)
+			_, _ = fmt.Fprintf(stderr, "Use -list-consoles to see supported consoles\n")
+			return exitUsage
+		}
+	}
+
+	cfg.consoleFilter, err = parseConsoleFilter(cfg.consoles)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+		_, _ = fmt.Fprintf(stderr, "Use -list-consoles to see supported consoles\n")
+		return exitUsage
+	}
+
+	paths, scanOK := expandInputs(cfg, stderr)
+	exitCode := identifyAll(cfg, paths, console, db, stdout, stderr)
+	if !scanOK {
+		exitCode = exitFailure
+	}
+	return exitCode
+}
+
+// parseFlags parses the command line into a config.
+// Input files may be given with repeated -i flags or as positional arguments.
+func parseFlags(args []string, stderr io.Writer) (*config, error) {
+	cfg := &config{}
+	var inputs stringList
+
+	fs := flag.NewFlagSet("gameid", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Var(&inputs, "i", "input file path (repeatable; files may also be given as arguments)")
+	fs.StringVar(&cfg.console, "c", "", "console type (auto-detect if omitted)")
+	fs.StringVar(&cfg.dbPath, "db", "", "path to game database (gob.gz or gob.zst file)")
+	fs.BoolVar(&cfg.jsonOutput, "json", false, "output as JSON")
+	fs.BoolVar(&cfg.ndjsonOutput, "ndjson", false, "output one JSON object per line, with per-file errors")
+	fs.BoolVar(&cfg.csvOutput, "csv", false, "output a CSV table with one row per identified file")
+	fs.BoolVar(&cfg.rawMetadata, "raw", false, "print all metadata with keys exactly as stored (JSON is always raw)")
+	fs.BoolVar(&cfg.recursive, "r", false, "recursively scan directory inputs for games")
+	fs.BoolVar(&cfg.archives, "archives", false, "with -r, also identify games inside ZIP/7z/RAR archives")
+	fs.StringVar(&cfg.consoles, "consoles", "", "only report games for these comma-separated consoles")
+	fs.StringVar(&cfg.hashes, "hash", "", "compute file hashes for cartridge games (comma-separated: crc32,md5,sha1)")
+	fs.BoolVar(&cfg.summary, "summary", false, "print per-console counts and unidentified files to stderr when done")
+	fs.BoolVar(&cfg.explain, "explain", false, "print each detection and identification step to stderr")
+	fs.BoolVar(&cfg.listConsoles, "list-consoles", false, "list supported consoles and exit")
+	fs.BoolVar(&cfg.listFiles, "list-files", false, "list the files on each disc image (ISO, CUE, CHD, CSO) and exit")
+	fs.BoolVar(&cfg.selfTest, "selftest", false, "check that each CHD codec and archive format decodes, then exit")
+	fs.BoolVar(&cfg.version, "version", false, "print version and exit")
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(stderr, "Usage: gameid [options] -i <file> [file ...]\n\n")
+		_, _ = fmt.Fprint(stderr, "Identifies video game files and returns metadata.\n\n")
+		_, _ = fmt.Fprint(stderr, "Options:\n")
+		fs.PrintDefaults()
+		_, _ = fmt.Fprint(stderr, "\nExamples:\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -i game.gba\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -i game.iso -c PSX\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -i game.n64 -db gamedb.gob.gz -json\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -ndjson roms/*.gba\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -r -csv roms/ > games.csv\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -r -summary -ndjson roms/ > games.ndjson\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -r -archives -consoles GBA,SNES roms/\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -hash crc32,sha1 game.gba\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -explain -i game.iso\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -list-files -i game.chd\n")
+		_, _ = fmt.Fprint(stderr, "  gameid -selftest\n")
+		_, _ = fmt.Fprint(stderr, "\nExit status is 0 if every input was identified, 1 if any failed, 2 on usage errors.\n")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("parse flags: %w", err)
+	}
+	cfg.inputs = append(inputs, fs.Args()...)
+
+	if cfg.version || cfg.listConsoles || cfg.selfTest {
+		return cfg, nil
+	}
+	if len(cfg.inputs) == 0 {
+		_, _ = fmt.Fprint(stderr, "Error: input file required (-i)\n")
+		fs.Usage()
+		return nil, errUsage
+	}
+	if boolInt(cfg.jsonOutput)+boolInt(cfg.ndjsonOutput)+boolInt(cfg.csvOutput) > 1 {
+		_, _ = fmt.Fprint(stderr, "Error: -json, -ndjson and -csv are mutually exclusive\n")
+		return nil, errUsage
+	}
+	hashNames, err := parseHashList(cfg.hashes)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+		return nil, errUsage
+	}
+	cfg.hashNames = hashNames
+	return cfg, nil
+}
 
-	//nolint:nestif // Console identification requires conditional branching
-	if *console != "" {
-		// Console specified, use it directly
-		parsedConsole, parseErr := gameid.ParseConsole(*console)
-		if parseErr != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error: unknown console '%s'\n", *console)
-			_, _ = fmt.Fprintf(os.Stderr, "Use -list-consoles to see supported consoles\n")
-			os.Exit(1)
+// identifyAll identifies every path and writes the results.
+// A failure on one path is reported and does not stop the others.
+//
+//nolint:gocognit,revive // Dispatches each result to the selected output format
+func identifyAll(
+	cfg *config, paths []string, console gameid.Console, db *gameid.GameDatabase, stdout, stderr io.Writer,
+) int {
+	exitCode := exitOK
+	multiple := len(paths) > 1 || cfg.recursive
+	printed := 0
+	var csvResults []*gameid.Result
+	summary := newScanSummary()
+	for _, path := range paths {
+		if skipByExtension(cfg.consoleFilter, path) {
+			continue
 		}
-		result, err = gameid.IdentifyWithConsole(*inputFile, parsedConsole, db)
-		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error identifying game: %v\n", err)
-			os.Exit(1)
+		if cfg.explain {
+			_, _ = fmt.Fprintln(stderr, "Explain "+path+":")
 		}
-	} else {
-		// Auto-detect console
-		result, err = gameid.Identify(*inputFile, db)
+		result, err := identify(path, console, db)
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error identifying game: %v\n", err)
-			os.Exit(1)
+			exitCode = exitFailure
+		} else if cfg.consoleFilter != nil && !cfg.consoleFilter[result.Console] {
+			continue
+		} else if hashErr := addHashes(result, path, cfg.hashNames, stderr); hashErr != nil {
+			_, _ = fmt.Fprintf(stderr, "Error hashing %s: %v\n", path, hashErr)
+			exitCode = exitFailure
+		}
+		summary.add(path, result, err)
+
+		switch {
+		case cfg.ndjsonOutput:
+			if writeErr := outputNDJSON(stdout, path, result, err); writeErr != nil {
+				_, _ = fmt.Fprintf(stderr, "Error: %v\n", writeErr)
+				return exitFailure
+			}
+		case err != nil:
+			if !multiple {
+				_, _ = fmt.Fprintf(stderr, "Error identifying game: %v\n", err)
+			} else {
+				_, _ = fmt.Fprintf(stderr, "Error identifying %s: %v\n", path, err)
+			}
+			printArchiveChoices(stderr, path, err)
+		case cfg.jsonOutput:
+			if writeErr := outputJSON(stdout, result); writeErr != nil {
+				_, _ = fmt.Fprintf(stderr, "Error: %v\n", writeErr)
+				return exitFailure
+			}
+		case cfg.csvOutput:
+			csvResults = append(csvResults, result)
+		default:
+			if multiple {
+				if printed > 0 {
+					_, _ = fmt.Fprintln(stdout)
+				}
+				_, _ = fmt.Fprintln(stdout, "File: "+path)
+			}
+			if cfg.rawMetadata {
+				outputRaw(stdout, result)
+			} else {
+				outputText(stdout, result)
+			}
+			printed++
 		}
 	}
+	if cfg.csvOutput {
+		if err := gameid.WriteCSV(stdout, csvResults); err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+	}
+	if cfg.summary {
+		summary.write(stderr)
+	}
+	return exitCode
+}
+
+// boolInt returns 1 for true and 0 for false.
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// printArchiveChoices lists the game files of an ambiguous archive as paths
+// that can be passed back to select one.
+func printArchiveChoices(w io.Writer, path string, err error) {
+	var ambiguous archive.AmbiguousGameFilesError
+	if !errors.As(err, &ambiguous) {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Choose a file inside the archive:")
+	for _, file := range ambiguous.Files {
+		_, _ = fmt.Fprintf(w, "  %s/%s (%s)\n", path, file.Name, file.Console)
+	}
+}
 
-	// Output results
-	if *jsonOutput {
-		if jsonErr := outputJSON(result); jsonErr != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", jsonErr)
-			os.Exit(1)
+// identify identifies a single file, using console if it is set.
+func identify(path string, console gameid.Console, db *gameid.GameDatabase) (*gameid.Result, error) {
+	if console != "" {
+		result, err := gameid.IdentifyWithConsole(path, console, db)
+		if err != nil {
+			return nil, fmt.Errorf("identify with console %s: %w", console, err)
 		}
-	} else {
-		outputText(result)
+		return result, nil
 	}
+
+	result, err := gameid.Identify(path, db)
+	if err != nil {
+		return nil, fmt.Errorf("identify: %w", err)
+	}
+	return result, nil
 }
 
-func outputJSON(result *gameid.Result) error {
-	enc := json.NewEncoder(os.Stdout)
+func outputJSON(w io.Writer, result *gameid.Result) error {
+	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	if err := enc.Encode(result); err != nil {
 		return fmt.Errorf("encode JSON: %w", err)
@@ -131,45 +349,38 @@ func outputJSON(result *gameid.Result) error {
 	return nil
 }
 
-//nolint:gocognit,revive // Output formatting requires many conditional checks
-func outputText(result *gameid.Result) {
-	fmt.Println("Console: " + string(result.Console)) //nolint:revive // Output for CLI
-	if result.ID != "" {
-		fmt.Println("ID: " + result.ID) //nolint:revive // Output for CLI
-	}
-	if result.Title != "" {
-		fmt.Println("Title: " + result.Title) //nolint:revive // Output for CLI
-	}
-	if result.InternalTitle != "" && result.InternalTitle != result.Title {
-		fmt.Println("Internal Title: " + result.InternalTitle) //nolint:revive // Output for CLI
-	}
-	if result.Region != "" {
-		fmt.Println("Region: " + result.Region) //nolint:revive // Output for CLI
-	}
+// ndjsonRecord is one line of -ndjson output.
+type ndjsonRecord struct {
+	Result *gameid.Result `json:"result,omitempty"`
+	Path   string         `json:"path"`
+	Error  string         `json:"error,omitempty"`
+}
 
-	// Print other metadata (skip those already printed)
-	skipKeys := map[string]bool{
-		"ID": true, "title": true, "internal_title": true, "region": true,
+func outputNDJSON(w io.Writer, path string, result *gameid.Result, identifyErr error) error {
+	record := ndjsonRecord{Path: path, Result: result}
+	if identifyErr != nil {
+		record.Error = identifyErr.Error()
 	}
+	if err := json.NewEncoder(w).Encode(record); err != nil {
+		return fmt.Errorf("encode JSON: %w", err)
+	}
+	return nil
+}
 
-	if len(result.Metadata) > 0 {
-		var otherKeys []string
-		for key := range result.Metadata {
-			if !skipKeys[key] {
-				otherKeys = append(otherKeys, key)
-			}
-		}
+func outputText(w io.Writer, result *gameid.Result) {
+	_, _ = fmt.Fprintln(w, result.String())
+}
 
-		if len(otherKeys) > 0 {
-			fmt.Println("\nMetadata:") //nolint:revive // Output for CLI
-			for _, key := range otherKeys {
-				// Format key for display
-				displayKey := strings.ReplaceAll(key, "_", " ")
-				//nolint:staticcheck // strings.Title is fine for simple ASCII
-				displayKey = strings.Title(displayKey)
-				//nolint:revive // Output for CLI
-				fmt.Println("  " + displayKey + ": " + result.Metadata[key])
-			}
-		}
+// outputRaw prints the console followed by every metadata entry, sorted by
+// key, with keys and values exactly as stored.
+func outputRaw(w io.Writer, result *gameid.Result) {
+	_, _ = fmt.Fprintln(w, "console: "+string(result.Console))
+	keys := make([]string, 0, len(result.Metadata))
+	for key := range result.Metadata {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		_, _ = fmt.Fprintln(w, key+": "+result.Metadata[key])
 	}
 }
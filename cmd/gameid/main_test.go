@@ -0,0 +1,317 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const snesFixture = "../../testdata/SNES/240pSuite.sfc"
+
+func TestRun_SingleFile(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-i", snesFixture}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+	if !strings.HasPrefix(stdout.String(), "Console: SNES") {
+		t.Errorf("stdout = %q, want SNES result", stdout.String())
+	}
+	if strings.Contains(stdout.String(), "File: ") {
+		t.Error("single input should not print a File: header")
+	}
+}
+
+func TestRun_NDJSONMixedInputs(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	unsupported := filepath.Join(tmpDir, "notes.txt")
+	if err := os.WriteFile(unsupported, []byte("not a game"), 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	missing := filepath.Join(tmpDir, "missing.gba")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-ndjson", "-i", snesFixture, unsupported, missing}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Errorf("run() = %d, want %d", code, exitFailure)
+	}
+
+	type record struct {
+		Result map[string]any `json:"result"`
+		Path   string         `json:"path"`
+		Error  string         `json:"error"`
+	}
+	var records []record
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		records = append(records, rec)
+		if rec.Error == "" && rec.Result["Console"] != "SNES" {
+			t.Errorf("result for %s = %v, want SNES", rec.Path, rec.Result)
+		}
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("got %d NDJSON lines, want 3", len(records))
+	}
+	wantErr := map[string]bool{snesFixture: false, unsupported: true, missing: true}
+	for _, rec := range records {
+		if (rec.Error != "") != wantErr[rec.Path] {
+			t.Errorf("record %s error = %q, want error %v", rec.Path, rec.Error, wantErr[rec.Path])
+		}
+	}
+}
+
+func TestRun_CSV(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "missing.gba")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-csv", snesFixture, missing}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Errorf("run() = %d, want %d", code, exitFailure)
+	}
+
+	rows, err := csv.NewReader(&stdout).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d CSV rows, want header plus one result: %q", len(rows), rows)
+	}
+	if rows[1][0] != snesFixture || rows[1][1] != "SNES" {
+		t.Errorf("row = %q, want path %s and console SNES", rows[1], snesFixture)
+	}
+	if !strings.Contains(stderr.String(), missing) {
+		t.Errorf("stderr = %q, want an error for %s", stderr.String(), missing)
+	}
+}
+
+func TestRun_TextMultipleInputs(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "missing.gba")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{snesFixture, missing}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Errorf("run() = %d, want %d", code, exitFailure)
+	}
+	if !strings.Contains(stdout.String(), "File: "+snesFixture) {
+		t.Errorf("stdout = %q, want File: header", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "Error identifying "+missing) {
+		t.Errorf("stderr = %q, want error for missing file", stderr.String())
+	}
+}
+
+func TestRun_AmbiguousArchiveListsChoices(t *testing.T) {
+	t.Parallel()
+
+	snes, err := os.ReadFile(snesFixture)
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range map[string][]byte{"suite.sfc": snes, "other.gb": make([]byte, 0x8000)} {
+		fw, createErr := zw.Create(name)
+		if createErr != nil {
+			t.Fatalf("Failed to create zip entry: %v", createErr)
+		}
+		if _, err = fw.Write(data); err != nil {
+			t.Fatalf("Failed to write zip entry: %v", err)
+		}
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+	zipPath := filepath.Join(t.TempDir(), "mixed.zip")
+	if err = os.WriteFile(zipPath, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("Failed to write zip: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{zipPath}, &stdout, &stderr); code != exitFailure {
+		t.Errorf("run() = %d, want %d", code, exitFailure)
+	}
+	for _, want := range []string{zipPath + "/suite.sfc (SNES)", zipPath + "/other.gb (GB)"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("stderr = %q, want choice %q", stderr.String(), want)
+		}
+	}
+}
+
+func TestRun_UsageErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no input", nil},
+		{"unknown flag", []string{"-nope"}},
+		{"unknown console", []string{"-c", "xbox", snesFixture}},
+		{"json and ndjson", []string{"-json", "-ndjson", snesFixture}},
+		{"csv and json", []string{"-csv", "-json", snesFixture}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, &stdout, &stderr); code != exitUsage {
+				t.Errorf("run(%v) = %d, want %d", tt.args, code, exitUsage)
+			}
+		})
+	}
+}
+
+func TestRun_Version(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-version"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run() = %d, want %d", code, exitOK)
+	}
+	if !strings.Contains(stdout.String(), appVersion) {
+		t.Errorf("stdout = %q, want version", stdout.String())
+	}
+}
+
+func TestRun_SelfTest(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-selftest"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run() = %d, want %d (stdout: %s)", code, exitOK, stdout.String())
+	}
+	for _, want := range []string{"  zlib   PASS\n", "  zip    PASS\n", "Self-test passed\n"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("stdout = %q, want it to contain %q", stdout.String(), want)
+		}
+	}
+}
+
+func TestRun_RawMetadata(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-raw", snesFixture}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"console: SNES\n", "\nrom_version: 0\n", "\ninternal_title: 0x"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("stdout = %q, want %q", out, want)
+		}
+	}
+	if strings.Contains(out, "Rom Version") {
+		t.Error("-raw output should not title-case keys")
+	}
+}
+
+func TestRun_JSONUsesRawKeys(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-json", snesFixture}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `"rom_version": "0"`) {
+		t.Errorf("stdout = %q, want raw rom_version key", stdout.String())
+	}
+}
+
+// The explain trace goes through the package-level logger hook, so this test
+// must not run alongside other identifications.
+//
+//nolint:paralleltest // Installs the gameid logger
+func TestRun_ExplainSaturn(t *testing.T) {
+	disc := make([]byte, 20*2048)
+	copy(disc, "SEGA SEGASATURN SEGA ENTERPRISES")
+	path := filepath.Join(t.TempDir(), "disc.iso")
+	if err := os.WriteFile(path, disc, 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-explain", "-i", path}, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("run() = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+	if !strings.HasPrefix(stdout.String(), "Console: Saturn") {
+		t.Errorf("stdout = %q, want Saturn result", stdout.String())
+	}
+	trace := stderr.String()
+	if !strings.Contains(trace, "Explain "+path+":") {
+		t.Errorf("stderr = %q, want explain heading for %s", trace, path)
+	}
+	if !strings.Contains(trace, "header magic matched: console=Saturn") {
+		t.Errorf("stderr = %q, want Saturn magic match", trace)
+	}
+
+	// The logger is removed once run returns
+	stderr.Reset()
+	if code := run([]string{"-i", path}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("run() = %d, want %d", code, exitOK)
+	}
+	if strings.Contains(stderr.String(), "magic") {
+		t.Errorf("stderr = %q after -explain run, want no trace", stderr.String())
+	}
+}
+
+func TestFormatExplainStep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		event string
+		want  string
+		kv    []any
+	}{
+		{event: "db.lookup", kv: []any{"console", "GBA", "key", "AXVE", "found", true},
+			want: "  database lookup: console=GBA key=AXVE hit"},
+		{event: "db.lookup", kv: []any{"found", false}, want: "  database lookup: miss"},
+		{event: "detect.registered", kv: []any{"ext", ".x", "console", "X", "err", nil},
+			want: "  registered detector consulted: ext=.x console=X"},
+		{event: "custom.event", kv: []any{"n", 1}, want: "  custom.event: n=1"},
+	}
+	for _, tt := range tests {
+		if got := formatExplainStep(tt.event, tt.kv); got != tt.want {
+			t.Errorf("formatExplainStep(%q) = %q, want %q", tt.event, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ZaparooProject/go-gameid"
+	"github.com/ZaparooProject/go-gameid/archive"
+	"github.com/ZaparooProject/go-gameid/iso9660"
+)
+
+// expandInputs resolves the inputs into the list of files to identify.
+// With -r, directories are walked recursively; everything else is passed
+// through unchanged. Problems found while scanning are reported to stderr,
+// and ok is false if any occurred.
+func expandInputs(cfg *config, stderr io.Writer) (paths []string, ok bool) {
+	ok = true
+	for _, input := range cfg.inputs {
+		info, err := os.Stat(input)
+		if !cfg.recursive || err != nil || !info.IsDir() {
+			paths = append(paths, input)
+			continue
+		}
+
+		found, scanOK := scanDirectory(cfg, input, stderr)
+		paths = append(paths, found...)
+		ok = ok && scanOK
+	}
+	return paths, ok
+}
+
+// scanDirectory walks root and returns every file that looks like a game.
+// Files with unsupported extensions are skipped, as are BIN tracks that a CUE
+// sheet in the scan already references (the CUE is identified instead).
+func scanDirectory(cfg *config, root string, stderr io.Writer) (paths []string, ok bool) {
+	ok = true
+	var cueTracks []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			_, _ = fmt.Fprintf(stderr, "Error scanning %s: %v\n", path, walkErr)
+			ok = false
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(path))
+		switch {
+		case cfg.archives && archive.IsArchiveExtension(ext):
+			found, err := scanArchive(path)
+			if err != nil {
+				_, _ = fmt.Fprintf(stderr, "Error scanning %s: %v\n", path, err)
+				ok = false
+				return nil
+			}
+			paths = append(paths, found...)
+		case gameid.HasSupportedExtension(path):
+			if ext == ".cue" {
+				if cue, err := iso9660.ParseCue(path); err == nil {
+					cueTracks = append(cueTracks, cue.BinFiles...)
+				}
+			}
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error scanning %s: %v\n", root, err)
+		ok = false
+	}
+
+	return removeCueTracks(paths, cueTracks), ok
+}
+
+// scanArchive returns archive paths ("game.zip/inner.gba") for each game
+// file inside the archive, in the form accepted by gameid.Identify.
+func scanArchive(path string) ([]string, error) {
+	arc, err := archive.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	defer func() { _ = arc.Close() }()
+
+	files, err := arc.List()
+	if err != nil {
+		return nil, fmt.Errorf("list archive: %w", err)
+	}
+
+	var paths []string
+	for _, file := range files {
+		if archive.IsGameFile(file.Name) {
+			paths = append(paths, path+"/"+file.Name)
+		}
+	}
+	return paths, nil
+}
+
+// removeCueTracks drops paths that are BIN tracks of a scanned CUE sheet.
+func removeCueTracks(paths, cueTracks []string) []string {
+	if len(cueTracks) == 0 {
+		return paths
+	}
+	tracks := make(map[string]bool, len(cueTracks))
+	for _, track := range cueTracks {
+		tracks[filepath.Clean(track)] = true
+	}
+
+	kept := paths[:0]
+	for _, path := range paths {
+		if !tracks[filepath.Clean(path)] {
+			kept = append(kept, path)
+		}
+	}
+	return kept
+}
+
+// parseConsoleFilter parses a comma-separated console list for -consoles.
+func parseConsoleFilter(list string) (map[gameid.Console]bool, error) {
+	if list == "" {
+		return nil, nil //nolint:nilnil // A nil filter means no filtering
+	}
+	filter := make(map[gameid.Console]bool)
+	for _, name := range strings.Split(list, ",") {
+		console, err := gameid.ParseConsole(name)
+		if err != nil {
+			return nil, fmt.Errorf("console filter: %w", err)
+		}
+		filter[console] = true
+	}
+	return filter, nil
+}
+
+// skipByExtension reports whether path can be skipped without reading it
+// because its extension maps to a console outside the filter. GB and GBC
+// games share a header format and are often named with either extension,
+// so a .gb or .gbc file is only skipped when both consoles are filtered out.
+func skipByExtension(filter map[gameid.Console]bool, path string) bool {
+	if filter == nil {
+		return false
+	}
+	console, err := gameid.DetectConsoleFromExtension(path)
+	if err != nil {
+		return false
+	}
+	if console == gameid.ConsoleGB || console == gameid.ConsoleGBC {
+		return !filter[gameid.ConsoleGB] && !filter[gameid.ConsoleGBC]
+	}
+	return !filter[console]
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ZaparooProject/go-gameid"
+)
+
+// writeGBAROM writes a minimal GBA ROM with the given game code.
+func writeGBAROM(t *testing.T, path, gameCode string) []byte {
+	t.Helper()
+
+	rom := make([]byte, 0xC0)
+	copy(rom[0xA0:], "TESTGAME")
+	copy(rom[0xAC:], gameCode)
+	copy(rom[0xB0:], "01")
+	rom[0xB2] = 0x96
+	if err := os.WriteFile(path, rom, 0o600); err != nil {
+		t.Fatalf("Failed to write test ROM: %v", err)
+	}
+	return rom
+}
+
+// createScanTree builds a directory with a GBA ROM, a SNES ROM in a
+// subdirectory, a non-game file and a ZIP containing another GBA ROM.
+func createScanTree(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	writeGBAROM(t, filepath.Join(root, "game.gba"), "ATST")
+
+	subDir := filepath.Join(root, "snes")
+	if err := os.Mkdir(subDir, 0o750); err != nil {
+		t.Fatalf("Failed to create subdirectory: %v", err)
+	}
+	snes, err := os.ReadFile(snesFixture)
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	if err = os.WriteFile(filepath.Join(subDir, "suite.sfc"), snes, 0o600); err != nil {
+		t.Fatalf("Failed to write SNES ROM: %v", err)
+	}
+
+	if err = os.WriteFile(filepath.Join(root, "readme.txt"), []byte("not a game"), 0o600); err != nil {
+		t.Fatalf("Failed to write readme: %v", err)
+	}
+
+	rom := writeGBAROM(t, filepath.Join(t.TempDir(), "inner.gba"), "AZIP")
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	fw, err := zw.Create("inner.gba")
+	if err != nil {
+		t.Fatalf("Failed to create zip entry: %v", err)
+	}
+	if _, err = fw.Write(rom); err != nil {
+		t.Fatalf("Failed to write zip entry: %v", err)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+	if err = os.WriteFile(filepath.Join(root, "packed.zip"), buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("Failed to write zip: %v", err)
+	}
+
+	return root
+}
+
+// scanIDs runs the CLI with -ndjson and returns the identified IDs and the exit code.
+func scanIDs(t *testing.T, args ...string) (ids []string, code int) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code = run(append([]string{"-ndjson"}, args...), &stdout, &stderr)
+
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		var record struct {
+			Result struct {
+				ID      string `json:"ID"`
+				Console string `json:"Console"`
+			} `json:"result"`
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line %q is not valid JSON: %v", scanner.Text(), err)
+		}
+		if record.Error != "" {
+			t.Errorf("unexpected error record: %s", scanner.Text())
+		}
+		ids = append(ids, record.Result.Console+":"+record.Result.ID)
+	}
+	slices.Sort(ids)
+	return ids, code
+}
+
+func TestRun_RecursiveScan(t *testing.T) {
+	t.Parallel()
+
+	root := createScanTree(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "recursive",
+			args: []string{"-r", root},
+			want: []string{"GBA:ATST", "SNES:"},
+		},
+		{
+			name: "recursive with archives",
+			args: []string{"-r", "-archives", root},
+			want: []string{"GBA:ATST", "GBA:AZIP", "SNES:"},
+		},
+		{
+			name: "console filter",
+			args: []string{"-r", "-archives", "-consoles", "gba", root},
+			want: []string{"GBA:ATST", "GBA:AZIP"},
+		},
+		{
+			name: "console filter excludes all",
+			args: []string{"-r", "-consoles", "PSX,N64", root},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ids, code := scanIDs(t, tt.args...)
+			if code != exitOK {
+				t.Errorf("run() = %d, want %d", code, exitOK)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("identified %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipByExtension(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		filter map[gameid.Console]bool
+		name   string
+		path   string
+		want   bool
+	}{
+		{name: "no filter", filter: nil, path: "game.gba", want: false},
+		{name: "console in filter", filter: map[gameid.Console]bool{gameid.ConsoleGBA: true}, path: "game.gba", want: false},
+		{name: "console outside filter", filter: map[gameid.Console]bool{gameid.ConsoleGBA: true}, path: "game.sfc", want: true},
+		{name: "unknown extension", filter: map[gameid.Console]bool{gameid.ConsoleGBA: true}, path: "game.xyz", want: false},
+		{name: "gb file with GBC filter", filter: map[gameid.Console]bool{gameid.ConsoleGBC: true}, path: "game.gb", want: false},
+		{name: "gbc file with GB filter", filter: map[gameid.Console]bool{gameid.ConsoleGB: true}, path: "game.gbc", want: false},
+		{name: "gb file outside filter", filter: map[gameid.Console]bool{gameid.ConsoleGBA: true}, path: "game.gb", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := skipByExtension(tt.filter, tt.path); got != tt.want {
+				t.Errorf("skipByExtension(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Summary(t *testing.T) {
+	t.Parallel()
+
+	root := createScanTree(t)
+	writeGBAROM(t, filepath.Join(root, "second.gba"), "AGB2")
+	broken := filepath.Join(root, "broken.gba")
+	if err := os.WriteFile(broken, []byte("short"), 0o600); err != nil {
+		t.Fatalf("Failed to write broken ROM: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-r", "-archives", "-summary", "-ndjson", root}, &stdout, &stderr)
+	if code != exitFailure {
+		t.Errorf("run() = %d, want %d", code, exitFailure)
+	}
+	if strings.Contains(stdout.String(), "Summary:") {
+		t.Error("summary was written to stdout")
+	}
+
+	summary := stderr.String()
+	for _, want := range []string{
+		"Summary: 5 files, 4 identified, 1 unidentified\n",
+		"  GBA: 3\n",
+		"  SNES: 1\n",
+		"Unidentified:\n  " + broken + "\n",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+}
+
+func TestRun_RecursiveSkipsCueTracks(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	cue := "FILE \"disc.bin\" BINARY\n  TRACK 01 MODE1/2352\n    INDEX 01 00:00:00\n"
+	if err := os.WriteFile(filepath.Join(root, "disc.cue"), []byte(cue), 0o600); err != nil {
+		t.Fatalf("Failed to write cue: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "disc.bin"), make([]byte, 4096), 0o600); err != nil {
+		t.Fatalf("Failed to write bin: %v", err)
+	}
+
+	cfg := &config{inputs: []string{root}, recursive: true}
+	paths, ok := expandInputs(cfg, &bytes.Buffer{})
+	if !ok {
+		t.Error("expandInputs() reported a scan failure")
+	}
+	if want := []string{filepath.Join(root, "disc.cue")}; !slices.Equal(paths, want) {
+		t.Errorf("expandInputs() = %v, want %v", paths, want)
+	}
+}
+
+func TestRun_InvalidConsoleFilter(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-consoles", "GBA,xbox", snesFixture}, &stdout, &stderr); code != exitUsage {
+		t.Errorf("run() = %d, want %d", code, exitUsage)
+	}
+}
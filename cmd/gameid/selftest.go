@@ -0,0 +1,281 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/ZaparooProject/go-gameid/archive"
+	"github.com/ZaparooProject/go-gameid/chd"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz/lzma"
+)
+
+// selftestArchives holds a stored copy of selftestPayload in each archive
+// format that has no encoder available to build one at run time.
+//
+//go:embed selftest/sample.7z selftest/sample.rar
+var selftestArchives embed.FS
+
+// selftestPayload is the content of the selftest.txt member in every sample
+// archive.
+var selftestPayload = []byte("gameid selftest\n")
+
+const (
+	selftestMember   = "selftest.txt"
+	selftestHunkSize = 4096
+	cdFrameSize      = 2448 // 2352-byte sector followed by 96 bytes of subcode
+	cdSubcodeSize    = 96
+)
+
+// knownCodecs lists every codec tag the chd package defines, so a build that
+// leaves one out still reports it.
+var knownCodecs = []uint32{
+	chd.CodecZlib, chd.CodecLZMA, chd.CodecHuff, chd.CodecFLAC, chd.CodecZstd,
+	chd.CodecCDZlib, chd.CodecCDLZMA, chd.CodecCDFLAC, chd.CodecCDZstd,
+}
+
+// codecSamples compresses a hunk into the layout each codec expects. Codecs
+// without an encoder here are reported as registered but not decoded.
+var codecSamples = map[uint32]func(hunk []byte) ([]byte, error){
+	chd.CodecZlib:   compressDeflate,
+	chd.CodecLZMA:   compressLZMA,
+	chd.CodecZstd:   compressZstd,
+	chd.CodecCDZlib: cdSample(compressDeflate, false),
+	chd.CodecCDLZMA: cdSample(compressLZMA, false),
+	chd.CodecCDZstd: cdSample(compressZstd, true),
+}
+
+// errSelftestMismatch reports a decode that succeeded with the wrong output.
+var errSelftestMismatch = errors.New("decoded data does not match")
+
+// selfTest reports which CHD codecs and archive formats this build supports,
+// decoding a small in-memory sample with each, for -selftest.
+func selfTest(stdout io.Writer) int {
+	exitCode := exitOK
+	report := func(name string, err error) {
+		if err != nil {
+			_, _ = fmt.Fprintf(stdout, "  %-6s FAIL: %v\n", name, err)
+			exitCode = exitFailure
+			return
+		}
+		_, _ = fmt.Fprintf(stdout, "  %-6s PASS\n", name)
+	}
+
+	_, _ = fmt.Fprintln(stdout, "CHD codecs:")
+	registered := chd.RegisteredCodecs()
+	tags := slices.Clone(knownCodecs)
+	for _, tag := range registered {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	for _, tag := range tags {
+		name := chd.CodecName(tag)
+		switch {
+		case !slices.Contains(registered, tag):
+			_, _ = fmt.Fprintf(stdout, "  %-6s not registered\n", name)
+		case codecSamples[tag] == nil:
+			_, _ = fmt.Fprintf(stdout, "  %-6s registered (no sample to decode)\n", name)
+		default:
+			report(name, checkCodec(tag))
+		}
+	}
+
+	_, _ = fmt.Fprintln(stdout, "Archive formats:")
+	report("zip", checkZIP())
+	for _, format := range []string{"7z", "rar"} {
+		report(format, checkArchive("selftest/sample."+format))
+	}
+
+	if exitCode == exitOK {
+		_, _ = fmt.Fprintln(stdout, "Self-test passed")
+	} else {
+		_, _ = fmt.Fprintln(stdout, "Self-test failed")
+	}
+	return exitCode
+}
+
+// checkCodec compresses a sample hunk for tag and decodes it with the
+// registered codec.
+func checkCodec(tag uint32) error {
+	codec, err := chd.GetCodec(tag)
+	if err != nil {
+		return fmt.Errorf("get codec: %w", err)
+	}
+
+	size := selftestHunkSize
+	if chd.IsCDCodec(tag) {
+		size = cdFrameSize
+	}
+	hunk := bytes.Repeat(selftestPayload, size/len(selftestPayload)+1)[:size]
+	src, err := codecSamples[tag](hunk)
+	if err != nil {
+		return fmt.Errorf("build sample: %w", err)
+	}
+
+	dst := make([]byte, size)
+	var n int
+	if cdCodec, ok := codec.(chd.CDCodec); ok {
+		n, err = cdCodec.DecompressCD(dst, src, size, 1)
+	} else {
+		n, err = codec.Decompress(dst, src)
+	}
+	if err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	if !bytes.Equal(dst[:n], hunk) {
+		return errSelftestMismatch
+	}
+	return nil
+}
+
+// checkZIP builds a deflated ZIP in memory and reads its member back.
+func checkZIP() error {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	member, err := writer.Create(selftestMember)
+	if err != nil {
+		return fmt.Errorf("build sample: %w", err)
+	}
+	if _, err = member.Write(selftestPayload); err != nil {
+		return fmt.Errorf("build sample: %w", err)
+	}
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("build sample: %w", err)
+	}
+	return readSampleArchive(buf.Bytes(), "selftest.zip")
+}
+
+// checkArchive reads the member of an embedded sample archive.
+func checkArchive(name string) error {
+	data, err := selftestArchives.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("read sample: %w", err)
+	}
+	return readSampleArchive(data, name)
+}
+
+// readSampleArchive opens data as the archive format named by name's
+// extension and checks that its member holds selftestPayload.
+func readSampleArchive(data []byte, name string) error {
+	arc, err := archive.OpenReader(bytes.NewReader(data), int64(len(data)), name)
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+	defer func() { _ = arc.Close() }()
+
+	reader, _, err := arc.Open(selftestMember)
+	if err != nil {
+		return fmt.Errorf("open member: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	got, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("read member: %w", err)
+	}
+	if !bytes.Equal(got, selftestPayload) {
+		return errSelftestMismatch
+	}
+	return nil
+}
+
+// compressDeflate produces raw deflate data, as CHD zlib hunks store.
+func compressDeflate(src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, fmt.Errorf("deflate: %w", err)
+	}
+	if _, err = writer.Write(src); err != nil {
+		return nil, fmt.Errorf("deflate: %w", err)
+	}
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("deflate: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// compressLZMA produces a headerless LZMA stream, as CHD LZMA hunks store.
+// The reader derives the properties from the hunk size, so the header the
+// encoder writes is dropped.
+func compressLZMA(src []byte) ([]byte, error) {
+	const lzmaHeaderSize = 13
+
+	var buf bytes.Buffer
+	writer, err := lzma.WriterConfig{DictCap: lzma.MinDictCap, Size: int64(len(src))}.NewWriter(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("lzma: %w", err)
+	}
+	if _, err = writer.Write(src); err != nil {
+		return nil, fmt.Errorf("lzma: %w", err)
+	}
+	if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("lzma: %w", err)
+	}
+	return buf.Bytes()[lzmaHeaderSize:], nil
+}
+
+// compressZstd produces a Zstandard frame.
+func compressZstd(src []byte) ([]byte, error) {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, fmt.Errorf("zstd: %w", err)
+	}
+	defer func() { _ = encoder.Close() }()
+	return encoder.EncodeAll(src, nil), nil
+}
+
+// cdSample wraps compress in the single-frame CD codec layout: the sector
+// compressed with compress, then the subcode deflated. The zstd layout has a
+// 4-byte sector length; the others have an empty ECC bitmap and a 2-byte
+// length.
+func cdSample(compress func([]byte) ([]byte, error), zstdLayout bool) func([]byte) ([]byte, error) {
+	return func(frame []byte) ([]byte, error) {
+		sectorSize := len(frame) - cdSubcodeSize
+		sector, err := compress(frame[:sectorSize])
+		if err != nil {
+			return nil, err
+		}
+		subcode, err := compressDeflate(frame[sectorSize:])
+		if err != nil {
+			return nil, err
+		}
+
+		var out []byte
+		if zstdLayout {
+			//nolint:gosec // Sample sectors compress to far less than 4 GiB
+			out = binary.BigEndian.AppendUint32(out, uint32(len(sector)))
+		} else {
+			//nolint:gosec // Sample sectors compress to far less than 64 KiB
+			out = binary.BigEndian.AppendUint16([]byte{0}, uint16(len(sector)))
+		}
+		out = append(out, sector...)
+		return append(out, subcode...), nil
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/ZaparooProject/go-gameid"
+)
+
+// scanSummary aggregates the outcome of a batch run for -summary.
+type scanSummary struct {
+	consoles   map[gameid.Console]int
+	unresolved []string
+	identified int
+}
+
+func newScanSummary() *scanSummary {
+	return &scanSummary{consoles: make(map[gameid.Console]int)}
+}
+
+// add records the outcome of identifying path.
+func (s *scanSummary) add(path string, result *gameid.Result, err error) {
+	if err != nil || result == nil {
+		s.unresolved = append(s.unresolved, path)
+		return
+	}
+	s.identified++
+	s.consoles[result.Console]++
+}
+
+// write prints the totals, the per-console counts sorted by console, and
+// the files that could not be identified.
+func (s *scanSummary) write(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "Summary: %d files, %d identified, %d unidentified\n",
+		s.identified+len(s.unresolved), s.identified, len(s.unresolved))
+
+	consoles := make([]gameid.Console, 0, len(s.consoles))
+	for console := range s.consoles {
+		consoles = append(consoles, console)
+	}
+	slices.Sort(consoles)
+	for _, console := range consoles {
+		_, _ = fmt.Fprintf(w, "  %s: %d\n", console, s.consoles[console])
+	}
+
+	if len(s.unresolved) > 0 {
+		_, _ = fmt.Fprintln(w, "Unidentified:")
+		for _, path := range s.unresolved {
+			_, _ = fmt.Fprintln(w, "  "+path)
+		}
+	}
+}
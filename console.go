@@ -19,13 +19,19 @@
 package gameid
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/ZaparooProject/go-gameid/archive"
 	"github.com/ZaparooProject/go-gameid/chd"
 	"github.com/ZaparooProject/go-gameid/identifier"
+	"github.com/ZaparooProject/go-gameid/internal/trace"
 	"github.com/ZaparooProject/go-gameid/iso9660"
 )
 
@@ -46,9 +52,23 @@ var extToConsole = map[string]identifier.Console{
 	".v64": identifier.ConsoleN64,
 	".ndd": identifier.ConsoleN64,
 
+	// PSP (PSN and homebrew EBOOTs; UMD images are .iso)
+	".pbp": identifier.ConsolePSP,
+
+	// Nintendo DS
+	".nds": identifier.ConsoleNDS,
+
+	// Nintendo 3DS
+	".3ds": identifier.Console3DS,
+	".cci": identifier.Console3DS,
+	".cxi": identifier.Console3DS,
+	".cia": identifier.Console3DS,
+
+	// Famicom Disk System
+	".fds": identifier.ConsoleFDS,
+
 	// NES
 	".nes": identifier.ConsoleNES,
-	".fds": identifier.ConsoleNES,
 	".unf": identifier.ConsoleNES,
 	".nez": identifier.ConsoleNES,
 
@@ -68,18 +88,106 @@ var extToConsole = map[string]identifier.Console{
 	".rvz": identifier.ConsoleGC,
 }
 
-// Ambiguous extensions that need header analysis
-var ambiguousExts = map[string]bool{
-	".bin": true,
-	".iso": true,
-	".cue": true,
-	".chd": true,
-	".cso": true,
-	".ecm": true,
+// Ambiguous extensions that need header analysis, with the consoles each can
+// hold. The candidates are what ExtensionConsoleMap reports; detection itself
+// goes by the file contents.
+var ambiguousExts = map[string][]identifier.Console{
+	".bin": {
+		identifier.ConsoleGenesis, identifier.ConsoleNeoGeoCD, identifier.ConsolePSX,
+		identifier.ConsolePS2, identifier.ConsoleSaturn, identifier.ConsoleSegaCD,
+	},
+	".iso": {
+		identifier.ConsoleGC, identifier.ConsoleNeoGeoCD, identifier.ConsolePSP, identifier.ConsolePSX,
+		identifier.ConsolePS2, identifier.ConsoleSaturn, identifier.ConsoleSegaCD, identifier.ConsoleWii,
+	},
+	".cue": {
+		identifier.ConsoleNeoGeoCD, identifier.ConsolePSX, identifier.ConsolePS2,
+		identifier.ConsoleSaturn, identifier.ConsoleSegaCD,
+	},
+	".chd": {
+		identifier.ConsoleGC, identifier.ConsoleNeoGeoCD, identifier.ConsolePSP, identifier.ConsolePSX,
+		identifier.ConsolePS2, identifier.ConsoleSaturn, identifier.ConsoleSegaCD, identifier.ConsoleWii,
+	},
+	".cso": {identifier.ConsolePSP, identifier.ConsolePS2},
+	".zso": {identifier.ConsolePSP, identifier.ConsolePS2},
+	".ecm": {
+		identifier.ConsoleNeoGeoCD, identifier.ConsolePSX,
+		identifier.ConsoleSaturn, identifier.ConsoleSegaCD,
+	},
+}
+
+// isAmbiguousExtension reports whether ext needs header analysis.
+func isAmbiguousExtension(ext string) bool {
+	_, ambiguous := ambiguousExts[ext]
+	return ambiguous
+}
+
+// lookupExtension returns the console that ext always means, from the
+// built-in table or an extension given to RegisterIdentifier.
+func lookupExtension(ext string) (identifier.Console, bool) {
+	if console, ok := extToConsole[ext]; ok {
+		return console, true
+	}
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	console, ok := registry.extensions[ext]
+	return console, ok
+}
+
+// ExtensionConsoleMap returns every extension DetectConsole recognizes
+// mapped to the consoles it can mean: one console for an unambiguous
+// extension, several for disc image formats that need header analysis.
+// Extensions given to RegisterIdentifier are included; those known only to a
+// RegisterDetector function are not, since their console isn't known until
+// the detector runs. The returned map is a copy and may be modified.
+func ExtensionConsoleMap() map[string][]Console {
+	extensions := make(map[string][]Console, len(extToConsole)+len(ambiguousExts))
+	for ext, console := range extToConsole {
+		extensions[ext] = []Console{console}
+	}
+	for ext, consoles := range ambiguousExts {
+		extensions[ext] = slices.Clone(consoles)
+	}
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	for ext, console := range registry.extensions {
+		extensions[ext] = []Console{console}
+	}
+	return extensions
+}
+
+// ConsoleExtensions returns the extensions in ExtensionConsoleMap that can
+// mean console, sorted.
+func ConsoleExtensions(console Console) []string {
+	var extensions []string
+	for ext, consoles := range ExtensionConsoleMap() {
+		if slices.Contains(consoles, console) {
+			extensions = append(extensions, ext)
+		}
+	}
+	slices.Sort(extensions)
+	return extensions
+}
+
+// SupportedExtensions returns every file extension gameid can identify,
+// sorted and with a leading dot: the cartridge and disc image extensions in
+// ExtensionConsoleMap, those handled by a RegisterDetector function and the
+// archive formats. Any of them followed by ".gz" is supported as well.
+func SupportedExtensions() []string {
+	extensions := slices.Collect(maps.Keys(ExtensionConsoleMap()))
+	registry.mu.RLock()
+	for ext := range registry.detectors {
+		extensions = append(extensions, ext)
+	}
+	registry.mu.RUnlock()
+	extensions = append(extensions, archive.Extensions()...)
+	slices.Sort(extensions)
+	return slices.Compact(extensions)
 }
 
 // DetectConsole attempts to detect the console type for a given file.
-// Returns the detected console or an error if detection fails.
+// Returns the detected console or an error if detection fails. Results for
+// regular files are cached once SetDetectionCacheSize enables the cache.
 func DetectConsole(path string) (identifier.Console, error) {
 	// Check if it's a block device (physical disc)
 	if isBlockDevice(path) {
@@ -95,6 +203,19 @@ func DetectConsole(path string) (identifier.Console, error) {
 		return detectConsoleFromDirectory(path)
 	}
 
+	if console, ok := detections.get(path, info); ok {
+		return console, nil
+	}
+	console, err := detectConsoleFromPath(path)
+	if err != nil {
+		return "", err
+	}
+	detections.put(path, info, console)
+	return console, nil
+}
+
+// detectConsoleFromPath is DetectConsole for a regular file.
+func detectConsoleFromPath(path string) (identifier.Console, error) {
 	// Get extension
 	ext := strings.ToLower(filepath.Ext(path))
 
@@ -104,17 +225,89 @@ func DetectConsole(path string) (identifier.Console, error) {
 		ext = strings.ToLower(filepath.Ext(path))
 	}
 
+	// Registered detectors take precedence over the built-in tables
+	if detect, ok := lookupDetector(ext); ok {
+		console, err := detect(path)
+		if trace.Enabled() {
+			trace.Log("detect.registered", "ext", ext, "console", console, "err", err)
+		}
+		if err == nil {
+			return console, nil
+		}
+		if _, mapped := lookupExtension(ext); !mapped && !isBuiltinExtension(ext) {
+			return "", fmt.Errorf("registered detector for %s: %w", ext, err)
+		}
+	}
+
 	// Check for unambiguous extension
-	if console, ok := extToConsole[ext]; ok {
+	if console, ok := lookupExtension(ext); ok {
+		if trace.Enabled() {
+			trace.Log("detect.extension", "ext", ext, "console", console)
+		}
 		return console, nil
 	}
 
-	// For ambiguous extensions, read header and analyze
-	if ambiguousExts[ext] {
+	// For ambiguous or missing extensions, read header and analyze
+	if isAmbiguousExtension(ext) || ext == "" {
 		return detectConsoleFromHeader(path, ext)
 	}
 
-	return "", identifier.ErrNotSupported{Format: ext}
+	return detectConsoleFromContent(path, ext)
+}
+
+// chdMagic is the magic word that opens every CHD file.
+var chdMagic = []byte("MComprHD")
+
+// detectConsoleFromContent is the last resort for unknown extensions: it
+// sniffs for a CHD, a cue sheet, a Sega disc or an ISO9660 filesystem, so
+// disc images saved as .img or .dsk still detect. Anything else is reported
+// as an unsupported extension.
+func detectConsoleFromContent(path, ext string) (identifier.Console, error) {
+	unsupported := identifier.ErrNotSupported{Format: ext}
+
+	file, err := os.Open(path) //nolint:gosec // Path from user input is expected
+	if err != nil {
+		return "", unsupported
+	}
+	header := make([]byte, 0x1000)
+	bytesRead, _ := file.ReadAt(header, 0)
+	header = header[:bytesRead]
+	_ = file.Close()
+
+	var console identifier.Console
+	switch {
+	case bytes.HasPrefix(header, chdMagic):
+		console, err = detectConsoleFromCHD(path)
+	case looksLikeCue(header):
+		console, err = detectConsoleFromCue(path)
+	default:
+		if sega, ok := detectSegaDiscMagic(header); ok {
+			return sega, nil
+		}
+		iso, isoErr := iso9660.Open(path)
+		if isoErr != nil {
+			return "", unsupported
+		}
+		defer func() { _ = iso.Close() }()
+		console, err = detectConsoleFromISO(iso)
+	}
+	if trace.Enabled() {
+		trace.Log("detect.content", "ext", ext, "console", console, "err", err)
+	}
+	if err != nil {
+		return "", unsupported
+	}
+	return console, nil
+}
+
+// looksLikeCue reports whether header reads as the start of a cue sheet:
+// text with a FILE command and a TRACK command.
+func looksLikeCue(header []byte) bool {
+	if len(header) == 0 || bytes.IndexByte(header, 0) != -1 {
+		return false
+	}
+	upper := bytes.ToUpper(header)
+	return bytes.Contains(upper, []byte("FILE ")) && bytes.Contains(upper, []byte("TRACK "))
 }
 
 // DetectConsoleFromExtension detects the console type based purely on file extension.
@@ -130,12 +323,12 @@ func DetectConsoleFromExtension(path string) (identifier.Console, error) {
 	}
 
 	// Check for unambiguous extension
-	if console, ok := extToConsole[ext]; ok {
+	if console, ok := lookupExtension(ext); ok {
 		return console, nil
 	}
 
 	// Ambiguous extensions cannot be detected without header analysis
-	if ambiguousExts[ext] {
+	if isAmbiguousExtension(ext) {
 		return "", identifier.ErrNotSupported{
 			Format: fmt.Sprintf("ambiguous extension %s requires header analysis", ext),
 		}
@@ -144,6 +337,85 @@ func DetectConsoleFromExtension(path string) (identifier.Console, error) {
 	return "", identifier.ErrNotSupported{Format: ext}
 }
 
+// ExtensionHints resolves extensions that DetectConsoleFromExtension can't
+// decide alone, for callers that know more about a file than its name, such
+// as a library organized into one folder per system.
+type ExtensionHints struct {
+	// Overrides maps extensions (".iso"; case-insensitive, the leading dot
+	// is optional) to the console they always mean. An override wins over
+	// the built-in table, ambiguous or not.
+	Overrides map[string]Console
+
+	// Console is the console of every ambiguous extension not overridden.
+	Console Console
+
+	// ParentDirs makes an ambiguous extension take the console named by the
+	// file's parent or grandparent directory ("PS2/game.iso",
+	// "PS2/Game/game.bin"), using the names ParseConsole accepts.
+	ParentDirs bool
+}
+
+// parentDirHintDepth is how many directories above a file ExtensionHints
+// with ParentDirs looks at. Deeper ancestors such as mount points are more
+// likely to match a console alias by accident.
+const parentDirHintDepth = 2
+
+// DetectConsoleFromExtensionWithHints is DetectConsoleFromExtension with
+// hints applied: overrides first, then the built-in table, then for ambiguous
+// extensions the explicit console and the parent directory names.
+func DetectConsoleFromExtensionWithHints(path string, hints ExtensionHints) (identifier.Console, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".gz" {
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(path, filepath.Ext(path))))
+	}
+	for override, console := range hints.Overrides {
+		if normalizeExtension(override) == ext {
+			return console, nil
+		}
+	}
+
+	console, err := DetectConsoleFromExtension(path)
+	if err == nil || !isAmbiguousExtension(ext) {
+		return console, err
+	}
+
+	if hints.Console != "" {
+		return hints.Console, nil
+	}
+	if hints.ParentDirs {
+		dir := filepath.Dir(path)
+		for range parentDirHintDepth {
+			if console, parseErr := ParseConsole(filepath.Base(dir)); parseErr == nil {
+				return console, nil
+			}
+			dir = filepath.Dir(dir)
+		}
+	}
+	return "", err
+}
+
+// HasSupportedExtension reports whether path has an extension that DetectConsole
+// can handle, either directly or through header analysis. A trailing .gz is ignored.
+func HasSupportedExtension(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".gz" {
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(path, filepath.Ext(path))))
+	}
+	if _, registered := lookupDetector(ext); registered {
+		return true
+	}
+	if _, mapped := lookupExtension(ext); mapped {
+		return true
+	}
+	return isBuiltinExtension(ext)
+}
+
+// isBuiltinExtension reports whether ext is handled by the built-in tables.
+func isBuiltinExtension(ext string) bool {
+	_, known := extToConsole[ext]
+	return known || isAmbiguousExtension(ext)
+}
+
 // detectConsoleFromDirectory detects console from a mounted disc directory
 func detectConsoleFromDirectory(path string) (identifier.Console, error) {
 	// Check for PSP (UMD_DATA.BIN)
@@ -175,6 +447,115 @@ func detectConsoleFromDirectory(path string) (identifier.Console, error) {
 	return "", identifier.ErrNotSupported{Format: "directory"}
 }
 
+// binPVDMagic is the start of an ISO9660 primary volume descriptor.
+var binPVDMagic = []byte{0x01, 'C', 'D', '0', '0', '1'}
+
+// binLayouts are the sector layouts probed for a .bin without a cue sheet:
+// raw Mode 1, raw Mode 2 (XA) and cooked 2048-byte sectors. dataOffset is
+// where user data starts within each sector.
+var binLayouts = []struct {
+	sectorSize int64
+	dataOffset int64
+}{
+	{sectorSize: 2352, dataOffset: 16},
+	{sectorSize: 2352, dataOffset: 24},
+	{sectorSize: 2048, dataOffset: 0},
+}
+
+// detectConsoleFromBin classifies a lone .bin disc image by probing each
+// sector layout for a Sega boot header in sector 0 or an ISO9660 PVD in
+// sector 16. It reports false if no layout matches, for instance because
+// the file is a cartridge ROM.
+func detectConsoleFromBin(reader io.ReaderAt, path string) (identifier.Console, bool) {
+	for _, layout := range binLayouts {
+		if console, ok := detectSegaBootHeader(reader, layout.dataOffset); ok {
+			return console, true
+		}
+
+		pvd := make([]byte, len(binPVDMagic))
+		if _, err := reader.ReadAt(pvd, 16*layout.sectorSize+layout.dataOffset); err != nil ||
+			!bytes.Equal(pvd, binPVDMagic) {
+			continue
+		}
+		iso, err := iso9660.OpenWithBlockSize(path, int(layout.sectorSize))
+		if err != nil {
+			continue
+		}
+		console, err := detectConsoleFromISO(iso)
+		_ = iso.Close()
+		return console, err == nil
+	}
+	return "", false
+}
+
+// detectSegaBootHeader reports a Saturn or Sega CD boot header in the first
+// sector, whose user data starts at dataOffset.
+func detectSegaBootHeader(reader io.ReaderAt, dataOffset int64) (identifier.Console, bool) {
+	sector0 := make([]byte, 0x100)
+	if _, err := reader.ReadAt(sector0, dataOffset); err != nil {
+		return "", false
+	}
+	console := identifier.Console("")
+	switch {
+	case identifier.ValidateSaturn(sector0):
+		console = identifier.ConsoleSaturn
+	case identifier.ValidateSegaCD(sector0):
+		console = identifier.ConsoleSegaCD
+	default:
+		return "", false
+	}
+	if trace.Enabled() {
+		trace.Log("detect.boot", "console", console, "offset", dataOffset)
+	}
+	return console, true
+}
+
+// DetectConsoleFromReader detects the console of data that isn't a file on
+// disk, such as an archive member or a network stream. hintExt is an optional
+// file extension, with or without the dot; an unambiguous one decides the
+// console without reading, as it does for DetectConsole. Otherwise the header
+// magic, the Saturn and Sega CD boot headers of cooked or raw sectors, and
+// an ISO9660 filesystem are checked in that order. Registered detectors need
+// a path and are not consulted.
+func DetectConsoleFromReader(reader io.ReaderAt, size int64, hintExt string) (identifier.Console, error) {
+	ext := ""
+	if hintExt != "" {
+		ext = normalizeExtension(hintExt)
+	}
+	if console, ok := lookupExtension(ext); ok {
+		if trace.Enabled() {
+			trace.Log("detect.extension", "ext", ext, "console", console)
+		}
+		return console, nil
+	}
+
+	header := make([]byte, 0x1000)
+	bytesRead, err := reader.ReadAt(header, 0)
+	if bytesRead == 0 && err != nil {
+		return "", fmt.Errorf("read header: %w", err)
+	}
+	if console, ok := detectConsoleFromMagic(header[:bytesRead]); ok {
+		return console, nil
+	}
+
+	for _, layout := range binLayouts {
+		if console, ok := detectSegaBootHeader(reader, layout.dataOffset); ok {
+			return console, nil
+		}
+	}
+
+	if iso, isoErr := iso9660.OpenReader(reader, size); isoErr == nil {
+		defer func() { _ = iso.Close() }()
+
+		return detectConsoleFromISO(iso)
+	}
+
+	if ext == "" {
+		return "", identifier.ErrNotSupported{Format: "unrecognized data"}
+	}
+	return "", identifier.ErrNotSupported{Format: ext}
+}
+
 // detectConsoleFromHeader reads the file header to determine console type
 func detectConsoleFromHeader(path, ext string) (identifier.Console, error) {
 	// Handle CUE files specially
@@ -187,6 +568,17 @@ func detectConsoleFromHeader(path, ext string) (identifier.Console, error) {
 		return detectConsoleFromCHD(path)
 	}
 
+	// Compressed ISOs only make sense once decompressed
+	if ext == ".cso" || ext == ".zso" {
+		iso, err := iso9660.OpenCISO(path)
+		if err != nil {
+			return "", fmt.Errorf("open CISO: %w", err)
+		}
+		defer func() { _ = iso.Close() }()
+
+		return detectConsoleFromISO(iso)
+	}
+
 	// Read header for analysis
 	file, err := os.Open(path) //nolint:gosec // Path from user input is expected
 	if err != nil {
@@ -194,33 +586,29 @@ func detectConsoleFromHeader(path, ext string) (identifier.Console, error) {
 	}
 	defer func() { _ = file.Close() }()
 
+	return detectConsoleFromFile(file, path, ext)
+}
+
+// detectConsoleFromFile is the header analysis of detectConsoleFromHeader
+// for a plain file already opened as reader. path is still needed to reopen
+// the file as an ISO9660 filesystem.
+func detectConsoleFromFile(reader io.ReaderAt, path, ext string) (identifier.Console, error) {
 	header := make([]byte, 0x1000)
-	bytesRead, err := file.Read(header)
-	if err != nil {
+	bytesRead, err := reader.ReadAt(header, 0)
+	if bytesRead == 0 && err != nil {
 		return "", fmt.Errorf("read header: %w", err)
 	}
 	header = header[:bytesRead]
 
-	// Try various magic word checks
-
-	// GameCube magic at 0x1C
-	if len(header) > 0x20 && identifier.ValidateGC(header) {
-		return identifier.ConsoleGC, nil
-	}
-
-	// Saturn magic
-	if identifier.ValidateSaturn(header) {
-		return identifier.ConsoleSaturn, nil
-	}
-
-	// Sega CD magic
-	if identifier.ValidateSegaCD(header) {
-		return identifier.ConsoleSegaCD, nil
+	// A lone .bin is usually a raw disc track with no cue sheet
+	if ext == ".bin" {
+		if console, ok := detectConsoleFromBin(reader, path); ok {
+			return console, nil
+		}
 	}
 
-	// Genesis magic (check before trying as ISO)
-	if identifier.ValidateGenesis(header) {
-		return identifier.ConsoleGenesis, nil
+	if console, ok := detectConsoleFromMagic(header); ok {
+		return console, nil
 	}
 
 	// Try parsing as ISO9660
@@ -234,6 +622,62 @@ func detectConsoleFromHeader(path, ext string) (identifier.Console, error) {
 	return "", identifier.ErrNotSupported{Format: ext}
 }
 
+// headerValidationOrder lists the built-in consoles whose identifiers
+// validate a raw file header, in the order they are tried. Wii comes before
+// GameCube because Wii discs carry both magic words. SNES is left out: its
+// only signature is a 16-bit checksum, which arbitrary data passes too often.
+var headerValidationOrder = []identifier.Console{
+	identifier.ConsoleWii,
+	identifier.ConsoleGC,
+	identifier.ConsoleSaturn,
+	identifier.ConsoleSegaCD,
+	identifier.ConsoleFDS,
+	identifier.ConsoleGenesis,
+	identifier.ConsoleN64,
+	identifier.ConsoleGBA,
+	identifier.Console3DS,
+	identifier.ConsoleNDS,
+	identifier.ConsoleGB,
+}
+
+// detectConsoleFromMagic asks each validating identifier whether it
+// recognizes the file header: the built-in consoles first, then any
+// registered identifiers that implement identifier.Validator.
+func detectConsoleFromMagic(header []byte) (identifier.Console, bool) {
+	consoles := append(slices.Clone(headerValidationOrder), registeredConsoles()...)
+	for _, console := range consoles {
+		id, ok := lookupIdentifier(console)
+		if !ok {
+			continue
+		}
+		if validator, ok := id.(identifier.Validator); ok && validator.Validate(header) {
+			if trace.Enabled() {
+				trace.Log("detect.magic", "console", console)
+			}
+			return console, true
+		}
+	}
+	return "", false
+}
+
+// detectSegaDiscMagic looks for the Saturn or Sega CD magic word anywhere in
+// the first sectors of a disc image.
+func detectSegaDiscMagic(header []byte) (identifier.Console, bool) {
+	console := identifier.Console("")
+	switch {
+	case identifier.ValidateSaturn(header):
+		console = identifier.ConsoleSaturn
+	case identifier.ValidateSegaCD(header):
+		console = identifier.ConsoleSegaCD
+	default:
+		return "", false
+	}
+	if trace.Enabled() {
+		trace.Log("detect.magic", "console", console)
+	}
+	return console, true
+}
+
 // detectConsoleFromCHD handles CHD disc image detection.
 func detectConsoleFromCHD(path string) (identifier.Console, error) {
 	chdFile, err := chd.Open(path)
@@ -250,14 +694,14 @@ func detectConsoleFromCHD(path string) (identifier.Console, error) {
 	}
 
 	// Check for Sega consoles first (they have magic words in raw sector data)
-	if identifier.ValidateSaturn(header) {
-		return identifier.ConsoleSaturn, nil
-	}
-	if identifier.ValidateSegaCD(header) {
-		return identifier.ConsoleSegaCD, nil
+	if console, ok := detectSegaDiscMagic(header); ok {
+		return console, nil
 	}
 
-	// Check for GameCube (non-ISO9660 proprietary format)
+	// Check for GameCube and Wii (non-ISO9660 proprietary formats)
+	if identifier.ValidateWii(header) {
+		return identifier.ConsoleWii, nil
+	}
 	if identifier.ValidateGC(header) {
 		return identifier.ConsoleGC, nil
 	}
@@ -295,11 +739,8 @@ func detectConsoleFromCue(path string) (identifier.Console, error) {
 	header = header[:bytesRead]
 
 	// Check for Sega consoles first (they have magic words in header)
-	if identifier.ValidateSaturn(header) {
-		return identifier.ConsoleSaturn, nil
-	}
-	if identifier.ValidateSegaCD(header) {
-		return identifier.ConsoleSegaCD, nil
+	if console, ok := detectSegaDiscMagic(header); ok {
+		return console, nil
 	}
 
 	// Try as ISO
@@ -313,12 +754,22 @@ func detectConsoleFromCue(path string) (identifier.Console, error) {
 }
 
 // detectConsoleFromISO detects console from ISO9660 filesystem.
+func detectConsoleFromISO(iso *iso9660.ISO9660) (identifier.Console, error) {
+	console, marker, err := findISOConsoleMarker(iso)
+	if err == nil && trace.Enabled() {
+		trace.Log("detect.iso", "console", console, "marker", marker)
+	}
+	return console, err
+}
+
+// findISOConsoleMarker returns the console an ISO9660 filesystem belongs to
+// and the root file that gave it away, or "none" when it fell back to PSX.
 //
 //nolint:gocognit,revive // Console detection requires checking many conditions
-func detectConsoleFromISO(iso *iso9660.ISO9660) (identifier.Console, error) {
+func findISOConsoleMarker(iso *iso9660.ISO9660) (identifier.Console, string, error) {
 	files, err := iso.IterFiles(true)
 	if err != nil {
-		return "", fmt.Errorf("iterate files: %w", err)
+		return "", "", fmt.Errorf("iterate files: %w", err)
 	}
 
 	// Build list of root file names (uppercase)
@@ -336,25 +787,25 @@ func detectConsoleFromISO(iso *iso9660.ISO9660) (identifier.Console, error) {
 	for _, fileName := range rootFiles {
 		switch fileName {
 		case "UMD_DATA.BIN":
-			return identifier.ConsolePSP, nil
+			return identifier.ConsolePSP, fileName, nil
 		case "IPL.TXT":
-			return identifier.ConsoleNeoGeoCD, nil
+			return identifier.ConsoleNeoGeoCD, fileName, nil
 		case "SYSTEM.CNF":
 			data, err := iso.ReadFileByPath("/SYSTEM.CNF")
 			if err == nil {
 				content := strings.ToUpper(string(data))
 				if strings.Contains(content, "BOOT2") {
-					return identifier.ConsolePS2, nil
+					return identifier.ConsolePS2, fileName, nil
 				}
 				if strings.Contains(content, "BOOT") {
-					return identifier.ConsolePSX, nil
+					return identifier.ConsolePSX, fileName, nil
 				}
 			}
 		}
 	}
 
 	// Default to PSX for ISO files without clear markers
-	return identifier.ConsolePSX, nil
+	return identifier.ConsolePSX, "none", nil
 }
 
 func fileExists(path string) bool {
@@ -390,7 +841,7 @@ func detectConsoleFromBlockDevice(path string) (identifier.Console, error) {
 	}
 
 	// Try parsing as ISO9660 disc
-	iso, err := iso9660.Open(path)
+	iso, err := openDeviceISO(path)
 	if err == nil {
 		defer func() { _ = iso.Close() }()
 
@@ -403,7 +854,7 @@ func detectConsoleFromBlockDevice(path string) (identifier.Console, error) {
 	if seekErr == nil {
 		bytesRead, readErr := blockDev.Read(header)
 		if readErr == nil && bytesRead > 0 {
-			iso, openErr := iso9660.Open(path)
+			iso, openErr := openDeviceISO(path)
 			if openErr == nil {
 				defer func() { _ = iso.Close() }()
 
@@ -19,12 +19,14 @@
 package gameid
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/ZaparooProject/go-gameid/identifier"
+	"github.com/ZaparooProject/go-gameid/internal/testiso"
 )
 
 //nolint:funlen // Table-driven test with many test cases
@@ -81,12 +83,24 @@ func TestDetectConsole(t *testing.T) {
 			content:  make([]byte, 0x40),
 			want:     identifier.ConsoleN64,
 		},
+		{
+			name:     "NDS by extension",
+			filename: "game.nds",
+			content:  make([]byte, 0x200),
+			want:     identifier.ConsoleNDS,
+		},
 		{
 			name:     "NES by extension",
 			filename: "game.nes",
 			content:  make([]byte, 0x100),
 			want:     identifier.ConsoleNES,
 		},
+		{
+			name:     "FDS by extension",
+			filename: "game.fds",
+			content:  make([]byte, 0x100),
+			want:     identifier.ConsoleFDS,
+		},
 		{
 			name:     "SNES by extension",
 			filename: "game.sfc",
@@ -202,6 +216,41 @@ func TestDetectConsoleFromHeader_GameCube(t *testing.T) {
 	}
 }
 
+func TestDetectConsoleFromHeader_Wii(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "game.iso")
+
+	// Wii magic is 0x5D1C9EA3 at offset 0x18; the GameCube slot stays empty
+	header := make([]byte, 0x440)
+	copy(header, "RSBE01")
+	header[0x18] = 0x5D
+	header[0x19] = 0x1C
+	header[0x1A] = 0x9E
+	header[0x1B] = 0xA3
+
+	if err := os.WriteFile(path, header, 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	console, err := DetectConsole(path)
+	if err != nil {
+		t.Fatalf("DetectConsole() error = %v", err)
+	}
+	if console != identifier.ConsoleWii {
+		t.Errorf("DetectConsole() = %v, want %v", console, identifier.ConsoleWii)
+	}
+
+	result, err := Identify(path, nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.Console != identifier.ConsoleWii || result.ID != "RSBE" {
+		t.Errorf("Identify() = %v %q, want %v %q", result.Console, result.ID, identifier.ConsoleWii, "RSBE")
+	}
+}
+
 func TestDetectConsoleFromHeader_Saturn(t *testing.T) {
 	t.Parallel()
 
@@ -300,6 +349,75 @@ func TestDetectConsoleFromHeader_Genesis(t *testing.T) {
 	}
 }
 
+func TestDetectConsole_Extensionless(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header []byte
+		want   identifier.Console
+	}{
+		{"N64 z64", append([]byte{0x80, 0x37, 0x12, 0x40}, make([]byte, 0x3C)...), identifier.ConsoleN64},
+		{"Genesis", append(append(make([]byte, 0x100), "SEGA GENESIS    "...), make([]byte, 0xF0)...),
+			identifier.ConsoleGenesis},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "GAME")
+			if err := os.WriteFile(path, tt.header, 0o600); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			console, err := DetectConsole(path)
+			if err != nil {
+				t.Fatalf("DetectConsole() error = %v", err)
+			}
+			if console != tt.want {
+				t.Errorf("DetectConsole() = %v, want %v", console, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectConsoleFromHeader_FDS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header []byte
+	}{
+		{"fwNES header", []byte("FDS\x1a\x01")},
+		{"headerless", []byte("\x01*NINTENDO-HVC*")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpDir := t.TempDir()
+			path := filepath.Join(tmpDir, "game.bin")
+
+			data := make([]byte, 0x100)
+			copy(data, tt.header)
+
+			if err := os.WriteFile(path, data, 0o600); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			console, err := DetectConsole(path)
+			if err != nil {
+				t.Fatalf("DetectConsole() error = %v", err)
+			}
+			if console != identifier.ConsoleFDS {
+				t.Errorf("DetectConsole() = %v, want %v", console, identifier.ConsoleFDS)
+			}
+		})
+	}
+}
+
 func TestDetectConsoleFromDirectory_PSP(t *testing.T) {
 	t.Parallel()
 
@@ -393,6 +511,126 @@ func TestDetectConsoleFromDirectory_Unsupported(t *testing.T) {
 	}
 }
 
+// rawBinImage wraps 2048-byte user data sectors in raw 2352-byte CD sectors
+// with a sync pattern and the given mode byte (1, or 2 for XA).
+func rawBinImage(cooked []byte, mode byte) []byte {
+	dataOffset := 16
+	if mode == 2 {
+		dataOffset = 24
+	}
+	sectors := (len(cooked) + 2047) / 2048
+	raw := make([]byte, sectors*2352)
+	for sector := range sectors {
+		rawSector := raw[sector*2352 : (sector+1)*2352]
+		copy(rawSector, []byte{0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00})
+		rawSector[15] = mode
+		copy(rawSector[dataOffset:dataOffset+2048], cooked[sector*2048:min((sector+1)*2048, len(cooked))])
+	}
+	return raw
+}
+
+func TestDetectConsoleFromBin(t *testing.T) {
+	t.Parallel()
+
+	saturnBoot := make([]byte, 20*2048)
+	copy(saturnBoot, "SEGA SEGASATURN SEGA ENTERPRISES")
+
+	ps2ISO := testiso.CreateMinimal(t, "PS2GAME", "PLAYSTATION", "", []testiso.File{
+		{Name: "SYSTEM.CNF;1", Data: []byte("BOOT2 = cdrom0:\\SLUS_200.62;1\r\n")},
+	})
+
+	genesis := make([]byte, 0x4000)
+	copy(genesis[0x100:], "SEGA GENESIS")
+
+	tests := []struct {
+		name string
+		want identifier.Console
+		data []byte
+	}{
+		{name: "saturn raw mode 1", data: rawBinImage(saturnBoot, 1), want: identifier.ConsoleSaturn},
+		// A trailing partial sector defeats the size-based block size guess
+		{name: "ps2 raw mode 2 truncated", data: append(rawBinImage(ps2ISO, 2), make([]byte, 100)...),
+			want: identifier.ConsolePS2},
+		{name: "ps2 raw mode 1", data: rawBinImage(ps2ISO, 1), want: identifier.ConsolePS2},
+		{name: "ps2 cooked", data: ps2ISO, want: identifier.ConsolePS2},
+		{name: "genesis cartridge", data: genesis, want: identifier.ConsoleGenesis},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "game.bin")
+			if err := os.WriteFile(path, tt.data, 0o600); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			console, err := DetectConsole(path)
+			if err != nil {
+				t.Fatalf("DetectConsole() error = %v", err)
+			}
+			if console != tt.want {
+				t.Errorf("DetectConsole() = %v, want %v", console, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectConsoleFromReader(t *testing.T) {
+	t.Parallel()
+
+	saturnBoot := make([]byte, 20*2048)
+	copy(saturnBoot, "SEGA SEGASATURN SEGA ENTERPRISES")
+
+	gba, err := os.ReadFile(createTestGBAFile(t, t.TempDir()))
+	if err != nil {
+		t.Fatalf("Failed to read GBA file: %v", err)
+	}
+
+	psxISO := testiso.CreateMinimal(t, "PSXGAME", "PLAYSTATION", "", []testiso.File{
+		{Name: "SYSTEM.CNF;1", Data: []byte("BOOT = cdrom:\\SLUS_012.34;1\r\n")},
+	})
+
+	tests := []struct {
+		name    string
+		hintExt string
+		want    identifier.Console
+		data    []byte
+	}{
+		{name: "saturn cooked", data: saturnBoot, want: identifier.ConsoleSaturn},
+		{name: "saturn raw", data: rawBinImage(saturnBoot, 1), hintExt: ".bin", want: identifier.ConsoleSaturn},
+		{name: "gba header", data: gba, want: identifier.ConsoleGBA},
+		{name: "gba header with hint", data: gba, hintExt: ".gba", want: identifier.ConsoleGBA},
+		{name: "hint without dot", data: make([]byte, 0x100), hintExt: "GBA", want: identifier.ConsoleGBA},
+		{name: "psx iso", data: psxISO, hintExt: ".iso", want: identifier.ConsolePSX},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			console, err := DetectConsoleFromReader(bytes.NewReader(tt.data), int64(len(tt.data)), tt.hintExt)
+			if err != nil {
+				t.Fatalf("DetectConsoleFromReader() error = %v", err)
+			}
+			if console != tt.want {
+				t.Errorf("DetectConsoleFromReader() = %v, want %v", console, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectConsoleFromReader_Unrecognized(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("not a game "), 100)
+	_, err := DetectConsoleFromReader(bytes.NewReader(data), int64(len(data)), ".bin")
+	var notSupported identifier.NotSupportedError
+	if !errors.As(err, &notSupported) {
+		t.Errorf("DetectConsoleFromReader() error = %v, want NotSupportedError", err)
+	}
+}
+
 func TestDetectConsoleFromHeader_AmbiguousISO(t *testing.T) {
 	t.Parallel()
 
@@ -412,6 +650,35 @@ func TestDetectConsoleFromHeader_AmbiguousISO(t *testing.T) {
 	}
 }
 
+func TestHasSupportedExtension(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"game.gba", true},
+		{"GAME.SFC", true},
+		{"game.nes.gz", true},
+		{"disc.iso", true},
+		{"disc.cue", true},
+		{"readme.txt", false},
+		{"archive.zip", false},
+		{"noext", false},
+		{"notes.txt.gz", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			if got := HasSupportedExtension(tt.path); got != tt.want {
+				t.Errorf("HasSupportedExtension(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFileExists(t *testing.T) {
 	t.Parallel()
 
@@ -478,6 +745,18 @@ func TestDetectConsoleFromCHD_GameCube(t *testing.T) {
 }
 
 // TestDetectConsoleFromCHD_NonExistent verifies error for missing CHD.
+func TestDetectConsole_CSO(t *testing.T) {
+	t.Parallel()
+
+	console, err := DetectConsole("testdata/PSP/minimal.cso")
+	if err != nil {
+		t.Fatalf("DetectConsole() error = %v", err)
+	}
+	if console != identifier.ConsolePSP {
+		t.Errorf("DetectConsole() = %v, want %v", console, identifier.ConsolePSP)
+	}
+}
+
 func TestDetectConsoleFromCHD_NonExistent(t *testing.T) {
 	t.Parallel()
 
@@ -488,6 +767,98 @@ func TestDetectConsoleFromCHD_NonExistent(t *testing.T) {
 }
 
 // TestDetectConsoleFromCue_MagicBased verifies CUE detection for consoles with magic headers.
+// copyAs copies a fixture into a temporary directory under a new name.
+func copyAs(t *testing.T, src, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// TestDetectConsole_ContentFallback checks that disc images with
+// extensions DetectConsole doesn't know are still recognized by content.
+func TestDetectConsole_ContentFallback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  string
+		file string
+		want identifier.Console
+	}{
+		{"ISO as img", "testdata/NeoGeoCD/240pTestSuite.iso", "game.img", identifier.ConsoleNeoGeoCD},
+		{"Sega CD ISO as dsk", "testdata/SegaCD/240p_SegaCD_USA.iso", "game.dsk", identifier.ConsoleSegaCD},
+		{"CHD as img", "testdata/SegaCD/240pSuite_USA.chd", "game.img", identifier.ConsoleSegaCD},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			console, err := DetectConsole(copyAs(t, tt.src, tt.file))
+			if err != nil {
+				t.Fatalf("DetectConsole() error = %v", err)
+			}
+			if console != tt.want {
+				t.Errorf("DetectConsole() = %v, want %v", console, tt.want)
+			}
+		})
+	}
+
+	t.Run("cue as txt", func(t *testing.T) {
+		t.Parallel()
+
+		tmpDir := t.TempDir()
+		binData := make([]byte, 0x100)
+		copy(binData, "SEGA SEGASATURN")
+		if err := os.WriteFile(filepath.Join(tmpDir, "game.bin"), binData, 0o600); err != nil {
+			t.Fatalf("Failed to write BIN file: %v", err)
+		}
+		cuePath := filepath.Join(tmpDir, "game.txt")
+		cueContent := "FILE \"game.bin\" BINARY\n  TRACK 01 MODE1/2352\n    INDEX 01 00:00:00\n"
+		if err := os.WriteFile(cuePath, []byte(cueContent), 0o600); err != nil {
+			t.Fatalf("Failed to write CUE file: %v", err)
+		}
+
+		console, err := DetectConsole(cuePath)
+		if err != nil || console != identifier.ConsoleSaturn {
+			t.Errorf("DetectConsole() = %v, %v; want Saturn", console, err)
+		}
+	})
+
+	t.Run("not a disc", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "notes.img")
+		if err := os.WriteFile(path, []byte("just some text"), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		var notSupported identifier.NotSupportedError
+		if _, err := DetectConsole(path); !errors.As(err, &notSupported) {
+			t.Errorf("DetectConsole() error = %v, want NotSupportedError", err)
+		}
+	})
+}
+
+func TestIdentify_ISOWithUnknownExtension(t *testing.T) {
+	t.Parallel()
+
+	result, err := Identify(copyAs(t, "testdata/NeoGeoCD/240pTestSuite.iso", "game.img"), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.Console != identifier.ConsoleNeoGeoCD {
+		t.Errorf("Console = %v, want %v", result.Console, identifier.ConsoleNeoGeoCD)
+	}
+}
+
 func TestDetectConsoleFromCue_MagicBased(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// csvHeader is the header row written by WriteCSV.
+var csvHeader = []string{"path", "console", "id", "title", "region", "metadata"}
+
+// WriteCSV writes results as CSV for spreadsheets: a header row followed by
+// one row per result with the columns path, console, id, title, region and
+// metadata. The metadata column holds the result's metadata as a JSON object
+// with sorted keys. Fields containing commas, quotes or newlines are quoted.
+func WriteCSV(w io.Writer, results []*Result) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("write CSV header: %w", err)
+	}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		metadata := []byte("{}")
+		if len(result.Metadata) > 0 {
+			encoded, err := json.Marshal(result.Metadata)
+			if err != nil {
+				return fmt.Errorf("encode metadata: %w", err)
+			}
+			metadata = encoded
+		}
+		row := []string{
+			result.Path,
+			string(result.Console),
+			result.ID,
+			result.Title,
+			result.Region,
+			string(metadata),
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("write CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flush CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	t.Parallel()
+
+	results := []*Result{
+		{
+			Path:     "roms/zelda.gba",
+			Console:  ConsoleGBA,
+			ID:       "BZME",
+			Title:    "Legend of Zelda, The - The Minish Cap",
+			Region:   "USA",
+			Metadata: map[string]string{"maker_code": "01", "internal_title": "GBAZELDA MC"},
+		},
+		nil,
+		{Console: ConsoleNES, Title: `Say "Hi"`},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, results); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("WriteCSV() wrote %d lines, want 3:\n%s", len(lines), buf.String())
+	}
+	if lines[0] != "path,console,id,title,region,metadata" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if !strings.Contains(lines[1], `,"Legend of Zelda, The - The Minish Cap",`) {
+		t.Errorf("row %q does not quote the title containing a comma", lines[1])
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	want := []string{
+		"roms/zelda.gba", "GBA", "BZME", "Legend of Zelda, The - The Minish Cap", "USA",
+		`{"internal_title":"GBAZELDA MC","maker_code":"01"}`,
+	}
+	for i, field := range want {
+		if rows[1][i] != field {
+			t.Errorf("row 1 column %s = %q, want %q", rows[0][i], rows[1][i], field)
+		}
+	}
+	if rows[2][3] != `Say "Hi"` || rows[2][5] != "{}" {
+		t.Errorf("row 2 = %q, want title %q and empty metadata object", rows[2], `Say "Hi"`)
+	}
+}
+
+func TestIdentifySetsPath(t *testing.T) {
+	t.Parallel()
+
+	const path = "testdata/archive/snes.zip"
+	result, err := Identify(path, nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.Path != path {
+		t.Errorf("Path = %q, want %q", result.Path, path)
+	}
+}
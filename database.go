@@ -19,20 +19,45 @@
 package gameid
 
 import (
+	"bufio"
+	"bytes"
 	"compress/gzip"
 	"encoding/gob"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
 
 	"github.com/ZaparooProject/go-gameid/identifier"
+	"github.com/ZaparooProject/go-gameid/internal/trace"
+)
+
+// Leading bytes of zstd frames and gzip members.
+var (
+	zstdMagic = []byte{0x28, 0xB5, 0x2F, 0xFD}
+	gzipMagic = []byte{0x1F, 0x8B}
 )
 
+// DatabaseVersion is the GameDatabase format written by this package. Bump it
+// whenever a change to GameDatabase or its key types makes older files decode
+// incorrectly.
+const DatabaseVersion = 1
+
 // GameDatabase holds the game metadata database.
 type GameDatabase struct {
+	// Version is the format version the database was written with.
+	// Databases saved before versioning was introduced decode as 0.
+	Version int
+
 	// Console-specific databases
 	// Key format varies by console (see identifier package)
-	GB       map[gbKey]map[string]string
+	GB       map[GBKey]map[string]string
 	GBA      map[string]map[string]string
 	GC       map[string]map[string]string
 	Genesis  map[string]map[string]string
@@ -43,37 +68,53 @@ type GameDatabase struct {
 	PS2      map[string]map[string]string
 	Saturn   map[string]map[string]string
 	SegaCD   map[string]map[string]string
-	SNES     map[snesKey]map[string]string
-	NeoGeoCD map[neogeoCDKey]map[string]string
+	SNES     map[SNESKey]map[string]string
+	NeoGeoCD map[NeoGeoCDKey]map[string]string
 
 	// ID prefixes for disc-based consoles
 	IDPrefixes map[identifier.Console][]string
+
+	// Variants holds further entries for string IDs that several releases
+	// share, such as regional versions with one serial, in the order they
+	// were added. The first entry stays in the console's table above and is
+	// what Lookup and LookupByString return; LookupAll returns them all.
+	Variants map[identifier.Console]map[string][]map[string]string
+
+	// fuzzyIndex caches each console's keys by normalized form for
+	// LookupFuzzy. AddEntry, AppendEntry and Merge drop it when they change
+	// a table.
+	fuzzyIndex map[identifier.Console]map[string]string
+	fuzzyMu    sync.Mutex
 }
 
-// gbKey is the lookup key for GB/GBC games: (internal_title, global_checksum)
-type gbKey struct {
-	Title    string
-	Checksum uint16
+// GBKey is the database key for GB and GBC games: the header title and the
+// global checksum stored in the header.
+type GBKey struct {
+	Title    string `json:"title"`
+	Checksum uint16 `json:"checksum"`
 }
 
-// snesKey is the lookup key for SNES games: (developer_id, internal_name_hex, rom_version, checksum)
-type snesKey struct {
-	InternalName string
-	DeveloperID  int
-	ROMVersion   int
-	Checksum     int
+// SNESKey is the database key for SNES games: the header's internal name as
+// hex, developer ID, ROM version and checksum.
+type SNESKey struct {
+	InternalName string `json:"internal_name"`
+	DeveloperID  int    `json:"developer_id"`
+	ROMVersion   int    `json:"rom_version"`
+	Checksum     int    `json:"checksum"`
 }
 
-// neogeoCDKey is the lookup key for NeoGeoCD games: (uuid, volume_id)
-type neogeoCDKey struct {
-	UUID     string
-	VolumeID string
+// NeoGeoCDKey is the database key for NeoGeoCD games: the disc's UUID and
+// volume ID.
+type NeoGeoCDKey struct {
+	UUID     string `json:"uuid"`
+	VolumeID string `json:"volume_id"`
 }
 
 // NewDatabase creates an empty database.
 func NewDatabase() *GameDatabase {
 	return &GameDatabase{
-		GB:         make(map[gbKey]map[string]string),
+		Version:    DatabaseVersion,
+		GB:         make(map[GBKey]map[string]string),
 		GBA:        make(map[string]map[string]string),
 		GC:         make(map[string]map[string]string),
 		Genesis:    make(map[string]map[string]string),
@@ -84,13 +125,14 @@ func NewDatabase() *GameDatabase {
 		PS2:        make(map[string]map[string]string),
 		Saturn:     make(map[string]map[string]string),
 		SegaCD:     make(map[string]map[string]string),
-		SNES:       make(map[snesKey]map[string]string),
-		NeoGeoCD:   make(map[neogeoCDKey]map[string]string),
+		SNES:       make(map[SNESKey]map[string]string),
+		NeoGeoCD:   make(map[NeoGeoCDKey]map[string]string),
 		IDPrefixes: make(map[identifier.Console][]string),
+		Variants:   make(map[identifier.Console]map[string][]map[string]string),
 	}
 }
 
-// LoadDatabase loads a database from a gob.gz file.
+// LoadDatabase loads a database from a gob.gz or gob.zst file.
 func LoadDatabase(path string) (*GameDatabase, error) {
 	dbFile, err := os.Open(path) //nolint:gosec // Path from user input is expected
 	if err != nil {
@@ -101,24 +143,79 @@ func LoadDatabase(path string) (*GameDatabase, error) {
 	return LoadDatabaseFromReader(dbFile)
 }
 
-// LoadDatabaseFromReader loads a database from a gzip-compressed gob reader.
+// LoadDatabaseFromReader loads a database from a compressed gob reader.
+// The compression is sniffed from the leading bytes: zstd streams are
+// recognized by their frame magic, anything else is read as gzip.
 func LoadDatabaseFromReader(r io.Reader) (*GameDatabase, error) {
-	gz, err := gzip.NewReader(r)
+	decompressed, closeReader, err := newDatabaseReader(r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		return nil, err
 	}
-	defer func() { _ = gz.Close() }()
+	defer closeReader()
 
+	// gob leaves zero-valued fields out, so start from 0 to tell unversioned
+	// files apart from current ones
 	db := NewDatabase()
-	dec := gob.NewDecoder(gz)
+	db.Version = 0
+	dec := gob.NewDecoder(decompressed)
 	if err := dec.Decode(db); err != nil {
-		return nil, fmt.Errorf("failed to decode database: %w", err)
+		return nil, fmt.Errorf("failed to decode database (regenerate it with cmd/dbgen if it "+
+			"was written by another version of go-gameid): %w", err)
+	}
+	if err := migrateDatabase(db); err != nil {
+		return nil, err
 	}
 
 	return db, nil
 }
 
-// SaveDatabase saves the database to a gob.gz file.
+// DatabaseVersionError is returned when a database file was written in a
+// format this version of the package cannot read.
+type DatabaseVersionError struct {
+	Version  int
+	Expected int
+}
+
+func (e DatabaseVersionError) Error() string {
+	return fmt.Sprintf("database format v%d, expected v%d: regenerate the database with cmd/dbgen",
+		e.Version, e.Expected)
+}
+
+// migrateDatabase brings a freshly decoded database up to DatabaseVersion.
+// Unversioned databases share the v1 layout and only need the field set;
+// any other version mismatch returns DatabaseVersionError.
+func migrateDatabase(db *GameDatabase) error {
+	if db.Version == 0 {
+		db.Version = 1
+	}
+	if db.Version != DatabaseVersion {
+		return DatabaseVersionError{Version: db.Version, Expected: DatabaseVersion}
+	}
+	return nil
+}
+
+// newDatabaseReader wraps r in the decompressor matching its leading bytes.
+func newDatabaseReader(r io.Reader) (io.Reader, func(), error) {
+	buffered := bufio.NewReader(r)
+	magic, _ := buffered.Peek(len(zstdMagic))
+
+	if bytes.Equal(magic, zstdMagic) {
+		zr, err := zstd.NewReader(buffered)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create zstd reader: %w", err)
+		}
+		return zr, zr.Close, nil
+	}
+
+	gz, err := gzip.NewReader(buffered)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	return gz, func() { _ = gz.Close() }, nil
+}
+
+// SaveDatabase saves the database to a gob file. Paths ending in .zst are
+// zstd-compressed; everything else uses gzip.
 func (db *GameDatabase) SaveDatabase(path string) error {
 	file, err := os.Create(path) //nolint:gosec // Path from user input is expected
 	if err != nil {
@@ -126,51 +223,59 @@ func (db *GameDatabase) SaveDatabase(path string) error {
 	}
 	defer func() { _ = file.Close() }()
 
-	gz := gzip.NewWriter(file)
-	defer func() { _ = gz.Close() }()
+	compressed, err := newDatabaseWriter(file, path)
+	if err != nil {
+		return err
+	}
 
-	enc := gob.NewEncoder(gz)
+	enc := gob.NewEncoder(compressed)
 	if err := enc.Encode(db); err != nil {
+		_ = compressed.Close()
 		return fmt.Errorf("failed to encode database: %w", err)
 	}
 
+	if err := compressed.Close(); err != nil {
+		return fmt.Errorf("failed to flush database: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close database file: %w", err)
+	}
+
 	return nil
 }
 
+// newDatabaseWriter picks the compressor for a database path by extension.
+func newDatabaseWriter(w io.Writer, path string) (io.WriteCloser, error) {
+	if strings.EqualFold(filepath.Ext(path), ".zst") {
+		zw, err := zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd writer: %w", err)
+		}
+		return zw, nil
+	}
+	return gzip.NewWriter(w), nil
+}
+
 // Lookup retrieves metadata for a game by console and key.
-//
-//nolint:exhaustive // Only some consoles use complex keys; others use LookupByString
 func (db *GameDatabase) Lookup(console identifier.Console, key any) (map[string]string, bool) {
+	entry, found := db.lookup(console, key)
+	if trace.Enabled() {
+		trace.Log("db.lookup", "console", console, "key", key, "found", found)
+	}
+	return entry, found
+}
+
+//nolint:exhaustive // Only some consoles use complex keys; others use LookupByString
+func (db *GameDatabase) lookup(console identifier.Console, key any) (map[string]string, bool) {
 	switch console {
 	case identifier.ConsoleGB, identifier.ConsoleGBC:
-		if k, ok := key.(struct {
-			title    string
-			checksum uint16
-		}); ok {
-			entry, found := db.GB[gbKey{Title: k.title, Checksum: k.checksum}]
-			return entry, found
-		}
-		// Also try direct gbKey type
-		if k, ok := key.(gbKey); ok {
+		if k, ok := toGBKey(key); ok {
 			entry, found := db.GB[k]
 			return entry, found
 		}
 	case identifier.ConsoleSNES:
-		if k, ok := key.(struct {
-			internalName string
-			developerID  int
-			romVersion   int
-			checksum     int
-		}); ok {
-			entry, found := db.SNES[snesKey{
-				DeveloperID:  k.developerID,
-				InternalName: k.internalName,
-				ROMVersion:   k.romVersion,
-				Checksum:     k.checksum,
-			}]
-			return entry, found
-		}
-		if k, ok := key.(snesKey); ok {
+		if k, ok := toSNESKey(key); ok {
 			entry, found := db.SNES[k]
 			return entry, found
 		}
@@ -180,14 +285,7 @@ func (db *GameDatabase) Lookup(console identifier.Console, key any) (map[string]
 			return entry, found
 		}
 	case identifier.ConsoleNeoGeoCD:
-		if k, ok := key.(struct {
-			uuid     string
-			volumeID string
-		}); ok {
-			entry, found := db.NeoGeoCD[neogeoCDKey{UUID: k.uuid, VolumeID: k.volumeID}]
-			return entry, found
-		}
-		if k, ok := key.(neogeoCDKey); ok {
+		if k, ok := toNeoGeoCDKey(key); ok {
 			entry, found := db.NeoGeoCD[k]
 			return entry, found
 		}
@@ -197,9 +295,16 @@ func (db *GameDatabase) Lookup(console identifier.Console, key any) (map[string]
 }
 
 // LookupByString retrieves metadata using a string key.
-//
-//nolint:exhaustive // GB, GBC, NES, SNES use Lookup with complex keys, not LookupByString
 func (db *GameDatabase) LookupByString(console identifier.Console, key string) (map[string]string, bool) {
+	entry, found := db.lookupByString(console, key)
+	if trace.Enabled() {
+		trace.Log("db.lookup", "console", console, "key", key, "found", found)
+	}
+	return entry, found
+}
+
+//nolint:exhaustive // GB, GBC, NES, SNES use Lookup with complex keys, not LookupByString
+func (db *GameDatabase) lookupByString(console identifier.Console, key string) (map[string]string, bool) {
 	switch console {
 	case identifier.ConsoleGBA:
 		entry, found := db.GBA[key]
@@ -229,21 +334,442 @@ func (db *GameDatabase) LookupByString(console identifier.Console, key string) (
 		entry, found := db.SegaCD[key]
 		return entry, found
 	case identifier.ConsoleNeoGeoCD:
-		// Try volume_ID as fallback for NeoGeoCD
-		for k, v := range db.NeoGeoCD {
-			if k.VolumeID == key {
-				return v, true
+		// Try volume_ID as fallback for NeoGeoCD. Releases sharing a volume
+		// ID resolve to the lowest UUID so the answer doesn't vary by run.
+		var match *NeoGeoCDKey
+		for k := range db.NeoGeoCD {
+			if k.VolumeID == key && (match == nil || k.UUID < match.UUID) {
+				match = &k
 			}
 		}
+		if match != nil {
+			return db.NeoGeoCD[*match], true
+		}
 	}
 
 	return nil, false
 }
 
+// LookupAll returns every entry stored under a string ID, the one
+// LookupByString returns first and then any added with AppendEntry, so callers
+// can choose between releases by region or language. The slice is new but
+// the maps are the database's own and must not be modified.
+func (db *GameDatabase) LookupAll(console identifier.Console, id string) ([]map[string]string, bool) {
+	var entries []map[string]string
+	if table := db.stringMap(console); table != nil {
+		if entry, found := (*table)[id]; found {
+			entries = append(entries, entry)
+			entries = append(entries, db.Variants[console][id]...)
+		}
+	}
+	if trace.Enabled() {
+		trace.Log("db.lookup_all", "console", console, "key", id, "found", len(entries))
+	}
+	return entries, len(entries) > 0
+}
+
+// LookupFuzzy retrieves metadata for a string ID, tolerating formatting
+// differences. If the exact lookup misses, the ID and database keys are
+// compared after normalization (uppercase, alphanumerics only, leading zeros
+// dropped from numbers), so "SLUS-00123", "slus 123" and "SLUS_00123" all
+// match. For PSX and PS2, a bare number is also tried with each ID prefix.
+//
+// The normalized keys are indexed on a console's first miss and the index is
+// reused, so once LookupFuzzy has been called, change the tables through
+// AddEntry, AppendEntry or Merge rather than directly.
+func (db *GameDatabase) LookupFuzzy(console identifier.Console, id string) (map[string]string, bool) {
+	if entry, found := db.LookupByString(console, id); found {
+		return entry, true
+	}
+
+	entries := db.stringMap(console)
+	if entries == nil {
+		return nil, false
+	}
+	query := normalizeID(id)
+	if query == "" {
+		return nil, false
+	}
+
+	candidates := []string{query}
+	if (console == identifier.ConsolePSX || console == identifier.ConsolePS2) && isDigits(query) {
+		for _, prefix := range db.GetIDPrefixes(console) {
+			candidates = append(candidates, normalizeID(prefix+query))
+		}
+	}
+
+	index := db.normalizedIndex(console, *entries)
+	for _, candidate := range candidates {
+		if key, ok := index[candidate]; ok {
+			return (*entries)[key], true
+		}
+	}
+	return nil, false
+}
+
+// normalizedIndex returns console's keys by normalized form, building the
+// index on first use. On collisions the smallest key is kept so results are
+// deterministic.
+func (db *GameDatabase) normalizedIndex(console identifier.Console, entries map[string]map[string]string) map[string]string {
+	db.fuzzyMu.Lock()
+	defer db.fuzzyMu.Unlock()
+
+	if index, ok := db.fuzzyIndex[console]; ok {
+		return index
+	}
+	index := make(map[string]string, len(entries))
+	for key := range entries {
+		norm := normalizeID(key)
+		if existing, ok := index[norm]; !ok || key < existing {
+			index[norm] = key
+		}
+	}
+	if db.fuzzyIndex == nil {
+		db.fuzzyIndex = make(map[identifier.Console]map[string]string)
+	}
+	db.fuzzyIndex[console] = index
+	return index
+}
+
+// dropNormalizedIndex discards the LookupFuzzy index of console after its
+// table changed.
+func (db *GameDatabase) dropNormalizedIndex(console identifier.Console) {
+	db.fuzzyMu.Lock()
+	defer db.fuzzyMu.Unlock()
+	delete(db.fuzzyIndex, console)
+}
+
+// normalizeID uppercases id, drops everything except letters and digits,
+// and strips leading zeros from each run of digits (a run of only zeros
+// becomes a single "0").
+func normalizeID(id string) string {
+	var sb strings.Builder
+	inNumber, pendingZero := false, false
+	flushZero := func() {
+		if pendingZero && !inNumber {
+			_ = sb.WriteByte('0')
+		}
+		inNumber, pendingZero = false, false
+	}
+	for _, r := range strings.ToUpper(id) {
+		switch {
+		case r == '0' && !inNumber:
+			pendingZero = true
+		case r >= '0' && r <= '9':
+			inNumber = true
+			_, _ = sb.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			flushZero()
+			_, _ = sb.WriteRune(r)
+		}
+	}
+	flushZero()
+	return sb.String()
+}
+
+// isDigits reports whether s is non-empty and contains only ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // GetIDPrefixes returns the ID prefixes for disc-based consoles.
 func (db *GameDatabase) GetIDPrefixes(console identifier.Console) []string {
 	return db.IDPrefixes[console]
 }
 
+// InvalidKeyError is returned by AddEntry when a key's type does not match
+// the key type used by the console's database.
+type InvalidKeyError struct {
+	Key     any
+	Console identifier.Console
+}
+
+func (e InvalidKeyError) Error() string {
+	return fmt.Sprintf("invalid database key type %T for console %s", e.Key, e.Console)
+}
+
+// AddEntry inserts or replaces a single database entry. Entries added under
+// the same string ID with AppendEntry are kept.
+// The key must match the console's key type: GBKey for GB/GBC, SNESKey for
+// SNES, NeoGeoCDKey for NeoGeoCD, int (CRC32) for NES, and string for all
+// other consoles. The key structs the identifiers build, which declare the
+// same fields unexported, are accepted as well. A mismatched key returns
+// InvalidKeyError.
+//
+//nolint:exhaustive,gocyclo,cyclop,revive // One case per console key type
+func (db *GameDatabase) AddEntry(console identifier.Console, key any, metadata map[string]string) error {
+	switch console {
+	case identifier.ConsoleGB, identifier.ConsoleGBC:
+		gb, ok := toGBKey(key)
+		if !ok {
+			return InvalidKeyError{Console: console, Key: key}
+		}
+		db.GB = addEntry(db.GB, gb, metadata)
+	case identifier.ConsoleSNES:
+		snes, ok := toSNESKey(key)
+		if !ok {
+			return InvalidKeyError{Console: console, Key: key}
+		}
+		db.SNES = addEntry(db.SNES, snes, metadata)
+	case identifier.ConsoleNeoGeoCD:
+		neogeo, ok := toNeoGeoCDKey(key)
+		if !ok {
+			return InvalidKeyError{Console: console, Key: key}
+		}
+		db.NeoGeoCD = addEntry(db.NeoGeoCD, neogeo, metadata)
+	case identifier.ConsoleNES:
+		crc, ok := key.(int)
+		if !ok {
+			return InvalidKeyError{Console: console, Key: key}
+		}
+		db.NES = addEntry(db.NES, crc, metadata)
+	default:
+		id, ok := key.(string)
+		if !ok {
+			return InvalidKeyError{Console: console, Key: key}
+		}
+		entries := db.stringMap(console)
+		if entries == nil {
+			return identifier.ErrNotSupported{Format: string(console)}
+		}
+		*entries = addEntry(*entries, id, metadata)
+		db.dropNormalizedIndex(console)
+	}
+	return nil
+}
+
+// AppendEntry adds an entry under a string ID, keeping any already stored
+// there: the first entry for an ID is the one LookupByString returns, and
+// LookupAll returns them all in the order they were added. Consoles whose
+// keys aren't strings return ErrNotSupported.
+func (db *GameDatabase) AppendEntry(console identifier.Console, id string, metadata map[string]string) error {
+	table := db.stringMap(console)
+	if table == nil {
+		return identifier.ErrNotSupported{Format: string(console)}
+	}
+	if _, exists := (*table)[id]; !exists {
+		*table = addEntry(*table, id, metadata)
+		db.dropNormalizedIndex(console)
+		return nil
+	}
+
+	if db.Variants == nil {
+		db.Variants = make(map[identifier.Console]map[string][]map[string]string)
+	}
+	if db.Variants[console] == nil {
+		db.Variants[console] = make(map[string][]map[string]string)
+	}
+	db.Variants[console][id] = append(db.Variants[console][id], metadata)
+	return nil
+}
+
+// stringMap returns a pointer to the string-keyed map for console,
+// or nil if the console does not use string keys.
+//
+//nolint:exhaustive // Only consoles with string keys are listed
+func (db *GameDatabase) stringMap(console identifier.Console) *map[string]map[string]string {
+	switch console {
+	case identifier.ConsoleGBA:
+		return &db.GBA
+	case identifier.ConsoleGC:
+		return &db.GC
+	case identifier.ConsoleGenesis:
+		return &db.Genesis
+	case identifier.ConsoleN64:
+		return &db.N64
+	case identifier.ConsolePSP:
+		return &db.PSP
+	case identifier.ConsolePSX:
+		return &db.PSX
+	case identifier.ConsolePS2:
+		return &db.PS2
+	case identifier.ConsoleSaturn:
+		return &db.Saturn
+	case identifier.ConsoleSegaCD:
+		return &db.SegaCD
+	default:
+		return nil
+	}
+}
+
+// consoleField returns the GameDatabase field that stores a console's games,
+// or "" for consoles without a table.
+func consoleField(console identifier.Console) string {
+	switch console {
+	case identifier.ConsoleGB, identifier.ConsoleGBC:
+		return "GB"
+	case identifier.ConsoleGBA, identifier.ConsoleGC, identifier.ConsoleGenesis, identifier.ConsoleN64,
+		identifier.ConsoleNES, identifier.ConsolePSP, identifier.ConsolePSX, identifier.ConsolePS2,
+		identifier.ConsoleSaturn, identifier.ConsoleSegaCD, identifier.ConsoleSNES, identifier.ConsoleNeoGeoCD:
+		return string(console)
+	default:
+		return ""
+	}
+}
+
+// The identifiers declare their key types locally with unexported fields, so
+// no type in this package is identical to them; the converters below accept
+// them by reading their fields by name.
+
+// toGBKey converts a GBKey or the GB identifier's key struct to a GBKey.
+func toGBKey(key any) (GBKey, bool) {
+	if k, ok := key.(GBKey); ok {
+		return k, true
+	}
+	fields, ok := keyFields(key, map[string]reflect.Kind{"title": reflect.String, "checksum": reflect.Uint16})
+	if !ok {
+		return GBKey{}, false
+	}
+	//nolint:gosec // keyFields checked the field is a uint16
+	return GBKey{Title: fields["title"].String(), Checksum: uint16(fields["checksum"].Uint())}, true
+}
+
+// toSNESKey converts a SNESKey or the SNES identifier's key struct to a SNESKey.
+func toSNESKey(key any) (SNESKey, bool) {
+	if k, ok := key.(SNESKey); ok {
+		return k, true
+	}
+	fields, ok := keyFields(key, map[string]reflect.Kind{
+		"internalName": reflect.String,
+		"developerID":  reflect.Int,
+		"romVersion":   reflect.Int,
+		"checksum":     reflect.Int,
+	})
+	if !ok {
+		return SNESKey{}, false
+	}
+	return SNESKey{
+		InternalName: fields["internalName"].String(),
+		DeveloperID:  int(fields["developerID"].Int()),
+		ROMVersion:   int(fields["romVersion"].Int()),
+		Checksum:     int(fields["checksum"].Int()),
+	}, true
+}
+
+// toNeoGeoCDKey converts a NeoGeoCDKey or the NeoGeoCD identifier's key struct to a NeoGeoCDKey.
+func toNeoGeoCDKey(key any) (NeoGeoCDKey, bool) {
+	if k, ok := key.(NeoGeoCDKey); ok {
+		return k, true
+	}
+	fields, ok := keyFields(key, map[string]reflect.Kind{"uuid": reflect.String, "volumeID": reflect.String})
+	if !ok {
+		return NeoGeoCDKey{}, false
+	}
+	return NeoGeoCDKey{UUID: fields["uuid"].String(), VolumeID: fields["volumeID"].String()}, true
+}
+
+// keyFields returns the fields of the struct key, which must have exactly
+// the named fields with the given kinds.
+func keyFields(key any, kinds map[string]reflect.Kind) (map[string]reflect.Value, bool) {
+	value := reflect.ValueOf(key)
+	if value.Kind() != reflect.Struct || value.NumField() != len(kinds) {
+		return nil, false
+	}
+	fields := make(map[string]reflect.Value, len(kinds))
+	for name, kind := range kinds {
+		field := value.FieldByName(name)
+		if !field.IsValid() || field.Kind() != kind {
+			return nil, false
+		}
+		fields[name] = field
+	}
+	return fields, true
+}
+
+// addEntry sets entries[key] to metadata, allocating entries if needed.
+func addEntry[K comparable](entries map[K]map[string]string, key K, metadata map[string]string) map[K]map[string]string {
+	if entries == nil {
+		entries = make(map[K]map[string]string)
+	}
+	entries[key] = metadata
+	return entries
+}
+
+// Merge adds all entries from other into db. Entries in other replace
+// entries in db with the same key, along with all their variants, and ID
+// prefixes from other are appended to db's prefixes, skipping any already
+// present.
+func (db *GameDatabase) Merge(other *GameDatabase) {
+	if other == nil {
+		return
+	}
+
+	db.GB = mergeEntries(db.GB, other.GB)
+	db.GBA = mergeEntries(db.GBA, other.GBA)
+	db.GC = mergeEntries(db.GC, other.GC)
+	db.Genesis = mergeEntries(db.Genesis, other.Genesis)
+	db.N64 = mergeEntries(db.N64, other.N64)
+	db.NES = mergeEntries(db.NES, other.NES)
+	db.PSP = mergeEntries(db.PSP, other.PSP)
+	db.PSX = mergeEntries(db.PSX, other.PSX)
+	db.PS2 = mergeEntries(db.PS2, other.PS2)
+	db.Saturn = mergeEntries(db.Saturn, other.Saturn)
+	db.SegaCD = mergeEntries(db.SegaCD, other.SegaCD)
+	db.SNES = mergeEntries(db.SNES, other.SNES)
+	db.NeoGeoCD = mergeEntries(db.NeoGeoCD, other.NeoGeoCD)
+	db.mergeVariants(other)
+	db.fuzzyMu.Lock()
+	db.fuzzyIndex = nil
+	db.fuzzyMu.Unlock()
+
+	if db.IDPrefixes == nil {
+		db.IDPrefixes = make(map[identifier.Console][]string)
+	}
+	for console, prefixes := range other.IDPrefixes {
+		existing := db.IDPrefixes[console]
+		for _, prefix := range prefixes {
+			if !slices.Contains(existing, prefix) {
+				existing = append(existing, prefix)
+			}
+		}
+		db.IDPrefixes[console] = existing
+	}
+}
+
+// mergeVariants replaces db's variants for every string ID other has an
+// entry for with other's variants for it.
+func (db *GameDatabase) mergeVariants(other *GameDatabase) {
+	for _, console := range AllConsoles {
+		table := other.stringMap(console)
+		if table == nil || (len(db.Variants[console]) == 0 && len(other.Variants[console]) == 0) {
+			continue
+		}
+		if db.Variants == nil {
+			db.Variants = make(map[identifier.Console]map[string][]map[string]string)
+		}
+		variants := db.Variants[console]
+		if variants == nil {
+			variants = make(map[string][]map[string]string)
+			db.Variants[console] = variants
+		}
+		for id := range *table {
+			if extra := other.Variants[console][id]; len(extra) > 0 {
+				variants[id] = slices.Clone(extra)
+			} else {
+				delete(variants, id)
+			}
+		}
+	}
+}
+
+// mergeEntries copies all entries from src into dst, allocating dst if needed.
+func mergeEntries[K comparable](dst, src map[K]map[string]string) map[K]map[string]string {
+	if dst == nil {
+		dst = make(map[K]map[string]string, len(src))
+	}
+	for key, metadata := range src {
+		dst[key] = metadata
+	}
+	return dst
+}
+
 // Ensure GameDatabase implements identifier.Database
 var _ identifier.Database = (*GameDatabase)(nil)
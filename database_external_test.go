@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ZaparooProject/go-gameid"
+)
+
+// TestIdentify_StructKeyDatabase builds a database the way an external
+// caller does, with the exported key types, and checks that the keys the GB
+// and SNES identifiers build find its entries after a save and load.
+func TestIdentify_StructKeyDatabase(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rom := make([]byte, 0x8000)
+	copy(rom[0x134:], "DBTEST")
+	rom[0x14E], rom[0x14F] = 0x12, 0x34
+	gbPath := filepath.Join(dir, "game.gb")
+	if err := os.WriteFile(gbPath, rom, 0o600); err != nil {
+		t.Fatalf("write GB ROM: %v", err)
+	}
+	snesPath := "testdata/SNES/240pSuite.sfc"
+
+	// Read the SNES key fields from the header
+	header, err := gameid.Identify(snesPath, nil)
+	if err != nil {
+		t.Fatalf("Identify(%s) error = %v", snesPath, err)
+	}
+	metaInt := func(key string) int {
+		value, err := strconv.ParseInt(header.Metadata[key], 0, 64)
+		if err != nil {
+			t.Fatalf("metadata %s = %q: %v", key, header.Metadata[key], err)
+		}
+		return int(value)
+	}
+
+	built := gameid.NewDatabase()
+	gb := gameid.GBKey{Title: "DBTEST", Checksum: 0x1234}
+	if err := built.AddEntry(gameid.ConsoleGB, gb, map[string]string{"title": "GB Title"}); err != nil {
+		t.Fatalf("AddEntry(GB) error = %v", err)
+	}
+	snes := gameid.SNESKey{
+		InternalName: header.Metadata["internal_title"],
+		DeveloperID:  metaInt("developer_ID"),
+		ROMVersion:   metaInt("rom_version"),
+		Checksum:     metaInt("checksum"),
+	}
+	if err := built.AddEntry(gameid.ConsoleSNES, snes, map[string]string{"title": "SNES Title"}); err != nil {
+		t.Fatalf("AddEntry(SNES) error = %v", err)
+	}
+	neogeo := gameid.NeoGeoCDKey{UUID: "2011-01-01-00-00-00-00", VolumeID: "NEOGEO"}
+	if err := built.AddEntry(gameid.ConsoleNeoGeoCD, neogeo, map[string]string{"title": "NeoGeoCD Title"}); err != nil {
+		t.Fatalf("AddEntry(NeoGeoCD) error = %v", err)
+	}
+
+	dbPath := filepath.Join(dir, "db.gob.gz")
+	if err := built.SaveDatabase(dbPath); err != nil {
+		t.Fatalf("SaveDatabase() error = %v", err)
+	}
+	db, err := gameid.LoadDatabase(dbPath)
+	if err != nil {
+		t.Fatalf("LoadDatabase() error = %v", err)
+	}
+
+	for path, want := range map[string]string{gbPath: "GB Title", snesPath: "SNES Title"} {
+		result, err := gameid.Identify(path, db)
+		if err != nil {
+			t.Fatalf("Identify(%s) error = %v", path, err)
+		}
+		if result.Title != want {
+			t.Errorf("Identify(%s) Title = %q, want the database title %q", path, result.Title, want)
+		}
+	}
+	if entry, found := db.Lookup(gameid.ConsoleNeoGeoCD, neogeo); !found || entry["title"] != "NeoGeoCD Title" {
+		t.Errorf("Lookup(NeoGeoCD) = %q, %v, want %q", entry["title"], found, "NeoGeoCD Title")
+	}
+}
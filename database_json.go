@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// jsonDatabase is the JSON representation of a GameDatabase.
+// String- and int-keyed maps are encoded as JSON objects. Struct-keyed maps
+// (GB, SNES, NeoGeoCD) cannot be JSON object keys, so they are encoded as
+// arrays of {key, metadata} entries sorted by key.
+type jsonDatabase struct {
+	Version    int                                                   `json:"version,omitempty"`
+	GBA        map[string]map[string]string                          `json:"GBA,omitempty"`
+	GC         map[string]map[string]string                          `json:"GC,omitempty"`
+	Genesis    map[string]map[string]string                          `json:"Genesis,omitempty"`
+	N64        map[string]map[string]string                          `json:"N64,omitempty"`
+	NES        map[int]map[string]string                             `json:"NES,omitempty"`
+	PSP        map[string]map[string]string                          `json:"PSP,omitempty"`
+	PSX        map[string]map[string]string                          `json:"PSX,omitempty"`
+	PS2        map[string]map[string]string                          `json:"PS2,omitempty"`
+	Saturn     map[string]map[string]string                          `json:"Saturn,omitempty"`
+	SegaCD     map[string]map[string]string                          `json:"SegaCD,omitempty"`
+	IDPrefixes map[identifier.Console][]string                       `json:"id_prefixes,omitempty"`
+	Variants   map[identifier.Console]map[string][]map[string]string `json:"variants,omitempty"`
+	GB         []jsonEntry[GBKey]                                    `json:"GB,omitempty"`
+	SNES       []jsonEntry[SNESKey]                                  `json:"SNES,omitempty"`
+	NeoGeoCD   []jsonEntry[NeoGeoCDKey]                              `json:"NeoGeoCD,omitempty"`
+}
+
+// jsonEntry is a single struct-keyed database entry.
+type jsonEntry[K comparable] struct {
+	Metadata map[string]string `json:"metadata"`
+	Key      K                 `json:"key"`
+}
+
+// ExportJSON writes the database to w as indented JSON.
+// Entries are written in a stable order so exports can be diffed.
+func (db *GameDatabase) ExportJSON(w io.Writer) error {
+	out := jsonDatabase{
+		Version:    db.Version,
+		GBA:        db.GBA,
+		GC:         db.GC,
+		Genesis:    db.Genesis,
+		N64:        db.N64,
+		NES:        db.NES,
+		PSP:        db.PSP,
+		PSX:        db.PSX,
+		PS2:        db.PS2,
+		Saturn:     db.Saturn,
+		SegaCD:     db.SegaCD,
+		IDPrefixes: db.IDPrefixes,
+		Variants:   db.Variants,
+		GB:         sortedEntries(db.GB, compareGBKeys),
+		SNES:       sortedEntries(db.SNES, compareSNESKeys),
+		NeoGeoCD:   sortedEntries(db.NeoGeoCD, compareNeoGeoCDKeys),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("failed to encode database JSON: %w", err)
+	}
+	return nil
+}
+
+// LoadDatabaseJSON loads a database from JSON written by ExportJSON.
+func LoadDatabaseJSON(r io.Reader) (*GameDatabase, error) {
+	var in jsonDatabase
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("failed to decode database JSON: %w", err)
+	}
+
+	db := NewDatabase()
+	db.Version = in.Version
+	if err := migrateDatabase(db); err != nil {
+		return nil, err
+	}
+	db.GBA = mergeEntries(db.GBA, in.GBA)
+	db.GC = mergeEntries(db.GC, in.GC)
+	db.Genesis = mergeEntries(db.Genesis, in.Genesis)
+	db.N64 = mergeEntries(db.N64, in.N64)
+	db.NES = mergeEntries(db.NES, in.NES)
+	db.PSP = mergeEntries(db.PSP, in.PSP)
+	db.PSX = mergeEntries(db.PSX, in.PSX)
+	db.PS2 = mergeEntries(db.PS2, in.PS2)
+	db.Saturn = mergeEntries(db.Saturn, in.Saturn)
+	db.SegaCD = mergeEntries(db.SegaCD, in.SegaCD)
+	for console, prefixes := range in.IDPrefixes {
+		db.IDPrefixes[console] = prefixes
+	}
+	for console, variants := range in.Variants {
+		db.Variants[console] = variants
+	}
+	for _, entry := range in.GB {
+		db.GB[entry.Key] = entry.Metadata
+	}
+	for _, entry := range in.SNES {
+		db.SNES[entry.Key] = entry.Metadata
+	}
+	for _, entry := range in.NeoGeoCD {
+		db.NeoGeoCD[entry.Key] = entry.Metadata
+	}
+
+	return db, nil
+}
+
+// sortedEntries converts a struct-keyed map into a slice of entries ordered by compare.
+func sortedEntries[K comparable](entries map[K]map[string]string, compare func(a, b K) int) []jsonEntry[K] {
+	out := make([]jsonEntry[K], 0, len(entries))
+	for key, metadata := range entries {
+		out = append(out, jsonEntry[K]{Key: key, Metadata: metadata})
+	}
+	slices.SortFunc(out, func(a, b jsonEntry[K]) int {
+		return compare(a.Key, b.Key)
+	})
+	return out
+}
+
+func compareGBKeys(a, b GBKey) int {
+	return cmp.Or(
+		cmp.Compare(a.Title, b.Title),
+		cmp.Compare(a.Checksum, b.Checksum),
+	)
+}
+
+func compareSNESKeys(a, b SNESKey) int {
+	return cmp.Or(
+		cmp.Compare(a.InternalName, b.InternalName),
+		cmp.Compare(a.DeveloperID, b.DeveloperID),
+		cmp.Compare(a.ROMVersion, b.ROMVersion),
+		cmp.Compare(a.Checksum, b.Checksum),
+	)
+}
+
+func compareNeoGeoCDKeys(a, b NeoGeoCDKey) int {
+	return cmp.Or(
+		cmp.Compare(a.UUID, b.UUID),
+		cmp.Compare(a.VolumeID, b.VolumeID),
+	)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// newPopulatedDatabase returns a database with at least one entry for every console map.
+func newPopulatedDatabase() *GameDatabase {
+	db := NewDatabase()
+	db.GB[GBKey{Title: "POKEMON RED", Checksum: 0x91E6}] = map[string]string{"title": "Pokemon Red"}
+	db.GB[GBKey{Title: "TETRIS", Checksum: 0x16BF}] = map[string]string{"title": "Tetris"}
+	db.GBA["BPEE"] = map[string]string{"title": "Pokemon Emerald"}
+	db.GC["GALE"] = map[string]string{"title": "Super Smash Bros. Melee"}
+	db.Genesis["MK-1079"] = map[string]string{"title": "Sonic the Hedgehog"}
+	db.N64["NSME"] = map[string]string{"title": "Super Mario 64"}
+	db.NES[0x12345678] = map[string]string{"title": "NES Game"}
+	db.PSP["ULUS10041"] = map[string]string{"title": "PSP Game"}
+	db.PSX["SLUS_00594"] = map[string]string{"title": "PSX Game"}
+	db.PS2["SLUS_20062"] = map[string]string{"title": "PS2 Game"}
+	db.Saturn["MK81009"] = map[string]string{"title": "Saturn Game"}
+	db.SegaCD["T1234"] = map[string]string{"title": "Sega CD Game"}
+	db.SNES[SNESKey{InternalName: "4d4152494f", DeveloperID: 1, ROMVersion: 0, Checksum: 0x1234}] = map[string]string{
+		"title": "SNES Game",
+	}
+	db.NeoGeoCD[NeoGeoCDKey{UUID: "1994-01-01-00-00-00-00", VolumeID: "NGCD"}] = map[string]string{
+		"title": "Neo Geo CD Game",
+	}
+	db.IDPrefixes[identifier.ConsolePSX] = []string{"SLUS", "SCUS"}
+	return db
+}
+
+func TestDatabase_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	db := newPopulatedDatabase()
+
+	gobPath := filepath.Join(t.TempDir(), "test.gob.gz")
+	if err := db.SaveDatabase(gobPath); err != nil {
+		t.Fatalf("SaveDatabase() error = %v", err)
+	}
+	gobDB, err := LoadDatabase(gobPath)
+	if err != nil {
+		t.Fatalf("LoadDatabase() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = db.ExportJSON(&buf); err != nil {
+		t.Fatalf("ExportJSON() error = %v", err)
+	}
+	jsonDB, err := LoadDatabaseJSON(&buf)
+	if err != nil {
+		t.Fatalf("LoadDatabaseJSON() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(gobDB, jsonDB) {
+		t.Errorf("gob and JSON databases differ:\ngob:  %+v\njson: %+v", gobDB, jsonDB)
+	}
+
+	structKeys := []struct {
+		key     any
+		console identifier.Console
+	}{
+		{GBKey{Title: "POKEMON RED", Checksum: 0x91E6}, identifier.ConsoleGB},
+		{SNESKey{InternalName: "4d4152494f", DeveloperID: 1, Checksum: 0x1234}, identifier.ConsoleSNES},
+		{NeoGeoCDKey{UUID: "1994-01-01-00-00-00-00", VolumeID: "NGCD"}, identifier.ConsoleNeoGeoCD},
+		{0x12345678, identifier.ConsoleNES},
+	}
+	for _, tt := range structKeys {
+		gobEntry, gobFound := gobDB.Lookup(tt.console, tt.key)
+		jsonEntry, jsonFound := jsonDB.Lookup(tt.console, tt.key)
+		if !gobFound || !jsonFound || !reflect.DeepEqual(gobEntry, jsonEntry) {
+			t.Errorf("Lookup(%s) gob = %v (%v), json = %v (%v)", tt.console, gobEntry, gobFound, jsonEntry, jsonFound)
+		}
+	}
+
+	stringKeys := map[identifier.Console]string{
+		identifier.ConsoleGBA:     "BPEE",
+		identifier.ConsoleGC:      "GALE",
+		identifier.ConsoleGenesis: "MK-1079",
+		identifier.ConsoleN64:     "NSME",
+		identifier.ConsolePSP:     "ULUS10041",
+		identifier.ConsolePSX:     "SLUS_00594",
+		identifier.ConsolePS2:     "SLUS_20062",
+		identifier.ConsoleSaturn:  "MK81009",
+		identifier.ConsoleSegaCD:  "T1234",
+	}
+	for console, key := range stringKeys {
+		gobEntry, gobFound := gobDB.LookupByString(console, key)
+		jsonEntry, jsonFound := jsonDB.LookupByString(console, key)
+		if !gobFound || !jsonFound || !reflect.DeepEqual(gobEntry, jsonEntry) {
+			t.Errorf("LookupByString(%s, %q) gob = %v (%v), json = %v (%v)",
+				console, key, gobEntry, gobFound, jsonEntry, jsonFound)
+		}
+	}
+}
+
+func TestDatabase_ExportJSON_StableOrder(t *testing.T) {
+	t.Parallel()
+
+	db := newPopulatedDatabase()
+
+	var first, second bytes.Buffer
+	if err := db.ExportJSON(&first); err != nil {
+		t.Fatalf("ExportJSON() error = %v", err)
+	}
+	if err := db.ExportJSON(&second); err != nil {
+		t.Fatalf("ExportJSON() error = %v", err)
+	}
+	if first.String() != second.String() {
+		t.Error("ExportJSON() output is not stable between calls")
+	}
+
+	// GB entries are sorted by title
+	out := first.String()
+	if strings.Index(out, "POKEMON RED") > strings.Index(out, "TETRIS") {
+		t.Error("GB entries are not sorted by key")
+	}
+}
+
+func TestLoadDatabaseJSON_Empty(t *testing.T) {
+	t.Parallel()
+
+	db, err := LoadDatabaseJSON(strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("LoadDatabaseJSON() error = %v", err)
+	}
+	if db.GB == nil || db.SNES == nil || db.NeoGeoCD == nil || db.PSX == nil || db.IDPrefixes == nil {
+		t.Error("LoadDatabaseJSON() should initialize all maps")
+	}
+}
+
+func TestLoadDatabaseJSON_Invalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := LoadDatabaseJSON(strings.NewReader("not json")); err == nil {
+		t.Error("LoadDatabaseJSON() should fail on invalid JSON")
+	}
+}
+
+func TestLoadDatabaseJSON_VersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadDatabaseJSON(strings.NewReader(`{"version": 99}`))
+	var versionErr DatabaseVersionError
+	if !errors.As(err, &versionErr) {
+		t.Fatalf("LoadDatabaseJSON() error = %v, want DatabaseVersionError", err)
+	}
+	if versionErr.Version != 99 {
+		t.Errorf("DatabaseVersionError.Version = %d, want 99", versionErr.Version)
+	}
+}
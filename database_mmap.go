@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// MappedDatabase is a read-only game database backed by a memory-mapped
+// file. Opening it only maps the compressed file; the tables are decoded
+// straight from the mapping, in one pass, the first time a lookup needs
+// them, so programs that never look anything up pay nothing for the
+// database.
+//
+// MappedDatabase is safe for concurrent use. Call Close to release the
+// mapping once the database is no longer needed.
+type MappedDatabase struct {
+	db    *GameDatabase
+	unmap func() error
+	err   error
+	data  []byte
+	once  sync.Once
+	errMu sync.Mutex
+}
+
+// LoadDatabaseMmap opens a gob.gz or gob.zst database without decoding it.
+// The tables are decoded on first lookup; a decode failure, including a
+// format version mismatch, makes every lookup miss and is reported by Err.
+func LoadDatabaseMmap(path string) (*MappedDatabase, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map database: %w", err)
+	}
+
+	// Reject files that are neither zstd nor gzip up front
+	if !bytes.HasPrefix(data, zstdMagic) && !bytes.HasPrefix(data, gzipMagic) {
+		_ = unmap()
+		return nil, errors.New("failed to map database: not a gzip or zstd file")
+	}
+
+	return &MappedDatabase{
+		db:    &GameDatabase{},
+		data:  data,
+		unmap: unmap,
+	}, nil
+}
+
+// Close releases the memory mapping. Lookups must not be made after Close.
+func (m *MappedDatabase) Close() error {
+	if m.unmap == nil {
+		return nil
+	}
+	err := m.unmap()
+	m.unmap = nil
+	m.data = nil
+	if err != nil {
+		return fmt.Errorf("failed to unmap database: %w", err)
+	}
+	return nil
+}
+
+// Err returns the error hit while decoding the database, if any.
+func (m *MappedDatabase) Err() error {
+	m.errMu.Lock()
+	defer m.errMu.Unlock()
+	return m.err
+}
+
+// Lookup retrieves metadata for a game by console and key.
+func (m *MappedDatabase) Lookup(console identifier.Console, key any) (map[string]string, bool) {
+	if !m.load() {
+		return nil, false
+	}
+	return m.db.Lookup(console, key)
+}
+
+// LookupByString retrieves metadata using a string key.
+func (m *MappedDatabase) LookupByString(console identifier.Console, key string) (map[string]string, bool) {
+	if !m.load() {
+		return nil, false
+	}
+	return m.db.LookupByString(console, key)
+}
+
+// LookupFuzzy retrieves metadata for a string ID, tolerating formatting
+// differences. See GameDatabase.LookupFuzzy.
+func (m *MappedDatabase) LookupFuzzy(console identifier.Console, id string) (map[string]string, bool) {
+	if !m.load() {
+		return nil, false
+	}
+	return m.db.LookupFuzzy(console, id)
+}
+
+// LookupAll returns every entry stored under a string ID. See
+// GameDatabase.LookupAll.
+func (m *MappedDatabase) LookupAll(console identifier.Console, id string) ([]map[string]string, bool) {
+	if !m.load() {
+		return nil, false
+	}
+	return m.db.LookupAll(console, id)
+}
+
+// GetIDPrefixes returns the ID prefixes for a disc-based console.
+func (m *MappedDatabase) GetIDPrefixes(console identifier.Console) []string {
+	if !m.load() {
+		return nil
+	}
+	return m.db.GetIDPrefixes(console)
+}
+
+// load decodes the mapped file once and reports whether the tables are
+// available. Decompressing the stream dominates the cost, so every table is
+// decoded in the same pass rather than one console at a time.
+func (m *MappedDatabase) load() bool {
+	m.once.Do(func() {
+		db, err := LoadDatabaseFromReader(bytes.NewReader(m.data))
+		if err != nil {
+			m.errMu.Lock()
+			m.err = err
+			m.errMu.Unlock()
+			return
+		}
+		m.db = db
+	})
+	return m.Err() == nil
+}
+
+// mapFileFallback reads the whole file where memory mapping is unavailable.
+func mapFileFallback(path string) (data []byte, unmap func() error, err error) {
+	data, err = os.ReadFile(path) //nolint:gosec // Path from user input is expected
+	if err != nil {
+		return nil, nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,276 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// Verify MappedDatabase implements identifier.Database
+var _ identifier.Database = (*MappedDatabase)(nil)
+
+// writeTestDatabase saves db under dir with the given file name.
+func writeTestDatabase(tb testing.TB, db *GameDatabase, dir, name string) string {
+	tb.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := db.SaveDatabase(path); err != nil {
+		tb.Fatalf("SaveDatabase() error = %v", err)
+	}
+	return path
+}
+
+func TestLoadDatabaseMmap(t *testing.T) {
+	t.Parallel()
+
+	db := NewDatabase()
+	db.GBA["BPEE"] = map[string]string{"title": "Pokemon Emerald"}
+	db.PSX["SLUS_00123"] = map[string]string{"title": "Test Game"}
+	db.NES[0x12345678] = map[string]string{"title": "NES Game"}
+	db.IDPrefixes[identifier.ConsolePSX] = []string{"SLUS", "SCUS"}
+
+	for _, name := range []string{"db.gob.gz", "db.gob.zst"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mapped, err := LoadDatabaseMmap(writeTestDatabase(t, db, t.TempDir(), name))
+			if err != nil {
+				t.Fatalf("LoadDatabaseMmap() error = %v", err)
+			}
+			defer func() { _ = mapped.Close() }()
+
+			if mapped.db.PSX != nil || mapped.db.GBA != nil {
+				t.Fatal("tables decoded before first lookup")
+			}
+
+			if entry, found := mapped.LookupByString(identifier.ConsoleGBA, "BPEE"); !found || entry["title"] != "Pokemon Emerald" {
+				t.Errorf("LookupByString(GBA) = %q, %v, want %q", entry["title"], found, "Pokemon Emerald")
+			}
+			if _, found := mapped.db.PSX["SLUS_00123"]; !found {
+				t.Error("PSX table not decoded in the same pass as GBA")
+			}
+
+			if entry, found := mapped.Lookup(identifier.ConsoleNES, 0x12345678); !found || entry["title"] != "NES Game" {
+				t.Errorf("Lookup(NES) = %q, %v, want %q", entry["title"], found, "NES Game")
+			}
+			if entry, found := mapped.LookupFuzzy(identifier.ConsolePSX, "123"); !found || entry["title"] != "Test Game" {
+				t.Errorf("LookupFuzzy(PSX) = %q, %v, want %q", entry["title"], found, "Test Game")
+			}
+			if prefixes := mapped.GetIDPrefixes(identifier.ConsolePSX); len(prefixes) != 2 {
+				t.Errorf("GetIDPrefixes(PSX) = %v, want 2 prefixes", prefixes)
+			}
+			if _, found := mapped.LookupByString(identifier.ConsoleWii, "RSBE"); found {
+				t.Error("LookupByString(Wii) found an entry in a console without a table")
+			}
+			if err := mapped.Err(); err != nil {
+				t.Errorf("Err() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestLoadDatabaseMmap_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	db := NewDatabase()
+	db.GBA["BPEE"] = map[string]string{"title": "Pokemon Emerald"}
+	db.N64["NSME"] = map[string]string{"title": "Super Mario 64"}
+
+	mapped, err := LoadDatabaseMmap(writeTestDatabase(t, db, t.TempDir(), "db.gob.gz"))
+	if err != nil {
+		t.Fatalf("LoadDatabaseMmap() error = %v", err)
+	}
+	defer func() { _ = mapped.Close() }()
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			console, key := identifier.ConsoleGBA, "BPEE"
+			if i%2 == 1 {
+				console, key = identifier.ConsoleN64, "NSME"
+			}
+			if _, found := mapped.LookupByString(console, key); !found {
+				t.Errorf("LookupByString(%s, %q) not found", console, key)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLoadDatabaseMmap_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	if _, err := LoadDatabaseMmap(filepath.Join(dir, "missing.gob.gz")); err == nil {
+		t.Error("LoadDatabaseMmap() should error for a missing file")
+	}
+
+	plain := filepath.Join(dir, "plain.gob")
+	if err := os.WriteFile(plain, []byte("not compressed"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := LoadDatabaseMmap(plain); err == nil {
+		t.Error("LoadDatabaseMmap() should error for an uncompressed file")
+	}
+
+	// A valid gzip header followed by garbage only fails once decoded
+	corrupt := filepath.Join(dir, "corrupt.gob.gz")
+	data := append([]byte{0x1F, 0x8B, 0x08, 0x00, 0, 0, 0, 0, 0, 0xFF}, []byte("garbage")...)
+	if err := os.WriteFile(corrupt, data, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	mapped, err := LoadDatabaseMmap(corrupt)
+	if err != nil {
+		t.Fatalf("LoadDatabaseMmap() error = %v", err)
+	}
+	defer func() { _ = mapped.Close() }()
+
+	if _, found := mapped.LookupByString(identifier.ConsoleGBA, "BPEE"); found {
+		t.Error("LookupByString() found an entry in a corrupt database")
+	}
+	if mapped.Err() == nil {
+		t.Error("Err() = nil, want decode error")
+	}
+}
+
+func TestLoadDatabaseMmap_VersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	db := NewDatabase()
+	db.Version = DatabaseVersion + 1
+	db.GBA["BPEE"] = map[string]string{"title": "Pokemon Emerald"}
+	path := writeTestDatabase(t, db, t.TempDir(), "future.gob.gz")
+
+	mapped, err := LoadDatabaseMmap(path)
+	if err != nil {
+		t.Fatalf("LoadDatabaseMmap() error = %v", err)
+	}
+	defer func() { _ = mapped.Close() }()
+
+	if _, found := mapped.LookupByString(identifier.ConsoleGBA, "BPEE"); found {
+		t.Error("LookupByString() found an entry in a mismatched database")
+	}
+	var versionErr DatabaseVersionError
+	if err := mapped.Err(); !errors.As(err, &versionErr) {
+		t.Errorf("Err() = %v, want DatabaseVersionError", err)
+	}
+}
+
+// newBenchmarkDatabase builds a database roughly shaped like the combined
+// GameDB release: large disc tables plus smaller cartridge tables.
+func newBenchmarkDatabase() *GameDatabase {
+	db := NewDatabase()
+	for i := range 20000 {
+		db.PS2[fmt.Sprintf("SLUS_%05d", i)] = map[string]string{"title": fmt.Sprintf("PS2 Game %d", i), "region": "USA"}
+		db.PSX[fmt.Sprintf("SLUS_%05d", i)] = map[string]string{"title": fmt.Sprintf("PSX Game %d", i), "region": "USA"}
+	}
+	for i := range 3000 {
+		db.GBA[fmt.Sprintf("A%03d", i)] = map[string]string{"title": fmt.Sprintf("GBA Game %d", i)}
+	}
+	db.GBA["BPEE"] = map[string]string{"title": "Pokemon Emerald"}
+	return db
+}
+
+// BenchmarkDatabaseColdLookup compares opening a database and making the
+// first lookup with eager gob decoding versus the memory-mapped lazy path.
+func BenchmarkDatabaseColdLookup(b *testing.B) {
+	path := writeTestDatabase(b, newBenchmarkDatabase(), b.TempDir(), "bench.gob.gz")
+
+	b.Run("Eager", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			db, err := LoadDatabase(path)
+			if err != nil {
+				b.Fatalf("LoadDatabase() error = %v", err)
+			}
+			if _, found := db.LookupByString(identifier.ConsoleGBA, "BPEE"); !found {
+				b.Fatal("lookup missed")
+			}
+		}
+	})
+
+	b.Run("Mmap", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			db, err := LoadDatabaseMmap(path)
+			if err != nil {
+				b.Fatalf("LoadDatabaseMmap() error = %v", err)
+			}
+			if _, found := db.LookupByString(identifier.ConsoleGBA, "BPEE"); !found {
+				b.Fatal("lookup missed")
+			}
+			_ = db.Close()
+		}
+	})
+}
+
+// BenchmarkDatabaseMultiConsoleLookup compares opening a database and making
+// a first lookup in several consoles, which a scan of a mixed collection does.
+func BenchmarkDatabaseMultiConsoleLookup(b *testing.B) {
+	path := writeTestDatabase(b, newBenchmarkDatabase(), b.TempDir(), "bench.gob.gz")
+	lookups := []struct {
+		console identifier.Console
+		key     string
+	}{
+		{identifier.ConsoleGBA, "BPEE"},
+		{identifier.ConsolePSX, "SLUS_00123"},
+		{identifier.ConsolePS2, "SLUS_00456"},
+	}
+
+	b.Run("Eager", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			db, err := LoadDatabase(path)
+			if err != nil {
+				b.Fatalf("LoadDatabase() error = %v", err)
+			}
+			for _, lookup := range lookups {
+				if _, found := db.LookupByString(lookup.console, lookup.key); !found {
+					b.Fatalf("%s lookup missed", lookup.console)
+				}
+			}
+		}
+	})
+
+	b.Run("Mmap", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			db, err := LoadDatabaseMmap(path)
+			if err != nil {
+				b.Fatalf("LoadDatabaseMmap() error = %v", err)
+			}
+			for _, lookup := range lookups {
+				if _, found := db.LookupByString(lookup.console, lookup.key); !found {
+					b.Fatalf("%s lookup missed", lookup.console)
+				}
+			}
+			_ = db.Close()
+		}
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// DatabaseStats summarizes the contents of a GameDatabase.
+type DatabaseStats struct {
+	// Entries maps each console table to its number of games. GB and GBC
+	// share one table, counted under identifier.ConsoleGB.
+	Entries map[identifier.Console]int
+	// IDPrefixes is the number of ID prefixes across all disc consoles.
+	IDPrefixes int
+	// Total is the number of games across all tables.
+	Total int
+}
+
+// Stats counts the entries in each console table. Comparing the counts of a
+// freshly generated database against the previous one is a quick check that
+// no console failed to download.
+func (db *GameDatabase) Stats() DatabaseStats {
+	stats := DatabaseStats{
+		Entries: map[identifier.Console]int{
+			identifier.ConsoleGB:       len(db.GB),
+			identifier.ConsoleGBA:      len(db.GBA),
+			identifier.ConsoleGC:       len(db.GC),
+			identifier.ConsoleGenesis:  len(db.Genesis),
+			identifier.ConsoleN64:      len(db.N64),
+			identifier.ConsoleNeoGeoCD: len(db.NeoGeoCD),
+			identifier.ConsoleNES:      len(db.NES),
+			identifier.ConsolePSP:      len(db.PSP),
+			identifier.ConsolePSX:      len(db.PSX),
+			identifier.ConsolePS2:      len(db.PS2),
+			identifier.ConsoleSaturn:   len(db.Saturn),
+			identifier.ConsoleSegaCD:   len(db.SegaCD),
+			identifier.ConsoleSNES:     len(db.SNES),
+		},
+	}
+	for _, count := range stats.Entries {
+		stats.Total += count
+	}
+	for _, prefixes := range db.IDPrefixes {
+		stats.IDPrefixes += len(prefixes)
+	}
+	return stats
+}
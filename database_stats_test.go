@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"testing"
+
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+func TestDatabase_Stats(t *testing.T) {
+	t.Parallel()
+
+	db := NewDatabase()
+	entries := []struct {
+		key     any
+		console identifier.Console
+	}{
+		{console: identifier.ConsoleGB, key: GBKey{Title: "TETRIS", Checksum: 0x16BF}},
+		{console: identifier.ConsoleGBC, key: GBKey{Title: "ZELDA DX", Checksum: 0x1234}},
+		{console: identifier.ConsoleGBA, key: "BPEE"},
+		{console: identifier.ConsoleGBA, key: "AXVE"},
+		{console: identifier.ConsoleNES, key: 0x12345678},
+		{console: identifier.ConsoleSNES, key: SNESKey{InternalName: "4d4152494f", DeveloperID: 1}},
+		{console: identifier.ConsolePS2, key: "SLUS_20062"},
+	}
+	for _, entry := range entries {
+		if err := db.AddEntry(entry.console, entry.key, map[string]string{"title": "Game"}); err != nil {
+			t.Fatalf("AddEntry() error = %v", err)
+		}
+	}
+	db.IDPrefixes[identifier.ConsolePS2] = []string{"SLUS", "SCUS"}
+	db.IDPrefixes[identifier.ConsolePSX] = []string{"SLES"}
+
+	stats := db.Stats()
+	want := map[identifier.Console]int{
+		identifier.ConsoleGB:   2,
+		identifier.ConsoleGBA:  2,
+		identifier.ConsoleNES:  1,
+		identifier.ConsoleSNES: 1,
+		identifier.ConsolePS2:  1,
+	}
+	for console, count := range stats.Entries {
+		if count != want[console] {
+			t.Errorf("Entries[%s] = %d, want %d", console, count, want[console])
+		}
+	}
+	if stats.Total != len(entries) {
+		t.Errorf("Total = %d, want %d", stats.Total, len(entries))
+	}
+	if stats.IDPrefixes != 3 {
+		t.Errorf("IDPrefixes = %d, want 3", stats.IDPrefixes)
+	}
+}
+
+func TestDatabase_Stats_Empty(t *testing.T) {
+	t.Parallel()
+
+	stats := (&GameDatabase{}).Stats()
+	if stats.Total != 0 || stats.IDPrefixes != 0 {
+		t.Errorf("Stats() = %+v, want zero counts", stats)
+	}
+}
@@ -22,8 +22,10 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/gob"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/ZaparooProject/go-gameid/identifier"
@@ -82,6 +84,9 @@ func TestNewDatabase(t *testing.T) {
 	if db.IDPrefixes == nil {
 		t.Error("IDPrefixes map is nil")
 	}
+	if db.Variants == nil {
+		t.Error("Variants map is nil")
+	}
 }
 
 func TestDatabase_SaveAndLoad(t *testing.T) {
@@ -273,6 +278,154 @@ func TestLoadDatabaseFromReader(t *testing.T) {
 	}
 }
 
+// encodeGzipGob gob-encodes value into a gzip stream.
+func encodeGzipGob(t *testing.T, value any) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := gob.NewEncoder(gz).Encode(value); err != nil {
+		t.Fatalf("Failed to encode database: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to close gzip writer: %v", err)
+	}
+	return &buf
+}
+
+func TestLoadDatabaseFromReader_VersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	db := NewDatabase()
+	db.Version = DatabaseVersion + 1
+	db.GBA["TEST"] = map[string]string{"title": "Test Game"}
+
+	_, err := LoadDatabaseFromReader(encodeGzipGob(t, db))
+	var versionErr DatabaseVersionError
+	if !errors.As(err, &versionErr) {
+		t.Fatalf("LoadDatabaseFromReader() error = %v, want DatabaseVersionError", err)
+	}
+	if versionErr.Version != DatabaseVersion+1 || versionErr.Expected != DatabaseVersion {
+		t.Errorf("DatabaseVersionError = %+v, want Version %d, Expected %d",
+			versionErr, DatabaseVersion+1, DatabaseVersion)
+	}
+	want := "database format v2, expected v1: regenerate the database with cmd/dbgen"
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestLoadDatabaseFromReader_Unversioned(t *testing.T) {
+	t.Parallel()
+
+	// Databases written before the Version field existed
+	legacy := struct {
+		GBA map[string]map[string]string
+	}{GBA: map[string]map[string]string{"TEST": {"title": "Test Game"}}}
+
+	db, err := LoadDatabaseFromReader(encodeGzipGob(t, legacy))
+	if err != nil {
+		t.Fatalf("LoadDatabaseFromReader() error = %v", err)
+	}
+	if db.Version != DatabaseVersion {
+		t.Errorf("Version = %d, want %d", db.Version, DatabaseVersion)
+	}
+	if _, found := db.LookupByString(identifier.ConsoleGBA, "TEST"); !found {
+		t.Error("GBA entry not found in unversioned database")
+	}
+}
+
+func TestDatabase_SaveAndLoadZstd(t *testing.T) {
+	t.Parallel()
+
+	db := NewDatabase()
+	db.GBA["BPEE"] = map[string]string{"title": "Pokemon Emerald", "region": "USA"}
+	db.PS2["SLUS_20062"] = map[string]string{"title": "Grand Theft Auto III"}
+	db.NES[0x12345678] = map[string]string{"title": "NES Game"}
+	db.IDPrefixes[identifier.ConsolePS2] = []string{"SLUS", "SLES"}
+
+	dbPath := filepath.Join(t.TempDir(), "test.gob.zst")
+	if err := db.SaveDatabase(dbPath); err != nil {
+		t.Fatalf("SaveDatabase() error = %v", err)
+	}
+
+	data, err := os.ReadFile(dbPath) //nolint:gosec // Test-controlled path
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !bytes.HasPrefix(data, zstdMagic) {
+		t.Fatalf("database file starts with %x, want zstd magic %x", data[:min(len(data), 4)], zstdMagic)
+	}
+
+	loadedDB, err := LoadDatabase(dbPath)
+	if err != nil {
+		t.Fatalf("LoadDatabase() error = %v", err)
+	}
+
+	tests := []struct {
+		console identifier.Console
+		key     string
+		want    string
+	}{
+		{console: identifier.ConsoleGBA, key: "BPEE", want: "Pokemon Emerald"},
+		{console: identifier.ConsolePS2, key: "SLUS_20062", want: "Grand Theft Auto III"},
+	}
+	for _, tt := range tests {
+		entry, found := loadedDB.LookupByString(tt.console, tt.key)
+		if !found || entry["title"] != tt.want {
+			t.Errorf("LookupByString(%s, %q) = %q, %v, want %q", tt.console, tt.key, entry["title"], found, tt.want)
+		}
+	}
+	if entry, found := loadedDB.Lookup(identifier.ConsoleNES, 0x12345678); !found || entry["title"] != "NES Game" {
+		t.Errorf("Lookup(NES) = %q, %v, want %q", entry["title"], found, "NES Game")
+	}
+	if prefixes := loadedDB.GetIDPrefixes(identifier.ConsolePS2); len(prefixes) != 2 {
+		t.Errorf("IDPrefixes count = %d, want 2", len(prefixes))
+	}
+}
+
+func TestLoadDatabaseFromReader_SniffsCompression(t *testing.T) {
+	t.Parallel()
+
+	db := NewDatabase()
+	db.GBA["TEST"] = map[string]string{"title": "Test Game"}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "gzip", path: "db.gob.gz"},
+		{name: "zstd", path: "db.gob.zst"},
+		{name: "zstd uppercase extension", path: "DB.GOB.ZST"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			compressed, err := newDatabaseWriter(&buf, tt.path)
+			if err != nil {
+				t.Fatalf("newDatabaseWriter() error = %v", err)
+			}
+			if err := gob.NewEncoder(compressed).Encode(db); err != nil {
+				t.Fatalf("Failed to encode database: %v", err)
+			}
+			if err := compressed.Close(); err != nil {
+				t.Fatalf("Failed to close writer: %v", err)
+			}
+
+			loadedDB, err := LoadDatabaseFromReader(&buf)
+			if err != nil {
+				t.Fatalf("LoadDatabaseFromReader() error = %v", err)
+			}
+			if entry, found := loadedDB.GBA["TEST"]; !found || entry["title"] != "Test Game" {
+				t.Errorf("GBA[TEST] = %q, %v, want %q", entry["title"], found, "Test Game")
+			}
+		})
+	}
+}
+
 func TestLoadDatabaseFromReader_InvalidGzip(t *testing.T) {
 	t.Parallel()
 
@@ -295,8 +448,8 @@ func TestDatabase_Lookup_GB(t *testing.T) {
 	t.Parallel()
 
 	db := NewDatabase()
-	// Add a GB entry with the gbKey struct
-	db.GB[gbKey{Title: "POKEMON RED", Checksum: 0x1234}] = map[string]string{
+	// Add a GB entry with the GBKey struct
+	db.GB[GBKey{Title: "POKEMON RED", Checksum: 0x1234}] = map[string]string{
 		"title":  "Pokemon Red",
 		"region": "USA",
 	}
@@ -342,8 +495,8 @@ func TestDatabase_Lookup_SNES(t *testing.T) {
 	t.Parallel()
 
 	db := NewDatabase()
-	// Add a SNES entry with the snesKey struct
-	db.SNES[snesKey{
+	// Add a SNES entry with the SNESKey struct
+	db.SNES[SNESKey{
 		InternalName: "SUPER MARIO WORLD",
 		DeveloperID:  0x01,
 		ROMVersion:   0x00,
@@ -396,8 +549,8 @@ func TestDatabase_Lookup_NeoGeoCD(t *testing.T) {
 	t.Parallel()
 
 	db := NewDatabase()
-	// Add a NeoGeoCD entry with the neogeoCDKey struct
-	db.NeoGeoCD[neogeoCDKey{
+	// Add a NeoGeoCD entry with the NeoGeoCDKey struct
+	db.NeoGeoCD[NeoGeoCDKey{
 		UUID:     "2024-01-01-00-00-00-00",
 		VolumeID: "BLAZING_STAR",
 	}] = map[string]string{
@@ -440,7 +593,7 @@ func TestDatabase_LookupByString_NeoGeoCD_VolumeIDFallback(t *testing.T) {
 
 	db := NewDatabase()
 	// Add a NeoGeoCD entry
-	db.NeoGeoCD[neogeoCDKey{
+	db.NeoGeoCD[NeoGeoCDKey{
 		UUID:     "2024-01-01-00-00-00-00",
 		VolumeID: "METAL_SLUG",
 	}] = map[string]string{
@@ -522,3 +675,402 @@ func TestDatabase_Lookup_UnsupportedConsole(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabase_Merge(t *testing.T) {
+	t.Parallel()
+
+	gbaDB := NewDatabase()
+	gbaDB.GBA["BPEE"] = map[string]string{"title": "Pokemon Emerald"}
+	gbaDB.GB[GBKey{Title: "TETRIS", Checksum: 0x16BF}] = map[string]string{"title": "Tetris"}
+	gbaDB.IDPrefixes[identifier.ConsolePSX] = []string{"SLUS", "SCUS"}
+
+	ps2DB := NewDatabase()
+	ps2DB.PS2["SLUS_20062"] = map[string]string{"title": "PS2 Game"}
+	ps2DB.NES[0xDEADBEEF] = map[string]string{"title": "NES Game"}
+	ps2DB.GBA["AMKE"] = map[string]string{"title": "Mario Kart"}
+	ps2DB.IDPrefixes[identifier.ConsolePSX] = []string{"SCUS", "SLPM"}
+	ps2DB.IDPrefixes[identifier.ConsolePS2] = []string{"SLUS"}
+
+	merged := NewDatabase()
+	merged.Merge(gbaDB)
+	merged.Merge(ps2DB)
+	merged.Merge(nil)
+
+	stringLookups := []struct {
+		console identifier.Console
+		key     string
+		want    string
+	}{
+		{identifier.ConsoleGBA, "BPEE", "Pokemon Emerald"},
+		{identifier.ConsoleGBA, "AMKE", "Mario Kart"},
+		{identifier.ConsolePS2, "SLUS_20062", "PS2 Game"},
+	}
+	for _, tt := range stringLookups {
+		entry, found := merged.LookupByString(tt.console, tt.key)
+		if !found {
+			t.Errorf("LookupByString(%s, %q) not found", tt.console, tt.key)
+			continue
+		}
+		if entry["title"] != tt.want {
+			t.Errorf("LookupByString(%s, %q) title = %q, want %q", tt.console, tt.key, entry["title"], tt.want)
+		}
+	}
+
+	if _, found := merged.Lookup(identifier.ConsoleGB, GBKey{Title: "TETRIS", Checksum: 0x16BF}); !found {
+		t.Error("GB entry from first database not found")
+	}
+	if _, found := merged.Lookup(identifier.ConsoleNES, 0xDEADBEEF); !found {
+		t.Error("NES entry from second database not found")
+	}
+
+	wantPSX := []string{"SLUS", "SCUS", "SLPM"}
+	if got := merged.GetIDPrefixes(identifier.ConsolePSX); !slices.Equal(got, wantPSX) {
+		t.Errorf("PSX prefixes = %v, want %v", got, wantPSX)
+	}
+	if got := merged.GetIDPrefixes(identifier.ConsolePS2); !slices.Equal(got, []string{"SLUS"}) {
+		t.Errorf("PS2 prefixes = %v, want [SLUS]", got)
+	}
+}
+
+func TestDatabase_Merge_OverridesAndNilMaps(t *testing.T) {
+	t.Parallel()
+
+	base := &GameDatabase{}
+	update := NewDatabase()
+	update.N64["NSME"] = map[string]string{"title": "Super Mario 64"}
+	base.Merge(update)
+
+	corrected := NewDatabase()
+	corrected.N64["NSME"] = map[string]string{"title": "Super Mario 64 (Rev 1)"}
+	base.Merge(corrected)
+
+	entry, found := base.LookupByString(identifier.ConsoleN64, "NSME")
+	if !found {
+		t.Fatal("N64 entry not found after merge")
+	}
+	if entry["title"] != "Super Mario 64 (Rev 1)" {
+		t.Errorf("title = %q, want later database to win", entry["title"])
+	}
+}
+
+func TestDatabase_AddEntry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key     any
+		name    string
+		console identifier.Console
+	}{
+		{name: "GB", console: identifier.ConsoleGB, key: GBKey{Title: "TETRIS", Checksum: 0x16BF}},
+		{name: "GBC", console: identifier.ConsoleGBC, key: GBKey{Title: "ZELDA DX", Checksum: 0x1234}},
+		{name: "SNES", console: identifier.ConsoleSNES, key: SNESKey{InternalName: "4d4152494f", DeveloperID: 1}},
+		{name: "NeoGeoCD", console: identifier.ConsoleNeoGeoCD, key: NeoGeoCDKey{UUID: "uuid", VolumeID: "NGCD"}},
+		{name: "NES", console: identifier.ConsoleNES, key: 0x12345678},
+		{name: "GBA", console: identifier.ConsoleGBA, key: "BPEE"},
+		{name: "GC", console: identifier.ConsoleGC, key: "GALE"},
+		{name: "Genesis", console: identifier.ConsoleGenesis, key: "MK-1079"},
+		{name: "N64", console: identifier.ConsoleN64, key: "NSME"},
+		{name: "PSP", console: identifier.ConsolePSP, key: "ULUS10041"},
+		{name: "PSX", console: identifier.ConsolePSX, key: "SLUS_00594"},
+		{name: "PS2", console: identifier.ConsolePS2, key: "SLUS_20062"},
+		{name: "Saturn", console: identifier.ConsoleSaturn, key: "MK81009"},
+		{name: "SegaCD", console: identifier.ConsoleSegaCD, key: "T1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := NewDatabase()
+			metadata := map[string]string{"title": "Custom " + tt.name}
+			if err := db.AddEntry(tt.console, tt.key, metadata); err != nil {
+				t.Fatalf("AddEntry() error = %v", err)
+			}
+
+			var entry map[string]string
+			var found bool
+			if id, ok := tt.key.(string); ok {
+				entry, found = db.LookupByString(tt.console, id)
+			} else {
+				entry, found = db.Lookup(tt.console, tt.key)
+			}
+			if !found {
+				t.Fatal("entry not found after AddEntry()")
+			}
+			if entry["title"] != metadata["title"] {
+				t.Errorf("title = %q, want %q", entry["title"], metadata["title"])
+			}
+		})
+	}
+}
+
+func TestDatabase_AddEntry_IdentifierKeys(t *testing.T) {
+	t.Parallel()
+
+	db := &GameDatabase{}
+
+	// Keys shaped like the ones built inside the identifier package
+	gbIdentKey := struct {
+		title    string
+		checksum uint16
+	}{title: "TETRIS", checksum: 0x16BF}
+	if err := db.AddEntry(identifier.ConsoleGB, gbIdentKey, map[string]string{"title": "Tetris"}); err != nil {
+		t.Fatalf("AddEntry() error = %v", err)
+	}
+	if _, found := db.Lookup(identifier.ConsoleGB, GBKey{Title: "TETRIS", Checksum: 0x16BF}); !found {
+		t.Error("GB entry added with identifier key not found")
+	}
+}
+
+func TestDatabase_AddEntry_KeyMismatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key     any
+		name    string
+		console identifier.Console
+	}{
+		{name: "GB with string", console: identifier.ConsoleGB, key: "TETRIS"},
+		{name: "SNES with GBKey", console: identifier.ConsoleSNES, key: GBKey{Title: "X"}},
+		{name: "NeoGeoCD with string", console: identifier.ConsoleNeoGeoCD, key: "NGCD"},
+		{name: "NES with uint32", console: identifier.ConsoleNES, key: uint32(0x12345678)},
+		{name: "GBA with int", console: identifier.ConsoleGBA, key: 42},
+		{name: "PSX with SNESKey", console: identifier.ConsolePSX, key: SNESKey{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := NewDatabase()
+			err := db.AddEntry(tt.console, tt.key, map[string]string{"title": "x"})
+			var keyErr InvalidKeyError
+			if !errors.As(err, &keyErr) {
+				t.Fatalf("AddEntry() error = %v, want InvalidKeyError", err)
+			}
+			if keyErr.Console != tt.console {
+				t.Errorf("InvalidKeyError.Console = %v, want %v", keyErr.Console, tt.console)
+			}
+		})
+	}
+}
+
+func TestDatabase_AddEntry_UnsupportedConsole(t *testing.T) {
+	t.Parallel()
+
+	db := NewDatabase()
+	err := db.AddEntry(identifier.ConsoleFDS, "ZEL", map[string]string{"title": "x"})
+	var notSupported identifier.ErrNotSupported
+	if !errors.As(err, &notSupported) {
+		t.Errorf("AddEntry() error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestDatabase_LookupFuzzy(t *testing.T) {
+	t.Parallel()
+
+	db := NewDatabase()
+	db.PSX["SLUS_00123"] = map[string]string{"title": "PSX Game"}
+	db.PS2["SCES_50490"] = map[string]string{"title": "PS2 Game"}
+	db.Saturn["MK81009"] = map[string]string{"title": "Saturn Game"}
+	db.SegaCD["T-6013"] = map[string]string{"title": "Sega CD Game"}
+	db.IDPrefixes[identifier.ConsolePSX] = []string{"SCUS", "SLUS"}
+	db.IDPrefixes[identifier.ConsolePS2] = []string{"SCES"}
+
+	tests := []struct {
+		name      string
+		console   identifier.Console
+		id        string
+		wantTitle string
+		wantFound bool
+	}{
+		{"exact", identifier.ConsolePSX, "SLUS_00123", "PSX Game", true},
+		{"dash instead of underscore", identifier.ConsolePSX, "SLUS-00123", "PSX Game", true},
+		{"lowercase with space", identifier.ConsolePSX, "slus 00123", "PSX Game", true},
+		{"dotted serial", identifier.ConsolePSX, "SLUS_001.23", "PSX Game", true},
+		{"missing leading zeros", identifier.ConsolePSX, "SLUS-123", "PSX Game", true},
+		{"extra leading zeros", identifier.ConsolePSX, "SLUS-000123", "PSX Game", true},
+		{"bare number uses prefixes", identifier.ConsolePSX, "00123", "PSX Game", true},
+		{"bare number PS2", identifier.ConsolePS2, "50490", "PS2 Game", true},
+		{"Saturn dash", identifier.ConsoleSaturn, "MK-81009", "Saturn Game", true},
+		{"Sega CD no dash", identifier.ConsoleSegaCD, "T6013", "Sega CD Game", true},
+		{"wrong number", identifier.ConsolePSX, "SLUS-00124", "", false},
+		{"wrong prefix", identifier.ConsolePSX, "SCES-00123", "", false},
+		{"bare number without prefix match", identifier.ConsoleSaturn, "81009", "", false},
+		{"empty", identifier.ConsolePSX, "-", "", false},
+		{"unsupported console", identifier.ConsoleNES, "SLUS-00123", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry, found := db.LookupFuzzy(tt.console, tt.id)
+			if found != tt.wantFound {
+				t.Fatalf("LookupFuzzy(%s, %q) found = %v, want %v", tt.console, tt.id, found, tt.wantFound)
+			}
+			if found && entry["title"] != tt.wantTitle {
+				t.Errorf("LookupFuzzy(%s, %q) title = %q, want %q", tt.console, tt.id, entry["title"], tt.wantTitle)
+			}
+		})
+	}
+}
+
+// TestDatabase_LookupFuzzyIndex checks that the normalized index is built
+// once per console and rebuilt after the table changes.
+func TestDatabase_LookupFuzzyIndex(t *testing.T) {
+	t.Parallel()
+
+	db := NewDatabase()
+	if err := db.AddEntry(identifier.ConsolePSX, "SLUS_00123", map[string]string{"title": "First"}); err != nil {
+		t.Fatalf("AddEntry() error = %v", err)
+	}
+	if _, found := db.LookupFuzzy(identifier.ConsolePSX, "SLUS-123"); !found {
+		t.Fatal("LookupFuzzy(SLUS-123) not found")
+	}
+	if db.fuzzyIndex[identifier.ConsolePSX] == nil {
+		t.Fatal("LookupFuzzy() did not keep its index")
+	}
+
+	if err := db.AddEntry(identifier.ConsolePSX, "SLUS_00456", map[string]string{"title": "Added"}); err != nil {
+		t.Fatalf("AddEntry() error = %v", err)
+	}
+	if entry, found := db.LookupFuzzy(identifier.ConsolePSX, "SLUS-456"); !found || entry["title"] != "Added" {
+		t.Errorf("LookupFuzzy(SLUS-456) after AddEntry = %q, %v, want %q", entry["title"], found, "Added")
+	}
+
+	if err := db.AppendEntry(identifier.ConsolePSX, "SLUS_00789", map[string]string{"title": "Appended"}); err != nil {
+		t.Fatalf("AppendEntry() error = %v", err)
+	}
+	if entry, found := db.LookupFuzzy(identifier.ConsolePSX, "SLUS-789"); !found || entry["title"] != "Appended" {
+		t.Errorf("LookupFuzzy(SLUS-789) after AppendEntry = %q, %v, want %q", entry["title"], found, "Appended")
+	}
+
+	other := NewDatabase()
+	other.PSX["SLUS_01000"] = map[string]string{"title": "Merged"}
+	db.Merge(other)
+	if entry, found := db.LookupFuzzy(identifier.ConsolePSX, "SLUS-1000"); !found || entry["title"] != "Merged" {
+		t.Errorf("LookupFuzzy(SLUS-1000) after Merge = %q, %v, want %q", entry["title"], found, "Merged")
+	}
+}
+
+func TestNormalizeID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"SLUS_00123", "SLUS123"},
+		{"slus-00123", "SLUS123"},
+		{"T-0000", "T0"},
+		{"ABC0", "ABC0"},
+		{"MK-81009-50", "MK8100950"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			if got := normalizeID(tt.input); got != tt.want {
+				t.Errorf("normalizeID(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatabase_LookupAll(t *testing.T) {
+	t.Parallel()
+
+	usa := map[string]string{"title": "Test Game", "region": "USA"}
+	europe := map[string]string{"title": "Test Game", "region": "Europe"}
+	db := NewDatabase()
+	for _, entry := range []map[string]string{usa, europe} {
+		if err := db.AppendEntry(ConsoleSaturn, "T12345", entry); err != nil {
+			t.Fatalf("AppendEntry() error = %v", err)
+		}
+	}
+
+	checkBoth := func(t *testing.T, db interface {
+		LookupAll(Console, string) ([]map[string]string, bool)
+	},
+	) {
+		t.Helper()
+		entries, found := db.LookupAll(ConsoleSaturn, "T12345")
+		if !found || len(entries) != 2 || entries[0]["region"] != "USA" || entries[1]["region"] != "Europe" {
+			t.Errorf("LookupAll() = %v, %v; want the USA and Europe entries in order", entries, found)
+		}
+	}
+
+	checkBoth(t, db)
+	if entry, found := db.LookupByString(ConsoleSaturn, "T12345"); !found || entry["region"] != "USA" {
+		t.Errorf("LookupByString() = %v, %v; want the first entry", entry, found)
+	}
+	if entries, found := db.LookupAll(ConsoleSaturn, "T99999"); found || entries != nil {
+		t.Errorf("LookupAll() = %v, %v for a missing ID", entries, found)
+	}
+	if err := db.AppendEntry(ConsoleNES, "1234", usa); err == nil {
+		t.Error("AppendEntry() accepted a console without string keys")
+	}
+
+	t.Run("save and load", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "db.gob.gz")
+		if err := db.SaveDatabase(path); err != nil {
+			t.Fatalf("SaveDatabase() error = %v", err)
+		}
+		loaded, err := LoadDatabase(path)
+		if err != nil {
+			t.Fatalf("LoadDatabase() error = %v", err)
+		}
+		checkBoth(t, loaded)
+
+		mapped, err := LoadDatabaseMmap(path)
+		if err != nil {
+			t.Fatalf("LoadDatabaseMmap() error = %v", err)
+		}
+		defer func() { _ = mapped.Close() }()
+		checkBoth(t, mapped)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		if err := db.ExportJSON(&buf); err != nil {
+			t.Fatalf("ExportJSON() error = %v", err)
+		}
+		loaded, err := LoadDatabaseJSON(&buf)
+		if err != nil {
+			t.Fatalf("LoadDatabaseJSON() error = %v", err)
+		}
+		checkBoth(t, loaded)
+	})
+}
+
+func TestDatabase_Merge_Variants(t *testing.T) {
+	t.Parallel()
+
+	base := NewDatabase()
+	for _, region := range []string{"USA", "Europe"} {
+		for _, id := range []string{"T11111", "T22222"} {
+			if err := base.AppendEntry(ConsoleSaturn, id, map[string]string{"region": region}); err != nil {
+				t.Fatalf("AppendEntry() error = %v", err)
+			}
+		}
+	}
+	other := NewDatabase()
+	if err := other.AddEntry(ConsoleSaturn, "T11111", map[string]string{"region": "Japan"}); err != nil {
+		t.Fatalf("AddEntry() error = %v", err)
+	}
+
+	base.Merge(other)
+	if entries, _ := base.LookupAll(ConsoleSaturn, "T11111"); len(entries) != 1 || entries[0]["region"] != "Japan" {
+		t.Errorf("LookupAll(T11111) = %v, want only the merged Japan entry", entries)
+	}
+	if entries, _ := base.LookupAll(ConsoleSaturn, "T22222"); len(entries) != 2 {
+		t.Errorf("LookupAll(T22222) = %v, want both untouched entries", entries)
+	}
+}
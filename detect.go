@@ -0,0 +1,254 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ZaparooProject/go-gameid/archive"
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// detectionHeaderSize is how much of a file DetectAndIdentify keeps from the
+// header read done for detection, matching what detectConsoleFromFile reads.
+const detectionHeaderSize = 0x1000
+
+// detections caches DetectConsole results. It is empty until
+// SetDetectionCacheSize enables it.
+var detections = &detectionCache{}
+
+// detectionKey identifies one version of a file. A file rewritten in place
+// gets a new modification time or size, and so a new key.
+type detectionKey struct {
+	modTime time.Time
+	path    string
+	size    int64
+}
+
+// detectionCache is a bounded map from files to detected consoles. Once the
+// limit is reached the oldest entry is evicted.
+type detectionCache struct {
+	entries map[detectionKey]identifier.Console
+	order   []detectionKey
+	limit   int
+	mu      sync.Mutex
+}
+
+// SetDetectionCacheSize makes DetectConsole, Identify and DetectAndIdentify
+// remember the console detected for up to n regular files, keyed on path,
+// modification time and size. Scanners that look at the same files
+// repeatedly can then skip the header read. n <= 0 disables the cache, which
+// is the default. Changing the size empties the cache.
+func SetDetectionCacheSize(n int) {
+	detections.mu.Lock()
+	defer detections.mu.Unlock()
+	detections.limit = max(n, 0)
+	detections.entries = nil
+	detections.order = nil
+}
+
+// get returns the cached console for the file at path described by info.
+func (c *detectionCache) get(path string, info os.FileInfo) (identifier.Console, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.limit == 0 {
+		return "", false
+	}
+	console, ok := c.entries[newDetectionKey(path, info)]
+	return console, ok
+}
+
+// put records console for the file at path described by info.
+func (c *detectionCache) put(path string, info os.FileInfo, console identifier.Console) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.limit == 0 {
+		return
+	}
+	key := newDetectionKey(path, info)
+	if _, exists := c.entries[key]; exists {
+		return
+	}
+	if c.entries == nil {
+		c.entries = make(map[detectionKey]identifier.Console, c.limit)
+	}
+	for len(c.order) >= c.limit {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.entries[key] = console
+	c.order = append(c.order, key)
+}
+
+// clear drops every entry, keeping the size limit.
+func (c *detectionCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+	c.order = nil
+}
+
+func newDetectionKey(path string, info os.FileInfo) detectionKey {
+	return detectionKey{path: path, modTime: info.ModTime(), size: info.Size()}
+}
+
+// headerReader serves reads that fall within the first bytes of a file from
+// a copy taken on the first such read, so detection and identification share
+// one header read. Other reads go to the underlying reader.
+type headerReader struct {
+	reader io.ReaderAt
+	err    error
+	header []byte
+	loaded bool
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *headerReader) ReadAt(buf []byte, off int64) (int, error) {
+	if off < 0 || off+int64(len(buf)) > detectionHeaderSize {
+		n, err := r.reader.ReadAt(buf, off)
+		return n, err //nolint:wrapcheck // io.ReaderAt callers check for io.EOF
+	}
+	if !r.loaded {
+		r.header = make([]byte, detectionHeaderSize)
+		n, err := r.reader.ReadAt(r.header, 0)
+		r.header, r.err, r.loaded = r.header[:n], err, true
+	}
+	if off >= int64(len(r.header)) {
+		if r.err != nil {
+			return 0, r.err
+		}
+		return 0, io.EOF
+	}
+	n := copy(buf, r.header[off:])
+	if n < len(buf) {
+		if r.err != nil {
+			return n, r.err
+		}
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// DetectAndIdentify is Identify for files whose console can only be told
+// from their contents, such as a .bin or .iso: the header read to detect the
+// console is reused by the identifier instead of being read again. Other
+// paths, including archives, directories and block devices, are handled
+// exactly as Identify handles them.
+func DetectAndIdentify(path string, db *GameDatabase) (*Result, error) {
+	var dbInterface identifier.Database
+	if db != nil {
+		dbInterface = db
+	}
+	result, err := detectAndIdentify(path, dbInterface)
+	setResultPath(result, path)
+	return result, err
+}
+
+func detectAndIdentify(path string, dbInterface identifier.Database) (*Result, error) {
+	archivePath, err := archive.ParsePath(path)
+	if err != nil {
+		return nil, fmt.Errorf("parse archive path: %w", err)
+	}
+	if archivePath != nil {
+		return identifyFromArchive(archivePath, dbInterface)
+	}
+	if isBlockDevice(path) {
+		return identifyPath(path, dbInterface)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat path: %w", err)
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if info.IsDir() || !sharesHeaderRead(ext) {
+		return identifyPath(path, dbInterface)
+	}
+
+	file, err := os.Open(path) //nolint:gosec // Path from user input is expected
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	return detectAndIdentifyFile(file, path, info, ext, dbInterface)
+}
+
+// sharesHeaderRead reports whether files with extension ext are detected
+// from a plain header read that DetectAndIdentify can hand on. Containers
+// such as CUE, CHD and CISO open their own readers, unambiguous extensions
+// need no read, and registered detectors take a path.
+func sharesHeaderRead(ext string) bool {
+	if _, registered := lookupDetector(ext); registered {
+		return false
+	}
+	switch ext {
+	case ".bin", ".iso", ".ecm", "":
+		return true
+	default:
+		return false
+	}
+}
+
+// detectAndIdentifyFile detects and identifies the regular file at path,
+// opened as reader, reading its header once.
+func detectAndIdentifyFile(
+	reader io.ReaderAt,
+	path string,
+	info os.FileInfo,
+	ext string,
+	database identifier.Database,
+) (*Result, error) {
+	shared := &headerReader{reader: reader}
+
+	console, cached := detections.get(path, info)
+	if !cached {
+		detected, err := detectConsoleFromFile(shared, path, ext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect console: %w", err)
+		}
+		console = detected
+		detections.put(path, info, console)
+	}
+
+	id, ok := lookupIdentifier(console)
+	if !ok {
+		return nil, identifier.ErrNotSupported{Format: string(console)}
+	}
+
+	result, handled, err := identifyFromPathIfSupported(id, path, database)
+	if err != nil {
+		return nil, err
+	}
+	if handled {
+		return result, nil
+	}
+
+	result, err = identifyCached(id, console, shared, info.Size(), database)
+	if err != nil {
+		return nil, fmt.Errorf("identify: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// countingReaderAt counts the reads that start at offset 0.
+type countingReaderAt struct {
+	reader      *os.File
+	headerReads int
+	mu          sync.Mutex
+}
+
+func (r *countingReaderAt) ReadAt(buf []byte, off int64) (int, error) {
+	if off == 0 {
+		r.mu.Lock()
+		r.headerReads++
+		r.mu.Unlock()
+	}
+	return r.reader.ReadAt(buf, off) //nolint:wrapcheck // test passthrough
+}
+
+func TestDetectAndIdentifyFile_ReadsHeaderOnce(t *testing.T) {
+	t.Parallel()
+
+	path := "testdata/Genesis/240pSuite-1.23.bin"
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	info, err := file.Stat()
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+
+	counting := &countingReaderAt{reader: file}
+	result, err := detectAndIdentifyFile(counting, path, info, ".bin", nil)
+	if err != nil {
+		t.Fatalf("detectAndIdentifyFile() error = %v", err)
+	}
+	if result.Console != identifier.ConsoleGenesis {
+		t.Errorf("Console = %v, want %v", result.Console, identifier.ConsoleGenesis)
+	}
+	if result.InternalTitle != "240P TEST SUITE" {
+		t.Errorf("InternalTitle = %q, want %q", result.InternalTitle, "240P TEST SUITE")
+	}
+	if counting.headerReads != 1 {
+		t.Errorf("header reads = %d, want 1", counting.headerReads)
+	}
+}
+
+func TestDetectAndIdentify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path    string
+		console identifier.Console
+	}{
+		{"testdata/Genesis/240pSuite-1.23.bin", identifier.ConsoleGenesis},
+		{"testdata/SNES/240pSuite.sfc", identifier.ConsoleSNES},
+		{"testdata/archive/genesis.zip", identifier.ConsoleGenesis},
+	}
+
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.path), func(t *testing.T) {
+			t.Parallel()
+
+			result, err := DetectAndIdentify(tt.path, nil)
+			if err != nil {
+				t.Fatalf("DetectAndIdentify() error = %v", err)
+			}
+			want, err := Identify(tt.path, nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if result.Console != tt.console {
+				t.Errorf("Console = %v, want %v", result.Console, tt.console)
+			}
+			if result.InternalTitle != want.InternalTitle {
+				t.Errorf("InternalTitle = %q, want %q", result.InternalTitle, want.InternalTitle)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // SetDetectionCacheSize changes package state
+func TestDetectionCache(t *testing.T) {
+	SetDetectionCacheSize(1)
+	t.Cleanup(func() { SetDetectionCacheSize(0) })
+
+	rom, err := os.ReadFile("testdata/Genesis/240pSuite-1.23.bin")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "game.bin")
+	if err := os.WriteFile(path, rom, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	console, err := DetectConsole(path)
+	if err != nil || console != identifier.ConsoleGenesis {
+		t.Fatalf("DetectConsole() = %v, %v, want %v", console, err, identifier.ConsoleGenesis)
+	}
+
+	// A cached result is returned even though the file no longer matches it.
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if err := os.WriteFile(path, make([]byte, len(rom)), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	if console, err := DetectConsole(path); err != nil || console != identifier.ConsoleGenesis {
+		t.Errorf("cached DetectConsole() = %v, %v, want %v", console, err, identifier.ConsoleGenesis)
+	}
+
+	// A new modification time invalidates the entry.
+	later := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	if console, err := DetectConsole(path); err == nil {
+		t.Errorf("DetectConsole() after change = %v, want error", console)
+	}
+
+	// The cache holds one entry, so detecting another file evicts the first.
+	other := filepath.Join(t.TempDir(), "other.bin")
+	if err := os.WriteFile(other, rom, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := DetectConsole(other); err != nil {
+		t.Fatalf("DetectConsole() error = %v", err)
+	}
+	if len(detections.entries) != 1 {
+		t.Errorf("cache entries = %d, want 1", len(detections.entries))
+	}
+}
@@ -24,11 +24,15 @@ package gameid
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/ZaparooProject/go-gameid/archive"
 	"github.com/ZaparooProject/go-gameid/identifier"
+	"github.com/ZaparooProject/go-gameid/internal/trace"
+	"github.com/ZaparooProject/go-gameid/iso9660"
 )
 
 // Result is an alias for identifier.Result for convenience.
@@ -39,12 +43,15 @@ type Console = identifier.Console
 
 // Re-export console constants for convenience.
 const (
+	Console3DS      = identifier.Console3DS
+	ConsoleFDS      = identifier.ConsoleFDS
 	ConsoleGB       = identifier.ConsoleGB
 	ConsoleGBC      = identifier.ConsoleGBC
 	ConsoleGBA      = identifier.ConsoleGBA
 	ConsoleGC       = identifier.ConsoleGC
 	ConsoleGenesis  = identifier.ConsoleGenesis
 	ConsoleN64      = identifier.ConsoleN64
+	ConsoleNDS      = identifier.ConsoleNDS
 	ConsoleNeoGeoCD = identifier.ConsoleNeoGeoCD
 	ConsoleNES      = identifier.ConsoleNES
 	ConsolePSP      = identifier.ConsolePSP
@@ -53,6 +60,7 @@ const (
 	ConsoleSaturn   = identifier.ConsoleSaturn
 	ConsoleSegaCD   = identifier.ConsoleSegaCD
 	ConsoleSNES     = identifier.ConsoleSNES
+	ConsoleWii      = identifier.ConsoleWii
 )
 
 // AllConsoles is a list of all supported consoles.
@@ -60,12 +68,15 @@ var AllConsoles = identifier.AllConsoles
 
 // identifiers maps console types to their identifier implementations.
 var identifiers = map[identifier.Console]identifier.Identifier{
+	identifier.Console3DS:      identifier.NewN3DSIdentifier(),
+	identifier.ConsoleFDS:      identifier.NewFDSIdentifier(),
 	identifier.ConsoleGB:       identifier.NewGBIdentifier(),
 	identifier.ConsoleGBC:      identifier.NewGBIdentifier(), // Same as GB
 	identifier.ConsoleGBA:      identifier.NewGBAIdentifier(),
 	identifier.ConsoleGC:       identifier.NewGCIdentifier(),
 	identifier.ConsoleGenesis:  identifier.NewGenesisIdentifier(),
 	identifier.ConsoleN64:      identifier.NewN64Identifier(),
+	identifier.ConsoleNDS:      identifier.NewNDSIdentifier(),
 	identifier.ConsoleNES:      identifier.NewNESIdentifier(),
 	identifier.ConsoleSNES:     identifier.NewSNESIdentifier(),
 	identifier.ConsolePSP:      identifier.NewPSPIdentifier(),
@@ -74,6 +85,17 @@ var identifiers = map[identifier.Console]identifier.Identifier{
 	identifier.ConsoleSaturn:   identifier.NewSaturnIdentifier(),
 	identifier.ConsoleSegaCD:   identifier.NewSegaCDIdentifier(),
 	identifier.ConsoleNeoGeoCD: identifier.NewNeoGeoCDIdentifier(),
+	identifier.ConsoleWii:      identifier.NewWiiIdentifier(),
+}
+
+// SetLogger installs fn to receive diagnostic events from console detection
+// and identification: which extension or header magic matched, where the ISO
+// primary volume descriptor was found and with what block size, and whether
+// database lookups hit. Events are a short dotted name ("db.lookup") followed
+// by alternating key/value pairs. Pass nil to turn logging off; when no logger
+// is set the hooks cost nothing.
+func SetLogger(fn func(event string, kv ...any)) {
+	trace.Set(fn)
 }
 
 // pathIdentifiers are identifiers that need the file path rather than just a reader.
@@ -89,16 +111,42 @@ type pathIdentifier interface {
 //   - Explicit: /path/to/archive.zip/internal/path/game.gba
 //   - Auto-detect: /path/to/archive.zip (finds first game file by extension)
 //
+// Auto-detection fails with archive.AmbiguousGameFilesError if the archive
+// holds games for more than one console; name the file explicitly instead.
+// Either form may pass through archives nested inside the outer one, such as
+// /path/to/archive.zip/roms.7z/game.sfc, up to archive.MaxNestingDepth layers.
+//
 // Supported archive formats: ZIP, 7z, RAR.
 // Only cartridge-based games (GB, GBC, GBA, NES, SNES, N64, Genesis) are supported in archives.
 func Identify(path string, db *GameDatabase) (*Result, error) {
+	// Convert database to interface (nil-safe)
+	var dbInterface identifier.Database
+	if db != nil {
+		dbInterface = db
+	}
+	result, err := identifyPath(path, dbInterface)
+	setResultPath(result, path)
+	return result, err
+}
+
+// setResultPath records the path a result was identified from, unless the
+// identification failed or already named one.
+func setResultPath(result *Result, path string) {
+	if result != nil && result.Path == "" {
+		result.Path = path
+	}
+}
+
+// identifyPath is Identify against any identifier.Database, so callers can
+// wrap the lookups.
+func identifyPath(path string, dbInterface identifier.Database) (*Result, error) {
 	// Check if path references an archive
 	archivePath, err := archive.ParsePath(path)
 	if err != nil {
 		return nil, fmt.Errorf("parse archive path: %w", err)
 	}
 	if archivePath != nil {
-		return identifyFromArchive(archivePath, db)
+		return identifyFromArchive(archivePath, dbInterface)
 	}
 
 	console, err := DetectConsole(path)
@@ -106,22 +154,27 @@ func Identify(path string, db *GameDatabase) (*Result, error) {
 		return nil, fmt.Errorf("failed to detect console: %w", err)
 	}
 
-	return IdentifyWithConsole(path, console, db)
+	return identifyWithConsole(path, console, dbInterface)
 }
 
 // IdentifyWithConsole identifies the game at the given path using the specified console type.
 // This is useful when the console is already known or when auto-detection fails.
 func IdentifyWithConsole(path string, console Console, db *GameDatabase) (*Result, error) {
-	id, ok := identifiers[console]
-	if !ok {
-		return nil, identifier.ErrNotSupported{Format: string(console)}
-	}
-
 	// Convert database to interface (nil-safe)
 	var dbInterface identifier.Database
 	if db != nil {
 		dbInterface = db
 	}
+	result, err := identifyWithConsole(path, console, dbInterface)
+	setResultPath(result, path)
+	return result, err
+}
+
+func identifyWithConsole(path string, console Console, dbInterface identifier.Database) (*Result, error) {
+	id, ok := lookupIdentifier(console)
+	if !ok {
+		return nil, identifier.ErrNotSupported{Format: string(console)}
+	}
 
 	// Check if it's a block device (physical disc)
 	if isBlockDevice(path) {
@@ -143,6 +196,7 @@ func IdentifyWithConsole(path string, console Console, db *GameDatabase) (*Resul
 		return nil, pathErr
 	}
 	if handled {
+		setCueSourcePath(result, path)
 		return result, nil
 	}
 
@@ -158,13 +212,26 @@ func IdentifyWithConsole(path string, console Console, db *GameDatabase) (*Resul
 		return nil, fmt.Errorf("failed to stat file: %w", statErr)
 	}
 
-	result, idErr := id.Identify(file, stat.Size(), dbInterface)
+	result, idErr := identifyCached(id, console, file, stat.Size(), dbInterface)
 	if idErr != nil {
 		return nil, fmt.Errorf("identify: %w", idErr)
 	}
 	return result, nil
 }
 
+// setCueSourcePath records the BIN file a CUE sheet resolved to, which is
+// the image the disc identifiers read.
+func setCueSourcePath(result *Result, path string) {
+	if result == nil || result.SourcePath != "" || !strings.EqualFold(filepath.Ext(path), ".cue") {
+		return
+	}
+	cue, err := iso9660.ParseCue(path)
+	if err != nil || len(cue.BinFiles) == 0 {
+		return
+	}
+	result.SourcePath = cue.BinFiles[0]
+}
+
 func identifyFromPathIfSupported(
 	ident identifier.Identifier,
 	path string,
@@ -189,7 +256,7 @@ func identifyFromPathIfSupported(
 
 // identifyFromDirectory identifies a game from a mounted disc directory.
 func identifyFromDirectory(path string, console Console, database identifier.Database) (*Result, error) {
-	id, ok := identifiers[console]
+	id, ok := lookupIdentifier(console)
 	if !ok {
 		return nil, identifier.ErrNotSupported{Format: string(console)}
 	}
@@ -218,7 +285,7 @@ func IdentifyFromReader(
 	console Console,
 	database *GameDatabase,
 ) (*Result, error) {
-	id, ok := identifiers[console]
+	id, ok := lookupIdentifier(console)
 	if !ok {
 		return nil, identifier.ErrNotSupported{Format: string(console)}
 	}
@@ -228,7 +295,54 @@ func IdentifyFromReader(
 		dbInterface = database
 	}
 
-	result, err := id.Identify(reader, size, dbInterface)
+	result, err := identifyCached(id, console, reader, size, dbInterface)
+	if err != nil {
+		return nil, fmt.Errorf("identify: %w", err)
+	}
+	return result, nil
+}
+
+// isoIdentifier is implemented by disc identifiers that read an ISO9660
+// filesystem rather than a fixed header.
+type isoIdentifier interface {
+	IdentifyFromISO(iso *iso9660.ISO9660, database identifier.Database) (*Result, error)
+}
+
+// IdentifyDiscFromReader identifies a disc image from an io.ReaderAt, such as
+// an ISO served over HTTP range requests, without needing a file path.
+// Cooked (2048-byte) and raw (2352-byte) sector layouts are accepted. For
+// consoles identified from the ISO9660 filesystem (PSX, PS2, PSP, Neo Geo CD)
+// the filesystem is parsed from reader; the others read their boot header.
+func IdentifyDiscFromReader(reader io.ReaderAt, size int64, console Console, db *GameDatabase) (*Result, error) {
+	if !IsDiscBased(console) {
+		return nil, identifier.ErrNotSupported{Format: string(console) + " is not disc-based"}
+	}
+	id, ok := lookupIdentifier(console)
+	if !ok {
+		return nil, identifier.ErrNotSupported{Format: string(console)}
+	}
+
+	var dbInterface identifier.Database
+	if db != nil {
+		dbInterface = db
+	}
+
+	isoID, ok := id.(isoIdentifier)
+	if !ok {
+		result, err := id.Identify(reader, size, dbInterface)
+		if err != nil {
+			return nil, fmt.Errorf("identify: %w", err)
+		}
+		return result, nil
+	}
+
+	iso, err := iso9660.OpenReader(reader, size)
+	if err != nil {
+		return nil, fmt.Errorf("open ISO: %w", err)
+	}
+	defer func() { _ = iso.Close() }()
+
+	result, err := isoID.IdentifyFromISO(iso, dbInterface)
 	if err != nil {
 		return nil, fmt.Errorf("identify: %w", err)
 	}
@@ -242,6 +356,10 @@ func ParseConsole(name string) (Console, error) {
 
 	// Direct matches
 	switch name {
+	case "3DS", "N3DS", "NINTENDO3DS":
+		return Console3DS, nil
+	case "FDS", "FAMICOMDISKSYSTEM":
+		return ConsoleFDS, nil
 	case "GB", "GAMEBOY":
 		return ConsoleGB, nil
 	case "GBC", "GAMEBOYCOLOR":
@@ -254,6 +372,8 @@ func ParseConsole(name string) (Console, error) {
 		return ConsoleGenesis, nil
 	case "N64", "NINTENDO64":
 		return ConsoleN64, nil
+	case "NDS", "NINTENDODS", "DS":
+		return ConsoleNDS, nil
 	case "NEOGEOCD", "NEOCD", "NGCD":
 		return ConsoleNeoGeoCD, nil
 	case "NES", "FAMICOM", "FC":
@@ -270,6 +390,14 @@ func ParseConsole(name string) (Console, error) {
 		return ConsoleSegaCD, nil
 	case "SNES", "SUPERFAMICOM", "SFC":
 		return ConsoleSNES, nil
+	case "WII", "RVL":
+		return ConsoleWii, nil
+	}
+
+	for _, console := range registeredConsoles() {
+		if strings.EqualFold(string(console), name) {
+			return console, nil
+		}
 	}
 
 	return "", identifier.ErrNotSupported{Format: name}
@@ -287,7 +415,7 @@ func SupportedConsoles() []string {
 // IsDiscBased returns true if the console uses disc-based media.
 func IsDiscBased(console Console) bool {
 	switch console {
-	case ConsoleGC, ConsoleNeoGeoCD, ConsolePSP, ConsolePSX, ConsolePS2, ConsoleSaturn, ConsoleSegaCD:
+	case ConsoleGC, ConsoleNeoGeoCD, ConsolePSP, ConsolePSX, ConsolePS2, ConsoleSaturn, ConsoleSegaCD, ConsoleWii:
 		return true
 	default:
 		return false
@@ -299,17 +427,32 @@ func IsCartridgeBased(console Console) bool {
 	return !IsDiscBased(console)
 }
 
+// defaultBlockDeviceSize is assumed for a block device whose size can't be
+// queried: a typical CD. Most identifiers only read the first few KB.
+const defaultBlockDeviceSize = 700 * 1024 * 1024
+
+// blockDeviceSizeOrDefault returns the device size from the platform query,
+// or defaultBlockDeviceSize where that is unavailable or fails.
+func blockDeviceSizeOrDefault(device *os.File) int64 {
+	if size, err := blockDeviceSize(device); err == nil && size > 0 {
+		return size
+	}
+	return defaultBlockDeviceSize
+}
+
 // identifyFromBlockDevice identifies a game from a physical disc (block device).
 //
 //nolint:revive // Line length acceptable for function signature with ignored parameter
 func identifyFromBlockDevice(path string, _ Console, ident identifier.Identifier, database identifier.Database) (*Result, error) {
 	// For disc-based consoles, use IdentifyFromPath which handles block devices.
-	result, handled, pathErr := identifyFromPathIfSupported(ident, path, database)
-	if pathErr != nil {
-		return nil, pathErr
-	}
-	if handled {
-		return result, nil
+	if identifyDevicesByPath {
+		result, handled, pathErr := identifyFromPathIfSupported(ident, path, database)
+		if pathErr != nil {
+			return nil, pathErr
+		}
+		if handled {
+			return result, nil
+		}
 	}
 	// Fall through to raw block device reading when path identification is unsupported.
 
@@ -320,12 +463,7 @@ func identifyFromBlockDevice(path string, _ Console, ident identifier.Identifier
 	}
 	defer func() { _ = blockDev.Close() }()
 
-	// Get device size (for block devices, we need to use ioctl or read to end)
-	// For now, use a reasonable default size for disc identification
-	// Most identifiers only need the first few KB
-	size := int64(700 * 1024 * 1024) // 700MB typical CD size
-
-	identified, err := ident.Identify(blockDev, size, database)
+	identified, err := ident.Identify(deviceReaderAt(blockDev), blockDeviceSizeOrDefault(blockDev), database)
 	if err != nil {
 		return nil, fmt.Errorf("identify: %w", err)
 	}
@@ -333,26 +471,16 @@ func identifyFromBlockDevice(path string, _ Console, ident identifier.Identifier
 }
 
 // identifyFromArchive identifies a game file inside an archive.
-func identifyFromArchive(archivePath *archive.Path, db *GameDatabase) (*Result, error) {
-	// Open the archive
-	arc, err := archive.Open(archivePath.ArchivePath)
+func identifyFromArchive(archivePath *archive.Path, dbInterface identifier.Database) (*Result, error) {
+	reader, size, internalPath, closeMember, err := openArchiveMember(archivePath)
 	if err != nil {
-		return nil, fmt.Errorf("open archive: %w", err)
+		return nil, err
 	}
-	defer func() { _ = arc.Close() }()
+	defer closeMember()
 
-	// Determine internal path (auto-detect if not specified)
-	internalPath := archivePath.InternalPath
-	if internalPath == "" {
-		detected, detectErr := archive.DetectGameFile(arc)
-		if detectErr != nil {
-			return nil, fmt.Errorf("detect game file in archive: %w", detectErr)
-		}
-		internalPath = detected
-	}
-
-	// Detect console from the internal file's extension
-	console, err := DetectConsoleFromExtension(internalPath)
+	// Detect console from the internal file's extension, falling back to
+	// its contents for ambiguous extensions such as .bin
+	console, err := DetectConsoleFromReader(reader, size, filepath.Ext(internalPath))
 	if err != nil {
 		return nil, fmt.Errorf("detect console from archive file: %w", err)
 	}
@@ -363,30 +491,80 @@ func identifyFromArchive(archivePath *archive.Path, db *GameDatabase) (*Result,
 	}
 
 	// Get the identifier for this console
-	id, ok := identifiers[console]
+	id, ok := lookupIdentifier(console)
 	if !ok {
 		return nil, identifier.ErrNotSupported{Format: string(console)}
 	}
 
-	// Convert database to interface (nil-safe)
-	var dbInterface identifier.Database
-	if db != nil {
-		dbInterface = db
+	// Identify the game
+	result, err := identifyCached(id, console, reader, size, dbInterface)
+	if err != nil {
+		return nil, fmt.Errorf("identify: %w", err)
+	}
+	result.SourcePath = internalPath
+	return result, nil
+}
+
+// openArchiveMember opens the archive file archivePath names, picking the game
+// file when it names none, and returns it buffered in memory along with its
+// name. The caller calls closeMember when done.
+func openArchiveMember(
+	archivePath *archive.Path,
+) (reader io.ReaderAt, size int64, internalPath string, closeMember func(), err error) {
+	// Open the archive, along with any archives nested inside it
+	arc, err := archive.OpenPath(archivePath)
+	if err != nil {
+		return nil, 0, "", nil, fmt.Errorf("open archive: %w", err)
+	}
+
+	// Determine internal path (auto-detect if not specified)
+	internalPath = archivePath.InternalPath
+	if internalPath == "" {
+		detected, detectErr := selectGameFile(arc, archivePath.ArchivePath)
+		if detectErr != nil {
+			_ = arc.Close()
+			return nil, 0, "", nil, fmt.Errorf("detect game file in archive: %w", detectErr)
+		}
+		internalPath = detected
 	}
 
 	// Open the file as ReaderAt (buffered in memory)
 	reader, size, closer, err := arc.OpenReaderAt(internalPath)
 	if err != nil {
-		return nil, fmt.Errorf("open file in archive: %w", err)
+		_ = arc.Close()
+		return nil, 0, "", nil, fmt.Errorf("open file in archive: %w", err)
 	}
-	defer func() { _ = closer.Close() }()
+	return reader, size, internalPath, func() {
+		_ = closer.Close()
+		_ = arc.Close()
+	}, nil
+}
 
-	// Identify the game
-	result, err := id.Identify(reader, size, dbInterface)
+// selectGameFile picks the game file to identify in an archive. Several files
+// for one console resolve to the first; files for different consoles are
+// reported as an archive.AmbiguousGameFilesError so callers can list them.
+func selectGameFile(arc archive.Archive, archivePath string) (string, error) {
+	games, err := archive.DetectGameFiles(arc)
 	if err != nil {
-		return nil, fmt.Errorf("identify: %w", err)
+		return "", fmt.Errorf("detect game files: %w", err)
 	}
-	return result, nil
+	for _, game := range games[1:] {
+		if gameFileGroup(game.Console) != gameFileGroup(games[0].Console) {
+			return "", archive.AmbiguousGameFilesError{Archive: archivePath, Files: games}
+		}
+	}
+	return games[0].Name, nil
+}
+
+// gameFileGroup returns the console an archive member's extension implies,
+// with GBC folded into GB: one identifier reads both and either extension is
+// used for either kind of ROM, so together they don't make an archive
+// ambiguous.
+func gameFileGroup(console string) string {
+	if console == string(ConsoleGBC) {
+		return string(ConsoleGB)
+	}
+	return console
 }
 
 // IdentifyFromArchive identifies a game from an already-opened archive.
@@ -404,7 +582,7 @@ func IdentifyFromArchive(
 		return nil, archive.DiscNotSupportedError{Console: string(console)}
 	}
 
-	id, ok := identifiers[console]
+	id, ok := lookupIdentifier(console)
 	if !ok {
 		return nil, identifier.ErrNotSupported{Format: string(console)}
 	}
@@ -420,9 +598,10 @@ func IdentifyFromArchive(
 	}
 	defer func() { _ = closer.Close() }()
 
-	result, err := id.Identify(reader, size, dbInterface)
+	result, err := identifyCached(id, console, reader, size, dbInterface)
 	if err != nil {
 		return nil, fmt.Errorf("identify: %w", err)
 	}
+	result.SourcePath = internalPath
 	return result, nil
 }
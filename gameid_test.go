@@ -19,12 +19,18 @@
 package gameid
 
 import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/ZaparooProject/go-gameid/archive"
 	"github.com/ZaparooProject/go-gameid/identifier"
+	"github.com/ZaparooProject/go-gameid/internal/testiso"
 )
 
 func TestParseConsole(t *testing.T) {
@@ -49,10 +55,18 @@ func TestParseConsole(t *testing.T) {
 		{"MD", "md", ConsoleGenesis, false},
 		{"N64", "n64", ConsoleN64, false},
 		{"Nintendo64", "nintendo64", ConsoleN64, false},
+		{"NDS", "nds", ConsoleNDS, false},
+		{"3DS", "3ds", Console3DS, false},
+		{"Nintendo3DS", "nintendo3ds", Console3DS, false},
+		{"NintendoDS", "NintendoDS", ConsoleNDS, false},
 		{"NES", "nes", ConsoleNES, false},
 		{"Famicom", "famicom", ConsoleNES, false},
+		{"FDS", "fds", ConsoleFDS, false},
+		{"FamicomDiskSystem", "famicomdisksystem", ConsoleFDS, false},
 		{"SNES", "snes", ConsoleSNES, false},
 		{"SuperFamicom", "superfamicom", ConsoleSNES, false},
+		{"Wii", "wii", ConsoleWii, false},
+		{"RVL", "RVL", ConsoleWii, false},
 		{"PSX", "psx", ConsolePSX, false},
 		{"PS1", "ps1", ConsolePSX, false},
 		{"PlayStation", "playstation", ConsolePSX, false},
@@ -94,10 +108,10 @@ func TestSupportedConsoles(t *testing.T) {
 
 	// Check that all expected consoles are present
 	expected := map[string]bool{
-		"GB": true, "GBC": true, "GBA": true, "GC": true,
-		"Genesis": true, "N64": true, "NeoGeoCD": true, "NES": true,
+		"3DS": true, "FDS": true, "GB": true, "GBC": true, "GBA": true, "GC": true,
+		"Genesis": true, "N64": true, "NDS": true, "NeoGeoCD": true, "NES": true,
 		"PSP": true, "PSX": true, "PS2": true, "Saturn": true,
-		"SegaCD": true, "SNES": true,
+		"SegaCD": true, "SNES": true, "Wii": true,
 	}
 
 	for _, c := range consoles {
@@ -110,7 +124,9 @@ func TestSupportedConsoles(t *testing.T) {
 func TestIsDiscBased(t *testing.T) {
 	t.Parallel()
 
-	discBased := []Console{ConsoleGC, ConsoleNeoGeoCD, ConsolePSP, ConsolePSX, ConsolePS2, ConsoleSaturn, ConsoleSegaCD}
+	discBased := []Console{
+		ConsoleGC, ConsoleNeoGeoCD, ConsolePSP, ConsolePSX, ConsolePS2, ConsoleSaturn, ConsoleSegaCD, ConsoleWii,
+	}
 	cartBased := []Console{ConsoleGB, ConsoleGBC, ConsoleGBA, ConsoleGenesis, ConsoleN64, ConsoleNES, ConsoleSNES}
 
 	for _, c := range discBased {
@@ -177,6 +193,45 @@ func createTestGBAFile(t *testing.T, tmpDir string) string {
 	return path
 }
 
+// TestSetLogger_GBA checks the events reported while identifying a GBA ROM.
+// It installs the package-level logger, so it can't run in parallel.
+//
+//nolint:paralleltest // SetLogger is global
+func TestSetLogger_GBA(t *testing.T) {
+	type event struct {
+		name string
+		kv   []any
+	}
+	var events []event
+	SetLogger(func(name string, kv ...any) {
+		events = append(events, event{name: name, kv: kv})
+	})
+	t.Cleanup(func() { SetLogger(nil) })
+
+	db := NewDatabase()
+	if err := db.AddEntry(ConsoleGBA, "ATST", map[string]string{"title": "Test Game"}); err != nil {
+		t.Fatalf("AddEntry() error = %v", err)
+	}
+	if _, err := Identify(createTestGBAFile(t, t.TempDir()), db); err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	SetLogger(nil)
+
+	want := []event{
+		{"detect.extension", []any{"ext", ".gba", "console", ConsoleGBA}},
+		{"gba.header", []any{"game_code", "ATST", "maker_code", "01", "logo_valid", true}},
+		{"db.lookup", []any{"console", ConsoleGBA, "key", "ATST", "found", true}},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events = %v, want %v", events, want)
+	}
+	for i := range want {
+		if events[i].name != want[i].name || !slices.Equal(events[i].kv, want[i].kv) {
+			t.Errorf("event %d = %v, want %v", i, events[i], want[i])
+		}
+	}
+}
+
 func TestIdentifyWithConsole(t *testing.T) {
 	t.Parallel()
 
@@ -426,6 +481,66 @@ func TestIdentifyFromDirectory_PSP(t *testing.T) {
 	}
 }
 
+func TestIdentify_NeoGeoCDDatabase(t *testing.T) {
+	t.Parallel()
+
+	const (
+		chdPath  = "testdata/NeoGeoCD/240pTestSuite.chd"
+		uuid     = "2023-06-02-22-00-14-49"
+		volumeID = "240TEST"
+	)
+
+	mounted := filepath.Join(t.TempDir(), volumeID)
+	if err := os.Mkdir(mounted, 0o750); err != nil {
+		t.Fatalf("create mount dir: %v", err)
+	}
+	ipl := []byte("240P.FIX,0,0\r\n240P.PRG,0,0\r\n\x1a")
+	if err := os.WriteFile(filepath.Join(mounted, "IPL.TXT"), ipl, 0o600); err != nil {
+		t.Fatalf("create IPL.TXT: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		entryUUID string
+		wantTitle string
+		decoy     bool
+	}{
+		// The decoy shares the volume ID and would win the fallback
+		{name: "uuid and volume ID", path: chdPath, entryUUID: uuid, wantTitle: "240p Test Suite", decoy: true},
+		{name: "volume ID fallback", path: chdPath, entryUUID: "1999-01-01-00-00-00-00", wantTitle: "240p Test Suite"},
+		{name: "mounted disc", path: mounted, entryUUID: uuid, wantTitle: "240p Test Suite"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := NewDatabase()
+			key := NeoGeoCDKey{UUID: tt.entryUUID, VolumeID: volumeID}
+			if err := db.AddEntry(ConsoleNeoGeoCD, key, map[string]string{"title": tt.wantTitle}); err != nil {
+				t.Fatalf("AddEntry() error = %v", err)
+			}
+			if tt.decoy {
+				decoy := NeoGeoCDKey{UUID: "1999-01-01-00-00-00-00", VolumeID: volumeID}
+				if err := db.AddEntry(ConsoleNeoGeoCD, decoy, map[string]string{"title": "Decoy"}); err != nil {
+					t.Fatalf("AddEntry() error = %v", err)
+				}
+			}
+
+			result, err := Identify(tt.path, db)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if result.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", result.Title, tt.wantTitle)
+			}
+			if result.Metadata["ipl_files"] == "" {
+				t.Error("ipl_files metadata is empty")
+			}
+		})
+	}
+}
+
 // TestIdentifyFromDirectory_CartridgeConsole verifies error when using directory with cartridge console.
 func TestIdentifyFromDirectory_CartridgeConsole(t *testing.T) {
 	t.Parallel()
@@ -554,6 +669,285 @@ func TestIdentifyFromArchive_WithInternalPath(t *testing.T) {
 	}
 }
 
+// writeTestZIP writes a ZIP archive of files to a temp directory.
+func writeTestZIP(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, data := range files {
+		fileWriter, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("create file in zip: %v", err)
+		}
+		if _, err := fileWriter.Write(data); err != nil {
+			t.Fatalf("write to zip: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close zip writer: %v", err)
+	}
+
+	zipPath := filepath.Join(t.TempDir(), "game.zip")
+	if err := os.WriteFile(zipPath, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("write zip: %v", err)
+	}
+	return zipPath
+}
+
+// TestIdentifyFromArchive_AmbiguousExtension verifies archive members with an
+// ambiguous extension are detected from their contents.
+func TestIdentifyFromArchive_AmbiguousExtension(t *testing.T) {
+	t.Parallel()
+
+	genesis := make([]byte, 0x4000)
+	copy(genesis[0x100:], "SEGA GENESIS")
+	saturn := make([]byte, 20*2048)
+	copy(saturn, "SEGA SEGASATURN SEGA ENTERPRISES")
+	zipPath := writeTestZIP(t, map[string][]byte{
+		"game.bin": genesis,
+		"disc.iso": saturn,
+	})
+
+	result, err := Identify(zipPath+"/game.bin", nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.Console != identifier.ConsoleGenesis {
+		t.Errorf("Console = %v, want %v", result.Console, identifier.ConsoleGenesis)
+	}
+
+	_, err = Identify(zipPath+"/disc.iso", nil)
+	var discErr archive.DiscNotSupportedError
+	if !errors.As(err, &discErr) {
+		t.Fatalf("Identify() error = %v, want DiscNotSupportedError", err)
+	}
+	if discErr.Console != string(identifier.ConsoleSaturn) {
+		t.Errorf("DiscNotSupportedError.Console = %q, want %q", discErr.Console, identifier.ConsoleSaturn)
+	}
+}
+
+// TestIdentify_SourcePath verifies results name the file actually identified
+// when the given path is a container.
+func TestIdentify_SourcePath(t *testing.T) {
+	t.Parallel()
+
+	rom, err := os.ReadFile("testdata/SNES/240pSuite.sfc")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	zipPath := writeTestZIP(t, map[string][]byte{
+		"readme.txt":    []byte("not a game"),
+		"roms/game.sfc": rom,
+	})
+	cueISO := filepath.Join("testdata", "SegaCD", "240p_SegaCD_USA.iso")
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"auto-detected archive member", zipPath, "roms/game.sfc"},
+		{"explicit archive member", "testdata/archive/snes.zip/240pSuite.sfc", "240pSuite.sfc"},
+		{"cue sheet", "testdata/SegaCD/240pSuite_USA.cue", cueISO},
+		{"plain file", "testdata/SNES/240pSuite.sfc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := Identify(tt.path, nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if result.SourcePath != tt.want {
+				t.Errorf("SourcePath = %q, want %q", result.SourcePath, tt.want)
+			}
+			if result.Path != tt.path {
+				t.Errorf("Path = %q, want %q", result.Path, tt.path)
+			}
+		})
+	}
+}
+
+// TestIdentifyFromArchive_GBAndGBC verifies an archive holding both a GB and
+// a GBC ROM is identified rather than reported as ambiguous.
+func TestIdentifyFromArchive_GBAndGBC(t *testing.T) {
+	t.Parallel()
+
+	zipPath := writeTestZIP(t, map[string][]byte{
+		"Game.gb":       make([]byte, 0x8000),
+		"Game (DX).gbc": make([]byte, 0x8000),
+	})
+
+	result, err := Identify(zipPath, nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.Console != ConsoleGB && result.Console != ConsoleGBC {
+		t.Errorf("Console = %v, want GB or GBC", result.Console)
+	}
+}
+
+// TestIdentifyFromArchive_MixedConsoles verifies auto-detection refuses to
+// guess between games for different consoles.
+func TestIdentifyFromArchive_MixedConsoles(t *testing.T) {
+	t.Parallel()
+
+	snes, err := os.ReadFile("testdata/SNES/240pSuite.sfc")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	zipPath := writeTestZIP(t, map[string][]byte{
+		"240pSuite.sfc": snes,
+		"other.gb":      make([]byte, 0x8000),
+	})
+
+	_, err = Identify(zipPath, nil)
+	var ambiguous archive.AmbiguousGameFilesError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Identify() error = %v, want AmbiguousGameFilesError", err)
+	}
+	if len(ambiguous.Files) != 2 {
+		t.Errorf("got %d candidate files, want 2", len(ambiguous.Files))
+	}
+
+	result, err := Identify(zipPath+"/240pSuite.sfc", nil)
+	if err != nil {
+		t.Fatalf("Identify() with explicit path error = %v", err)
+	}
+	if result.Console != identifier.ConsoleSNES {
+		t.Errorf("Console = %v, want %v", result.Console, identifier.ConsoleSNES)
+	}
+}
+
+// TestIdentifyFromArchive_Nested verifies paths through a 7z stored inside a ZIP.
+func TestIdentifyFromArchive_Nested(t *testing.T) {
+	t.Parallel()
+
+	inner, err := os.ReadFile("testdata/archive/snes.7z")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	zipPath := writeTestZIP(t, map[string][]byte{"roms.7z": inner})
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"explicit", zipPath + "/roms.7z/240pSuite.sfc"},
+		{"auto-detect", zipPath + "/roms.7z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := Identify(tt.path, nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if result.Console != identifier.ConsoleSNES {
+				t.Errorf("Console = %v, want %v", result.Console, identifier.ConsoleSNES)
+			}
+		})
+	}
+}
+
+// TestIdentifyDiscFromReader verifies disc identification without a file path.
+func TestIdentifyDiscFromReader(t *testing.T) {
+	t.Parallel()
+
+	cooked := testiso.CreateMinimal(t, "PSXTEST", "PLAYSTATION", "", []testiso.File{
+		{Name: "SYSTEM.CNF;1", Data: []byte("BOOT = cdrom:\\SLUS_012.34;1\r\n")},
+	})
+
+	tests := []struct {
+		name  string
+		image []byte
+	}{
+		{"cooked", cooked},
+		{"raw mode 2", rawBinImage(cooked, 2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := IdentifyDiscFromReader(bytes.NewReader(tt.image), int64(len(tt.image)), ConsolePSX, nil)
+			if err != nil {
+				t.Fatalf("IdentifyDiscFromReader() error = %v", err)
+			}
+			if result.ID != "SLUS-01234" {
+				t.Errorf("ID = %q, want %q", result.ID, "SLUS-01234")
+			}
+		})
+	}
+}
+
+// TestIdentifyDiscFromReader_HeaderConsole verifies consoles identified from a
+// boot header rather than ISO9660 are read directly.
+func TestIdentifyDiscFromReader_HeaderConsole(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.Open("testdata/GC/GameCube-240pSuite-1.17.iso")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	info, err := file.Stat()
+	if err != nil {
+		t.Fatalf("stat fixture: %v", err)
+	}
+
+	result, err := IdentifyDiscFromReader(file, info.Size(), ConsoleGC, nil)
+	if err != nil {
+		t.Fatalf("IdentifyDiscFromReader() error = %v", err)
+	}
+	if result.Console != ConsoleGC || result.ID == "" {
+		t.Errorf("got console %v, ID %q; want GC with an ID", result.Console, result.ID)
+	}
+
+	var notSupported identifier.ErrNotSupported
+	if _, err := IdentifyDiscFromReader(file, info.Size(), ConsoleGBA, nil); !errors.As(err, &notSupported) {
+		t.Errorf("IdentifyDiscFromReader(GBA) error = %v, want ErrNotSupported", err)
+	}
+}
+
+// sizeRecorder is an identifier that records the size it is asked to read.
+type sizeRecorder struct {
+	size int64
+}
+
+func (*sizeRecorder) Console() Console { return ConsolePS2 }
+
+func (s *sizeRecorder) Identify(_ io.ReaderAt, size int64, _ identifier.Database) (*Result, error) {
+	s.size = size
+	return identifier.NewResult(ConsolePS2), nil
+}
+
+// TestIdentifyFromBlockDevice_SizeFallback verifies the device size reaches
+// the identifier, falling back to a CD-sized default when the platform can't
+// query it. A regular file stands in for the device: the Linux ioctl rejects
+// it and other platforms have no query, so both take the fallback.
+func TestIdentifyFromBlockDevice_SizeFallback(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "sr0")
+	if err := os.WriteFile(path, make([]byte, 4096), 0o600); err != nil {
+		t.Fatalf("write device stand-in: %v", err)
+	}
+
+	recorder := &sizeRecorder{}
+	if _, err := identifyFromBlockDevice(path, ConsolePS2, recorder, nil); err != nil {
+		t.Fatalf("identifyFromBlockDevice() error = %v", err)
+	}
+	if recorder.size != defaultBlockDeviceSize {
+		t.Errorf("Identify() size = %d, want fallback %d", recorder.size, defaultBlockDeviceSize)
+	}
+}
+
 // TestDetectConsoleFromExtension tests extension-based console detection.
 func TestDetectConsoleFromExtension(t *testing.T) {
 	t.Parallel()
@@ -570,6 +964,7 @@ func TestDetectConsoleFromExtension(t *testing.T) {
 		{"GB", "game.gb", ConsoleGB, false},
 		{"GBC", "game.gbc", ConsoleGBC, false},
 		{"NES", "game.nes", ConsoleNES, false},
+		{"FDS", "game.fds", ConsoleFDS, false},
 		{"SNES sfc", "game.sfc", ConsoleSNES, false},
 		{"SNES smc", "game.smc", ConsoleSNES, false},
 		{"N64 z64", "game.z64", ConsoleN64, false},
@@ -607,6 +1002,52 @@ func TestDetectConsoleFromExtension(t *testing.T) {
 	}
 }
 
+func TestDetectConsoleFromExtensionWithHints(t *testing.T) {
+	t.Parallel()
+
+	overrides := map[string]Console{"ISO": ConsolePS2, ".gba": ConsoleNDS}
+
+	tests := []struct {
+		name    string
+		path    string
+		hints   ExtensionHints
+		want    Console
+		wantErr bool
+	}{
+		{"override resolves iso", "game.iso", ExtensionHints{Overrides: overrides}, ConsolePS2, false},
+		{"override beats table", "game.gba", ExtensionHints{Overrides: overrides}, ConsoleNDS, false},
+		{"override with gz", "game.iso.gz", ExtensionHints{Overrides: overrides}, ConsolePS2, false},
+		{"no override for bin", "game.bin", ExtensionHints{Overrides: overrides}, "", true},
+		{"explicit console", "game.bin", ExtensionHints{Console: ConsoleSaturn}, ConsoleSaturn, false},
+		{"explicit console ignored", "game.sfc", ExtensionHints{Console: ConsoleSaturn}, ConsoleSNES, false},
+		{"parent dir", filepath.Join("roms", "PS2", "game.iso"), ExtensionHints{ParentDirs: true}, ConsolePS2, false},
+		{
+			"grandparent dir", filepath.Join("roms", "psx", "Game (USA)", "game.cue"),
+			ExtensionHints{ParentDirs: true}, ConsolePSX, false,
+		},
+		{
+			"too deep", filepath.Join("PS2", "a", "b", "game.iso"),
+			ExtensionHints{ParentDirs: true}, "", true,
+		},
+		{"parent dirs off", filepath.Join("PS2", "game.iso"), ExtensionHints{}, "", true},
+		{"unsupported", filepath.Join("PS2", "game.xyz"), ExtensionHints{ParentDirs: true}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DetectConsoleFromExtensionWithHints(tt.path, tt.hints)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DetectConsoleFromExtensionWithHints(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DetectConsoleFromExtensionWithHints(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
 // TestIdentifyFromArchive_Direct tests the IdentifyFromArchive function directly.
 func TestIdentifyFromArchive_Direct(t *testing.T) {
 	t.Parallel()
@@ -7,7 +7,9 @@ require (
 	github.com/klauspost/compress v1.18.0
 	github.com/mewkiz/flac v1.0.12
 	github.com/nwaples/rardecode/v2 v2.2.2
+	github.com/pierrec/lz4/v4 v4.1.22
 	github.com/ulikunitz/xz v0.5.15
+	golang.org/x/text v0.21.0
 )
 
 require (
@@ -17,8 +19,6 @@ require (
 	github.com/hashicorp/golang-lru/v2 v2.0.7 // indirect
 	github.com/icza/bitio v1.1.0 // indirect
 	github.com/mewkiz/pkg v0.0.0-20230226050401-4010bf0fec14 // indirect
-	github.com/pierrec/lz4/v4 v4.1.22 // indirect
 	github.com/spf13/afero v1.11.0 // indirect
 	go4.org v0.0.0-20200411211856-f5505b9728dd // indirect
-	golang.org/x/text v0.21.0 // indirect
 )
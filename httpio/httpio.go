@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package httpio reads remote files over HTTP range requests, so games on a
+// web server or object storage can be identified without downloading them.
+package httpio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ReadAheadSize is the smallest range fetched per request. Identifiers
+// issue many small reads close together, so rounding fetches up to this size
+// turns them into a handful of requests.
+const ReadAheadSize = 32 * 1024
+
+// Common errors
+var (
+	ErrRangeNotSupported = errors.New("server does not support range requests")
+	ErrUnexpectedRange   = errors.New("server returned an unexpected range")
+)
+
+// NewReaderAt returns a ReaderAt over the file at url and its size, using
+// http.DefaultClient.
+func NewReaderAt(url string) (io.ReaderAt, int64, error) {
+	return NewReaderAtWithClient(http.DefaultClient, url)
+}
+
+// NewReaderAtWithClient is NewReaderAt with a caller-supplied client, for
+// servers that need authentication or custom transports. The size is learned
+// from a one-byte range request, which also confirms the server honors ranges.
+func NewReaderAtWithClient(client *http.Client, url string) (io.ReaderAt, int64, error) {
+	reader := &rangeReader{client: client, url: url}
+	_, total, err := reader.fetch(0, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+	reader.size = total
+	return reader, total, nil
+}
+
+// rangeReader implements io.ReaderAt with HTTP range requests, keeping the
+// most recently fetched range to serve nearby reads.
+type rangeReader struct {
+	client  *http.Client
+	url     string
+	cache   []byte
+	size    int64
+	cacheAt int64
+	mu      sync.Mutex
+}
+
+// ReadAt reads len(buf) bytes at off, fetching at least ReadAheadSize bytes
+// per request. It returns io.EOF when the read runs past the end of the file.
+func (r *rangeReader) ReadAt(buf []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	want := min(int64(len(buf)), r.size-off)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if off < r.cacheAt || off+want > r.cacheAt+int64(len(r.cache)) {
+		data, err := r.fetchAtLeast(off, want)
+		if err != nil {
+			return 0, err
+		}
+		r.cache, r.cacheAt = data, off
+	}
+	n := copy(buf, r.cache[off-r.cacheAt:])
+	if int64(n) < int64(len(buf)) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// fetchAtLeast returns at least want bytes at off, which must lie within the
+// file. Servers that cap the size of a range answer with less than asked, so
+// the rest is requested until want bytes have arrived.
+func (r *rangeReader) fetchAtLeast(off, want int64) ([]byte, error) {
+	data, _, err := r.fetch(off, max(want, ReadAheadSize))
+	if err != nil {
+		return nil, err
+	}
+	for int64(len(data)) < want {
+		next := off + int64(len(data))
+		more, _, err := r.fetch(next, max(want-int64(len(data)), ReadAheadSize))
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, more...)
+	}
+	return data, nil
+}
+
+// fetch requests length bytes at off and returns them with the total file
+// size from the Content-Range header. The server may shorten the range, at
+// the end of the file or to its own size cap, but always returns at least
+// one byte.
+func (r *rangeReader) fetch(off, length int64) ([]byte, int64, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, r.url, http.NoBody)
+	if err != nil {
+		return nil, 0, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, off+length-1))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("request range: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		return nil, 0, ErrRangeNotSupported
+	default:
+		return nil, 0, fmt.Errorf("request range: HTTP %d", resp.StatusCode)
+	}
+
+	start, end, total, err := parseContentRange(resp.Header.Get("Content-Range"))
+	if err != nil {
+		return nil, 0, err
+	}
+	if start != off || end < start || end-start+1 > length {
+		return nil, 0, fmt.Errorf("%w: asked for %d-%d, got %d-%d", ErrUnexpectedRange, off, off+length-1, start, end)
+	}
+
+	data := make([]byte, end-start+1)
+	if _, err := io.ReadFull(resp.Body, data); err != nil {
+		return nil, 0, fmt.Errorf("read range: %w", err)
+	}
+	return data, total, nil
+}
+
+// parseContentRange parses a "bytes start-end/total" Content-Range value.
+// An unknown total ("*") is rejected since ReaderAt callers need the size.
+func parseContentRange(value string) (start, end, total int64, err error) {
+	spec, ok := strings.CutPrefix(value, "bytes ")
+	rangePart, totalPart, hasTotal := strings.Cut(spec, "/")
+	startPart, endPart, hasEnd := strings.Cut(rangePart, "-")
+	if !ok || !hasTotal || !hasEnd {
+		return 0, 0, 0, fmt.Errorf("%w: Content-Range %q", ErrUnexpectedRange, value)
+	}
+
+	start, startErr := strconv.ParseInt(startPart, 10, 64)
+	end, endErr := strconv.ParseInt(endPart, 10, 64)
+	total, totalErr := strconv.ParseInt(totalPart, 10, 64)
+	if startErr != nil || endErr != nil || totalErr != nil || end >= total {
+		return 0, 0, 0, fmt.Errorf("%w: Content-Range %q", ErrUnexpectedRange, value)
+	}
+	return start, end, total, nil
+}
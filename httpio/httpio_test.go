@@ -0,0 +1,198 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package httpio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ZaparooProject/go-gameid"
+)
+
+// countingWriter counts body bytes written to a response.
+type countingWriter struct {
+	http.ResponseWriter
+	served *atomic.Int64
+}
+
+func (w countingWriter) Write(p []byte) (int, error) {
+	w.served.Add(int64(len(p)))
+	return w.ResponseWriter.Write(p) //nolint:wrapcheck // Test passthrough
+}
+
+// newRangeServer serves data with range support and counts the body bytes sent.
+func newRangeServer(t *testing.T, data []byte) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+
+	served := &atomic.Int64{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(countingWriter{ResponseWriter: w, served: served}, r, "game.gba", time.Time{},
+			bytes.NewReader(data))
+	}))
+	t.Cleanup(server.Close)
+	return server, served
+}
+
+// testGBAROM returns a 4 MiB GBA ROM with game code ATST.
+func testGBAROM() []byte {
+	rom := make([]byte, 4<<20)
+	copy(rom[0xA0:], "TESTGAME    ")
+	copy(rom[0xAC:], "ATST")
+	copy(rom[0xB0:], "01")
+	rom[0xB2] = 0x96
+	return rom
+}
+
+func TestNewReaderAt_IdentifyGBA(t *testing.T) {
+	t.Parallel()
+
+	rom := testGBAROM()
+	server, served := newRangeServer(t, rom)
+
+	reader, size, err := NewReaderAt(server.URL)
+	if err != nil {
+		t.Fatalf("NewReaderAt() error = %v", err)
+	}
+	if size != int64(len(rom)) {
+		t.Errorf("size = %d, want %d", size, len(rom))
+	}
+
+	result, err := gameid.IdentifyFromReader(reader, size, gameid.ConsoleGBA, nil)
+	if err != nil {
+		t.Fatalf("IdentifyFromReader() error = %v", err)
+	}
+	if result.ID != "ATST" {
+		t.Errorf("ID = %q, want %q", result.ID, "ATST")
+	}
+	if got := served.Load(); got > 2*ReadAheadSize {
+		t.Errorf("served %d bytes, want at most %d of a %d byte ROM", got, 2*ReadAheadSize, len(rom))
+	}
+}
+
+func TestReaderAt_ReadAt(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 3*ReadAheadSize+100)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	server, _ := newRangeServer(t, data)
+
+	reader, size, err := NewReaderAt(server.URL)
+	if err != nil {
+		t.Fatalf("NewReaderAt() error = %v", err)
+	}
+
+	tests := []struct {
+		wantErr error
+		name    string
+		off     int64
+		length  int
+		wantN   int
+	}{
+		{name: "start", off: 0, length: 16, wantN: 16},
+		{name: "cached", off: 100, length: 200, wantN: 200},
+		{name: "larger than read-ahead", off: 10, length: 2 * ReadAheadSize, wantN: 2 * ReadAheadSize},
+		{name: "tail", off: size - 50, length: 50, wantN: 50},
+		{name: "past end", off: size - 10, length: 50, wantN: 10, wantErr: io.EOF},
+		{name: "at end", off: size, length: 1, wantN: 0, wantErr: io.EOF},
+	}
+	for _, tt := range tests {
+		buf := make([]byte, tt.length)
+		n, err := reader.ReadAt(buf, tt.off)
+		if n != tt.wantN || !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: ReadAt() = (%d, %v), want (%d, %v)", tt.name, n, err, tt.wantN, tt.wantErr)
+			continue
+		}
+		if !bytes.Equal(buf[:n], data[tt.off:tt.off+int64(n)]) {
+			t.Errorf("%s: ReadAt() returned wrong bytes", tt.name)
+		}
+	}
+}
+
+func TestReaderAt_CappedRanges(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, 3*ReadAheadSize)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+
+	// Like CDNs that cap range sizes, answer at most rangeCap bytes per request
+	const rangeCap = 1000
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var start, end int64
+		if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end); err == nil {
+			r.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, min(end, start+rangeCap-1)))
+		}
+		http.ServeContent(w, r, "game.gba", time.Time{}, bytes.NewReader(data))
+	}))
+	defer server.Close()
+
+	reader, size, err := NewReaderAt(server.URL)
+	if err != nil {
+		t.Fatalf("NewReaderAt() error = %v", err)
+	}
+	if size != int64(len(data)) {
+		t.Fatalf("size = %d, want %d", size, len(data))
+	}
+
+	buf := make([]byte, 2*ReadAheadSize)
+	n, err := reader.ReadAt(buf, 10)
+	if n != len(buf) || err != nil {
+		t.Fatalf("ReadAt() = (%d, %v), want (%d, nil)", n, err, len(buf))
+	}
+	if !bytes.Equal(buf, data[10:10+len(buf)]) {
+		t.Error("ReadAt() returned wrong bytes")
+	}
+}
+
+func TestNewReaderAt_NoRangeSupport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("whole file"))
+	}))
+	defer server.Close()
+
+	if _, _, err := NewReaderAt(server.URL); !errors.Is(err, ErrRangeNotSupported) {
+		t.Errorf("NewReaderAt() error = %v, want ErrRangeNotSupported", err)
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	t.Parallel()
+
+	start, end, total, err := parseContentRange("bytes 0-0/4096")
+	if err != nil || start != 0 || end != 0 || total != 4096 {
+		t.Errorf("parseContentRange() = (%d, %d, %d, %v), want (0, 0, 4096, nil)", start, end, total, err)
+	}
+	for _, value := range []string{"", "bytes 0-9/*", "bytes */100", "items 0-1/2", "bytes 5-200/100"} {
+		if _, _, _, err := parseContentRange(value); !errors.Is(err, ErrUnexpectedRange) {
+			t.Errorf("parseContentRange(%q) error = %v, want ErrUnexpectedRange", value, err)
+		}
+	}
+}
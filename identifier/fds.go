@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ZaparooProject/go-gameid/internal/binary"
+)
+
+// FDS image layout
+const (
+	fdsHeaderSize       = 16    // Optional fwNES header ("FDS\x1A" + side count + padding)
+	fdsSideSize         = 65500 // Size of one disk side in a dumped image
+	fdsInfoBlockSize    = 0x38  // Size of the disk info block (block 1)
+	fdsMarkerOffset     = 0x01
+	fdsManufacturerCode = 0x0F
+	fdsGameNameOffset   = 0x10
+	fdsGameNameSize     = 3
+	fdsGameTypeOffset   = 0x13
+	fdsRevisionOffset   = 0x14
+	fdsSideNumberOffset = 0x15
+	fdsDiskNumberOffset = 0x16
+	fdsInfoBlockCode    = 0x01
+)
+
+// fdsHeaderMagic is the fwNES header magic found at the start of headered images.
+var fdsHeaderMagic = []byte("FDS\x1A")
+
+// fdsHVCMarker is the verification string found in every disk side's info block.
+var fdsHVCMarker = []byte("*NINTENDO-HVC*")
+
+// FDSIdentifier identifies Famicom Disk System games.
+type FDSIdentifier struct{}
+
+// NewFDSIdentifier creates a new FDS identifier.
+func NewFDSIdentifier() *FDSIdentifier {
+	return &FDSIdentifier{}
+}
+
+// Console returns the console type.
+func (*FDSIdentifier) Console() Console {
+	return ConsoleFDS
+}
+
+// Identify extracts FDS game information from the given reader.
+// Both headered (fwNES) and headerless images are supported. There is no
+// FDS database, so the database is not consulted.
+func (*FDSIdentifier) Identify(reader io.ReaderAt, size int64, _ Database) (*Result, error) {
+	dataOffset, err := fdsDataOffset(reader, size)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := binary.ReadBytesAt(reader, dataOffset, fdsInfoBlockSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FDS disk info block: %w", err)
+	}
+	if !fdsIsInfoBlock(info) {
+		return nil, ErrInvalidFormat{Console: ConsoleFDS, Reason: "missing *NINTENDO-HVC* marker"}
+	}
+
+	gameName := binary.ExtractPrintable(info[fdsGameNameOffset : fdsGameNameOffset+fdsGameNameSize])
+	manufacturer := info[fdsManufacturerCode]
+
+	result := NewResult(ConsoleFDS)
+	result.ID = gameName
+	result.SetMetadata("ID", gameName)
+	result.SetMetadata("manufacturer_code", fmt.Sprintf("0x%02x", manufacturer))
+	if licensee, ok := gbLicenseeOldCodes[manufacturer]; ok {
+		result.SetMetadata("licensee", licensee)
+	}
+	result.SetMetadata("game_type", binary.ExtractPrintable(info[fdsGameTypeOffset:fdsGameTypeOffset+1]))
+	result.SetMetadata("revision", fmt.Sprintf("%d", info[fdsRevisionOffset]))
+	result.SetMetadata("side_number", fmt.Sprintf("%d", info[fdsSideNumberOffset]))
+	result.SetMetadata("disk_number", fmt.Sprintf("%d", info[fdsDiskNumberOffset]))
+	result.SetMetadata("disk_sides", fmt.Sprintf("%d", fdsCountSides(reader, dataOffset, size)))
+
+	return result, nil
+}
+
+// fdsDataOffset returns the offset of the first disk side, skipping the
+// optional fwNES header.
+func fdsDataOffset(reader io.ReaderAt, size int64) (int64, error) {
+	if size < fdsInfoBlockSize {
+		return 0, ErrInvalidFormat{Console: ConsoleFDS, Reason: "file too small"}
+	}
+
+	magic, err := binary.ReadBytesAt(reader, 0, len(fdsHeaderMagic))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read FDS header: %w", err)
+	}
+	if !bytes.Equal(magic, fdsHeaderMagic) {
+		return 0, nil
+	}
+	if size < fdsHeaderSize+fdsInfoBlockSize {
+		return 0, ErrInvalidFormat{Console: ConsoleFDS, Reason: "file too small"}
+	}
+	return fdsHeaderSize, nil
+}
+
+// fdsCountSides counts the disk sides that start with a valid info block.
+// The fwNES header also stores a side count, but it is not always accurate,
+// so the sides are counted directly.
+func fdsCountSides(reader io.ReaderAt, dataOffset, size int64) int {
+	sides := 0
+	for offset := dataOffset; offset+fdsInfoBlockSize <= size; offset += fdsSideSize {
+		block, err := binary.ReadBytesAt(reader, offset, fdsInfoBlockSize)
+		if err != nil || !fdsIsInfoBlock(block) {
+			break
+		}
+		sides++
+	}
+	return sides
+}
+
+// Validate reports whether header, the start of a file, could be a Famicom Disk System image.
+func (*FDSIdentifier) Validate(header []byte) bool {
+	return ValidateFDS(header)
+}
+
+// ValidateFDS checks if the given data looks like an FDS image, with or
+// without the fwNES header.
+func ValidateFDS(header []byte) bool {
+	return bytes.HasPrefix(header, fdsHeaderMagic) || fdsIsInfoBlock(header)
+}
+
+// fdsIsInfoBlock reports whether block starts with a disk info block.
+func fdsIsInfoBlock(block []byte) bool {
+	end := fdsMarkerOffset + len(fdsHVCMarker)
+	if len(block) < end {
+		return false
+	}
+	return block[0] == fdsInfoBlockCode && bytes.Equal(block[fdsMarkerOffset:end], fdsHVCMarker)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"bytes"
+	"testing"
+)
+
+// createFDSSide creates a single 65500-byte FDS disk side with a disk info block.
+func createFDSSide(gameName string, manufacturer, sideNumber byte) []byte {
+	side := make([]byte, fdsSideSize)
+	side[0] = fdsInfoBlockCode
+	copy(side[fdsMarkerOffset:], fdsHVCMarker)
+	side[fdsManufacturerCode] = manufacturer
+	copy(side[fdsGameNameOffset:], gameName)
+	side[fdsGameTypeOffset] = ' '
+	side[fdsRevisionOffset] = 1
+	side[fdsSideNumberOffset] = sideNumber
+	return side
+}
+
+// createFDSImage builds an FDS image from the given number of sides,
+// optionally prefixed with a fwNES header.
+func createFDSImage(gameName string, sides int, headered bool) []byte {
+	var buf bytes.Buffer
+	if headered {
+		header := make([]byte, fdsHeaderSize)
+		copy(header, fdsHeaderMagic)
+		header[4] = byte(sides)
+		buf.Write(header)
+	}
+	for i := range sides {
+		buf.Write(createFDSSide(gameName, 0x01, byte(i%2)))
+	}
+	return buf.Bytes()
+}
+
+func TestFDSIdentifier_Identify(t *testing.T) {
+	t.Parallel()
+
+	identifier := NewFDSIdentifier()
+
+	tests := []struct {
+		name      string
+		gameName  string
+		wantSides string
+		sides     int
+		headered  bool
+	}{
+		{name: "headered single side", gameName: "ZEL", sides: 1, headered: true, wantSides: "1"},
+		{name: "headered two sides", gameName: "MET", sides: 2, headered: true, wantSides: "2"},
+		{name: "headerless single side", gameName: "KID", sides: 1, headered: false, wantSides: "1"},
+		{name: "headerless four sides", gameName: "DQ3", sides: 4, headered: false, wantSides: "4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data := createFDSImage(tt.gameName, tt.sides, tt.headered)
+			result, err := identifier.Identify(bytes.NewReader(data), int64(len(data)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+
+			if result.Console != ConsoleFDS {
+				t.Errorf("Console = %v, want %v", result.Console, ConsoleFDS)
+			}
+			if result.ID != tt.gameName {
+				t.Errorf("ID = %q, want %q", result.ID, tt.gameName)
+			}
+			if got := result.Metadata["disk_sides"]; got != tt.wantSides {
+				t.Errorf("disk_sides = %q, want %q", got, tt.wantSides)
+			}
+			if got := result.Metadata["manufacturer_code"]; got != "0x01" {
+				t.Errorf("manufacturer_code = %q, want %q", got, "0x01")
+			}
+			if got := result.Metadata["licensee"]; got != "Nintendo" {
+				t.Errorf("licensee = %q, want %q", got, "Nintendo")
+			}
+			if got := result.Metadata["revision"]; got != "1" {
+				t.Errorf("revision = %q, want %q", got, "1")
+			}
+		})
+	}
+}
+
+func TestFDSIdentifier_TruncatedLastSide(t *testing.T) {
+	t.Parallel()
+
+	// A trailing partial side without an info block must not be counted
+	data := createFDSImage("ZEL", 1, false)
+	data = append(data, make([]byte, 100)...)
+
+	result, err := NewFDSIdentifier().Identify(bytes.NewReader(data), int64(len(data)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if got := result.Metadata["disk_sides"]; got != "1" {
+		t.Errorf("disk_sides = %q, want %q", got, "1")
+	}
+}
+
+func TestFDSIdentifier_InvalidMarker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"too small", make([]byte, 16)},
+		{"headerless without marker", make([]byte, fdsSideSize)},
+		{"headered without marker", append([]byte("FDS\x1a\x01"), make([]byte, fdsSideSize)...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewFDSIdentifier().Identify(bytes.NewReader(tt.data), int64(len(tt.data)), nil)
+			if err == nil {
+				t.Error("Identify() should return an error")
+			}
+		})
+	}
+}
+
+func TestValidateFDS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header []byte
+		want   bool
+	}{
+		{"fwNES header", []byte("FDS\x1a\x02"), true},
+		{"disk info block", createFDSSide("ZEL", 0x01, 0)[:0x38], true},
+		{"wrong block code", append([]byte{0x02}, fdsHVCMarker...), false},
+		{"empty", nil, false},
+		{"NES header", []byte("NES\x1a"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ValidateFDS(tt.header); got != tt.want {
+				t.Errorf("ValidateFDS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -344,3 +344,15 @@ func FuzzValidateGB(f *testing.F) {
 		_ = ValidateGB(data)
 	})
 }
+
+// FuzzValidateNDS fuzzes Nintendo DS ROM validation.
+func FuzzValidateNDS(f *testing.F) {
+	f.Add(createNDSHeader("MARIO KART", "AMCE", "01", 0x00))
+	f.Add(make([]byte, 0x200))
+	f.Add([]byte{})
+
+	f.Fuzz(func(_ *testing.T, data []byte) {
+		// Should not panic
+		_ = ValidateNDS(data)
+	})
+}
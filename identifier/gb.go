@@ -21,6 +21,8 @@ package identifier
 import (
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 
 	"github.com/ZaparooProject/go-gameid/internal/binary"
 )
@@ -90,6 +92,27 @@ var gbCartridgeTypes = map[byte]string{
 	0xFF: "HuC1 + RAM + Battery",
 }
 
+// setGBCartridgeFeatures splits a combined cartridge type such as
+// "MBC3 + Timer + RAM + Battery" into mbc_type and has_* flags. Unknown
+// cartridge types get no flags.
+func setGBCartridgeFeatures(result *Result, cartridgeType string) {
+	if cartridgeType == "Unknown" {
+		return
+	}
+
+	parts := strings.Split(cartridgeType, " + ")
+	mbcType := parts[0]
+	if mbcType == "ROM" {
+		mbcType = "None"
+	}
+	features := parts[1:]
+	result.SetMetadata("mbc_type", mbcType)
+	result.SetMetadata("has_ram", fmt.Sprintf("%t", slices.Contains(features, "RAM")))
+	result.SetMetadata("has_battery", fmt.Sprintf("%t", slices.Contains(features, "Battery")))
+	result.SetMetadata("has_rtc", fmt.Sprintf("%t", slices.Contains(features, "Timer")))
+	result.SetMetadata("has_rumble", fmt.Sprintf("%t", slices.Contains(features, "Rumble")))
+}
+
 // GB ROM size and bank count lookup table
 var gbROMSizeBanks = map[byte]struct {
 	size  int
@@ -338,6 +361,11 @@ var gbLicenseeOldCodes = map[byte]string{
 }
 
 // GBIdentifier identifies Game Boy and Game Boy Color games.
+//
+// The result's Console comes from the CGB flag at 0x143, not the file
+// extension: ConsoleGBC for 0x80 (GBC enhanced, still runs on a GB) and 0xC0
+// (GBC only), ConsoleGB for everything else unless ForceGBC is set. The
+// gbc_only and gb_compatible metadata spell out which case applies.
 type GBIdentifier struct {
 	// ForceGBC forces identification as GBC even when the ROM supports both
 	ForceGBC bool
@@ -354,22 +382,38 @@ func (*GBIdentifier) Console() Console {
 }
 
 // Identify extracts GB/GBC game information from the given reader.
-//
-//nolint:gocognit,gocyclo,revive,cyclop,funlen // This function's complexity is necessary for proper header parsing
 func (g *GBIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Result, error) {
+	return g.identify(reader, size, db, false)
+}
+
+// IdentifyHeader is Identify reading only the 0x150-byte header. The global
+// checksum stored in the header is still reported and used for the database
+// lookup, but global_checksum_actual, which sums the whole ROM, is left out.
+func (g *GBIdentifier) IdentifyHeader(reader io.ReaderAt, size int64, db Database) (*Result, error) {
+	return g.identify(reader, size, db, true)
+}
+
+//nolint:gocognit,gocyclo,revive,cyclop,funlen // This function's complexity is necessary for proper header parsing
+func (g *GBIdentifier) identify(reader io.ReaderAt, size int64, db Database, headerOnly bool) (*Result, error) {
 	if size < gbHeaderSize {
 		return nil, ErrInvalidFormat{Console: ConsoleGB, Reason: "file too small"}
 	}
 
-	// Read the entire file for checksum calculation
-	data := make([]byte, size)
-	if _, err := reader.ReadAt(data, 0); err != nil && err != io.EOF {
+	// Read the entire file for checksum calculation, unless only the
+	// header is wanted
+	readSize := int(size)
+	if headerOnly {
+		readSize = gbHeaderSize
+	} else if err := checkCartridgeSize(ConsoleGB, size); err != nil {
+		return nil, err
+	}
+	data, err := readROM(reader, ConsoleGB, 0, readSize)
+	if err != nil {
 		return nil, fmt.Errorf("failed to read GB ROM: %w", err)
 	}
 
-	// Validate Nintendo logo (not fatal if invalid, just suspicious)
-	// logo := data[gbNintendoLogoOffset : gbNintendoLogoOffset+gbNintendoLogoSize]
-	// _ = binary.BytesEqual(logo, gbNintendoLogo)
+	// The Nintendo logo is not checked: a bad one is suspicious but not
+	// fatal. ValidateStrict rejects it.
 
 	// Check CGB flag to determine if it's a GBC game
 	cgbFlag := data[gbCGBFlagOffset]
@@ -459,17 +503,16 @@ func (g *GBIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*R
 
 	// Header checksum
 	headerChecksumExpected := data[gbHeaderChecksumOffset]
-	headerChecksumActual := uint8(0)
-	for i := 0x0134; i < 0x014D; i++ {
-		headerChecksumActual = headerChecksumActual - data[i] - 1
-	}
+	headerChecksumActual := gbHeaderChecksum(data)
 
 	// Global checksum
 	globalChecksumExpected := uint16(data[gbGlobalChecksumOffset])<<8 | uint16(data[gbGlobalChecksumOffset+1])
 	var globalChecksumActual uint16
-	for i, b := range data {
-		if i != gbGlobalChecksumOffset && i != gbGlobalChecksumOffset+1 {
-			globalChecksumActual += uint16(b)
+	if !headerOnly {
+		for i, b := range data {
+			if i != gbGlobalChecksumOffset && i != gbGlobalChecksumOffset+1 {
+				globalChecksumActual += uint16(b)
+			}
 		}
 	}
 
@@ -477,8 +520,11 @@ func (g *GBIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*R
 	result.InternalTitle = title
 	result.SetMetadata("internal_title", title)
 	result.SetMetadata("cgb_mode", cgbMode)
+	result.SetMetadata("gbc_only", fmt.Sprintf("%t", cgbFlag == 0xC0))
+	result.SetMetadata("gb_compatible", fmt.Sprintf("%t", cgbFlag != 0xC0))
 	result.SetMetadata("sgb_support", fmt.Sprintf("%t", sgbSupport))
 	result.SetMetadata("cartridge_type", cartridgeType)
+	setGBCartridgeFeatures(result, cartridgeType)
 	result.SetMetadata("rom_size", romSize)
 	result.SetMetadata("rom_banks", romBanks)
 	result.SetMetadata("ram_size", ramSize)
@@ -488,7 +534,9 @@ func (g *GBIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*R
 	result.SetMetadata("header_checksum_expected", fmt.Sprintf("0x%02x", headerChecksumExpected))
 	result.SetMetadata("header_checksum_actual", fmt.Sprintf("0x%02x", headerChecksumActual))
 	result.SetMetadata("global_checksum_expected", fmt.Sprintf("0x%04x", globalChecksumExpected))
-	result.SetMetadata("global_checksum_actual", fmt.Sprintf("0x%04x", globalChecksumActual))
+	if !headerOnly {
+		result.SetMetadata("global_checksum_actual", fmt.Sprintf("0x%04x", globalChecksumActual))
+	}
 
 	if manufacturerCode != "" {
 		result.SetMetadata("manufacturer_code", manufacturerCode)
@@ -507,13 +555,48 @@ func (g *GBIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*R
 	}
 
 	// If no title from database, use internal title
-	if result.Title == "" {
-		result.Title = result.InternalTitle
-	}
+	result.SetROMTitle(result.InternalTitle)
 
 	return result, nil
 }
 
+// gbHeaderChecksum computes the header checksum the boot ROM verifies over
+// 0x134-0x14C.
+func gbHeaderChecksum(header []byte) uint8 {
+	checksum := uint8(0)
+	for _, b := range header[gbTitleOffset:gbHeaderChecksumOffset] {
+		checksum = checksum - b - 1
+	}
+	return checksum
+}
+
+// ValidateStrict rejects a ROM whose Nintendo logo or header checksum is
+// wrong, the two checks the boot ROM makes before starting a cartridge.
+func (*GBIdentifier) ValidateStrict(reader io.ReaderAt, size int64) error {
+	if size < gbHeaderSize {
+		return ErrInvalidFormat{Console: ConsoleGB, Reason: "file too small"}
+	}
+	header, err := readROM(reader, ConsoleGB, 0, gbHeaderSize)
+	if err != nil {
+		return fmt.Errorf("failed to read GB header: %w", err)
+	}
+	if !ValidateGB(header) {
+		return ErrInvalidFormat{Console: ConsoleGB, Reason: "Nintendo logo mismatch"}
+	}
+	if expected, actual := header[gbHeaderChecksumOffset], gbHeaderChecksum(header); expected != actual {
+		return ErrInvalidFormat{
+			Console: ConsoleGB,
+			Reason:  fmt.Sprintf("header checksum 0x%02x, computed 0x%02x", expected, actual),
+		}
+	}
+	return nil
+}
+
+// Validate reports whether header, the start of a file, could be a Game Boy or Game Boy Color ROM.
+func (*GBIdentifier) Validate(header []byte) bool {
+	return ValidateGB(header)
+}
+
 // ValidateGB checks if the given data looks like a valid GB/GBC ROM.
 func ValidateGB(header []byte) bool {
 	if len(header) < gbHeaderSize {
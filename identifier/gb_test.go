@@ -20,6 +20,7 @@ package identifier
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 )
 
@@ -150,6 +151,47 @@ func TestGBIdentifier_Identify(t *testing.T) {
 	}
 }
 
+func TestGBIdentifier_CGBClassification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		wantMode     string
+		wantGBCOnly  string
+		wantGBCompat string
+		wantConsole  Console
+		cgbFlag      byte
+	}{
+		{"dual", "GBC (supports GB)", "false", "true", ConsoleGBC, 0x80},
+		{"GBC only", "GBC only", "true", "false", ConsoleGBC, 0xC0},
+		{"DMG", "GB", "false", "true", ConsoleGB, 0x00},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := createGBHeader("TEST", tt.cgbFlag, 0, 0x00)
+			result, err := NewGBIdentifier().Identify(bytes.NewReader(header), int64(len(header)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if result.Console != tt.wantConsole {
+				t.Errorf("Console = %v, want %v", result.Console, tt.wantConsole)
+			}
+			if got := result.Metadata["cgb_mode"]; got != tt.wantMode {
+				t.Errorf("cgb_mode = %q, want %q", got, tt.wantMode)
+			}
+			if got := result.Metadata["gbc_only"]; got != tt.wantGBCOnly {
+				t.Errorf("gbc_only = %q, want %q", got, tt.wantGBCOnly)
+			}
+			if got := result.Metadata["gb_compatible"]; got != tt.wantGBCompat {
+				t.Errorf("gb_compatible = %q, want %q", got, tt.wantGBCompat)
+			}
+		})
+	}
+}
+
 func TestGBIdentifier_InvalidLogo(t *testing.T) {
 	t.Parallel()
 
@@ -178,3 +220,92 @@ func TestGBIdentifier_TooSmall(t *testing.T) {
 		t.Error("expected error for small file, got nil")
 	}
 }
+
+func TestGBIdentifier_CartridgeFeatures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		want     map[string]string
+		name     string
+		cartType byte
+	}{
+		{
+			name:     "MBC3 with RTC",
+			cartType: 0x10,
+			want: map[string]string{
+				"mbc_type": "MBC3", "has_rtc": "true", "has_ram": "true", "has_battery": "true", "has_rumble": "false",
+			},
+		},
+		{
+			name:     "MBC5 with rumble",
+			cartType: 0x1C,
+			want: map[string]string{
+				"mbc_type": "MBC5", "has_rtc": "false", "has_ram": "false", "has_battery": "false", "has_rumble": "true",
+			},
+		},
+		{
+			name:     "ROM only",
+			cartType: 0x00,
+			want: map[string]string{
+				"mbc_type": "None", "has_rtc": "false", "has_ram": "false", "has_battery": "false", "has_rumble": "false",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rom := createGBHeader("FEATURES", 0x00, 0, tt.cartType)
+			result, err := NewGBIdentifier().Identify(bytes.NewReader(rom), int64(len(rom)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			for key, want := range tt.want {
+				if got := result.Metadata[key]; got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+			if result.Metadata["cartridge_type"] != gbCartridgeTypes[tt.cartType] {
+				t.Errorf("cartridge_type = %q, want %q", result.Metadata["cartridge_type"], gbCartridgeTypes[tt.cartType])
+			}
+		})
+	}
+}
+
+func TestGBIdentifier_UnknownCartridgeHasNoFlags(t *testing.T) {
+	t.Parallel()
+
+	rom := createGBHeader("UNKNOWN", 0x00, 0, 0x77)
+	result, err := NewGBIdentifier().Identify(bytes.NewReader(rom), int64(len(rom)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if got, ok := result.Metadata["has_rtc"]; ok {
+		t.Errorf("has_rtc = %q for unknown cartridge type, want unset", got)
+	}
+}
+
+func TestGBIdentifier_ValidateStrict(t *testing.T) {
+	t.Parallel()
+
+	valid := createGBHeader("TETRIS", 0x00, 0x1234, 0x00)
+	valid[0x14D] = gbHeaderChecksum(valid)
+	badLogo := bytes.Clone(valid)
+	badLogo[0x104] = 0
+	badChecksum := bytes.Clone(valid)
+	badChecksum[0x14D]++
+
+	for name, rom := range map[string][]byte{"corrupted logo": badLogo, "wrong header checksum": badChecksum} {
+		var invalid ErrInvalidFormat
+		if err := NewGBIdentifier().ValidateStrict(bytes.NewReader(rom), int64(len(rom))); !errors.As(err, &invalid) {
+			t.Errorf("%s: ValidateStrict() error = %v, want ErrInvalidFormat", name, err)
+		}
+		if _, err := NewGBIdentifier().Identify(bytes.NewReader(rom), int64(len(rom)), nil); err != nil {
+			t.Errorf("%s: Identify() error = %v, want it tolerated", name, err)
+		}
+	}
+	if err := NewGBIdentifier().ValidateStrict(bytes.NewReader(valid), int64(len(valid))); err != nil {
+		t.Errorf("ValidateStrict() error = %v for a valid header", err)
+	}
+}
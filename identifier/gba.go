@@ -23,6 +23,7 @@ import (
 	"io"
 
 	"github.com/ZaparooProject/go-gameid/internal/binary"
+	"github.com/ZaparooProject/go-gameid/internal/trace"
 )
 
 // GBA header offsets
@@ -39,6 +40,7 @@ const (
 	gbaMainUnitCodeOffset = 0xB3
 	gbaDeviceTypeOffset   = 0xB4
 	gbaSoftwareVerOffset  = 0xBC
+	gbaComplementOffset   = 0xBD
 )
 
 // GBA Nintendo logo - used to validate GBA ROMs
@@ -78,15 +80,13 @@ func (*GBAIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Re
 	}
 
 	// Read header
-	header, err := binary.ReadBytesAt(reader, 0, gbaHeaderSize)
+	header, err := readROM(reader, ConsoleGBA, 0, gbaHeaderSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read GBA header: %w", err)
 	}
 
-	// Validate Nintendo logo (optional - some homebrew may not have it)
-	// Not a fatal error if invalid, just note it - some valid GBA ROMs may have modified logos
-	// logo := header[gbaNintendoLogoOffset : gbaNintendoLogoOffset+gbaNintendoLogoSize]
-	// _ = binary.BytesEqual(logo, gbaNintendoLogo)
+	// The Nintendo logo is not checked: some homebrew lacks it and some valid
+	// ROMs have modified logos. ValidateStrict rejects them.
 
 	// Extract title (12 bytes at 0xA0)
 	title := binary.ExtractPrintable(header[gbaTitleOffset : gbaTitleOffset+gbaTitleSize])
@@ -101,6 +101,9 @@ func (*GBAIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Re
 	mainUnitCode := header[gbaMainUnitCodeOffset]
 	deviceType := header[gbaDeviceTypeOffset]
 	softwareVersion := header[gbaSoftwareVerOffset]
+	if trace.Enabled() {
+		trace.Log("gba.header", "game_code", gameCode, "maker_code", makerCode, "logo_valid", ValidateGBA(header))
+	}
 
 	result := NewResult(ConsoleGBA)
 	result.ID = gameCode
@@ -108,6 +111,7 @@ func (*GBAIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Re
 	result.SetMetadata("ID", gameCode)
 	result.SetMetadata("internal_title", title)
 	result.SetMetadata("maker_code", makerCode)
+	result.SetMetadata("publisher", PublisherFromMakerCode(makerCode))
 	result.SetMetadata("main_unit_code", fmt.Sprintf("0x%02x", mainUnitCode))
 	result.SetMetadata("device_type", fmt.Sprintf("0x%02x", deviceType))
 	result.SetMetadata("software_version", fmt.Sprintf("%d", softwareVersion))
@@ -119,14 +123,54 @@ func (*GBAIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Re
 		}
 	}
 
-	// If no title from database, use internal title
-	if result.Title == "" {
-		result.Title = result.InternalTitle
+	// Without a database region, the game code's destination character
+	// still tells the region the cartridge was sold in
+	if _, ok := result.Metadata["region"]; !ok {
+		result.SetMetadata("region", string(RegionFromGBAGameCode(gameCode)))
 	}
 
+	// If no title from database, use internal title
+	result.SetROMTitle(result.InternalTitle)
+
 	return result, nil
 }
 
+// gbaComplementCheck computes the header complement check over 0xA0-0xBC.
+func gbaComplementCheck(header []byte) uint8 {
+	check := uint8(0)
+	for _, b := range header[gbaTitleOffset:gbaComplementOffset] {
+		check -= b
+	}
+	return check - 0x19
+}
+
+// ValidateStrict rejects a ROM whose Nintendo logo or header complement check
+// is wrong; the BIOS refuses to boot either.
+func (*GBAIdentifier) ValidateStrict(reader io.ReaderAt, size int64) error {
+	if size < gbaHeaderSize {
+		return ErrInvalidFormat{Console: ConsoleGBA, Reason: "file too small"}
+	}
+	header, err := readROM(reader, ConsoleGBA, 0, gbaHeaderSize)
+	if err != nil {
+		return fmt.Errorf("failed to read GBA header: %w", err)
+	}
+	if !ValidateGBA(header) {
+		return ErrInvalidFormat{Console: ConsoleGBA, Reason: "Nintendo logo mismatch"}
+	}
+	if expected, actual := header[gbaComplementOffset], gbaComplementCheck(header); expected != actual {
+		return ErrInvalidFormat{
+			Console: ConsoleGBA,
+			Reason:  fmt.Sprintf("header complement check 0x%02x, computed 0x%02x", expected, actual),
+		}
+	}
+	return nil
+}
+
+// Validate reports whether header, the start of a file, could be a Game Boy Advance ROM.
+func (*GBAIdentifier) Validate(header []byte) bool {
+	return ValidateGBA(header)
+}
+
 // ValidateGBA checks if the given data looks like a valid GBA ROM.
 func ValidateGBA(header []byte) bool {
 	if len(header) < gbaHeaderSize {
@@ -20,6 +20,7 @@ package identifier
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 )
 
@@ -85,6 +86,7 @@ func TestGBAIdentifier_Identify(t *testing.T) {
 		makerCode     string
 		wantID        string
 		wantInternal  string
+		wantPublisher string
 		version       uint8
 	}{
 		{
@@ -95,6 +97,7 @@ func TestGBAIdentifier_Identify(t *testing.T) {
 			version:       0,
 			wantID:        "BPEE",
 			wantInternal:  "POKEMON EMER",
+			wantPublisher: "Nintendo",
 		},
 		{
 			name:          "Mario Kart",
@@ -104,6 +107,15 @@ func TestGBAIdentifier_Identify(t *testing.T) {
 			version:       1,
 			wantID:        "AMKE",
 			wantInternal:  "MARIOKART",
+			wantPublisher: "Nintendo",
+		},
+		{
+			name:          "Unknown maker",
+			gameCode:      "AZZE",
+			internalTitle: "HOMEBREW",
+			makerCode:     "ZZ",
+			wantID:        "AZZE",
+			wantInternal:  "HOMEBREW",
 		},
 	}
 
@@ -130,6 +142,52 @@ func TestGBAIdentifier_Identify(t *testing.T) {
 			if result.Console != ConsoleGBA {
 				t.Errorf("Console = %v, want %v", result.Console, ConsoleGBA)
 			}
+
+			if got := result.Metadata["publisher"]; got != testCase.wantPublisher {
+				t.Errorf("publisher = %q, want %q", got, testCase.wantPublisher)
+			}
+		})
+	}
+}
+
+func TestGBAIdentifier_GameCodeRegion(t *testing.T) {
+	t.Parallel()
+
+	db := newMockDatabase()
+	db.addEntry(ConsoleGBA, "AXVP", map[string]string{"title": "Test", "region": "Germany"})
+
+	tests := []struct {
+		db             Database
+		gameCode       string
+		wantRegion     string
+		wantRegionCode Region
+	}{
+		{gameCode: "AXVE", wantRegion: "USA", wantRegionCode: RegionUSA},
+		{gameCode: "AXVJ", wantRegion: "Japan", wantRegionCode: RegionJapan},
+		{gameCode: "AXVP", wantRegion: "Europe", wantRegionCode: RegionEurope},
+		{gameCode: "AXVQ", wantRegion: "", wantRegionCode: RegionUnknown},
+		// A database region wins over the game code
+		{db: db, gameCode: "AXVP", wantRegion: "Germany", wantRegionCode: RegionEurope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.gameCode, func(t *testing.T) {
+			t.Parallel()
+
+			header := createGBAHeader(tt.gameCode, "REGIONTEST", "01", 0)
+			result, err := NewGBAIdentifier().Identify(bytes.NewReader(header), int64(len(header)), tt.db)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if result.Region != tt.wantRegion {
+				t.Errorf("Region = %q, want %q", result.Region, tt.wantRegion)
+			}
+			if got := result.Metadata["region"]; got != tt.wantRegion {
+				t.Errorf("region metadata = %q, want %q", got, tt.wantRegion)
+			}
+			if result.RegionCode != tt.wantRegionCode {
+				t.Errorf("RegionCode = %q, want %q", result.RegionCode, tt.wantRegionCode)
+			}
 		})
 	}
 }
@@ -146,3 +204,39 @@ func TestGBAIdentifier_InvalidLogo(t *testing.T) {
 		t.Error("ValidateGBA() should return false for invalid logo")
 	}
 }
+
+func TestGBAIdentifier_ValidateStrict(t *testing.T) {
+	t.Parallel()
+
+	valid := createGBAHeader("ATST", "TESTGAME", "01", 0)
+	valid[0xBD] = gbaComplementCheck(valid)
+	badLogo := bytes.Clone(valid)
+	badLogo[0x10] ^= 0xFF
+	badComplement := bytes.Clone(valid)
+	badComplement[0xBD]++
+
+	tests := []struct {
+		name    string
+		rom     []byte
+		wantErr bool
+	}{
+		{name: "valid", rom: valid},
+		{name: "corrupted logo", rom: badLogo, wantErr: true},
+		{name: "wrong complement check", rom: badComplement, wantErr: true},
+		{name: "too small", rom: valid[:0x80], wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := NewGBAIdentifier().ValidateStrict(bytes.NewReader(tt.rom), int64(len(tt.rom)))
+			var invalid ErrInvalidFormat
+			switch {
+			case tt.wantErr && !errors.As(err, &invalid):
+				t.Errorf("ValidateStrict() error = %v, want ErrInvalidFormat", err)
+			case !tt.wantErr && err != nil:
+				t.Errorf("ValidateStrict() error = %v, want nil", err)
+			}
+		})
+	}
+}
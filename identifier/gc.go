@@ -19,13 +19,20 @@
 package identifier
 
 import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"path/filepath"
 	"strings"
 
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+
 	"github.com/ZaparooProject/go-gameid/chd"
-	"github.com/ZaparooProject/go-gameid/internal/binary"
+	bin "github.com/ZaparooProject/go-gameid/internal/binary"
 )
 
 // GameCube header offsets
@@ -41,6 +48,31 @@ const (
 	gcInternalNameSize   = 0x03E0 // 0x0020 to 0x0400
 )
 
+// GameCube file system table (FST) and banner layout
+const (
+	gcFSTOffsetOffset   = 0x0424
+	gcFSTSizeOffset     = 0x0428
+	gcFSTEntrySize      = 12
+	gcFSTMaxSize        = 16 * 1024 * 1024
+	gcBannerFileName    = "opening.bnr"
+	gcBannerMetaOffset  = 0x1820
+	gcBannerMetaSize    = 0x0140
+	gcBannerShortSize   = 0x20
+	gcBannerLongSize    = 0x40
+	gcBannerDescSize    = 0x80
+	gcBannerBNR2Entries = 6
+)
+
+// GameCube banner magic words. BNR1 holds a single metadata block in the
+// disc's regional language; BNR2 (PAL) holds one per language.
+var (
+	gcBannerMagicBNR1 = []byte("BNR1")
+	gcBannerMagicBNR2 = []byte("BNR2")
+)
+
+// gcBannerLanguages lists the BNR2 metadata blocks in disc order.
+var gcBannerLanguages = []string{"english", "german", "french", "spanish", "italian", "dutch"}
+
 // GameCube magic word at offset 0x1C
 var gcMagicWord = []byte{0xC2, 0x33, 0x9F, 0x3D}
 
@@ -64,39 +96,43 @@ func (*GCIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Res
 	}
 
 	// Read header
-	header, err := binary.ReadBytesAt(reader, 0, gcHeaderSize)
+	header, err := bin.ReadBytesAt(reader, 0, gcHeaderSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read GameCube header: %w", err)
 	}
 
 	// Validate magic word (at offset 0x1C)
 	magic := header[0x1C : 0x1C+4]
-	if !binary.BytesEqual(magic, gcMagicWord) {
+	if !bin.BytesEqual(magic, gcMagicWord) {
 		return nil, ErrInvalidFormat{Console: ConsoleGC, Reason: "invalid magic word"}
 	}
 
 	// Extract game ID (4 bytes at 0x0000)
-	gameID := binary.CleanString(header[gcGameIDOffset : gcGameIDOffset+gcGameIDSize])
+	gameID := bin.CleanString(header[gcGameIDOffset : gcGameIDOffset+gcGameIDSize])
 
 	// Extract maker code (2 bytes at 0x0004)
-	makerCode := binary.CleanString(header[gcMakerCodeOffset : gcMakerCodeOffset+gcMakerCodeSize])
+	makerCode := bin.CleanString(header[gcMakerCodeOffset : gcMakerCodeOffset+gcMakerCodeSize])
 
 	// Extract disk ID and version
 	diskID := header[gcDiskIDOffset]
 	version := header[gcVersionOffset]
 
 	// Extract internal title (0x0020 to 0x0400)
-	internalTitle := binary.CleanString(header[gcInternalNameOffset : gcInternalNameOffset+gcInternalNameSize])
+	internalTitle := bin.CleanString(header[gcInternalNameOffset : gcInternalNameOffset+gcInternalNameSize])
 
 	result := NewResult(ConsoleGC)
 	result.ID = gameID
 	result.InternalTitle = internalTitle
 	result.SetMetadata("ID", gameID)
 	result.SetMetadata("maker_code", makerCode)
+	result.SetMetadata("publisher", PublisherFromMakerCode(makerCode))
 	result.SetMetadata("disk_ID", fmt.Sprintf("%d", diskID))
 	result.SetMetadata("version", fmt.Sprintf("%d", version))
 	result.SetMetadata("internal_title", internalTitle)
 
+	banners := gcReadBanner(reader, size, header)
+	setGCBannerMetadata(result, banners)
+
 	// Database lookup
 	if db != nil && gameID != "" {
 		if entry, found := db.LookupByString(ConsoleGC, gameID); found {
@@ -104,21 +140,186 @@ func (*GCIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Res
 		}
 	}
 
-	// If no title from database, use internal title
-	if result.Title == "" {
-		result.Title = result.InternalTitle
+	// If no title from database, prefer the banner title over the boot header
+	romTitle := result.Metadata["banner_title"]
+	if romTitle == "" {
+		romTitle = result.InternalTitle
 	}
+	result.SetROMTitle(romTitle)
 
 	return result, nil
 }
 
+// gcBanner is one metadata block of an opening.bnr file.
+type gcBanner struct {
+	shortTitle  string
+	shortMaker  string
+	longTitle   string
+	longMaker   string
+	description string
+}
+
+// title returns the long game name, falling back to the short one.
+func (b gcBanner) title() string {
+	return cmp.Or(b.longTitle, b.shortTitle)
+}
+
+// maker returns the long company name, falling back to the short one.
+func (b gcBanner) maker() string {
+	return cmp.Or(b.longMaker, b.shortMaker)
+}
+
+// gcReadBanner locates opening.bnr in the root of the disc's FST and parses
+// its metadata blocks. The banner is optional, so a missing or malformed
+// banner yields nil rather than an error.
+func gcReadBanner(reader io.ReaderAt, size int64, header []byte) []gcBanner {
+	offset, length, ok := gcFindRootFile(reader, size, header, gcBannerFileName)
+	if !ok || length < gcBannerMetaOffset+gcBannerMetaSize {
+		return nil
+	}
+
+	magic, err := bin.ReadBytesAt(reader, offset, 4)
+	if err != nil {
+		return nil
+	}
+
+	count := 0
+	switch {
+	case bin.BytesEqual(magic, gcBannerMagicBNR1):
+		count = 1
+	case bin.BytesEqual(magic, gcBannerMagicBNR2):
+		count = min(gcBannerBNR2Entries, int((length-gcBannerMetaOffset)/gcBannerMetaSize))
+	default:
+		return nil
+	}
+
+	meta, err := bin.ReadBytesAt(reader, offset+gcBannerMetaOffset, count*gcBannerMetaSize)
+	if err != nil {
+		return nil
+	}
+
+	// BNR1 text is Shift-JIS on Japanese discs; everything else is Windows-1252
+	var decoder encoding.Encoding = charmap.Windows1252
+	if count == 1 && header[gcGameIDOffset+3] == 'J' {
+		decoder = japanese.ShiftJIS
+	}
+
+	banners := make([]gcBanner, count)
+	for i := range banners {
+		banners[i] = parseGCBannerMeta(meta[i*gcBannerMetaSize:(i+1)*gcBannerMetaSize], decoder)
+	}
+	return banners
+}
+
+// parseGCBannerMeta parses a 0x140-byte banner metadata block.
+func parseGCBannerMeta(block []byte, decoder encoding.Encoding) gcBanner {
+	field := func(start, length int) string {
+		raw := block[start : start+length]
+		if end := bytes.IndexByte(raw, 0); end != -1 {
+			raw = raw[:end]
+		}
+		decoded, err := decoder.NewDecoder().Bytes(raw)
+		if err != nil {
+			return bin.CleanString(raw)
+		}
+		return strings.TrimSpace(string(decoded))
+	}
+
+	const (
+		shortMakerStart = gcBannerShortSize
+		longTitleStart  = shortMakerStart + gcBannerShortSize
+		longMakerStart  = longTitleStart + gcBannerLongSize
+		descStart       = longMakerStart + gcBannerLongSize
+	)
+	return gcBanner{
+		shortTitle:  field(0, gcBannerShortSize),
+		shortMaker:  field(shortMakerStart, gcBannerShortSize),
+		longTitle:   field(longTitleStart, gcBannerLongSize),
+		longMaker:   field(longMakerStart, gcBannerLongSize),
+		description: field(descStart, gcBannerDescSize),
+	}
+}
+
+// setGCBannerMetadata stores banner text. The first block provides the
+// unsuffixed keys; further BNR2 languages are suffixed with their name.
+func setGCBannerMetadata(result *Result, banners []gcBanner) {
+	for i, banner := range banners {
+		suffix := ""
+		if i > 0 {
+			suffix = "_" + gcBannerLanguages[i]
+		}
+		setIfNotEmpty := func(key, value string) {
+			if value != "" {
+				result.SetMetadata(key+suffix, value)
+			}
+		}
+		setIfNotEmpty("banner_title", banner.title())
+		if i == 0 && banner.shortTitle != banner.title() {
+			setIfNotEmpty("banner_short_title", banner.shortTitle)
+		}
+		setIfNotEmpty("banner_maker", banner.maker())
+		setIfNotEmpty("banner_description", banner.description)
+	}
+}
+
+// gcFindRootFile looks up a file in the root directory of the disc's FST and
+// returns its disc offset and length.
+func gcFindRootFile(reader io.ReaderAt, size int64, header []byte, name string) (offset, length int64, found bool) {
+	fstOffset := int64(binary.BigEndian.Uint32(header[gcFSTOffsetOffset:]))
+	fstSize := int64(binary.BigEndian.Uint32(header[gcFSTSizeOffset:]))
+	if fstOffset == 0 || fstSize < gcFSTEntrySize || fstSize > gcFSTMaxSize || fstOffset+fstSize > size {
+		return 0, 0, false
+	}
+
+	fst, err := bin.ReadBytesAt(reader, fstOffset, int(fstSize))
+	if err != nil {
+		return 0, 0, false
+	}
+
+	// The root entry's length field holds the total entry count; the string
+	// table follows the entries.
+	entryCount := int64(binary.BigEndian.Uint32(fst[8:12]))
+	stringTable := entryCount * gcFSTEntrySize
+	if entryCount == 0 || stringTable > fstSize {
+		return 0, 0, false
+	}
+
+	for idx := int64(1); idx < entryCount; {
+		entry := fst[idx*gcFSTEntrySize : (idx+1)*gcFSTEntrySize]
+		isDir := entry[0] != 0
+		nameOffset := stringTable + int64(binary.BigEndian.Uint32(entry[0:4])&0x00FFFFFF)
+		value := int64(binary.BigEndian.Uint32(entry[8:12]))
+
+		if isDir {
+			// Directories store the index just past their last child
+			if value <= idx {
+				return 0, 0, false
+			}
+			idx = value
+			continue
+		}
+
+		if nameOffset < fstSize && strings.EqualFold(bin.CleanString(fst[nameOffset:]), name) {
+			return int64(binary.BigEndian.Uint32(entry[4:8])), value, true
+		}
+		idx++
+	}
+
+	return 0, 0, false
+}
+
+// Validate reports whether header, the start of a file, could be a GameCube disc.
+func (*GCIdentifier) Validate(header []byte) bool {
+	return ValidateGC(header)
+}
+
 // ValidateGC checks if the given data looks like a valid GameCube disc.
 func ValidateGC(header []byte) bool {
 	if len(header) < 0x20 {
 		return false
 	}
 	magic := header[0x1C : 0x1C+4]
-	return binary.BytesEqual(magic, gcMagicWord)
+	return bin.BytesEqual(magic, gcMagicWord)
 }
 
 // IdentifyFromPath handles path-based identification for GameCube discs.
@@ -135,15 +336,18 @@ func (g *GCIdentifier) IdentifyFromPath(path string, db Database) (*Result, erro
 
 // identifyFromCHD reads GameCube disc data from a CHD file.
 func (g *GCIdentifier) identifyFromCHD(path string, db Database) (*Result, error) {
+	return identifyNintendoDiscCHD(path, g, db)
+}
+
+// identifyNintendoDiscCHD runs a GameCube or Wii identifier over a CHD image.
+// These discs don't use ISO9660, but their file offsets are addressed in
+// 2048-byte logical sectors, so read user data rather than raw CD frames.
+func identifyNintendoDiscCHD(path string, ident Identifier, db Database) (*Result, error) {
 	chdFile, err := chd.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("open CHD: %w", err)
 	}
 	defer func() { _ = chdFile.Close() }()
 
-	// GameCube discs don't use ISO9660 - read raw sector data
-	reader := chdFile.RawSectorReader()
-	size := chdFile.Size()
-
-	return g.Identify(reader, size, db)
+	return ident.Identify(chdFile.SectorReader(), chdFile.DataTrackSize(), db)
 }
@@ -20,6 +20,9 @@ package identifier
 
 import (
 	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -143,6 +146,220 @@ func TestGCIdentifier_Identify(t *testing.T) {
 	}
 }
 
+// gcBannerText holds the text fields of one banner metadata block.
+type gcBannerText struct {
+	shortTitle, shortMaker, longTitle, longMaker, description string
+}
+
+// createGCDiscWithBanner builds a disc image whose FST root holds a
+// sys/ directory and an opening.bnr with the given magic and text blocks.
+func createGCDiscWithBanner(gameID, magic string, blocks []gcBannerText) []byte {
+	const (
+		fstOffset    = 0x2000
+		bannerOffset = 0x3000
+	)
+	disc := make([]byte, bannerOffset+gcBannerMetaOffset+len(blocks)*gcBannerMetaSize)
+	copy(disc, createGCHeader(gameID, "01", "BOOT HEADER TITLE", 0, 0))
+
+	// Entries: root, "sys" directory holding "dummy.bin", then "opening.bnr"
+	names := []byte("sys\x00dummy.bin\x00opening.bnr\x00")
+	fst := make([]byte, 4*gcFSTEntrySize, 4*gcFSTEntrySize+len(names))
+	putEntry := func(idx int, isDir bool, nameOffset, offset, length uint32) {
+		entry := fst[idx*gcFSTEntrySize:]
+		binary.BigEndian.PutUint32(entry[0:], nameOffset)
+		if isDir {
+			entry[0] = 1
+		}
+		binary.BigEndian.PutUint32(entry[4:], offset)
+		binary.BigEndian.PutUint32(entry[8:], length)
+	}
+	putEntry(0, true, 0, 0, 4)
+	putEntry(1, true, 0, 0, 3)
+	putEntry(2, false, 4, 0x2800, 16)
+	putEntry(3, false, 14, bannerOffset, uint32(gcBannerMetaOffset+len(blocks)*gcBannerMetaSize))
+	fst = append(fst, names...)
+
+	copy(disc[fstOffset:], fst)
+	binary.BigEndian.PutUint32(disc[gcFSTOffsetOffset:], fstOffset)
+	binary.BigEndian.PutUint32(disc[gcFSTSizeOffset:], uint32(len(fst)))
+
+	copy(disc[bannerOffset:], magic)
+	for i, block := range blocks {
+		meta := disc[bannerOffset+gcBannerMetaOffset+i*gcBannerMetaSize:]
+		copy(meta[0x00:], block.shortTitle)
+		copy(meta[0x20:], block.shortMaker)
+		copy(meta[0x40:], block.longTitle)
+		copy(meta[0x80:], block.longMaker)
+		copy(meta[0xC0:], block.description)
+	}
+	return disc
+}
+
+func TestGCIdentifier_Banner(t *testing.T) {
+	t.Parallel()
+
+	english := gcBannerText{
+		shortTitle:  "Wind Waker",
+		shortMaker:  "Nintendo",
+		longTitle:   "The Legend of Zelda: The Wind Waker",
+		longMaker:   "Nintendo Co., Ltd.",
+		description: "Set sail on a grand adventure.",
+	}
+	german := gcBannerText{
+		shortTitle:  "Wind Waker",
+		longTitle:   "Zelda: The Wind Waker",
+		longMaker:   "Nintendo",
+		description: "Stich in See.",
+	}
+
+	tests := []struct {
+		wantMetadata map[string]string
+		name         string
+		gameID       string
+		magic        string
+		wantTitle    string
+		blocks       []gcBannerText
+	}{
+		{
+			name:      "BNR1",
+			gameID:    "GZLE",
+			magic:     "BNR1",
+			blocks:    []gcBannerText{english},
+			wantTitle: "The Legend of Zelda: The Wind Waker",
+			wantMetadata: map[string]string{
+				"banner_title":       "The Legend of Zelda: The Wind Waker",
+				"banner_short_title": "Wind Waker",
+				"banner_maker":       "Nintendo Co., Ltd.",
+				"banner_description": "Set sail on a grand adventure.",
+			},
+		},
+		{
+			name:      "BNR2 languages",
+			gameID:    "GZLP",
+			magic:     "BNR2",
+			blocks:    []gcBannerText{english, german},
+			wantTitle: "The Legend of Zelda: The Wind Waker",
+			wantMetadata: map[string]string{
+				"banner_title":               "The Legend of Zelda: The Wind Waker",
+				"banner_title_german":        "Zelda: The Wind Waker",
+				"banner_maker_german":        "Nintendo",
+				"banner_description_german":  "Stich in See.",
+				"banner_short_title_german":  "",
+				"banner_description_spanish": "",
+			},
+		},
+		{
+			name:      "Short title only",
+			gameID:    "GZLE",
+			magic:     "BNR1",
+			blocks:    []gcBannerText{{shortTitle: "Wind Waker", shortMaker: "Nintendo"}},
+			wantTitle: "Wind Waker",
+			wantMetadata: map[string]string{
+				"banner_title":       "Wind Waker",
+				"banner_short_title": "",
+				"banner_maker":       "Nintendo",
+			},
+		},
+		{
+			name:      "Unknown magic falls back to boot header",
+			gameID:    "GZLE",
+			magic:     "XXXX",
+			blocks:    []gcBannerText{english},
+			wantTitle: "BOOT HEADER TITLE",
+			wantMetadata: map[string]string{
+				"banner_title": "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			disc := createGCDiscWithBanner(tt.gameID, tt.magic, tt.blocks)
+			result, err := NewGCIdentifier().Identify(bytes.NewReader(disc), int64(len(disc)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+
+			if result.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", result.Title, tt.wantTitle)
+			}
+			for key, want := range tt.wantMetadata {
+				if got := result.Metadata[key]; got != want {
+					t.Errorf("Metadata[%q] = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGCIdentifier_BannerNoFST(t *testing.T) {
+	t.Parallel()
+
+	header := createGCHeader("GALE", "01", "Test Game", 0, 0)
+	// FST pointing past the end of the image must be ignored
+	binary.BigEndian.PutUint32(header[gcFSTOffsetOffset:], 0x10000)
+	binary.BigEndian.PutUint32(header[gcFSTSizeOffset:], 0x100)
+
+	result, err := NewGCIdentifier().Identify(bytes.NewReader(header), int64(len(header)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.Title != "Test Game" {
+		t.Errorf("Title = %q, want %q", result.Title, "Test Game")
+	}
+}
+
+func TestGCIdentifier_BannerFixtures(t *testing.T) {
+	t.Parallel()
+
+	identifier := NewGCIdentifier()
+
+	tests := []struct {
+		identify func(path string) (*Result, error)
+		name     string
+		path     string
+	}{
+		{
+			name: "ISO",
+			path: filepath.Join("..", "testdata", "GC", "GameCube-240pSuite-1.17.iso"),
+			identify: func(path string) (*Result, error) {
+				data, err := os.ReadFile(path) //nolint:gosec // Test fixture path
+				if err != nil {
+					return nil, err
+				}
+				return identifier.Identify(bytes.NewReader(data), int64(len(data)), nil)
+			},
+		},
+		{
+			name: "CHD",
+			path: filepath.Join("..", "testdata", "GC", "GameCube-240pSuite-1.17.chd"),
+			identify: func(path string) (*Result, error) {
+				return identifier.IdentifyFromPath(path, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tt.identify(tt.path)
+			if err != nil {
+				t.Fatalf("identify %s: %v", tt.path, err)
+			}
+
+			if got := result.Metadata["banner_title"]; got != "homebrew program for the GameCube" {
+				t.Errorf("banner_title = %q, want %q", got, "homebrew program for the GameCube")
+			}
+			if got := result.Metadata["banner_maker"]; got != "www.gc-forever.com" {
+				t.Errorf("banner_maker = %q, want %q", got, "www.gc-forever.com")
+			}
+		})
+	}
+}
+
 func TestGCIdentifier_InvalidMagic(t *testing.T) {
 	t.Parallel()
 
@@ -68,6 +68,27 @@ var genesisRegionSupport = map[byte]string{
 	'E': "Europe",
 }
 
+// Newer Genesis headers store the region as a single hex digit bitmask
+// instead of J/U/E letters.
+const (
+	genesisRegionBitJapan    = 0x1 // Domestic 60Hz
+	genesisRegionBitAmericas = 0x4 // Overseas 60Hz
+	genesisRegionBitEurope   = 0x8 // Overseas 50Hz
+)
+
+// genesisChecksumStart is the first ROM byte covered by the header checksum.
+const genesisChecksumStart = 0x200
+
+// genesisChecksumChunkSize is the read size used when summing ROM words.
+const genesisChecksumChunkSize = 64 * 1024
+
+// SMD (Super Magic Drive) copier dumps carry a 512-byte header and store the
+// ROM in 16 KiB blocks, each holding the odd bytes followed by the even bytes.
+const (
+	smdHeaderSize = 0x200
+	smdBlockSize  = 0x4000
+)
+
 // Genesis software types
 var genesisSoftwareTypes = map[string]string{
 	"GM": "Game",
@@ -77,7 +98,14 @@ var genesisSoftwareTypes = map[string]string{
 }
 
 // GenesisIdentifier identifies Sega Genesis / Mega Drive games.
-type GenesisIdentifier struct{}
+type GenesisIdentifier struct {
+	// PreferredRegion selects which header title becomes InternalTitle and,
+	// without a database title, Title: the domestic (Japanese) title for
+	// RegionJapan and the overseas title for any other region, falling back
+	// to the other when it is blank. When unset, InternalTitle is the
+	// domestic title and Title prefers the overseas one.
+	PreferredRegion Region
+}
 
 // NewGenesisIdentifier creates a new Genesis identifier.
 func NewGenesisIdentifier() *GenesisIdentifier {
@@ -90,13 +118,142 @@ func (*GenesisIdentifier) Console() Console {
 }
 
 // Identify extracts Genesis game information from the given reader.
-func (*GenesisIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Result, error) {
+// SMD interleaved dumps are deinterleaved on the fly and reported with
+// rom_format "SMD".
+func (g *GenesisIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Result, error) {
+	return g.identify(reader, size, db, false)
+}
+
+// IdentifyHeader is Identify reading only the header, or the first SMD block
+// of an SMD dump. checksum_actual, which sums the whole ROM, is left out.
+func (g *GenesisIdentifier) IdentifyHeader(reader io.ReaderAt, size int64, db Database) (*Result, error) {
+	return g.identify(reader, size, db, true)
+}
+
+func (g *GenesisIdentifier) identify(reader io.ReaderAt, size int64, db Database, headerOnly bool) (*Result, error) {
+	smd := isGenesisSMD(reader, size)
+	if smd {
+		reader = newSMDReader(reader, size)
+		size -= smdHeaderSize
+	}
+
 	data, magicWordInd, err := genesisReadHeader(reader, size)
 	if err != nil {
 		return nil, err
 	}
 
-	return genesisParseHeader(data, magicWordInd, db)
+	result, err := genesisParseHeader(data, magicWordInd, g.PreferredRegion, db)
+	if err != nil {
+		return nil, err
+	}
+	if smd {
+		result.SetMetadata("rom_format", "SMD")
+	}
+
+	if !headerOnly && size > genesisChecksumStart {
+		checksum, err := genesisComputeChecksum(reader, size)
+		if err != nil {
+			return nil, err
+		}
+		result.SetMetadata("checksum_actual", fmt.Sprintf("0x%04x", checksum))
+	}
+
+	return result, nil
+}
+
+// isGenesisSMD reports whether reader holds an SMD dump: a 512-byte copier
+// header marked 0xAA 0xBB at offset 8, whole 16 KiB blocks after it, and no
+// linear Genesis header in the first block.
+func isGenesisSMD(reader io.ReaderAt, size int64) bool {
+	if size < smdHeaderSize+smdBlockSize || (size-smdHeaderSize)%smdBlockSize != 0 {
+		return false
+	}
+	header := make([]byte, smdHeaderSize)
+	if _, err := reader.ReadAt(header, 0); err != nil {
+		return false
+	}
+	if header[8] != 0xAA || header[9] != 0xBB {
+		return false
+	}
+	return findGenesisMagicWord(header) == -1
+}
+
+// smdReader presents an SMD dump as the linear ROM it was made from.
+type smdReader struct {
+	reader io.ReaderAt
+	block  []byte // deinterleaved copy of block cached
+	raw    []byte
+	size   int64
+	cached int64
+}
+
+// newSMDReader wraps an SMD dump of fileSize bytes, header included.
+func newSMDReader(reader io.ReaderAt, fileSize int64) *smdReader {
+	return &smdReader{
+		reader: reader,
+		size:   fileSize - smdHeaderSize,
+		block:  make([]byte, smdBlockSize),
+		raw:    make([]byte, smdBlockSize),
+		cached: -1,
+	}
+}
+
+// ReadAt reads deinterleaved ROM bytes starting at the linear offset off.
+func (r *smdReader) ReadAt(buf []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+	read := 0
+	for read < len(buf) {
+		pos := off + int64(read)
+		if pos >= r.size {
+			return read, io.EOF
+		}
+		if err := r.loadBlock(pos / smdBlockSize); err != nil {
+			return read, err
+		}
+		read += copy(buf[read:], r.block[pos%smdBlockSize:])
+	}
+	return read, nil
+}
+
+// loadBlock deinterleaves block idx into r.block. The first half of an SMD
+// block holds the odd ROM bytes and the second half the even ones.
+func (r *smdReader) loadBlock(idx int64) error {
+	if idx == r.cached {
+		return nil
+	}
+	if _, err := r.reader.ReadAt(r.raw, smdHeaderSize+idx*smdBlockSize); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to read SMD block %d: %w", idx, err)
+	}
+	const half = smdBlockSize / 2
+	for i := range half {
+		r.block[i*2] = r.raw[half+i]
+		r.block[i*2+1] = r.raw[i]
+	}
+	r.cached = idx
+	return nil
+}
+
+// genesisComputeChecksum sums the big-endian 16-bit words from 0x200 to the
+// end of the ROM, as the console's boot code does. A trailing odd byte is
+// treated as the high byte of a final word.
+func genesisComputeChecksum(reader io.ReaderAt, size int64) (uint16, error) {
+	var sum uint16
+	buf := make([]byte, genesisChecksumChunkSize)
+	for offset := int64(genesisChecksumStart); offset < size; offset += int64(len(buf)) {
+		chunk := buf[:min(int64(len(buf)), size-offset)]
+		if _, err := reader.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			return 0, fmt.Errorf("failed to read Genesis ROM for checksum: %w", err)
+		}
+		for i := 0; i+1 < len(chunk); i += 2 {
+			sum += binary.BigEndian.Uint16(chunk[i:])
+		}
+		if len(chunk)%2 == 1 {
+			sum += uint16(chunk[len(chunk)-1]) << 8
+		}
+	}
+	return sum, nil
 }
 
 // genesisReadHeader reads the Genesis ROM header and finds the magic word.
@@ -150,7 +307,7 @@ func findGenesisMagicWord(data []byte) int {
 // genesisParseHeader parses the Genesis header and returns the result.
 //
 //nolint:funlen,revive // Header parsing requires many field extractions
-func genesisParseHeader(data []byte, magicWordInd int, db Database) (*Result, error) {
+func genesisParseHeader(data []byte, magicWordInd int, preferred Region, db Database) (*Result, error) {
 	extractString := func(offset, length int) string {
 		start := magicWordInd + offset
 		end := start + length
@@ -178,6 +335,7 @@ func genesisParseHeader(data []byte, magicWordInd int, db Database) (*Result, er
 	softwareType := extractString(0x080, 0x002)
 	gameID := extractString(0x082, 0x009)
 	revision := extractString(0x08C, 0x002)
+	sram := parseGenesisSRAM(extractBytes(0x0B0, 0x00C))
 
 	// Checksum is big-endian uint16
 	checksumBytes := extractBytes(0x08E, 2)
@@ -189,7 +347,8 @@ func genesisParseHeader(data []byte, magicWordInd int, db Database) (*Result, er
 	deviceSupport := parseGenesisDeviceSupport(extractBytes(0x090, 0x010))
 	addrs := parseGenesisAddresses(extractBytes(0x0A0, 4),
 		extractBytes(0x0A4, 4), extractBytes(0x0A8, 4), extractBytes(0x0AC, 4))
-	regionSupport := parseGenesisRegionSupport(extractBytes(0x0F0, 0x003))
+	regionCode := extractBytes(0x0F0, 0x003)
+	regionSupport := parseGenesisRegionSupport(regionCode)
 
 	// Normalize serial for database lookup (remove dashes and spaces)
 	serial := strings.ReplaceAll(gameID, "-", "")
@@ -199,6 +358,9 @@ func genesisParseHeader(data []byte, magicWordInd int, db Database) (*Result, er
 	result := NewResult(ConsoleGenesis)
 	result.ID = gameID
 	result.InternalTitle = titleDomestic
+	if preferred != RegionUnknown {
+		result.InternalTitle = GenesisTitleForRegion(preferred, titleDomestic, titleOverseas)
+	}
 	result.SetMetadata("system_type", systemType)
 	result.SetMetadata("publisher", publisher)
 	result.SetMetadata("release_year", releaseYear)
@@ -208,6 +370,10 @@ func genesisParseHeader(data []byte, magicWordInd int, db Database) (*Result, er
 	result.SetMetadata("ID", gameID)
 	result.SetMetadata("revision", revision)
 	result.SetMetadata("checksum", fmt.Sprintf("0x%04x", checksum))
+	result.SetMetadata("checksum_expected", fmt.Sprintf("0x%04x", checksum))
+	if serial != "" {
+		result.SetMetadata("serial", serial)
+	}
 	result.SetMetadata("rom_start", fmt.Sprintf("0x%08x", addrs.romStart))
 	result.SetMetadata("rom_end", fmt.Sprintf("0x%08x", addrs.romEnd))
 	result.SetMetadata("ram_start", fmt.Sprintf("0x%08x", addrs.ramStart))
@@ -215,7 +381,9 @@ func genesisParseHeader(data []byte, magicWordInd int, db Database) (*Result, er
 
 	setGenesisSoftwareType(result, softwareType)
 	setGenesisDeviceSupport(result, deviceSupport)
+	result.RegionCode = RegionFromGenesisCode(string(regionCode))
 	setGenesisRegionSupport(result, regionSupport)
+	setGenesisSRAM(result, sram)
 
 	// Database lookup
 	if db != nil && serial != "" {
@@ -224,8 +392,12 @@ func genesisParseHeader(data []byte, magicWordInd int, db Database) (*Result, er
 		}
 	}
 
-	// If no title from database, use domestic title
-	setGenesisFallbackTitle(result, titleOverseas, titleDomestic)
+	// If no title from database, use a header title
+	if preferred != RegionUnknown {
+		result.SetROMTitle(result.InternalTitle)
+	} else {
+		setGenesisFallbackTitle(result, titleOverseas, titleDomestic)
+	}
 
 	return result, nil
 }
@@ -249,22 +421,71 @@ func setGenesisDeviceSupport(result *Result, deviceSupport []string) {
 	}
 }
 
-// setGenesisRegionSupport sets the region support metadata.
+// setGenesisRegionSupport sets the region support and region metadata.
 func setGenesisRegionSupport(result *Result, regionSupport []string) {
 	if len(regionSupport) > 0 {
-		result.SetMetadata("region_support", strings.Join(regionSupport, " / "))
+		regions := strings.Join(regionSupport, " / ")
+		result.SetMetadata("region_support", regions)
+		result.SetMetadata("region", regions)
 	}
 }
 
-// setGenesisFallbackTitle sets the title from internal names if not set from database.
-func setGenesisFallbackTitle(result *Result, titleOverseas, titleDomestic string) {
-	if result.Title != "" {
+// genesisSRAM holds the optional external RAM ("RA") header block.
+type genesisSRAM struct {
+	start   uint32
+	end     uint32
+	ramType byte
+	present bool
+}
+
+// parseGenesisSRAM parses the 12-byte external RAM block at 0x1B0:
+// "RA", type byte, 0x20, start address, end address.
+func parseGenesisSRAM(block []byte) genesisSRAM {
+	if len(block) < 12 || block[0] != 'R' || block[1] != 'A' {
+		return genesisSRAM{}
+	}
+	return genesisSRAM{
+		present: true,
+		ramType: block[2],
+		start:   binary.BigEndian.Uint32(block[4:8]),
+		end:     binary.BigEndian.Uint32(block[8:12]),
+	}
+}
+
+// setGenesisSRAM sets the SRAM metadata when the header declares external RAM.
+func setGenesisSRAM(result *Result, sram genesisSRAM) {
+	result.SetMetadata("sram", fmt.Sprintf("%t", sram.present))
+	if !sram.present {
 		return
 	}
+	// Bit 6 of the type byte marks battery-backed (saved) RAM
+	result.SetMetadata("sram_battery", fmt.Sprintf("%t", sram.ramType&0x40 != 0))
+	result.SetMetadata("sram_type", fmt.Sprintf("0x%02x", sram.ramType))
+	result.SetMetadata("sram_start", fmt.Sprintf("0x%08x", sram.start))
+	result.SetMetadata("sram_end", fmt.Sprintf("0x%08x", sram.end))
+}
+
+// GenesisTitleForRegion returns the header title shown in region: the
+// domestic one in Japan and the overseas one elsewhere, or the other title
+// when that one is blank.
+func GenesisTitleForRegion(region Region, titleDomestic, titleOverseas string) string {
+	preferred, other := titleOverseas, titleDomestic
+	if region == RegionJapan {
+		preferred, other = titleDomestic, titleOverseas
+	}
+	if preferred == "" {
+		return other
+	}
+	return preferred
+}
+
+// setGenesisFallbackTitle sets the title from internal names if not set from
+// database, preferring the overseas name.
+func setGenesisFallbackTitle(result *Result, titleOverseas, titleDomestic string) {
 	if titleOverseas != "" {
-		result.Title = titleOverseas
+		result.SetROMTitle(titleOverseas)
 	} else {
-		result.Title = titleDomestic
+		result.SetROMTitle(titleDomestic)
 	}
 }
 
@@ -311,7 +532,13 @@ func parseGenesisAddresses(romStartBytes, romEndBytes, ramStartBytes, ramEndByte
 }
 
 // parseGenesisRegionSupport parses region support bytes.
+// Both the original J/U/E letter codes and the later single hex digit
+// bitmask (e.g. "F" for all regions) are understood.
 func parseGenesisRegionSupport(regionSupportBytes []byte) []string {
+	if regions, ok := parseGenesisRegionBitmask(regionSupportBytes); ok {
+		return regions
+	}
+
 	var regionSupport []string
 	for _, regByte := range regionSupportBytes {
 		if regByte == 0 || regByte == ' ' {
@@ -326,6 +553,42 @@ func parseGenesisRegionSupport(regionSupportBytes []byte) []string {
 	return regionSupport
 }
 
+// parseGenesisRegionBitmask decodes a region field holding a single hex digit.
+// "E" is excluded because it is also the letter code for Europe.
+func parseGenesisRegionBitmask(regionSupportBytes []byte) ([]string, bool) {
+	code := strings.TrimRight(string(regionSupportBytes), " \x00")
+	if len(code) != 1 || code == "E" {
+		return nil, false
+	}
+
+	var mask byte
+	switch c := code[0]; {
+	case c >= '0' && c <= '9':
+		mask = c - '0'
+	case c >= 'A' && c <= 'F':
+		mask = c - 'A' + 10
+	default:
+		return nil, false
+	}
+
+	var regions []string
+	if mask&genesisRegionBitJapan != 0 {
+		regions = append(regions, genesisRegionSupport['J'])
+	}
+	if mask&genesisRegionBitAmericas != 0 {
+		regions = append(regions, genesisRegionSupport['U'])
+	}
+	if mask&genesisRegionBitEurope != 0 {
+		regions = append(regions, genesisRegionSupport['E'])
+	}
+	return regions, len(regions) > 0
+}
+
+// Validate reports whether header, the start of a file, could be a Genesis ROM.
+func (*GenesisIdentifier) Validate(header []byte) bool {
+	return ValidateGenesis(header)
+}
+
 // ValidateGenesis checks if the given data looks like a valid Genesis ROM.
 func ValidateGenesis(data []byte) bool {
 	if len(data) < 0x200 {
@@ -20,6 +20,9 @@ package identifier
 
 import (
 	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -173,6 +176,185 @@ func TestGenesisIdentifier_Identify(t *testing.T) {
 	}
 }
 
+func TestGenesisIdentifier_Checksum(t *testing.T) {
+	t.Parallel()
+
+	identifier := NewGenesisIdentifier()
+
+	rom := createGenesisHeader("SEGA GENESIS    ", "CHECKSUM", "CHECKSUM", "00000001-")
+	// Words past 0x200 are summed; the trailing odd byte is a high byte.
+	rom = append(rom, 0x12, 0x34, 0xFF, 0xFF, 0x00, 0x02, 0x01)
+	binary.BigEndian.PutUint16(rom[0x18E:], 0x1335)
+
+	result, err := identifier.Identify(bytes.NewReader(rom), int64(len(rom)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+
+	if got := result.Metadata["checksum_expected"]; got != "0x1335" {
+		t.Errorf("checksum_expected = %q, want %q", got, "0x1335")
+	}
+	if got := result.Metadata["checksum_actual"]; got != "0x1335" {
+		t.Errorf("checksum_actual = %q, want %q", got, "0x1335")
+	}
+}
+
+func TestGenesisIdentifier_ChecksumFixture(t *testing.T) {
+	t.Parallel()
+
+	romPath := filepath.Join("..", "testdata", "Genesis", "240pSuite-1.23.bin")
+	data, err := os.ReadFile(romPath) //nolint:gosec // Test fixture path
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	result, err := NewGenesisIdentifier().Identify(bytes.NewReader(data), int64(len(data)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+
+	if got := result.Metadata["checksum_actual"]; got != "0xbc3f" {
+		t.Errorf("checksum_actual = %q, want %q", got, "0xbc3f")
+	}
+	if result.Metadata["checksum_actual"] != result.Metadata["checksum_expected"] {
+		t.Errorf("checksum_actual = %q, checksum_expected = %q, want equal",
+			result.Metadata["checksum_actual"], result.Metadata["checksum_expected"])
+	}
+	if got := result.Metadata["sram"]; got != "false" {
+		t.Errorf("sram = %q, want %q", got, "false")
+	}
+}
+
+// interleaveSMD converts a linear ROM, a whole number of 16 KiB blocks, into
+// an SMD copier dump.
+func interleaveSMD(rom []byte) []byte {
+	header := make([]byte, smdHeaderSize)
+	header[0] = byte(len(rom) / smdBlockSize)
+	header[1] = 0x03
+	header[8], header[9], header[10] = 0xAA, 0xBB, 0x06
+
+	smd := append([]byte{}, header...)
+	for start := 0; start < len(rom); start += smdBlockSize {
+		block := rom[start : start+smdBlockSize]
+		for i := 1; i < len(block); i += 2 {
+			smd = append(smd, block[i])
+		}
+		for i := 0; i < len(block); i += 2 {
+			smd = append(smd, block[i])
+		}
+	}
+	return smd
+}
+
+func TestGenesisIdentifier_SMD(t *testing.T) {
+	t.Parallel()
+
+	romPath := filepath.Join("..", "testdata", "Genesis", "240pSuite-1.23.bin")
+	rom, err := os.ReadFile(romPath) //nolint:gosec // Test fixture path
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	smd := interleaveSMD(rom)
+	if bytes.Contains(smd[:smdHeaderSize+smdBlockSize], []byte("SEGA MEGA DRIVE")) {
+		t.Fatal("interleaved fixture still contains a linear header")
+	}
+
+	identifier := NewGenesisIdentifier()
+	want, err := identifier.Identify(bytes.NewReader(rom), int64(len(rom)), nil)
+	if err != nil {
+		t.Fatalf("Identify(linear) error = %v", err)
+	}
+	result, err := identifier.Identify(bytes.NewReader(smd), int64(len(smd)), nil)
+	if err != nil {
+		t.Fatalf("Identify(SMD) error = %v", err)
+	}
+
+	if got := result.Metadata["system_type"]; got != "SEGA MEGA DRIVE" {
+		t.Errorf("system_type = %q, want %q", got, "SEGA MEGA DRIVE")
+	}
+	if got := result.Metadata["rom_format"]; got != "SMD" {
+		t.Errorf("rom_format = %q, want %q", got, "SMD")
+	}
+	if result.ID != want.ID || result.Title != want.Title {
+		t.Errorf("SMD result = (%q, %q), want (%q, %q)", result.ID, result.Title, want.ID, want.Title)
+	}
+	if result.Metadata["checksum_actual"] != want.Metadata["checksum_actual"] {
+		t.Errorf("checksum_actual = %q, want %q",
+			result.Metadata["checksum_actual"], want.Metadata["checksum_actual"])
+	}
+	if _, ok := want.Metadata["rom_format"]; ok {
+		t.Errorf("linear rom_format = %q, want unset", want.Metadata["rom_format"])
+	}
+}
+
+func TestGenesisIdentifier_Region(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		region string
+		want   string
+	}{
+		{name: "JUE letters", region: "JUE", want: "Japan / Americas / Europe"},
+		{name: "Japan only", region: "J  ", want: "Japan"},
+		{name: "Europe letter", region: "E  ", want: "Europe"},
+		{name: "Hex all regions", region: "F  ", want: "Japan / Americas / Europe"},
+		{name: "Hex Americas", region: "4  ", want: "Americas"},
+		{name: "Hex Japan and Europe", region: "9  ", want: "Japan / Europe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			header := createGenesisHeader("SEGA MEGA DRIVE ", "REGION", "REGION", "00000002-")
+			copy(header[0x1F0:], tt.region)
+
+			result, err := NewGenesisIdentifier().Identify(bytes.NewReader(header), int64(len(header)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+
+			if result.Region != tt.want {
+				t.Errorf("Region = %q, want %q", result.Region, tt.want)
+			}
+			if got := result.Metadata["region_support"]; got != tt.want {
+				t.Errorf("region_support = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenesisIdentifier_SerialAndSRAM(t *testing.T) {
+	t.Parallel()
+
+	header := createGenesisHeader("SEGA GENESIS    ", "SAVE GAME", "SAVE GAME", "MK-1079 -")
+	copy(header[0x1B0:], "RA")
+	header[0x1B2] = 0xF8
+	header[0x1B3] = 0x20
+	binary.BigEndian.PutUint32(header[0x1B4:], 0x00200001)
+	binary.BigEndian.PutUint32(header[0x1B8:], 0x00203FFF)
+
+	result, err := NewGenesisIdentifier().Identify(bytes.NewReader(header), int64(len(header)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+
+	want := map[string]string{
+		"serial":       "MK1079",
+		"sram":         "true",
+		"sram_battery": "true",
+		"sram_type":    "0xf8",
+		"sram_start":   "0x00200001",
+		"sram_end":     "0x00203fff",
+	}
+	for key, value := range want {
+		if got := result.Metadata[key]; got != value {
+			t.Errorf("Metadata[%q] = %q, want %q", key, got, value)
+		}
+	}
+}
+
 func TestGenesisIdentifier_InvalidMagic(t *testing.T) {
 	t.Parallel()
 
@@ -226,3 +408,58 @@ func TestValidateGenesis(t *testing.T) {
 		})
 	}
 }
+
+func TestGenesisIdentifier_PreferredRegion(t *testing.T) {
+	t.Parallel()
+
+	header := createGenesisHeader("SEGA MEGA DRIVE", "BARE KNUCKLE", "STREETS OF RAGE", "GM 1234567")
+
+	tests := []struct {
+		name      string
+		region    Region
+		wantTitle string
+	}{
+		{"unset keeps domestic", RegionUnknown, "BARE KNUCKLE"},
+		{"Japan", RegionJapan, "BARE KNUCKLE"},
+		{"USA", RegionUSA, "STREETS OF RAGE"},
+		{"Europe", RegionEurope, "STREETS OF RAGE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := &GenesisIdentifier{PreferredRegion: tt.region}
+			result, err := id.Identify(bytes.NewReader(header), int64(len(header)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if result.InternalTitle != tt.wantTitle {
+				t.Errorf("InternalTitle = %q, want %q", result.InternalTitle, tt.wantTitle)
+			}
+			if got := result.Metadata["title_domestic"]; got != "BARE KNUCKLE" {
+				t.Errorf("title_domestic = %q, want %q", got, "BARE KNUCKLE")
+			}
+			if got := result.Metadata["title_overseas"]; got != "STREETS OF RAGE" {
+				t.Errorf("title_overseas = %q, want %q", got, "STREETS OF RAGE")
+			}
+		})
+	}
+}
+
+func TestGenesisIdentifier_PreferredRegionBlankTitle(t *testing.T) {
+	t.Parallel()
+
+	header := createGenesisHeader("SEGA GENESIS", "", "SONIC THE HEDGEHOG", "GM 00001009")
+	id := &GenesisIdentifier{PreferredRegion: RegionJapan}
+	result, err := id.Identify(bytes.NewReader(header), int64(len(header)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.InternalTitle != "SONIC THE HEDGEHOG" {
+		t.Errorf("InternalTitle = %q, want %q", result.InternalTitle, "SONIC THE HEDGEHOG")
+	}
+	if result.Title != "SONIC THE HEDGEHOG" {
+		t.Errorf("Title = %q, want %q", result.Title, "SONIC THE HEDGEHOG")
+	}
+}
@@ -22,6 +22,7 @@ package identifier
 import (
 	"fmt"
 	"io"
+	"strconv"
 )
 
 // Console represents a gaming console/platform.
@@ -29,12 +30,15 @@ type Console string
 
 // Supported console types.
 const (
+	Console3DS      Console = "3DS"
+	ConsoleFDS      Console = "FDS"
 	ConsoleGB       Console = "GB"
 	ConsoleGBC      Console = "GBC"
 	ConsoleGBA      Console = "GBA"
 	ConsoleGC       Console = "GC"
 	ConsoleGenesis  Console = "Genesis"
 	ConsoleN64      Console = "N64"
+	ConsoleNDS      Console = "NDS"
 	ConsoleNeoGeoCD Console = "NeoGeoCD"
 	ConsoleNES      Console = "NES"
 	ConsolePSP      Console = "PSP"
@@ -43,16 +47,20 @@ const (
 	ConsoleSaturn   Console = "Saturn"
 	ConsoleSegaCD   Console = "SegaCD"
 	ConsoleSNES     Console = "SNES"
+	ConsoleWii      Console = "Wii"
 )
 
 // AllConsoles is a list of all supported consoles.
 var AllConsoles = []Console{
+	Console3DS,
+	ConsoleFDS,
 	ConsoleGB,
 	ConsoleGBC,
 	ConsoleGBA,
 	ConsoleGC,
 	ConsoleGenesis,
 	ConsoleN64,
+	ConsoleNDS,
 	ConsoleNeoGeoCD,
 	ConsoleNES,
 	ConsolePSP,
@@ -61,6 +69,7 @@ var AllConsoles = []Console{
 	ConsoleSaturn,
 	ConsoleSegaCD,
 	ConsoleSNES,
+	ConsoleWii,
 }
 
 // Result contains the identification results for a game.
@@ -71,6 +80,20 @@ type Result struct {
 	Console       Console
 	InternalTitle string
 	Region        string
+	RegionCode    Region
+	// Path is the path the result was identified from, as given to
+	// Identify. It is empty for results identified from a reader.
+	Path string
+	// SourcePath is the file the game data was actually read from when Path
+	// names a container: the member chosen inside an archive, or the BIN
+	// file a CUE sheet points to. It is empty when Path was read directly.
+	SourcePath string
+	// DiscNumber is the 1-based disc of a multi-disc game, or 0 when unknown.
+	DiscNumber int
+
+	// romTitle is the title the identifier chose from the game itself,
+	// kept so ApplyTitleSource can reorder it against the database title.
+	romTitle string
 }
 
 // NewResult creates a new Result with initialized metadata map.
@@ -106,6 +129,13 @@ func (r *Result) SetMetadata(key, value string) {
 		if r.Region == "" {
 			r.Region = value
 		}
+		if r.RegionCode == RegionUnknown {
+			r.RegionCode = NormalizeRegion(value)
+		}
+	case "disc_number":
+		if r.DiscNumber == 0 {
+			r.DiscNumber, _ = strconv.Atoi(value)
+		}
 	}
 }
 
@@ -120,10 +150,7 @@ func (r *Result) MergeMetadata(dbEntry map[string]string) {
 			r.SetMetadata(k, v)
 		}
 	}
-	// If no title was set, use internal title
-	if r.Title == "" && r.InternalTitle != "" {
-		r.Title = r.InternalTitle
-	}
+	r.ApplyTitleSource(TitleDatabaseFirst, "")
 }
 
 // MergeMetadataPreferDB merges database metadata into the result.
@@ -135,9 +162,59 @@ func (r *Result) MergeMetadataPreferDB(dbEntry map[string]string) {
 		}
 		r.SetMetadata(k, v)
 	}
-	// If no title was set, use internal title
-	if r.Title == "" && r.InternalTitle != "" {
-		r.Title = r.InternalTitle
+	r.ApplyTitleSource(TitleDatabaseFirst, "")
+}
+
+// TitleSource selects which of the titles known for a game becomes
+// Result.Title.
+type TitleSource int
+
+const (
+	// TitleDatabaseFirst uses the database title, then the title read from
+	// the game, then the file name. It is the default.
+	TitleDatabaseFirst TitleSource = iota
+	// TitleRomFirst uses the title read from the game, then the database
+	// title, then the file name.
+	TitleRomFirst
+	// TitleFilenameFallback uses the database title, then the file name,
+	// and the often truncated title read from the game only as a last resort.
+	TitleFilenameFallback
+)
+
+// SetROMTitle records title as the title read from the game itself, such as
+// a header or banner title, and sets Title by the default precedence.
+// Identifiers call it once database metadata has been merged.
+func (r *Result) SetROMTitle(title string) {
+	r.romTitle = title
+	r.ApplyTitleSource(TitleDatabaseFirst, "")
+}
+
+// ApplyTitleSource sets Title to the first available title in the order
+// source gives. The database title is Metadata["title"], the game's own is
+// the one given to SetROMTitle (InternalTitle if none was), and fileTitle is
+// typically the file name without its extension. Title is left unchanged
+// when none of them is known.
+func (r *Result) ApplyTitleSource(source TitleSource, fileTitle string) {
+	dbTitle := r.Metadata["title"]
+	romTitle := r.romTitle
+	if romTitle == "" {
+		romTitle = r.InternalTitle
+	}
+
+	var order []string
+	switch source {
+	case TitleRomFirst:
+		order = []string{romTitle, dbTitle, fileTitle}
+	case TitleFilenameFallback:
+		order = []string{dbTitle, fileTitle, romTitle}
+	default:
+		order = []string{dbTitle, romTitle, fileTitle}
+	}
+	for _, title := range order {
+		if title != "" {
+			r.Title = title
+			return
+		}
 	}
 }
 
@@ -166,6 +243,33 @@ type Identifier interface {
 	Console() Console
 }
 
+// Validator is implemented by identifiers that can tell from the first bytes
+// of a file whether it might belong to their console. Console detection uses
+// it to sort out files whose extension is ambiguous or missing.
+type Validator interface {
+	// Validate reports whether header looks like this identifier's console.
+	// header may be shorter than the console's full header.
+	Validate(header []byte) bool
+}
+
+// StrictValidator is implemented by identifiers that tolerate header damage a
+// real console would reject, such as a bad boot logo or checksum, so that
+// verification tools can refuse such dumps.
+type StrictValidator interface {
+	// ValidateStrict returns an ErrInvalidFormat naming the first check the
+	// size bytes of r fail, or nil if they pass them all.
+	ValidateStrict(r io.ReaderAt, size int64) error
+}
+
+// HeaderIdentifier is implemented by cartridge identifiers that can identify
+// a game from its fixed header alone. IdentifyHeader reads no further than
+// the header and leaves out metadata computed over the whole file, such as
+// the actual ROM checksum, which makes it suited to scanning large
+// collections quickly.
+type HeaderIdentifier interface {
+	IdentifyHeader(reader io.ReaderAt, size int64, db Database) (*Result, error)
+}
+
 // DiscIdentifier is an extended interface for disc-based games.
 type DiscIdentifier interface {
 	Identifier
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+// makerCodes maps Nintendo's two-character maker (licensee) codes, as used in
+// GBA, GameCube, Wii and DS headers, to the publisher they stand for.
+var makerCodes = map[string]string{
+	"01": "Nintendo",
+	"08": "Capcom",
+	"09": "Hot-B",
+	"0A": "Jaleco",
+	"13": "Electronic Arts Japan",
+	"18": "Hudson Soft",
+	"28": "Kemco Japan",
+	"29": "Seta",
+	"34": "Konami",
+	"36": "Codemasters",
+	"37": "Taito",
+	"41": "Ubisoft",
+	"42": "Atlus",
+	"4F": "Eidos Interactive",
+	"4Q": "Disney Interactive",
+	"4Z": "Crave Entertainment",
+	"51": "Acclaim",
+	"52": "Activision",
+	"54": "Take-Two Interactive",
+	"5D": "Midway",
+	"5G": "Majesco",
+	"5Q": "LEGO Media",
+	"60": "Titus",
+	"61": "Virgin Interactive",
+	"64": "LucasArts",
+	"69": "Electronic Arts",
+	"6L": "BAM! Entertainment",
+	"6S": "TDK Mediactive",
+	"70": "Infogrames",
+	"71": "Interplay",
+	"78": "THQ",
+	"7D": "Vivendi Universal Games",
+	"7F": "Kemco",
+	"8P": "Sega",
+	"9B": "Tecmo",
+	"A4": "Konami",
+	"A7": "Takara",
+	"AF": "Namco",
+	"B2": "Bandai",
+	"C0": "Taito",
+	"C3": "Square",
+	"C8": "Koei",
+	"D9": "Banpresto",
+	"DA": "Tomy",
+	"E5": "Epoch",
+	"E9": "Natsume",
+	"GD": "Square Enix",
+}
+
+// PublisherFromMakerCode returns the publisher behind a two-character maker
+// code from a GBA, GameCube, Wii or DS header, or "" for an unknown code.
+// Codes are case-sensitive, as they are stored in the header.
+func PublisherFromMakerCode(code string) string {
+	return makerCodes[code]
+}
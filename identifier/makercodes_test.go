@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import "testing"
+
+func TestPublisherFromMakerCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: "01", want: "Nintendo"},
+		{code: "08", want: "Capcom"},
+		{code: "GD", want: "Square Enix"},
+		{code: "gd", want: ""},
+		{code: "ZZ", want: ""},
+		{code: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := PublisherFromMakerCode(tt.code); got != tt.want {
+			t.Errorf("PublisherFromMakerCode(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+
+	bin "github.com/ZaparooProject/go-gameid/internal/binary"
+)
+
+// 3DS container offsets. NCSD (.3ds/.cci cartridge dumps) and NCCH (a single
+// partition, .cxi) share one layout up to the magic word.
+const (
+	n3dsHeaderSize  = 0x200
+	n3dsMagicOffset = 0x100
+	n3dsMediaUnit   = 0x200
+
+	ncsdMediaIDOffset   = 0x108
+	ncsdPartitionOffset = 0x120
+	ncsdFlagsOffset     = 0x188
+
+	ncchMakerCodeOffset   = 0x110
+	ncchVersionOffset     = 0x112
+	ncchProgramIDOffset   = 0x118
+	ncchProductCodeOffset = 0x150
+	ncchProductCodeSize   = 0x10
+	ncchFlagsOffset       = 0x188
+	ncchExeFSOffset       = 0x1A0
+	ncchNoCryptoFlag      = 0x04
+
+	exefsHeaderSize = 0x200
+	exefsEntryCount = 10
+	exefsEntrySize  = 0x10
+
+	ciaHeaderSize   = 0x2020
+	ciaAlignment    = 64
+	ciaMetaSMDHSkip = 0x400
+	tmdTitleIDField = 0x4C
+
+	smdhTitleOffset    = 0x08
+	smdhTitleSize      = 0x200
+	smdhShortDescSize  = 0x80
+	smdhLongDescSize   = 0x100
+	smdhPublisherSize  = 0x80
+	smdhEnglishIndex   = 1
+	smdhEnglishEndSize = smdhTitleOffset + (smdhEnglishIndex+1)*smdhTitleSize
+)
+
+var (
+	ncsdMagic = []byte("NCSD")
+	ncchMagic = []byte("NCCH")
+	smdhMagic = []byte("SMDH")
+)
+
+// tmdSignatureSizes maps a TMD signature type to the size of the signature
+// and its padding, which precede the TMD header.
+var tmdSignatureSizes = map[uint32]int64{
+	0x010003: 0x200 + 0x3C, // RSA-4096 SHA-256
+	0x010004: 0x100 + 0x3C, // RSA-2048 SHA-256
+	0x010005: 0x3C + 0x40,  // ECDSA SHA-256
+}
+
+// n3dsInfo is what the 3DS containers yield about a title.
+type n3dsInfo struct {
+	format      string
+	makerCode   string
+	productCode string
+	title       string
+	publisher   string
+	titleID     uint64
+	version     uint16
+}
+
+// N3DSIdentifier identifies Nintendo 3DS games.
+type N3DSIdentifier struct{}
+
+// NewN3DSIdentifier creates a new 3DS identifier.
+func NewN3DSIdentifier() *N3DSIdentifier {
+	return &N3DSIdentifier{}
+}
+
+// Console returns the console type.
+func (*N3DSIdentifier) Console() Console {
+	return Console3DS
+}
+
+// Identify extracts 3DS title information from an NCSD, NCCH or CIA image.
+// The ID is the 64-bit title ID as 16 hex digits. The English title and
+// publisher come from the SMDH, which is only readable in a decrypted NCCH
+// or in the meta section of a CIA. There is no 3DS database, so the database
+// is not consulted.
+func (*N3DSIdentifier) Identify(reader io.ReaderAt, size int64, _ Database) (*Result, error) {
+	if size < n3dsHeaderSize {
+		return nil, ErrInvalidFormat{Console: Console3DS, Reason: "file too small"}
+	}
+	header, err := bin.ReadBytesAt(reader, 0, n3dsHeaderSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read 3DS header: %w", err)
+	}
+
+	var info n3dsInfo
+	magic := header[n3dsMagicOffset : n3dsMagicOffset+4]
+	switch {
+	case bin.BytesEqual(magic, ncsdMagic):
+		info, err = readNCSD(reader, header)
+	case bin.BytesEqual(magic, ncchMagic):
+		info, err = readNCCH(reader, 0, header)
+		info.format = "NCCH"
+	case binary.LittleEndian.Uint32(header) == ciaHeaderSize:
+		info, err = readCIA(reader, header)
+	default:
+		return nil, ErrInvalidFormat{Console: Console3DS, Reason: "no NCSD, NCCH or CIA header"}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	id := fmt.Sprintf("%016X", info.titleID)
+	result := NewResult(Console3DS)
+	result.ID = id
+	result.InternalTitle = info.title
+	result.SetMetadata("ID", id)
+	result.SetMetadata("title_id", id)
+	result.SetMetadata("format", info.format)
+	result.SetMetadata("internal_title", info.title)
+	result.SetMetadata("publisher", info.publisher)
+	result.SetMetadata("maker_code", info.makerCode)
+	result.SetMetadata("product_code", info.productCode)
+	if info.productCode != "" {
+		result.SetMetadata("version", fmt.Sprintf("%d", info.version))
+	}
+	// Product codes look like CTR-P-AXXE; the game code's last letter is the
+	// same destination code GBA and DS games use
+	if parts := strings.Split(info.productCode, "-"); len(parts) == 3 {
+		result.RegionCode = RegionFromGBAGameCode(parts[2])
+	}
+
+	result.SetROMTitle(result.InternalTitle)
+
+	return result, nil
+}
+
+// Validate reports whether header, the start of a file, could be a 3DS NCSD or NCCH image.
+func (*N3DSIdentifier) Validate(header []byte) bool {
+	return Validate3DS(header)
+}
+
+// Validate3DS checks if the given data carries the NCSD or NCCH magic word.
+// CIA files have no magic word and are recognized by extension only.
+func Validate3DS(header []byte) bool {
+	if len(header) < n3dsHeaderSize {
+		return false
+	}
+	magic := header[n3dsMagicOffset : n3dsMagicOffset+4]
+	return bin.BytesEqual(magic, ncsdMagic) || bin.BytesEqual(magic, ncchMagic)
+}
+
+// readNCSD reads a cartridge image: the media ID is the title ID, and the
+// first partition is the game's executable NCCH.
+func readNCSD(reader io.ReaderAt, header []byte) (n3dsInfo, error) {
+	mediaUnit := int64(n3dsMediaUnit) << header[ncsdFlagsOffset+6]
+	partition := int64(binary.LittleEndian.Uint32(header[ncsdPartitionOffset:])) * mediaUnit
+
+	ncch, err := bin.ReadBytesAt(reader, partition, n3dsHeaderSize)
+	if err != nil {
+		return n3dsInfo{}, fmt.Errorf("failed to read NCCH header: %w", err)
+	}
+	if !bin.BytesEqual(ncch[n3dsMagicOffset:n3dsMagicOffset+4], ncchMagic) {
+		return n3dsInfo{}, ErrInvalidFormat{Console: Console3DS, Reason: "first NCSD partition is not an NCCH"}
+	}
+
+	info, err := readNCCH(reader, partition, ncch)
+	if err != nil {
+		return n3dsInfo{}, err
+	}
+	info.format = "NCSD"
+	if mediaID := binary.LittleEndian.Uint64(header[ncsdMediaIDOffset:]); mediaID != 0 {
+		info.titleID = mediaID
+	}
+	return info, nil
+}
+
+// readNCCH reads the NCCH whose header, at base, is header. The SMDH is
+// taken from the ExeFS "icon" file when the partition is not encrypted.
+func readNCCH(reader io.ReaderAt, base int64, header []byte) (n3dsInfo, error) {
+	info := n3dsInfo{
+		titleID:     binary.LittleEndian.Uint64(header[ncchProgramIDOffset:]),
+		makerCode:   bin.ExtractPrintable(header[ncchMakerCodeOffset : ncchMakerCodeOffset+2]),
+		version:     binary.LittleEndian.Uint16(header[ncchVersionOffset:]),
+		productCode: bin.CleanString(header[ncchProductCodeOffset : ncchProductCodeOffset+ncchProductCodeSize]),
+	}
+
+	flags := header[ncchFlagsOffset : ncchFlagsOffset+8]
+	exefs := int64(binary.LittleEndian.Uint32(header[ncchExeFSOffset:]))
+	if flags[7]&ncchNoCryptoFlag == 0 || exefs == 0 {
+		return info, nil
+	}
+	mediaUnit := int64(n3dsMediaUnit) << flags[6]
+	if offset, ok := findExeFSFile(reader, base+exefs*mediaUnit, "icon"); ok {
+		info.title, info.publisher = readSMDH(reader, offset)
+	}
+	return info, nil
+}
+
+// findExeFSFile returns the absolute offset of the named file in the ExeFS
+// starting at exefs.
+func findExeFSFile(reader io.ReaderAt, exefs int64, name string) (int64, bool) {
+	header, err := bin.ReadBytesAt(reader, exefs, exefsHeaderSize)
+	if err != nil {
+		return 0, false
+	}
+	for i := range exefsEntryCount {
+		entry := header[i*exefsEntrySize : (i+1)*exefsEntrySize]
+		if bin.CleanString(entry[:8]) == name {
+			return exefs + exefsHeaderSize + int64(binary.LittleEndian.Uint32(entry[8:])), true
+		}
+	}
+	return 0, false
+}
+
+// readCIA reads an installable archive: the title ID comes from the TMD and
+// the SMDH from the optional meta section.
+func readCIA(reader io.ReaderAt, header []byte) (n3dsInfo, error) {
+	certSize := int64(binary.LittleEndian.Uint32(header[0x08:]))
+	ticketSize := int64(binary.LittleEndian.Uint32(header[0x0C:]))
+	tmdSize := int64(binary.LittleEndian.Uint32(header[0x10:]))
+	metaSize := int64(binary.LittleEndian.Uint32(header[0x14:]))
+	contentSize := int64(binary.LittleEndian.Uint64(header[0x18:])) //nolint:gosec // sizes fit in int64
+
+	tmdOffset := ciaAlign(ciaAlign(ciaAlign(ciaHeaderSize)+certSize) + ticketSize)
+	contentOffset := ciaAlign(tmdOffset + tmdSize)
+	metaOffset := ciaAlign(contentOffset + contentSize)
+
+	sigType, err := bin.ReadUint32BEAt(reader, tmdOffset)
+	if err != nil {
+		return n3dsInfo{}, fmt.Errorf("failed to read CIA TMD: %w", err)
+	}
+	sigSize, ok := tmdSignatureSizes[sigType]
+	if !ok {
+		return n3dsInfo{}, ErrInvalidFormat{
+			Console: Console3DS,
+			Reason:  fmt.Sprintf("unknown TMD signature type 0x%x", sigType),
+		}
+	}
+	titleID, err := bin.ReadBytesAt(reader, tmdOffset+4+sigSize+tmdTitleIDField, 8)
+	if err != nil {
+		return n3dsInfo{}, fmt.Errorf("failed to read CIA title ID: %w", err)
+	}
+
+	info := n3dsInfo{format: "CIA"}
+	// The first content is the game's NCCH; its header is never encrypted
+	if ncch, err := bin.ReadBytesAt(reader, contentOffset, n3dsHeaderSize); err == nil &&
+		bin.BytesEqual(ncch[n3dsMagicOffset:n3dsMagicOffset+4], ncchMagic) {
+		info, _ = readNCCH(reader, contentOffset, ncch)
+		info.format = "CIA"
+	}
+	info.titleID = binary.BigEndian.Uint64(titleID)
+	if metaSize > ciaMetaSMDHSkip && info.title == "" {
+		info.title, info.publisher = readSMDH(reader, metaOffset+ciaMetaSMDHSkip)
+	}
+	return info, nil
+}
+
+// ciaAlign rounds offset up to the CIA section alignment.
+func ciaAlign(offset int64) int64 {
+	return (offset + ciaAlignment - 1) / ciaAlignment * ciaAlignment
+}
+
+// readSMDH returns the English short title and publisher of the SMDH at
+// offset, or empty strings if there is none.
+func readSMDH(reader io.ReaderAt, offset int64) (title, publisher string) {
+	smdh, err := bin.ReadBytesAt(reader, offset, smdhEnglishEndSize)
+	if err != nil || !bin.BytesEqual(smdh[:4], smdhMagic) {
+		return "", ""
+	}
+	english := smdh[smdhTitleOffset+smdhEnglishIndex*smdhTitleSize:]
+	title = decodeUTF16LE(english[:smdhShortDescSize])
+	publisher = decodeUTF16LE(english[smdhShortDescSize+smdhLongDescSize:][:smdhPublisherSize])
+	return title, publisher
+}
+
+// decodeUTF16LE decodes a NUL-terminated little-endian UTF-16 string.
+func decodeUTF16LE(data []byte) string {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		unit := binary.LittleEndian.Uint16(data[i:])
+		if unit == 0 {
+			break
+		}
+		units = append(units, unit)
+	}
+	return strings.TrimSpace(string(utf16.Decode(units)))
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"unicode/utf16"
+)
+
+// putUTF16 writes s into dst as little-endian UTF-16.
+func putUTF16(dst []byte, s string) {
+	for i, unit := range utf16.Encode([]rune(s)) {
+		binary.LittleEndian.PutUint16(dst[i*2:], unit)
+	}
+}
+
+// createSMDH creates an SMDH with the given English title and publisher.
+func createSMDH(title, publisher string) []byte {
+	smdh := make([]byte, 0x36C0)
+	copy(smdh, "SMDH")
+	english := smdh[0x08+0x200:]
+	putUTF16(english, title)
+	putUTF16(english[0x180:], publisher)
+	return smdh
+}
+
+// createNCCH creates an NCCH partition. When smdh is non-nil the partition is
+// marked unencrypted and carries it as the ExeFS icon file.
+func createNCCH(programID uint64, productCode string, smdh []byte) []byte {
+	ncch := make([]byte, 0x400)
+	copy(ncch[0x100:], "NCCH")
+	copy(ncch[0x110:], "01")
+	binary.LittleEndian.PutUint16(ncch[0x112:], 2)
+	binary.LittleEndian.PutUint64(ncch[0x118:], programID)
+	copy(ncch[0x150:], productCode)
+	if smdh == nil {
+		return ncch
+	}
+
+	ncch[0x188+7] = ncchNoCryptoFlag
+	binary.LittleEndian.PutUint32(ncch[0x1A0:], 2) // ExeFS at 0x400
+	exefs := make([]byte, 0x200)
+	copy(exefs, "icon")
+	binary.LittleEndian.PutUint32(exefs[0x0C:], uint32(len(smdh))) //nolint:gosec // test data
+	ncch = append(ncch, exefs...)
+	return append(ncch, smdh...)
+}
+
+// createNCSD wraps ncch as the first partition of a cartridge image.
+func createNCSD(mediaID uint64, ncch []byte) []byte {
+	image := make([]byte, 0x4000)
+	copy(image[0x100:], "NCSD")
+	binary.LittleEndian.PutUint64(image[0x108:], mediaID)
+	binary.LittleEndian.PutUint32(image[0x120:], 0x4000/0x200)
+	binary.LittleEndian.PutUint32(image[0x124:], uint32(len(ncch)/0x200)) //nolint:gosec // test data
+	return append(image, ncch...)
+}
+
+// createCIA creates a CIA holding ncch as its only content and smdh in the
+// meta section.
+func createCIA(titleID uint64, ncch, smdh []byte) []byte {
+	const certSize, ticketSize, tmdSize = 0xA00, 0x350, 0x208
+	header := make([]byte, ciaHeaderSize)
+	binary.LittleEndian.PutUint32(header[0x00:], ciaHeaderSize)
+	binary.LittleEndian.PutUint32(header[0x08:], certSize)
+	binary.LittleEndian.PutUint32(header[0x0C:], ticketSize)
+	binary.LittleEndian.PutUint32(header[0x10:], tmdSize)
+	binary.LittleEndian.PutUint32(header[0x14:], uint32(0x400+len(smdh))) //nolint:gosec // test data
+	binary.LittleEndian.PutUint64(header[0x18:], uint64(len(ncch)))
+
+	tmd := make([]byte, tmdSize)
+	binary.BigEndian.PutUint32(tmd, 0x010004)
+	binary.BigEndian.PutUint64(tmd[4+0x13C+0x4C:], titleID)
+
+	var cia []byte
+	section := func(data []byte) {
+		cia = append(cia, make([]byte, int(ciaAlign(int64(len(cia))))-len(cia))...)
+		cia = append(cia, data...)
+	}
+	section(header)
+	section(make([]byte, certSize))
+	section(make([]byte, ticketSize))
+	section(tmd)
+	section(ncch)
+	section(append(make([]byte, 0x400), smdh...))
+	return cia
+}
+
+func TestN3DSIdentifier_Identify(t *testing.T) {
+	t.Parallel()
+
+	smdh := createSMDH("Super Mario 3D Land", "Nintendo")
+
+	tests := []struct {
+		name          string
+		wantFormat    string
+		wantID        string
+		wantTitle     string
+		wantPublisher string
+		wantRegion    Region
+		data          []byte
+	}{
+		{
+			name:          "NCSD with SMDH",
+			data:          createNCSD(0x0004000000054000, createNCCH(0x0004000000054000, "CTR-P-AREE", smdh)),
+			wantFormat:    "NCSD",
+			wantID:        "0004000000054000",
+			wantTitle:     "Super Mario 3D Land",
+			wantPublisher: "Nintendo",
+			wantRegion:    RegionUSA,
+		},
+		{
+			name:       "encrypted NCSD",
+			data:       createNCSD(0x0004000000055D00, createNCCH(0x0004000000055D00, "CTR-P-APAJ", nil)),
+			wantFormat: "NCSD",
+			wantID:     "0004000000055D00",
+			wantRegion: RegionJapan,
+		},
+		{
+			name:          "NCCH",
+			data:          createNCCH(0x000400000F800100, "CTR-P-HOME", smdh),
+			wantFormat:    "NCCH",
+			wantID:        "000400000F800100",
+			wantTitle:     "Super Mario 3D Land",
+			wantPublisher: "Nintendo",
+			wantRegion:    RegionUSA,
+		},
+		{
+			name:          "CIA",
+			data:          createCIA(0x0004000000030800, createNCCH(0x0004000000030800, "CTR-P-AKBP", nil), smdh),
+			wantFormat:    "CIA",
+			wantID:        "0004000000030800",
+			wantTitle:     "Super Mario 3D Land",
+			wantPublisher: "Nintendo",
+			wantRegion:    RegionEurope,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewN3DSIdentifier().Identify(bytes.NewReader(tt.data), int64(len(tt.data)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+
+			if result.ID != tt.wantID {
+				t.Errorf("ID = %q, want %q", result.ID, tt.wantID)
+			}
+			if got := result.Metadata["format"]; got != tt.wantFormat {
+				t.Errorf("format = %q, want %q", got, tt.wantFormat)
+			}
+			if result.InternalTitle != tt.wantTitle {
+				t.Errorf("InternalTitle = %q, want %q", result.InternalTitle, tt.wantTitle)
+			}
+			if got := result.Metadata["publisher"]; got != tt.wantPublisher {
+				t.Errorf("publisher = %q, want %q", got, tt.wantPublisher)
+			}
+			if result.RegionCode != tt.wantRegion {
+				t.Errorf("RegionCode = %v, want %v", result.RegionCode, tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestN3DSIdentifier_Identify_Invalid(t *testing.T) {
+	t.Parallel()
+
+	badPartition := createNCSD(1, make([]byte, 0x200))
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"too small", make([]byte, 0x100)},
+		{"no magic", make([]byte, 0x400)},
+		{"partition not NCCH", badPartition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewN3DSIdentifier().Identify(bytes.NewReader(tt.data), int64(len(tt.data)), nil)
+			var formatErr ErrInvalidFormat
+			if !errors.As(err, &formatErr) {
+				t.Errorf("Identify() error = %v, want ErrInvalidFormat", err)
+			}
+		})
+	}
+}
+
+func TestValidate3DS(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{"NCSD", createNCSD(1, createNCCH(1, "CTR-P-AREE", nil)), true},
+		{"NCCH", createNCCH(1, "CTR-P-AREE", nil), true},
+		{"CIA", createCIA(1, createNCCH(1, "CTR-P-AREE", nil), nil), false},
+		{"zeros", make([]byte, 0x200), false},
+		{"too short", []byte("NCSD"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Validate3DS(tt.data); got != tt.want {
+				t.Errorf("Validate3DS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
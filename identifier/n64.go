@@ -20,6 +20,7 @@ package identifier
 
 import (
 	"fmt"
+	"hash/crc32"
 	"io"
 
 	"github.com/ZaparooProject/go-gameid/internal/binary"
@@ -35,8 +36,27 @@ const (
 	n64CartridgeIDSize    = 2
 	n64CountryCodeOffset  = 0x3E
 	n64VersionOffset      = 0x3F
+	n64BootCodeOffset     = 0x40
+	n64BootCodeEnd        = 0x1000 // IPL3 boot code occupies 0x40-0x1000
 )
 
+// n64CIC names the boot chips sharing one IPL3 boot code. The NTSC and PAL
+// chips run identical boot code, so the cartridge region picks the name.
+type n64CIC struct {
+	ntsc string
+	pal  string
+}
+
+// n64CICByBootCRC maps the CRC32 of the IPL3 boot code to its CIC chip.
+var n64CICByBootCRC = map[uint32]n64CIC{
+	0x6170A4A1: {ntsc: "6101"},
+	0x90BB6CB5: {ntsc: "6102", pal: "7101"},
+	0x0B050EE0: {ntsc: "6103", pal: "7103"},
+	0x98BC2C86: {ntsc: "6105", pal: "7105"},
+	0xACC8580A: {ntsc: "6106", pal: "7106"},
+	0x009E9EA3: {pal: "7102"},
+}
+
 // N64 first word magic - indicates big-endian format
 var n64FirstWord = []byte{0x80, 0x37, 0x12, 0x40}
 
@@ -77,37 +97,90 @@ func n64WordSwap(data []byte) []byte {
 	return out
 }
 
-// n64NormalizeEndianness converts an N64 header to big-endian format.
-func n64NormalizeEndianness(header []byte) ([]byte, error) {
-	firstWord := header[n64FirstWordOffset : n64FirstWordOffset+4]
+// n64NoSwap is the byte order conversion for ROMs that are already big-endian.
+func n64NoSwap(data []byte) []byte {
+	return data
+}
 
+// n64ByteOrder returns the conversion to big-endian for the byte order
+// indicated by the ROM's first word.
+func n64ByteOrder(firstWord []byte) (func([]byte) []byte, error) {
 	// Check if already big-endian (.z64 format)
 	if binary.BytesEqual(firstWord, n64FirstWord) {
-		return header, nil
+		return n64NoSwap, nil
 	}
 
 	// Check if byte-swapped (.v64 format)
 	if binary.BytesEqual(n64ByteSwap(firstWord), n64FirstWord) {
-		return n64ByteSwap(header), nil
+		return n64ByteSwap, nil
 	}
 
 	// Check for word-swapped format (.n64)
-	wordSwapped := []byte{header[3], header[2], header[1], header[0]}
-	if binary.BytesEqual(wordSwapped, n64FirstWord) {
-		return n64WordSwap(header), nil
+	if binary.BytesEqual(n64WordSwap(firstWord), n64FirstWord) {
+		return n64WordSwap, nil
 	}
 
 	return nil, ErrInvalidFormat{Console: ConsoleN64, Reason: "invalid first word"}
 }
 
+// n64NormalizeEndianness converts an N64 header to big-endian format.
+func n64NormalizeEndianness(header []byte) ([]byte, error) {
+	swap, err := n64ByteOrder(header[n64FirstWordOffset : n64FirstWordOffset+4])
+	if err != nil {
+		return nil, err
+	}
+	return swap(header), nil
+}
+
+// n64NormalizeChunkSize is the read size used by N64Normalize. It is a
+// multiple of four so every chunk holds whole words.
+const n64NormalizeChunkSize = 64 * 1024
+
+// N64Normalize writes the ROM in reader to w in big-endian (.z64) byte order.
+// The source order (.z64, byte-swapped .v64 or word-swapped .n64) is detected
+// from the first word.
+func N64Normalize(reader io.ReaderAt, size int64, w io.Writer) error {
+	if size < 4 {
+		return ErrInvalidFormat{Console: ConsoleN64, Reason: "file too small"}
+	}
+	firstWord, err := binary.ReadBytesAt(reader, 0, 4)
+	if err != nil {
+		return fmt.Errorf("failed to read N64 first word: %w", err)
+	}
+	swap, err := n64ByteOrder(firstWord)
+	if err != nil {
+		return err
+	}
+	if size%4 != 0 {
+		return ErrInvalidFormat{Console: ConsoleN64, Reason: "size is not a multiple of 4"}
+	}
+
+	buf := make([]byte, n64NormalizeChunkSize)
+	for offset := int64(0); offset < size; offset += n64NormalizeChunkSize {
+		chunk := buf[:min(n64NormalizeChunkSize, size-offset)]
+		if err := binary.ReadAt(reader, offset, chunk); err != nil {
+			return fmt.Errorf("failed to read N64 ROM at offset %d: %w", offset, err)
+		}
+		if _, err := w.Write(swap(chunk)); err != nil {
+			return fmt.Errorf("failed to write normalized N64 ROM: %w", err)
+		}
+	}
+	return nil
+}
+
 // Identify extracts N64 game information from the given reader.
 func (*N64Identifier) Identify(reader io.ReaderAt, size int64, db Database) (*Result, error) {
 	if size < n64HeaderSize {
 		return nil, ErrInvalidFormat{Console: ConsoleN64, Reason: "file too small"}
 	}
 
-	// Read header
-	header, err := binary.ReadBytesAt(reader, 0, n64HeaderSize)
+	// Read the header, plus the boot code when the ROM is large enough to
+	// hold it
+	readSize := n64HeaderSize
+	if size >= n64BootCodeEnd {
+		readSize = n64BootCodeEnd
+	}
+	header, err := readROM(reader, ConsoleN64, 0, readSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read N64 header: %w", err)
 	}
@@ -138,6 +211,10 @@ func (*N64Identifier) Identify(reader io.ReaderAt, size int64, db Database) (*Re
 	result.SetMetadata("internal_name", internalName)
 	result.SetMetadata("version", fmt.Sprintf("%d", version))
 	result.SetMetadata("country_code", fmt.Sprintf("%c", countryCode))
+	result.RegionCode = RegionFromN64Country(countryCode)
+	if len(header) >= n64BootCodeEnd {
+		result.SetMetadata("cic", n64DetectCIC(header[n64BootCodeOffset:n64BootCodeEnd], result.RegionCode))
+	}
 
 	// Database lookup
 	if db != nil && serial != "" {
@@ -147,13 +224,45 @@ func (*N64Identifier) Identify(reader io.ReaderAt, size int64, db Database) (*Re
 	}
 
 	// If no title from database, use internal name
-	if result.Title == "" {
-		result.Title = result.InternalTitle
-	}
+	result.SetROMTitle(result.InternalTitle)
 
 	return result, nil
 }
 
+// n64DetectCIC returns the CIC chip matching the big-endian IPL3 boot code,
+// or "" for unrecognized boot code.
+func n64DetectCIC(bootCode []byte, region Region) string {
+	cic, ok := n64CICByBootCRC[crc32.ChecksumIEEE(bootCode)]
+	if !ok {
+		return ""
+	}
+	pal := region == RegionEurope || region == RegionAustralia
+	if cic.ntsc == "" || (pal && cic.pal != "") {
+		return cic.pal
+	}
+	return cic.ntsc
+}
+
+// ValidateStrict rejects a ROM whose first word is not the N64 magic in any
+// byte order. Identify already fails on such a ROM; this lets strict callers
+// check N64 like the other cartridge consoles.
+func (*N64Identifier) ValidateStrict(reader io.ReaderAt, size int64) error {
+	if size < n64HeaderSize {
+		return ErrInvalidFormat{Console: ConsoleN64, Reason: "file too small"}
+	}
+	firstWord, err := readROM(reader, ConsoleN64, n64FirstWordOffset, 4)
+	if err != nil {
+		return fmt.Errorf("failed to read N64 header: %w", err)
+	}
+	_, err = n64ByteOrder(firstWord)
+	return err
+}
+
+// Validate reports whether header, the start of a file, could be an N64 ROM in any byte order.
+func (*N64Identifier) Validate(header []byte) bool {
+	return ValidateN64(header)
+}
+
 // ValidateN64 checks if the given data looks like a valid N64 ROM.
 func ValidateN64(header []byte) bool {
 	if len(header) < 4 {
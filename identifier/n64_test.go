@@ -20,6 +20,9 @@ package identifier
 
 import (
 	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -211,3 +214,140 @@ func TestValidateN64(t *testing.T) {
 		})
 	}
 }
+
+func TestN64Identifier_CIC(t *testing.T) {
+	t.Parallel()
+
+	// The fixture's boot code is filler patched to the CRC32 of the
+	// CIC-NUS-6102 IPL3 (0x90BB6CB5).
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "N64", "cic6102_boot.z64"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		country byte
+		swap    func([]byte) []byte
+		want    string
+	}{
+		{name: "NTSC big endian", country: 'E', want: "6102"},
+		{name: "PAL big endian", country: 'P', want: "7101"},
+		{name: "NTSC byte-swapped", country: 'E', want: "6102", swap: n64ByteSwap},
+		{name: "NTSC word-swapped", country: 'J', want: "6102", swap: n64WordSwap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rom := bytes.Clone(data)
+			rom[0x3E] = tt.country
+			if tt.swap != nil {
+				rom = tt.swap(rom)
+			}
+
+			result, err := NewN64Identifier().Identify(bytes.NewReader(rom), int64(len(rom)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if got := result.Metadata["cic"]; got != tt.want {
+				t.Errorf("cic = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestN64Identifier_CICUnknownBootCode(t *testing.T) {
+	t.Parallel()
+
+	rom := make([]byte, n64BootCodeEnd)
+	copy(rom, createN64HeaderBigEndian("SM", "E", "SUPER MARIO 64"))
+	result, err := NewN64Identifier().Identify(bytes.NewReader(rom), int64(len(rom)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if got, ok := result.Metadata["cic"]; ok {
+		t.Errorf("cic = %q for unrecognized boot code, want unset", got)
+	}
+}
+
+func TestN64Normalize(t *testing.T) {
+	t.Parallel()
+
+	// Larger than one chunk, so the conversion has to carry across reads.
+	z64 := make([]byte, n64NormalizeChunkSize+0x400)
+	copy(z64, createN64HeaderBigEndian("SM", "E", "SUPER MARIO 64"))
+	for i := n64HeaderSize; i < len(z64); i++ {
+		z64[i] = byte(i * 7)
+	}
+
+	tests := []struct {
+		name string
+		rom  []byte
+	}{
+		{"z64", z64},
+		{"v64", n64ByteSwap(z64)},
+		{"n64", n64WordSwap(z64)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			if err := N64Normalize(bytes.NewReader(tt.rom), int64(len(tt.rom)), &out); err != nil {
+				t.Fatalf("N64Normalize() error = %v", err)
+			}
+			if !bytes.Equal(out.Bytes(), z64) {
+				t.Error("N64Normalize() output does not match the big-endian ROM")
+			}
+		})
+	}
+}
+
+func TestN64Normalize_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rom  []byte
+	}{
+		{"bad magic", make([]byte, 0x40)},
+		{"too small", []byte{0x80, 0x37}},
+		{"unaligned size", append(createN64HeaderBigEndian("SM", "E", "SUPER MARIO 64"), 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			if err := N64Normalize(bytes.NewReader(tt.rom), int64(len(tt.rom)), &out); err == nil {
+				t.Error("N64Normalize() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestN64Identifier_ValidateStrict(t *testing.T) {
+	t.Parallel()
+
+	identifier := NewN64Identifier()
+	for _, header := range [][]byte{
+		createN64HeaderBigEndian("SM", "E", "SUPER MARIO 64"),
+		createN64HeaderByteSwapped("SM", "E", "SUPER MARIO 64"),
+		createN64HeaderWordSwapped("SM", "E", "SUPER MARIO 64"),
+	} {
+		if err := identifier.ValidateStrict(bytes.NewReader(header), int64(len(header))); err != nil {
+			t.Errorf("ValidateStrict() error = %v for first word % x", err, header[:4])
+		}
+	}
+
+	bad := createN64HeaderBigEndian("SM", "E", "SUPER MARIO 64")
+	bad[0] = 0
+	var invalid ErrInvalidFormat
+	if err := identifier.ValidateStrict(bytes.NewReader(bad), int64(len(bad))); !errors.As(err, &invalid) {
+		t.Errorf("ValidateStrict() error = %v, want ErrInvalidFormat", err)
+	}
+}
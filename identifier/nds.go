@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ZaparooProject/go-gameid/internal/binary"
+	"github.com/ZaparooProject/go-gameid/internal/crc16"
+)
+
+// NDS header offsets
+const (
+	ndsHeaderSize       = 0x160
+	ndsTitleOffset      = 0x00
+	ndsTitleSize        = 12
+	ndsGameCodeOffset   = 0x0C
+	ndsGameCodeSize     = 4
+	ndsMakerCodeOffset  = 0x10
+	ndsMakerCodeSize    = 2
+	ndsUnitCodeOffset   = 0x12
+	ndsRomVersionOffset = 0x1E
+	ndsHeaderCRCOffset  = 0x15E
+)
+
+// ndsUnitNames names the unit code at 0x12.
+var ndsUnitNames = map[byte]string{
+	0x00: "NDS",
+	0x02: "NDS+DSi",
+	0x03: "DSi",
+}
+
+// NDSIdentifier identifies Nintendo DS games.
+type NDSIdentifier struct{}
+
+// NewNDSIdentifier creates a new NDS identifier.
+func NewNDSIdentifier() *NDSIdentifier {
+	return &NDSIdentifier{}
+}
+
+// Console returns the console type.
+func (*NDSIdentifier) Console() Console {
+	return ConsoleNDS
+}
+
+// Identify extracts NDS game information from the given reader. The header
+// CRC16 must match, as it must for the console to boot the cartridge. There
+// is no NDS database, so the database is not consulted.
+func (*NDSIdentifier) Identify(reader io.ReaderAt, size int64, _ Database) (*Result, error) {
+	if size < ndsHeaderSize {
+		return nil, ErrInvalidFormat{Console: ConsoleNDS, Reason: "file too small"}
+	}
+
+	header, err := binary.ReadBytesAt(reader, 0, ndsHeaderSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NDS header: %w", err)
+	}
+
+	expected := ndsStoredCRC(header)
+	actual := ndsHeaderCRC(header)
+	if expected != actual {
+		return nil, ErrInvalidFormat{
+			Console: ConsoleNDS,
+			Reason:  fmt.Sprintf("header CRC 0x%04x, want 0x%04x", actual, expected),
+		}
+	}
+
+	title := binary.ExtractPrintable(header[ndsTitleOffset : ndsTitleOffset+ndsTitleSize])
+	gameCode := binary.ExtractPrintable(header[ndsGameCodeOffset : ndsGameCodeOffset+ndsGameCodeSize])
+	makerCode := binary.ExtractPrintable(header[ndsMakerCodeOffset : ndsMakerCodeOffset+ndsMakerCodeSize])
+	unitCode := header[ndsUnitCodeOffset]
+
+	result := NewResult(ConsoleNDS)
+	result.ID = gameCode
+	result.InternalTitle = title
+	result.SetMetadata("ID", gameCode)
+	// DS game codes end in the same destination letter as GBA ones
+	result.RegionCode = RegionFromGBAGameCode(gameCode)
+	result.SetMetadata("internal_title", title)
+	result.SetMetadata("maker_code", makerCode)
+	result.SetMetadata("publisher", PublisherFromMakerCode(makerCode))
+	result.SetMetadata("unit_code", fmt.Sprintf("0x%02x", unitCode))
+	result.SetMetadata("unit", ndsUnitNames[unitCode])
+	result.SetMetadata("rom_version", fmt.Sprintf("%d", header[ndsRomVersionOffset]))
+	result.SetMetadata("header_checksum", fmt.Sprintf("0x%04x", expected))
+
+	result.SetROMTitle(result.InternalTitle)
+
+	return result, nil
+}
+
+// Validate reports whether header, the start of a file, could be a Nintendo DS ROM.
+func (*NDSIdentifier) Validate(header []byte) bool {
+	return ValidateNDS(header)
+}
+
+// ValidateNDS checks if the given data starts with an NDS header whose
+// CRC16 matches.
+func ValidateNDS(header []byte) bool {
+	if len(header) < ndsHeaderSize {
+		return false
+	}
+	return ndsStoredCRC(header) == ndsHeaderCRC(header)
+}
+
+// ndsStoredCRC returns the little-endian header CRC16 stored at 0x15E.
+func ndsStoredCRC(header []byte) uint16 {
+	return uint16(header[ndsHeaderCRCOffset]) | uint16(header[ndsHeaderCRCOffset+1])<<8
+}
+
+// ndsHeaderCRC computes the CRC16 of the header bytes before the stored CRC.
+func ndsHeaderCRC(header []byte) uint16 {
+	return crc16.Modbus(header[:ndsHeaderCRCOffset])
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// createNDSHeader creates an NDS ROM header with a correct header CRC16.
+func createNDSHeader(title, gameCode, makerCode string, unitCode byte) []byte {
+	header := make([]byte, 0x200)
+	copy(header[0x00:0x0C], title)
+	copy(header[0x0C:0x10], gameCode)
+	copy(header[0x10:0x12], makerCode)
+	header[0x12] = unitCode
+	header[0x1E] = 1
+	crc := ndsHeaderCRC(header)
+	header[0x15E] = byte(crc)
+	header[0x15F] = byte(crc >> 8)
+	return header
+}
+
+func TestNDSIdentifier_Identify(t *testing.T) {
+	t.Parallel()
+
+	header := createNDSHeader("POKEMON D", "ADAE", "01", 0x00)
+	result, err := NewNDSIdentifier().Identify(bytes.NewReader(header), int64(len(header)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+
+	if result.Console != ConsoleNDS {
+		t.Errorf("Console = %v, want %v", result.Console, ConsoleNDS)
+	}
+	if result.ID != "ADAE" {
+		t.Errorf("ID = %q, want %q", result.ID, "ADAE")
+	}
+	if result.InternalTitle != "POKEMON D" {
+		t.Errorf("InternalTitle = %q, want %q", result.InternalTitle, "POKEMON D")
+	}
+	if result.Title != "POKEMON D" {
+		t.Errorf("Title = %q, want %q", result.Title, "POKEMON D")
+	}
+	if result.RegionCode != RegionUSA {
+		t.Errorf("RegionCode = %v, want %v", result.RegionCode, RegionUSA)
+	}
+	wantMeta := map[string]string{
+		"maker_code":  "01",
+		"unit_code":   "0x00",
+		"unit":        "NDS",
+		"rom_version": "1",
+	}
+	for key, want := range wantMeta {
+		if got := result.Metadata[key]; got != want {
+			t.Errorf("Metadata[%q] = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestNDSIdentifier_Identify_Errors(t *testing.T) {
+	t.Parallel()
+
+	corrupt := createNDSHeader("POKEMON D", "ADAE", "01", 0x00)
+	corrupt[0x00] = 'X'
+
+	tests := []struct {
+		name   string
+		header []byte
+	}{
+		{"too small", make([]byte, 0x100)},
+		{"bad CRC", corrupt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewNDSIdentifier().Identify(bytes.NewReader(tt.header), int64(len(tt.header)), nil)
+			var formatErr ErrInvalidFormat
+			if !errors.As(err, &formatErr) {
+				t.Errorf("Identify() error = %v, want ErrInvalidFormat", err)
+			}
+		})
+	}
+}
+
+func TestValidateNDS(t *testing.T) {
+	t.Parallel()
+
+	valid := createNDSHeader("MARIO KART", "AMCP", "01", 0x02)
+	corrupt := bytes.Clone(valid)
+	corrupt[0x0C] = 'B'
+
+	tests := []struct {
+		name   string
+		header []byte
+		want   bool
+	}{
+		{"valid", valid, true},
+		{"bad CRC", corrupt, false},
+		{"too short", valid[:0x100], false},
+		{"zeros", make([]byte, 0x200), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ValidateNDS(tt.header); got != tt.want {
+				t.Errorf("ValidateNDS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ package identifier
 import (
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -40,14 +41,30 @@ func (*NeoGeoCDIdentifier) Console() Console {
 	return ConsoleNeoGeoCD
 }
 
-// Identify extracts Neo Geo CD game information from the given reader.
-// For disc-based games, use IdentifyFromPath instead.
-func (*NeoGeoCDIdentifier) Identify(_ io.ReaderAt, _ int64, _ Database) (*Result, error) {
-	return nil, ErrNotSupported{Format: "raw reader for NeoGeoCD"}
+// Identify identifies a Neo Geo CD game from a reader over a cooked or raw
+// data track image. Use IdentifyFromPath for CUE sheets and CHD files.
+func (n *NeoGeoCDIdentifier) Identify(reader io.ReaderAt, size int64, database Database) (*Result, error) {
+	iso, err := iso9660.OpenReader(reader, size)
+	if err != nil {
+		return nil, fmt.Errorf("open ISO: %w", err)
+	}
+	return n.identifyFromISO(iso, database)
+}
+
+// IdentifyFromISO identifies a Neo Geo CD game from an already parsed filesystem.
+func (n *NeoGeoCDIdentifier) IdentifyFromISO(iso *iso9660.ISO9660, database Database) (*Result, error) {
+	return n.identifyFromISO(iso, database)
 }
 
-// IdentifyFromPath identifies a Neo Geo CD game from a file path.
+// IdentifyFromPath identifies a Neo Geo CD game from a file path. A
+// directory is taken as a mounted disc: its name stands in for the volume ID,
+// and with no creation date to read the database is searched by the volume ID
+// alone.
 func (n *NeoGeoCDIdentifier) IdentifyFromPath(path string, database Database) (*Result, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return n.identifyFromDirectory(path, database)
+	}
+
 	var iso interface {
 		GetUUID() string
 		GetVolumeID() string
@@ -81,28 +98,48 @@ func (n *NeoGeoCDIdentifier) IdentifyFromPath(path string, database Database) (*
 	return n.identifyFromISO(iso, database)
 }
 
+// identifyFromDirectory identifies a mounted Neo Geo CD from its IPL.TXT.
+func (*NeoGeoCDIdentifier) identifyFromDirectory(path string, db Database) (*Result, error) {
+	ipl, err := os.ReadFile(filepath.Join(path, neoGeoCDIPLFile)) //nolint:gosec // Path from user input is expected
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", neoGeoCDIPLFile, err)
+	}
+	return identifyNeoGeoCD("", filepath.Base(filepath.Clean(path)), ipl, db), nil
+}
+
 func (*NeoGeoCDIdentifier) identifyFromISO(iso interface {
 	GetUUID() string
 	GetVolumeID() string
 }, db Database,
 ) (*Result, error) {
-	result := NewResult(ConsoleNeoGeoCD)
+	var ipl []byte
+	if disc, ok := iso.(*iso9660.ISO9660); ok {
+		ipl = readNeoGeoCDIPL(disc)
+	}
+	return identifyNeoGeoCD(iso.GetUUID(), iso.GetVolumeID(), ipl, db), nil
+}
 
-	uuid := iso.GetUUID()
-	volumeID := iso.GetVolumeID()
+// identifyNeoGeoCD builds the result for a disc with the given PVD creation
+// date (uuid), volume ID and IPL.TXT contents, any of which may be empty.
+func identifyNeoGeoCD(uuid, volumeID string, ipl []byte, db Database) *Result {
+	result := NewResult(ConsoleNeoGeoCD)
 
 	result.SetMetadata("uuid", uuid)
 	result.SetMetadata("volume_ID", volumeID)
+	result.SetMetadata("ipl_files", strings.Join(parseNeoGeoCDIPL(ipl), " / "))
 
 	// NeoGeoCD uses (uuid, volume_ID) tuple as primary key, with volume_ID as fallback
 	if db != nil {
-		// Try (uuid, volume_ID) tuple first
-		type neogeoCDKey struct {
-			uuid     string
-			volumeID string
+		var entry map[string]string
+		found := false
+		if uuid != "" {
+			// Try (uuid, volume_ID) tuple first
+			type neogeoCDKey struct {
+				uuid     string
+				volumeID string
+			}
+			entry, found = db.Lookup(ConsoleNeoGeoCD, neogeoCDKey{uuid: uuid, volumeID: volumeID})
 		}
-		key := neogeoCDKey{uuid: uuid, volumeID: volumeID}
-		entry, found := db.Lookup(ConsoleNeoGeoCD, key)
 		if !found && volumeID != "" {
 			// Fallback to just volume_ID
 			entry, found = db.LookupByString(ConsoleNeoGeoCD, volumeID)
@@ -117,5 +154,44 @@ func (*NeoGeoCDIdentifier) identifyFromISO(iso interface {
 		result.ID = volumeID
 	}
 
-	return result, nil
+	return result
+}
+
+// neoGeoCDIPLFile is the root file listing the program files the console
+// loads at boot.
+const neoGeoCDIPLFile = "IPL.TXT"
+
+// readNeoGeoCDIPL returns the contents of IPL.TXT in the disc root, or nil if
+// there is none.
+func readNeoGeoCDIPL(disc *iso9660.ISO9660) []byte {
+	var ipl *iso9660.FileInfo
+	err := disc.WalkFiles(true, func(file iso9660.FileInfo) bool {
+		if strings.EqualFold(cleanISOFileName(file.Path), neoGeoCDIPLFile) {
+			ipl = &file
+			return false
+		}
+		return true
+	})
+	if err != nil || ipl == nil {
+		return nil
+	}
+	data, err := disc.ReadFile(*ipl)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// parseNeoGeoCDIPL returns the file names IPL.TXT loads. Each line is
+// "NAME.EXT,bank,offset"; a 0x1A byte ends the text.
+func parseNeoGeoCDIPL(ipl []byte) []string {
+	text, _, _ := strings.Cut(string(ipl), "\x1a")
+	var files []string
+	for _, line := range strings.Split(text, "\n") {
+		name, _, _ := strings.Cut(line, ",")
+		if name = strings.TrimSpace(name); name != "" {
+			files = append(files, name)
+		}
+	}
+	return files
 }
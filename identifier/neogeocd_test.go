@@ -19,10 +19,15 @@
 package identifier
 
 import (
+	"bytes"
 	"errors"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/ZaparooProject/go-gameid/internal/testiso"
+	"github.com/ZaparooProject/go-gameid/iso9660"
 )
 
 // mockNeoGeoCDISO implements the interface needed for NeoGeoCD identification.
@@ -43,15 +48,22 @@ func TestNeoGeoCDIdentifier_Console(t *testing.T) {
 	}
 }
 
-func TestNeoGeoCDIdentifier_Identify_ReturnsNotSupported(t *testing.T) {
+func TestNeoGeoCDIdentifier_Identify_Reader(t *testing.T) {
 	t.Parallel()
 
+	isoData := testiso.CreateMinimal(t, "NGCDTEST", "", "", nil)
+
 	id := NewNeoGeoCDIdentifier()
-	_, err := id.Identify(nil, 0, nil)
+	result, err := id.Identify(bytes.NewReader(isoData), int64(len(isoData)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if !strings.HasPrefix(result.Metadata["volume_ID"], "NGCDTEST") {
+		t.Errorf("volume_ID metadata = %q, want prefix %q", result.Metadata["volume_ID"], "NGCDTEST")
+	}
 
-	var notSupported ErrNotSupported
-	if !errors.As(err, &notSupported) {
-		t.Errorf("Identify() error = %v, want ErrNotSupported", err)
+	if _, err := id.Identify(nil, 0, nil); !errors.Is(err, iso9660.ErrPVDNotFound) {
+		t.Errorf("Identify() error = %v, want ErrPVDNotFound for an empty reader", err)
 	}
 }
 
@@ -164,6 +176,19 @@ func TestNeoGeoCDIdentifier_identifyFromISO(t *testing.T) {
 	})
 }
 
+func TestParseNeoGeoCDIPL(t *testing.T) {
+	t.Parallel()
+
+	ipl := []byte("240P.FIX,0,0\r\n240P.SPR,0,0\r\n\r\n240P.PRG,0,0\r\n\x1a\x00\x00JUNK,0,0")
+	want := []string{"240P.FIX", "240P.SPR", "240P.PRG"}
+	if got := parseNeoGeoCDIPL(ipl); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseNeoGeoCDIPL() = %q, want %q", got, want)
+	}
+	if got := parseNeoGeoCDIPL(nil); got != nil {
+		t.Errorf("parseNeoGeoCDIPL(nil) = %q, want nil", got)
+	}
+}
+
 // TestNeoGeoCDIdentifier_RealISO tests with real test data if available.
 func TestNeoGeoCDIdentifier_RealISO(t *testing.T) {
 	t.Parallel()
@@ -41,6 +41,9 @@ func (*NESIdentifier) Console() Console {
 // Identify extracts NES game information from the given reader.
 func (*NESIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Result, error) {
 	// Read entire file for CRC32 calculation
+	if err := checkCartridgeSize(ConsoleNES, size); err != nil {
+		return nil, err
+	}
 	data := make([]byte, size)
 	if _, err := reader.ReadAt(data, 0); err != nil && err != io.EOF {
 		return nil, fmt.Errorf("failed to read NES ROM: %w", err)
@@ -19,7 +19,10 @@
 package identifier
 
 import (
+	"fmt"
 	"io"
+
+	"github.com/ZaparooProject/go-gameid/iso9660"
 )
 
 // PS2Identifier identifies PlayStation 2 games.
@@ -35,10 +38,19 @@ func (*PS2Identifier) Console() Console {
 	return ConsolePS2
 }
 
-// Identify extracts PS2 game information from the given reader.
-// For disc-based games, use IdentifyFromPath instead.
-func (*PS2Identifier) Identify(_ io.ReaderAt, _ int64, _ Database) (*Result, error) {
-	return nil, ErrNotSupported{Format: "raw reader for PS2"}
+// Identify identifies a PS2 game from a reader over a cooked or raw
+// disc image. Use IdentifyFromPath for CUE sheets and CHD files.
+func (*PS2Identifier) Identify(reader io.ReaderAt, size int64, database Database) (*Result, error) {
+	iso, err := iso9660.OpenReader(reader, size)
+	if err != nil {
+		return nil, fmt.Errorf("open ISO: %w", err)
+	}
+	return identifyPlayStation(iso, ConsolePS2, database, "")
+}
+
+// IdentifyFromISO identifies a PS2 game from an already parsed filesystem.
+func (*PS2Identifier) IdentifyFromISO(iso *iso9660.ISO9660, database Database) (*Result, error) {
+	return identifyPlayStation(iso, ConsolePS2, database, "")
 }
 
 // IdentifyFromPath identifies a PS2 game from a file path.
@@ -19,8 +19,12 @@
 package identifier
 
 import (
+	"bytes"
 	"errors"
 	"testing"
+
+	"github.com/ZaparooProject/go-gameid/internal/testiso"
+	"github.com/ZaparooProject/go-gameid/iso9660"
 )
 
 func TestPS2Identifier_Console(t *testing.T) {
@@ -32,15 +36,56 @@ func TestPS2Identifier_Console(t *testing.T) {
 	}
 }
 
-func TestPS2Identifier_Identify_ReturnsNotSupported(t *testing.T) {
+func TestPS2Identifier_Identify_Reader(t *testing.T) {
 	t.Parallel()
 
+	isoData := testiso.CreateMinimal(t, "PS2TEST", "PLAYSTATION", "", []testiso.File{
+		{Name: "SYSTEM.CNF;1", Data: []byte("BOOT2 = cdrom0:\\SLES_123.45;1\r\n")},
+	})
+
 	id := NewPS2Identifier()
-	_, err := id.Identify(nil, 0, nil)
+	result, err := id.Identify(bytes.NewReader(isoData), int64(len(isoData)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.ID != "SLES-12345" {
+		t.Errorf("result.ID = %q, want %q", result.ID, "SLES-12345")
+	}
+
+	if _, err := id.Identify(nil, 0, nil); !errors.Is(err, iso9660.ErrPVDNotFound) {
+		t.Errorf("Identify() error = %v, want ErrPVDNotFound for an empty reader", err)
+	}
+}
+
+func TestPS2Identifier_BootELFSerial(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		systemCNF string
+		wantID    string
+	}{
+		{"BOOT2 = cdrom0:\\SLPM_123.45;1\r\nVER = 1.00\r\nVMODE = NTSC\r\n", "SLPM-12345"},
+		// SCAJ is not a known root-file prefix; only the BOOT2 line names it
+		{"BOOT2 = cdrom0:\\SCAJ_200.01;1\r\n", "SCAJ-20001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantID, func(t *testing.T) {
+			t.Parallel()
+
+			isoData := testiso.CreateMinimal(t, "PS2TEST", "PLAYSTATION", "", []testiso.File{
+				{Name: "SYSTEM.CNF;1", Data: []byte(tt.systemCNF)},
+			})
 
-	var notSupported ErrNotSupported
-	if !errors.As(err, &notSupported) {
-		t.Errorf("Identify() error = %v, want ErrNotSupported", err)
+			// No database: the ID comes from the boot executable alone
+			result, err := NewPS2Identifier().Identify(bytes.NewReader(isoData), int64(len(isoData)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if result.ID != tt.wantID {
+				t.Errorf("result.ID = %q, want %q", result.ID, tt.wantID)
+			}
+		})
 	}
 }
 
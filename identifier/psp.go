@@ -19,14 +19,30 @@
 package identifier
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 
+	bin "github.com/ZaparooProject/go-gameid/internal/binary"
 	"github.com/ZaparooProject/go-gameid/iso9660"
 )
 
+// EBOOT.PBP header: the magic and version are followed by the offsets of
+// PARAM.SFO, ICON0.PNG, ICON1.PMF, PIC0.PNG, PIC1.PNG, SND0.AT3, DATA.PSP
+// and DATA.PSAR, each section ending where the next begins.
+const (
+	pbpHeaderSize  = 0x28
+	pbpSFOOffset   = 0x08
+	pbpIcon0Offset = 0x0C
+	pbpMaxSFOSize  = 64 * 1024
+)
+
+var pbpMagic = []byte("\x00PBP")
+
 // PSPIdentifier identifies PlayStation Portable games.
 type PSPIdentifier struct{}
 
@@ -40,10 +56,23 @@ func (*PSPIdentifier) Console() Console {
 	return ConsolePSP
 }
 
-// Identify extracts PSP game information from the given reader.
-// For disc-based games, use IdentifyFromPath instead.
-func (*PSPIdentifier) Identify(_ io.ReaderAt, _ int64, _ Database) (*Result, error) {
-	return nil, ErrNotSupported{Format: "raw reader for PSP"}
+// Identify extracts PSP game information from a reader over a UMD image or
+// an EBOOT.PBP. Use IdentifyFromPath for CHD and CSO files.
+func (*PSPIdentifier) Identify(reader io.ReaderAt, size int64, database Database) (*Result, error) {
+	if size >= pbpHeaderSize && isPBP(reader) {
+		return identifyPSPFromPBP(reader, size, database)
+	}
+
+	iso, err := iso9660.OpenReader(reader, size)
+	if err != nil {
+		return nil, fmt.Errorf("open ISO: %w", err)
+	}
+	return identifyPSPFromISO(iso, database)
+}
+
+// IdentifyFromISO identifies a PSP game from an already parsed filesystem.
+func (*PSPIdentifier) IdentifyFromISO(iso *iso9660.ISO9660, database Database) (*Result, error) {
+	return identifyPSPFromISO(iso, database)
 }
 
 // IdentifyFromPath identifies a PSP game from a file path.
@@ -54,11 +83,18 @@ func (*PSPIdentifier) IdentifyFromPath(path string, database Database) (*Result,
 	var err error
 
 	switch ext {
+	case ".pbp":
+		return identifyPSPFromPBPPath(path, database)
 	case ".chd":
 		iso, err = iso9660.OpenCHD(path)
 		if err != nil {
 			return nil, fmt.Errorf("open CHD: %w", err)
 		}
+	case ".cso", ".zso":
+		iso, err = iso9660.OpenCISO(path)
+		if err != nil {
+			return nil, fmt.Errorf("open CISO: %w", err)
+		}
 	default:
 		iso, err = iso9660.Open(path)
 		if err != nil {
@@ -121,3 +157,76 @@ func identifyPSPFromISO(iso *iso9660.ISO9660, database Database) (*Result, error
 
 	return result, nil
 }
+
+// isPBP reports whether reader starts with the EBOOT.PBP magic.
+func isPBP(reader io.ReaderAt) bool {
+	magic := make([]byte, len(pbpMagic))
+	if _, err := reader.ReadAt(magic, 0); err != nil {
+		return false
+	}
+	return bytes.Equal(magic, pbpMagic)
+}
+
+// identifyPSPFromPBPPath identifies the EBOOT.PBP at path.
+func identifyPSPFromPBPPath(path string, database Database) (*Result, error) {
+	file, err := os.Open(path) //nolint:gosec // Path from user input is expected
+	if err != nil {
+		return nil, fmt.Errorf("open PBP: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat PBP: %w", err)
+	}
+	return identifyPSPFromPBP(file, stat.Size(), database)
+}
+
+// identifyPSPFromPBP identifies a PSN or homebrew EBOOT.PBP from the
+// PARAM.SFO its header points to.
+func identifyPSPFromPBP(reader io.ReaderAt, size int64, database Database) (*Result, error) {
+	if size < pbpHeaderSize {
+		return nil, ErrInvalidFormat{Console: ConsolePSP, Reason: "PBP header truncated"}
+	}
+	header, err := bin.ReadBytesAt(reader, 0, pbpHeaderSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PBP header: %w", err)
+	}
+	if !bytes.Equal(header[:len(pbpMagic)], pbpMagic) {
+		return nil, ErrInvalidFormat{Console: ConsolePSP, Reason: "not a PBP file"}
+	}
+
+	start := int64(binary.LittleEndian.Uint32(header[pbpSFOOffset:]))
+	end := int64(binary.LittleEndian.Uint32(header[pbpIcon0Offset:]))
+	if start < pbpHeaderSize || end <= start || end > size || end-start > pbpMaxSFOSize {
+		return nil, ErrInvalidFormat{Console: ConsolePSP, Reason: "PBP has no valid PARAM.SFO"}
+	}
+	sfo, err := bin.ReadBytesAt(reader, start, int(end-start))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PARAM.SFO: %w", err)
+	}
+	params, ok := parseSFO(sfo)
+	if !ok {
+		return nil, ErrInvalidFormat{Console: ConsolePSP, Reason: "malformed PARAM.SFO"}
+	}
+
+	serial := params["DISC_ID"]
+	result := NewResult(ConsolePSP)
+	result.ID = serial
+	result.InternalTitle = params["TITLE"]
+	result.SetMetadata("ID", serial)
+	result.SetMetadata("internal_title", params["TITLE"])
+	result.SetMetadata("category", params["CATEGORY"])
+	result.SetMetadata("disc_version", params["DISC_VERSION"])
+	result.SetMetadata("format", "PBP")
+
+	if database != nil && serial != "" {
+		if entry, found := database.LookupByString(ConsolePSP, serial); found {
+			result.MergeMetadata(entry)
+		}
+	}
+
+	result.SetROMTitle(result.InternalTitle)
+
+	return result, nil
+}
@@ -19,6 +19,8 @@
 package identifier
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"os"
 	"path/filepath"
@@ -26,6 +28,7 @@ import (
 	"testing"
 
 	"github.com/ZaparooProject/go-gameid/internal/testiso"
+	"github.com/ZaparooProject/go-gameid/iso9660"
 )
 
 func TestPSPIdentifier_Console(t *testing.T) {
@@ -37,15 +40,24 @@ func TestPSPIdentifier_Console(t *testing.T) {
 	}
 }
 
-func TestPSPIdentifier_Identify_ReturnsNotSupported(t *testing.T) {
+func TestPSPIdentifier_Identify_Reader(t *testing.T) {
 	t.Parallel()
 
+	isoData := testiso.CreateMinimal(t, "PSPTEST", "PLAYSTATION", "", []testiso.File{
+		{Name: "UMD_DATA.BIN;1", Data: []byte("UCUS-98765|Example Game")},
+	})
+
 	id := NewPSPIdentifier()
-	_, err := id.Identify(nil, 0, nil)
+	result, err := id.Identify(bytes.NewReader(isoData), int64(len(isoData)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.ID != "UCUS-98765" {
+		t.Errorf("result.ID = %q, want %q", result.ID, "UCUS-98765")
+	}
 
-	var notSupported ErrNotSupported
-	if !errors.As(err, &notSupported) {
-		t.Errorf("Identify() error = %v, want ErrNotSupported", err)
+	if _, err := id.Identify(nil, 0, nil); !errors.Is(err, iso9660.ErrPVDNotFound) {
+		t.Errorf("Identify() error = %v, want ErrPVDNotFound for an empty reader", err)
 	}
 }
 
@@ -59,6 +71,19 @@ func TestPSPIdentifier_IdentifyFromPath_NonExistent(t *testing.T) {
 	}
 }
 
+func TestPSPIdentifier_IdentifyFromPath_CSO(t *testing.T) {
+	t.Parallel()
+
+	id := NewPSPIdentifier()
+	result, err := id.IdentifyFromPath("../testdata/PSP/minimal.cso", nil)
+	if err != nil {
+		t.Fatalf("IdentifyFromPath() error = %v", err)
+	}
+	if result.ID != "ULUS-99999" {
+		t.Errorf("result.ID = %q, want %q", result.ID, "ULUS-99999")
+	}
+}
+
 func TestPSPIdentifier_IdentifyFromPath_UMDData(t *testing.T) {
 	t.Parallel()
 
@@ -83,3 +108,86 @@ func TestPSPIdentifier_IdentifyFromPath_UMDData(t *testing.T) {
 		t.Errorf("volume_ID metadata = %q, want prefix %q", result.Metadata["volume_ID"], "PSPTEST")
 	}
 }
+
+// createPBP builds an EBOOT.PBP whose PARAM.SFO section is sfo, followed by
+// empty icon and media sections and a small DATA.PSP.
+func createPBP(sfo []byte) []byte {
+	header := make([]byte, pbpHeaderSize)
+	copy(header, pbpMagic)
+	binary.LittleEndian.PutUint32(header[0x04:], 0x00010000)
+	binary.LittleEndian.PutUint32(header[pbpSFOOffset:], pbpHeaderSize)
+	end := uint32(pbpHeaderSize + len(sfo)) //nolint:gosec // test data
+	for offset := pbpIcon0Offset; offset < pbpHeaderSize; offset += 4 {
+		binary.LittleEndian.PutUint32(header[offset:], end)
+	}
+	pbp := append(header, sfo...)
+	return append(pbp, []byte("~PSP")...)
+}
+
+func TestPSPIdentifier_Identify_PBP(t *testing.T) {
+	t.Parallel()
+
+	pbp := createPBP(createSFO(
+		sfoParam{key: "CATEGORY", value: "EG"},
+		sfoParam{key: "DISC_ID", value: "NPUG80086"},
+		sfoParam{key: "DISC_VERSION", value: "1.00"},
+		sfoParam{key: "TITLE", value: "flOw"},
+	))
+	path := filepath.Join(t.TempDir(), "EBOOT.PBP")
+	if err := os.WriteFile(path, pbp, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	id := NewPSPIdentifier()
+	fromReader, err := id.Identify(bytes.NewReader(pbp), int64(len(pbp)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	fromPath, err := id.IdentifyFromPath(path, nil)
+	if err != nil {
+		t.Fatalf("IdentifyFromPath() error = %v", err)
+	}
+
+	for name, result := range map[string]*Result{"Identify": fromReader, "IdentifyFromPath": fromPath} {
+		if result.ID != "NPUG80086" {
+			t.Errorf("%s: ID = %q, want %q", name, result.ID, "NPUG80086")
+		}
+		if result.Title != "flOw" {
+			t.Errorf("%s: Title = %q, want %q", name, result.Title, "flOw")
+		}
+		if got := result.Metadata["category"]; got != "EG" {
+			t.Errorf("%s: category = %q, want %q", name, got, "EG")
+		}
+		if got := result.Metadata["format"]; got != "PBP" {
+			t.Errorf("%s: format = %q, want %q", name, got, "PBP")
+		}
+	}
+}
+
+func TestPSPIdentifier_Identify_PBPInvalid(t *testing.T) {
+	t.Parallel()
+
+	noSFO := createPBP(make([]byte, 0x40))
+	badOffsets := createPBP(createSFO(sfoParam{key: "TITLE", value: "flOw"}))
+	binary.LittleEndian.PutUint32(badOffsets[pbpIcon0Offset:], 0x10)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"malformed PARAM.SFO", noSFO},
+		{"section offsets out of order", badOffsets},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewPSPIdentifier().Identify(bytes.NewReader(tt.data), int64(len(tt.data)), nil)
+			var formatErr ErrInvalidFormat
+			if !errors.As(err, &formatErr) {
+				t.Errorf("Identify() error = %v, want ErrInvalidFormat", err)
+			}
+		})
+	}
+}
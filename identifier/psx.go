@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -43,6 +44,23 @@ var playStationSerialPrefixes = map[string]struct{}{
 	"TCPS": {},
 }
 
+// playStationLicenseSector is the system-area sector holding the license
+// string pressed onto PlayStation discs, e.g.
+// "          Licensed  by          Sony Computer Entertainment Amer  ica ".
+const playStationLicenseSector = 4
+
+// playStationLicensees maps the end of the license string to the disc's
+// region. The string is padded to a fixed layout, so spaces are removed
+// before matching.
+var playStationLicensees = []struct {
+	suffix string
+	region string
+}{
+	{suffix: "America", region: "USA"},
+	{suffix: "Europe", region: "Europe"},
+	{suffix: "Inc.", region: "Japan"},
+}
+
 // playstationISO is the interface for PlayStation disc images.
 type playstationISO interface {
 	GetUUID() string
@@ -59,7 +77,7 @@ type isoFileReader interface {
 	ReadFile(info iso9660.FileInfo) ([]byte, error)
 }
 
-// openPlayStationISO opens an ISO from a path, handling CUE and CHD files.
+// openPlayStationISO opens an ISO from a path, handling CUE, CHD and CSO files.
 func openPlayStationISO(path string) (playstationISO, error) {
 	ext := strings.ToLower(filepath.Ext(path))
 
@@ -78,6 +96,13 @@ func openPlayStationISO(path string) (playstationISO, error) {
 		}
 		return iso, nil
 
+	case ".cso", ".zso":
+		iso, err := iso9660.OpenCISO(path)
+		if err != nil {
+			return nil, fmt.Errorf("open CISO: %w", err)
+		}
+		return iso, nil
+
 	default:
 		iso, err := iso9660.Open(path)
 		if err != nil {
@@ -123,6 +148,7 @@ func identifyPlayStation(
 	result.SetMetadata("uuid", iso.GetUUID())
 	result.SetMetadata("volume_ID", iso.GetVolumeID())
 	result.SetMetadata("root_files", strings.Join(rootFiles, " / "))
+	result.SetMetadata("region", regionFromLicenseSector(iso))
 
 	// Database lookup
 	if database != nil && serial != "" {
@@ -131,9 +157,54 @@ func identifyPlayStation(
 		}
 	}
 
+	// Without a license string or database region, the serial prefix
+	// still tells the region the disc was sold in
+	if _, ok := result.Metadata["region"]; !ok {
+		result.SetMetadata("region", string(RegionFromPlayStationSerial(serial)))
+	}
+
+	// Multi-disc sets carry the disc in their Redump-style title
+	if number, total := discFromTitle(result.Title); number > 0 {
+		result.SetMetadata("disc_number", strconv.Itoa(number))
+		if total > 0 {
+			result.SetMetadata("disc_total", strconv.Itoa(total))
+		}
+	}
+
 	return result, nil
 }
 
+// discFromTitle extracts the disc number from a "(Disc 2)" or "(Disc 2 of 3)"
+// title tag. total is 0 when the tag does not name it; number is 0 when the
+// title has no disc tag.
+func discFromTitle(title string) (number, total int) {
+	const tag = "(disc "
+	rest := strings.ToLower(title)
+	for {
+		start := strings.Index(rest, tag)
+		if start < 0 {
+			return 0, 0
+		}
+		rest = rest[start+len(tag):]
+		end := strings.IndexByte(rest, ')')
+		if end < 0 {
+			return 0, 0
+		}
+		fields := strings.Fields(rest[:end])
+		if len(fields) == 0 || (len(fields) != 1 && (len(fields) != 3 || fields[1] != "of")) {
+			continue
+		}
+		number, err := strconv.Atoi(fields[0])
+		if err != nil || number <= 0 {
+			continue
+		}
+		if len(fields) == 3 {
+			total, _ = strconv.Atoi(fields[2])
+		}
+		return number, total
+	}
+}
+
 func playStationRootInfo(iso playstationISO, console Console, database Database) (
 	rootFiles []string,
 	serial string,
@@ -221,6 +292,59 @@ func serialFromSystemCNF(content string) string {
 			return serial
 		}
 	}
+	return serialFromBootLine(content)
+}
+
+// serialFromBootLine returns the serial named by the executable on the BOOT2
+// (PS2) or BOOT (PSX) line of SYSTEM.CNF, e.g. "cdrom0:\SLPM_123.45;1".
+// Unlike the root file scan it accepts any four-letter prefix, so discs from
+// publishers missing from playStationSerialPrefixes still get an ID.
+func serialFromBootLine(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		if key = strings.ToUpper(strings.TrimSpace(key)); key != "BOOT2" && key != "BOOT" {
+			continue
+		}
+		name := strings.ToUpper(strings.TrimSpace(value))
+		name = name[strings.LastIndexAny(name, `\/:`)+1:]
+		name, _, _ = strings.Cut(name, ";")
+		if serial := parsePlayStationSerial(strings.TrimSpace(name)); serial != "" {
+			return serial
+		}
+	}
+	return ""
+}
+
+// regionFromLicenseSector reads the region from the disc's license string.
+// Only parsed ISO images expose the system area; other sources return "".
+func regionFromLicenseSector(iso playstationISO) string {
+	disc, ok := iso.(*iso9660.ISO9660)
+	if !ok {
+		return ""
+	}
+	data, err := disc.ReadFile(iso9660.FileInfo{Path: "license sector", LBA: playStationLicenseSector, Size: 2048})
+	if err != nil {
+		return ""
+	}
+	return regionFromLicense(data)
+}
+
+// regionFromLicense maps a license sector to a region, or "" when it holds
+// no recognizable license string.
+func regionFromLicense(data []byte) string {
+	compact := strings.ReplaceAll(string(data), " ", "")
+	_, licensee, found := strings.Cut(compact, "LicensedbySonyComputerEntertainment")
+	if !found {
+		return ""
+	}
+	for _, candidate := range playStationLicensees {
+		if strings.HasPrefix(licensee, candidate.suffix) {
+			return candidate.region
+		}
+	}
 	return ""
 }
 
@@ -255,11 +379,25 @@ func serialFromRootFile(fileName string) string {
 	if len(name) < 9 {
 		return ""
 	}
+	if _, ok := playStationSerialPrefixes[name[:4]]; !ok {
+		return ""
+	}
+	return parsePlayStationSerial(name)
+}
 
-	prefix := name[:4]
-	if _, ok := playStationSerialPrefixes[prefix]; !ok {
+// parsePlayStationSerial parses an upper-case name of the form
+// XXXX_NNN.NN (separators optional) into the XXXX_NNNNN serial format,
+// without checking the prefix against playStationSerialPrefixes.
+func parsePlayStationSerial(name string) string {
+	if len(name) < 9 {
 		return ""
 	}
+	prefix := name[:4]
+	for _, letter := range prefix {
+		if letter < 'A' || letter > 'Z' {
+			return ""
+		}
+	}
 
 	pos := 4
 	if pos < len(name) && isSerialSeparator(rune(name[pos])) {
@@ -372,10 +510,19 @@ func (*PSXIdentifier) Console() Console {
 	return ConsolePSX
 }
 
-// Identify extracts PSX game information from the given reader.
-// For disc-based games, use IdentifyFromPath instead.
-func (*PSXIdentifier) Identify(_ io.ReaderAt, _ int64, _ Database) (*Result, error) {
-	return nil, ErrNotSupported{Format: "raw reader for PSX"}
+// Identify identifies a PSX game from a reader over a cooked or raw
+// disc image. Use IdentifyFromPath for CUE sheets and CHD files.
+func (*PSXIdentifier) Identify(reader io.ReaderAt, size int64, database Database) (*Result, error) {
+	iso, err := iso9660.OpenReader(reader, size)
+	if err != nil {
+		return nil, fmt.Errorf("open ISO: %w", err)
+	}
+	return identifyPlayStation(iso, ConsolePSX, database, "")
+}
+
+// IdentifyFromISO identifies a PSX game from an already parsed filesystem.
+func (*PSXIdentifier) IdentifyFromISO(iso *iso9660.ISO9660, database Database) (*Result, error) {
+	return identifyPlayStation(iso, ConsolePSX, database, "")
 }
 
 // IdentifyFromPath identifies a PSX game from a file path.
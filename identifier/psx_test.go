@@ -19,10 +19,12 @@
 package identifier
 
 import (
+	"bytes"
 	"errors"
 	"slices"
 	"testing"
 
+	"github.com/ZaparooProject/go-gameid/internal/testiso"
 	"github.com/ZaparooProject/go-gameid/iso9660"
 )
 
@@ -144,6 +146,8 @@ func TestSerialFromSystemCNF(t *testing.T) {
 		{"boot", "BOOT = cdrom:\\SLUS_005.94;1", "SLUS_00594"},
 		{"boot2", "BOOT2 = cdrom0:\\SLES-123.45;1", "SLES_12345"},
 		{"none", "BOOT = cdrom:\\MAIN.EXE;1", ""},
+		{"boot2 unlisted prefix", "BOOT2 = cdrom0:\\SCAJ_200.01;1\r\nVER = 1.00", "SCAJ_20001"},
+		{"boot2 in subdirectory", "BOOT2 = cdrom0:\\DATA\\SLKA-250.01;1", "SLKA_25001"},
 	}
 
 	for _, tt := range tests {
@@ -509,6 +513,67 @@ func TestIdentifyPlayStation(t *testing.T) {
 	})
 }
 
+func TestIdentifyPlayStation_DiscNumber(t *testing.T) {
+	t.Parallel()
+
+	db := newMockDatabase()
+	db.setPrefixes(ConsolePSX, []string{"SLUS"})
+	db.addEntry(ConsolePSX, "SLUS_00892", map[string]string{
+		"title": "Final Fantasy VII (USA) (Disc 2)",
+		"ID":    "SLUS-00892",
+	})
+
+	mockISO := &mockPlayStationISO{
+		files: []iso9660.FileInfo{{Path: "/SLUS_008.92;1"}},
+	}
+
+	result, err := identifyPlayStation(mockISO, ConsolePSX, db, "")
+	if err != nil {
+		t.Fatalf("identifyPlayStation() error = %v", err)
+	}
+	if result.DiscNumber != 2 {
+		t.Errorf("result.DiscNumber = %d, want 2", result.DiscNumber)
+	}
+	if result.Metadata["disc_number"] != "2" {
+		t.Errorf("disc_number metadata = %q, want %q", result.Metadata["disc_number"], "2")
+	}
+	if _, ok := result.Metadata["disc_total"]; ok {
+		t.Errorf("disc_total metadata = %q, want unset", result.Metadata["disc_total"])
+	}
+}
+
+func TestDiscFromTitle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		title      string
+		wantNumber int
+		wantTotal  int
+	}{
+		{title: "Final Fantasy VII (USA) (Disc 2)", wantNumber: 2},
+		{title: "Metal Gear Solid (Europe) (Disc 1 of 2)", wantNumber: 1, wantTotal: 2},
+		{title: "Game (DISC 3)", wantNumber: 3},
+		{title: "Game (Disc A) (Disc 4)", wantNumber: 4},
+		{title: "Single Disc Game (USA)"},
+		{title: "Game (Disc )"},
+		{title: "Game (Disc 0)"},
+		{title: "Game (Disc 2"},
+		{title: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			number, total := discFromTitle(tt.title)
+			if number != tt.wantNumber || total != tt.wantTotal {
+				t.Errorf("discFromTitle(%q) = (%d, %d), want (%d, %d)",
+					tt.title, number, total, tt.wantNumber, tt.wantTotal)
+			}
+		})
+	}
+}
+
 // Tests for PSXIdentifier
 func TestIdentifyPlayStation_FilenameFallbackWithDatabase(t *testing.T) {
 	t.Parallel()
@@ -564,15 +629,24 @@ func TestPSXIdentifier_Console(t *testing.T) {
 	}
 }
 
-func TestPSXIdentifier_Identify_ReturnsNotSupported(t *testing.T) {
+func TestPSXIdentifier_Identify_Reader(t *testing.T) {
 	t.Parallel()
 
+	isoData := testiso.CreateMinimal(t, "PSXTEST", "PLAYSTATION", "", []testiso.File{
+		{Name: "SYSTEM.CNF;1", Data: []byte("BOOT = cdrom:\\SLUS_012.34;1\r\n")},
+	})
+
 	id := NewPSXIdentifier()
-	_, err := id.Identify(nil, 0, nil)
+	result, err := id.Identify(bytes.NewReader(isoData), int64(len(isoData)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.ID != "SLUS-01234" {
+		t.Errorf("result.ID = %q, want %q", result.ID, "SLUS-01234")
+	}
 
-	var notSupported ErrNotSupported
-	if !errors.As(err, &notSupported) {
-		t.Errorf("Identify() error = %v, want ErrNotSupported", err)
+	if _, err := id.Identify(bytes.NewReader(make([]byte, 4096)), 4096, nil); !errors.Is(err, iso9660.ErrPVDNotFound) {
+		t.Errorf("Identify() error = %v, want ErrPVDNotFound for a non-ISO reader", err)
 	}
 }
 
@@ -601,3 +675,94 @@ func TestIdentifyPlayStation_VersionSuffix(t *testing.T) {
 		t.Errorf("root_files = %q, want %q", rootFiles, "SLUS_123.45")
 	}
 }
+
+func TestPSXIdentifier_LicenseRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		license    string
+		wantRegion string
+		wantCode   Region
+	}{
+		{"          Licensed  by          Sony Computer Entertainment Amer  ica ", "USA", RegionUSA},
+		{"          Licensed  by          Sony Computer Entertainment Euro pe   ", "Europe", RegionEurope},
+		{"          Licensed  by          Sony Computer Entertainment Inc.", "Japan", RegionJapan},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantRegion, func(t *testing.T) {
+			t.Parallel()
+
+			// No SYSTEM.CNF, so the region can only come from the license
+			isoData := testiso.CreateMinimal(t, "PSXTEST", "PLAYSTATION", "", nil)
+			copy(isoData[playStationLicenseSector*2048:], tt.license)
+
+			result, err := NewPSXIdentifier().Identify(bytes.NewReader(isoData), int64(len(isoData)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if got := result.Metadata["region"]; got != tt.wantRegion {
+				t.Errorf("region = %q, want %q", got, tt.wantRegion)
+			}
+			if result.RegionCode != tt.wantCode {
+				t.Errorf("RegionCode = %q, want %q", result.RegionCode, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPSXIdentifier_SerialRegion(t *testing.T) {
+	t.Parallel()
+
+	db := newMockDatabase()
+	db.addEntry(ConsolePSX, "SLES_01234", map[string]string{"title": "Test", "region": "Germany"})
+
+	tests := []struct {
+		db         Database
+		boot       string
+		license    string
+		wantRegion string
+	}{
+		{boot: "SLPS_012.34", wantRegion: "Japan"},
+		{boot: "SLUS_012.34", wantRegion: "USA"},
+		{boot: "SCES_012.34", wantRegion: "Europe"},
+		// The license string wins over the serial prefix
+		{
+			boot:       "SLUS_012.34",
+			license:    "          Licensed  by          Sony Computer Entertainment Euro pe   ",
+			wantRegion: "Europe",
+		},
+		// So does a database region
+		{db: db, boot: "SLES_012.34", wantRegion: "Germany"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.boot+tt.license, func(t *testing.T) {
+			t.Parallel()
+
+			isoData := testiso.CreateMinimal(t, "PSXTEST", "PLAYSTATION", "", []testiso.File{
+				{Name: "SYSTEM.CNF;1", Data: []byte("BOOT = cdrom:\\" + tt.boot + ";1\r\n")},
+			})
+			copy(isoData[playStationLicenseSector*2048:], tt.license)
+
+			result, err := NewPSXIdentifier().Identify(bytes.NewReader(isoData), int64(len(isoData)), tt.db)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if got := result.Metadata["region"]; got != tt.wantRegion {
+				t.Errorf("region = %q, want %q", got, tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestRegionFromLicense_NoLicense(t *testing.T) {
+	t.Parallel()
+
+	if got := regionFromLicense(make([]byte, 2048)); got != "" {
+		t.Errorf("regionFromLicense() = %q, want empty", got)
+	}
+	if got := regionFromLicense([]byte("Licensed by Sony Computer Entertainment Korea")); got != "" {
+		t.Errorf("regionFromLicense() = %q for an unknown licensee, want empty", got)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxCartridgeSize caps the files that cartridge identifiers read or checksum
+// in full. No cartridge holds more than 64 MiB; a bigger file with a
+// cartridge extension is mislabeled and would otherwise be read end to end.
+const maxCartridgeSize = 64 << 20
+
+// checkCartridgeSize rejects a file too large to be one of console's
+// cartridge ROMs, before its whole contents are read.
+func checkCartridgeSize(console Console, size int64) error {
+	if size > maxCartridgeSize {
+		return ErrInvalidFormat{
+			Console: console,
+			Reason:  fmt.Sprintf("file too large: %d bytes exceeds the %d byte cartridge limit", size, maxCartridgeSize),
+		}
+	}
+	return nil
+}
+
+// readROM reads n bytes of a console's ROM at offset. A read that comes up
+// short, because the file is truncated or a sparse or network reader holds
+// less than its reported size, is an ErrInvalidFormat giving the byte
+// counts, so callers can slice the result at fixed offsets.
+func readROM(reader io.ReaderAt, console Console, offset int64, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	read, err := reader.ReadAt(buf, offset)
+	if read == n {
+		return buf, nil
+	}
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err //nolint:wrapcheck // callers add the context
+	}
+	return nil, ErrInvalidFormat{
+		Console: console,
+		Reason:  fmt.Sprintf("truncated: read %d of %d bytes at offset 0x%X", read, n, offset),
+	}
+}
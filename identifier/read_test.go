@@ -0,0 +1,240 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// shortReaderAt serves data but never returns bytes at or past limit, like a
+// truncated file or a network reader whose reported size is too large.
+type shortReaderAt struct {
+	data  []byte
+	limit int64
+}
+
+func (r shortReaderAt) ReadAt(buf []byte, off int64) (int, error) {
+	if off >= r.limit {
+		return 0, io.EOF
+	}
+	end := min(off+int64(len(buf)), r.limit, int64(len(r.data)))
+	n := copy(buf, r.data[off:end])
+	if n < len(buf) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// maxReadReaderAt records the furthest offset any read reaches.
+type maxReadReaderAt struct {
+	reader io.ReaderAt
+	end    int64
+}
+
+func (r *maxReadReaderAt) ReadAt(buf []byte, off int64) (int, error) {
+	n, err := r.reader.ReadAt(buf, off)
+	r.end = max(r.end, off+int64(n))
+	return n, err //nolint:wrapcheck // Test reader passes errors through unchanged
+}
+
+func TestIdentifyHeader_ReadsOnlyHeader(t *testing.T) {
+	t.Parallel()
+
+	const romSize = 1 << 20
+	pad := func(rom []byte) []byte {
+		return append(rom, make([]byte, romSize-len(rom))...)
+	}
+
+	tests := []struct {
+		identifier HeaderIdentifier
+		name       string
+		data       []byte
+		limit      int64
+		wholeFile  string
+	}{
+		{NewGBIdentifier(), "GB", pad(createMinimalGBROM()), gbHeaderSize, "global_checksum_actual"},
+		{NewGenesisIdentifier(), "Genesis", pad(createMinimalGenesisROM()), 0x200, "checksum_actual"},
+		{
+			NewSNESIdentifier(), "SNES", pad(createMinimalSNESROMLoROM()),
+			snesHiROMHeaderStart - snesWindowBefore + snesWindowSize, "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := &maxReadReaderAt{reader: bytes.NewReader(tt.data)}
+			result, err := tt.identifier.IdentifyHeader(reader, int64(len(tt.data)), nil)
+			if err != nil {
+				t.Fatalf("IdentifyHeader() error = %v", err)
+			}
+			if end := reader.end; end > tt.limit {
+				t.Errorf("IdentifyHeader() read up to 0x%X, want at most 0x%X", end, tt.limit)
+			}
+			if _, ok := result.Metadata[tt.wholeFile]; tt.wholeFile != "" && ok {
+				t.Errorf("IdentifyHeader() set %s, which needs the whole file", tt.wholeFile)
+			}
+		})
+	}
+}
+
+func TestIdentify_TruncatedReads(t *testing.T) {
+	t.Parallel()
+
+	// Pad the header-only ROMs so some limits fall past the header
+	gb := append(createMinimalGBROM(), make([]byte, 0x7EB0)...)
+	gba := createGBAHeader("BPEE", "POKEMON EMER", "01", 0)
+	snes := createMinimalSNESROMLoROM()
+	n64 := append(createMinimalN64ROM(), make([]byte, 0xFC0)...)
+
+	tests := []struct {
+		identifier Identifier
+		name       string
+		data       []byte
+		limit      int64
+	}{
+		{NewGBIdentifier(), "GB before title", gb, 0x130},
+		{NewGBIdentifier(), "GB after header", gb, 0x200},
+		{NewGBAIdentifier(), "GBA before game code", gba, 0xA8},
+		{NewSNESIdentifier(), "SNES before header", snes, 0x7F00},
+		{NewSNESIdentifier(), "SNES inside header", snes, 0x7FD0},
+		{NewN64Identifier(), "N64 inside header", n64, 0x20},
+		{NewN64Identifier(), "N64 before boot code end", n64, 0x800},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := shortReaderAt{data: tt.data, limit: tt.limit}
+			_, err := tt.identifier.Identify(reader, int64(len(tt.data)), nil)
+			var formatErr InvalidFormatError
+			if !errors.As(err, &formatErr) {
+				t.Fatalf("Identify() error = %v, want InvalidFormatError", err)
+			}
+			if !strings.Contains(err.Error(), "truncated") {
+				t.Errorf("Identify() error = %q, want it to mention truncation", err)
+			}
+		})
+	}
+}
+
+func TestReadROM(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("0123456789")
+
+	got, err := readROM(bytes.NewReader(data), ConsoleGB, 2, 4)
+	if err != nil || string(got) != "2345" {
+		t.Errorf("readROM() = %q, %v, want %q", got, err, "2345")
+	}
+
+	_, err = readROM(bytes.NewReader(data), ConsoleGB, 8, 4)
+	want := "invalid GB format: truncated: read 2 of 4 bytes at offset 0x8"
+	if err == nil || err.Error() != want {
+		t.Errorf("readROM() error = %v, want %q", err, want)
+	}
+}
+
+// sparseReaderAt reports a size far beyond its data, reading zeros past the
+// end of data, and records the largest read asked of it.
+type sparseReaderAt struct {
+	data    []byte
+	largest int
+}
+
+func (r *sparseReaderAt) ReadAt(buf []byte, off int64) (int, error) {
+	r.largest = max(r.largest, len(buf))
+	clear(buf)
+	if off < int64(len(r.data)) {
+		copy(buf, r.data[off:])
+	}
+	return len(buf), nil
+}
+
+func TestIdentify_OversizedCartridge(t *testing.T) {
+	t.Parallel()
+
+	const hugeSize = 4 << 30
+
+	tests := []struct {
+		identify func(io.ReaderAt, int64) error
+		name     string
+		data     []byte
+	}{
+		{
+			name: "GB",
+			data: createMinimalGBROM(),
+			identify: func(reader io.ReaderAt, size int64) error {
+				_, err := NewGBIdentifier().Identify(reader, size, nil)
+				return err
+			},
+		},
+		{
+			name: "SNES strict",
+			data: createMinimalSNESROMLoROM(),
+			identify: func(reader io.ReaderAt, size int64) error {
+				return NewSNESIdentifier().ValidateStrict(reader, size)
+			},
+		},
+		{
+			name: "NES",
+			data: []byte("NES\x1a"),
+			identify: func(reader io.ReaderAt, size int64) error {
+				_, err := NewNESIdentifier().Identify(reader, size, nil)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := &sparseReaderAt{data: tt.data}
+			err := tt.identify(reader, hugeSize)
+			var invalid InvalidFormatError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("error = %v, want InvalidFormatError", err)
+			}
+			if reader.largest > snesWindowSize {
+				t.Errorf("largest read = %d bytes, want only header reads", reader.largest)
+			}
+		})
+	}
+}
+
+func TestSNESIdentifier_OversizedReadsHeaderOnly(t *testing.T) {
+	t.Parallel()
+
+	// Identify only reads the header windows, so a huge file is still
+	// identified without being read in full
+	reader := &sparseReaderAt{data: createMinimalSNESROMLoROM()}
+	if _, err := NewSNESIdentifier().Identify(reader, 4<<30, nil); err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if reader.largest > snesWindowSize {
+		t.Errorf("largest read = %d bytes, want at most %d", reader.largest, snesWindowSize)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import "strings"
+
+// Region is a canonical game region. Result.Region keeps the raw string from
+// the header or database; Result.RegionCode holds the normalized value so
+// results from different consoles can be filtered the same way.
+type Region string
+
+// Canonical regions. European countries fold into RegionEurope and games
+// released for more than one region report RegionWorld.
+const (
+	RegionUnknown   Region = ""
+	RegionUSA       Region = "USA"
+	RegionJapan     Region = "Japan"
+	RegionEurope    Region = "Europe"
+	RegionWorld     Region = "World"
+	RegionAsia      Region = "Asia"
+	RegionKorea     Region = "Korea"
+	RegionChina     Region = "China"
+	RegionBrazil    Region = "Brazil"
+	RegionAustralia Region = "Australia"
+)
+
+// regionAliases maps lower-cased region names and codes found in headers and
+// databases to their canonical region. Single letters follow the No-Intro
+// and Sega conventions, where "E" means Europe.
+var regionAliases = map[string]Region{
+	"usa": RegionUSA, "us": RegionUSA, "u": RegionUSA, "america": RegionUSA, "americas": RegionUSA,
+	"north america": RegionUSA, "canada": RegionUSA, "ntsc-u": RegionUSA, "ntsc-uc": RegionUSA,
+	"japan": RegionJapan, "jpn": RegionJapan, "jp": RegionJapan, "j": RegionJapan, "ntsc-j": RegionJapan,
+	"europe": RegionEurope, "eur": RegionEurope, "eu": RegionEurope, "e": RegionEurope, "pal": RegionEurope,
+	"uk": RegionEurope, "france": RegionEurope, "germany": RegionEurope, "italy": RegionEurope,
+	"spain": RegionEurope, "netherlands": RegionEurope, "sweden": RegionEurope, "scandinavia": RegionEurope,
+	"world": RegionWorld, "w": RegionWorld,
+	"asia":  RegionAsia,
+	"korea": RegionKorea, "kor": RegionKorea, "k": RegionKorea,
+	"china": RegionChina, "chn": RegionChina,
+	"brazil": RegionBrazil, "bra": RegionBrazil,
+	"australia": RegionAustralia, "aus": RegionAustralia,
+}
+
+// n64CountryRegions maps the N64 header country byte (0x3E) to a region.
+var n64CountryRegions = map[byte]Region{
+	'A': RegionAsia, 'B': RegionBrazil, 'C': RegionChina, 'D': RegionEurope,
+	'E': RegionUSA, 'F': RegionEurope, 'G': RegionUSA, 'H': RegionEurope,
+	'I': RegionEurope, 'J': RegionJapan, 'K': RegionKorea, 'L': RegionEurope,
+	'N': RegionUSA, 'P': RegionEurope, 'S': RegionEurope, 'U': RegionAustralia,
+	'W': RegionEurope, 'X': RegionEurope, 'Y': RegionEurope, 'Z': RegionEurope,
+}
+
+// gbaCountryRegions maps the last character of a GBA game code to a region.
+var gbaCountryRegions = map[byte]Region{
+	'C': RegionChina, 'D': RegionEurope, 'E': RegionUSA, 'F': RegionEurope,
+	'H': RegionEurope, 'I': RegionEurope, 'J': RegionJapan, 'K': RegionKorea,
+	'P': RegionEurope, 'S': RegionEurope, 'U': RegionAustralia, 'X': RegionEurope,
+	'Y': RegionEurope, 'Z': RegionEurope,
+}
+
+// playStationSerialRegions maps the four-letter prefix of a PlayStation serial
+// to the region its publisher sold it in.
+var playStationSerialRegions = map[string]Region{
+	"SCUS": RegionUSA, "SLUS": RegionUSA, "PUPX": RegionUSA,
+	"SCPS": RegionJapan, "SCPM": RegionJapan, "SLPS": RegionJapan, "SLPM": RegionJapan,
+	"PAPX": RegionJapan, "PBPX": RegionJapan, "PCPX": RegionJapan, "TCPS": RegionJapan,
+	"SCES": RegionEurope, "SCED": RegionEurope, "SLES": RegionEurope, "SLED": RegionEurope,
+	"SCKA": RegionKorea, "SLKA": RegionKorea,
+	"SCAJ": RegionAsia, "SLAJ": RegionAsia,
+}
+
+// NormalizeRegion maps a free-form region string such as "USA", "U", "Europe"
+// or "Japan / Americas" to a canonical region. Lists naming more than one
+// region normalize to RegionWorld; unrecognized values give RegionUnknown.
+func NormalizeRegion(raw string) Region {
+	parts := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == '/' || r == ',' || r == '+' || r == '|'
+	})
+
+	found := RegionUnknown
+	for _, part := range parts {
+		region, ok := regionAliases[strings.ToLower(strings.TrimSpace(part))]
+		if !ok {
+			continue
+		}
+		if found != RegionUnknown && found != region {
+			return RegionWorld
+		}
+		found = region
+	}
+	return found
+}
+
+// RegionFromN64Country maps the N64 header country byte to a region. Unlike
+// the No-Intro convention, 'E' is the North American release.
+func RegionFromN64Country(code byte) Region {
+	return n64CountryRegions[code]
+}
+
+// RegionFromGBAGameCode maps a four-character GBA game code to a region using
+// its final (destination) character.
+func RegionFromGBAGameCode(gameCode string) Region {
+	if len(gameCode) != gbaGameCodeSize {
+		return RegionUnknown
+	}
+	return gbaCountryRegions[gameCode[gbaGameCodeSize-1]]
+}
+
+// RegionFromGenesisCode maps the Genesis header region field (J/U/E letters or
+// the newer single hex digit bitmask) to a region.
+func RegionFromGenesisCode(code string) Region {
+	return NormalizeRegion(strings.Join(parseGenesisRegionSupport([]byte(code)), "/"))
+}
+
+// RegionFromPlayStationSerial maps a PSX or PS2 serial such as "SLUS-01234"
+// or "SCES_123.45" to a region using its four-letter prefix.
+func RegionFromPlayStationSerial(serial string) Region {
+	if len(serial) < 4 {
+		return RegionUnknown
+	}
+	return playStationSerialRegions[strings.ToUpper(serial[:4])]
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNormalizeRegion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw  string
+		want Region
+	}{
+		{"USA", RegionUSA},
+		{"U", RegionUSA},
+		{"J", RegionJapan},
+		{"Europe", RegionEurope},
+		{"  pal ", RegionEurope},
+		{"Germany, France", RegionEurope},
+		{"Japan / Americas / Europe", RegionWorld},
+		{"USA, Japan", RegionWorld},
+		{"Korea", RegionKorea},
+		{"Mars", RegionUnknown},
+		{"", RegionUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NormalizeRegion(tt.raw); got != tt.want {
+				t.Errorf("NormalizeRegion(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegionFromConsoleCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		got  Region
+		want Region
+	}{
+		{"N64 E", RegionFromN64Country('E'), RegionUSA},
+		{"N64 P", RegionFromN64Country('P'), RegionEurope},
+		{"N64 J", RegionFromN64Country('J'), RegionJapan},
+		{"N64 unknown", RegionFromN64Country('?'), RegionUnknown},
+		{"GBA BPEE", RegionFromGBAGameCode("BPEE"), RegionUSA},
+		{"GBA AXVJ", RegionFromGBAGameCode("AXVJ"), RegionJapan},
+		{"GBA AXVD", RegionFromGBAGameCode("AXVD"), RegionEurope},
+		{"GBA short code", RegionFromGBAGameCode("BP"), RegionUnknown},
+		{"Genesis J", RegionFromGenesisCode("J  "), RegionJapan},
+		{"Genesis U", RegionFromGenesisCode("U"), RegionUSA},
+		{"Genesis JUE", RegionFromGenesisCode("JUE"), RegionWorld},
+		{"Genesis hex Europe", RegionFromGenesisCode("8"), RegionEurope},
+		{"PlayStation SLPS", RegionFromPlayStationSerial("SLPS_012.34"), RegionJapan},
+		{"PlayStation SLUS", RegionFromPlayStationSerial("SLUS-01234"), RegionUSA},
+		{"PlayStation SCES", RegionFromPlayStationSerial("sces_123.45"), RegionEurope},
+		{"PlayStation unknown", RegionFromPlayStationSerial("ABCD_123.45"), RegionUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tt.got != tt.want {
+				t.Errorf("region = %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentify_RegionCode(t *testing.T) {
+	t.Parallel()
+
+	n64Header := createN64HeaderBigEndian("SM", "E", "SUPER MARIO 64")
+	n64Result, err := NewN64Identifier().Identify(bytes.NewReader(n64Header), int64(len(n64Header)), nil)
+	if err != nil {
+		t.Fatalf("N64 Identify() error = %v", err)
+	}
+	if n64Result.RegionCode != RegionUSA {
+		t.Errorf("N64 RegionCode = %q, want %q", n64Result.RegionCode, RegionUSA)
+	}
+
+	genesisHeader := createGenesisHeader("SEGA MEGA DRIVE ", "REGION", "REGION", "00000002-")
+	copy(genesisHeader[0x1F0:], "J  ")
+	genesisResult, err := NewGenesisIdentifier().Identify(
+		bytes.NewReader(genesisHeader), int64(len(genesisHeader)), nil)
+	if err != nil {
+		t.Fatalf("Genesis Identify() error = %v", err)
+	}
+	if genesisResult.RegionCode != RegionJapan {
+		t.Errorf("Genesis RegionCode = %q, want %q", genesisResult.RegionCode, RegionJapan)
+	}
+	if genesisResult.Region != "Japan" {
+		t.Errorf("Genesis Region = %q, want raw %q", genesisResult.Region, "Japan")
+	}
+}
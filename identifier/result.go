@@ -0,0 +1,243 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// resultFieldKeys are metadata keys that are mirrored by Result fields and
+// therefore omitted from the metadata section of String.
+var resultFieldKeys = map[string]bool{
+	"ID": true, "title": true, "internal_title": true, "region": true,
+}
+
+// String returns a human-readable, multi-line description of the result.
+// Metadata keys are sorted and formatted for display ("rom_version" becomes
+// "Rom Version").
+func (r *Result) String() string {
+	var sb strings.Builder
+	_, _ = sb.WriteString("Console: " + string(r.Console))
+	writeLine := func(label, value string) {
+		if value != "" {
+			_, _ = sb.WriteString("\n" + label + ": " + value)
+		}
+	}
+	writeLine("ID", r.ID)
+	writeLine("Title", r.Title)
+	if r.InternalTitle != r.Title {
+		writeLine("Internal Title", r.InternalTitle)
+	}
+	writeLine("Region", r.Region)
+
+	keys := make([]string, 0, len(r.Metadata))
+	for key := range r.Metadata {
+		if !resultFieldKeys[key] {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) > 0 {
+		slices.Sort(keys)
+		_, _ = sb.WriteString("\n\nMetadata:")
+		for _, key := range keys {
+			_, _ = sb.WriteString("\n  " + displayKey(key) + ": " + r.Metadata[key])
+		}
+	}
+	return sb.String()
+}
+
+// MarshalJSON encodes the result with a fixed field order (Console, ID,
+// Title, InternalTitle, Region, RegionCode, Path, SourcePath, DiscNumber,
+// Metadata) and metadata sorted by key, so the output is byte-for-byte
+// stable. Path and SourcePath are left out when empty and DiscNumber when
+// unknown.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	_ = buf.WriteByte('{')
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"Console", string(r.Console)},
+		{"ID", r.ID},
+		{"Title", r.Title},
+		{"InternalTitle", r.InternalTitle},
+		{"Region", r.Region},
+		{"RegionCode", string(r.RegionCode)},
+	}
+	for _, field := range fields {
+		writeJSONString(&buf, field.name)
+		_ = buf.WriteByte(':')
+		writeJSONString(&buf, field.value)
+		_ = buf.WriteByte(',')
+	}
+	for _, field := range []struct {
+		name  string
+		value string
+	}{{"Path", r.Path}, {"SourcePath", r.SourcePath}} {
+		if field.value != "" {
+			writeJSONString(&buf, field.name)
+			_ = buf.WriteByte(':')
+			writeJSONString(&buf, field.value)
+			_ = buf.WriteByte(',')
+		}
+	}
+	if r.DiscNumber > 0 {
+		writeJSONString(&buf, "DiscNumber")
+		_, _ = buf.WriteString(":" + strconv.Itoa(r.DiscNumber) + ",")
+	}
+
+	writeJSONString(&buf, "Metadata")
+	_ = buf.WriteByte(':')
+	if r.Metadata == nil {
+		_, _ = buf.WriteString("null")
+	} else {
+		keys := make([]string, 0, len(r.Metadata))
+		for key := range r.Metadata {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		_ = buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				_ = buf.WriteByte(',')
+			}
+			writeJSONString(&buf, key)
+			_ = buf.WriteByte(':')
+			writeJSONString(&buf, r.Metadata[key])
+		}
+		_ = buf.WriteByte('}')
+	}
+	_ = buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// FieldDiff is a field that differs between two results. Field is a Result
+// field name, or "Metadata." followed by the key for metadata entries.
+type FieldDiff struct {
+	Field string
+	Value string
+	Other string
+}
+
+// Equal reports whether r and other describe the same identification, and
+// lists the fields that differ, sorted with Result fields first. Values are
+// normalized before comparison: surrounding space is trimmed, "None" and
+// "null" count as empty, and hex values ("0x1A2B") compare case-insensitively.
+// A metadata key missing on one side equals an empty value on the other.
+// Path and SourcePath are not compared: they say where the game was read
+// from, not which game it is, so the same dump found in two places is equal.
+func (r *Result) Equal(other *Result) (bool, []FieldDiff) {
+	if r == nil || other == nil {
+		if r == other {
+			return true, nil
+		}
+		return false, []FieldDiff{{Field: "Result", Value: resultPresence(r), Other: resultPresence(other)}}
+	}
+
+	var diffs []FieldDiff
+	compare := func(field, value, otherValue string) {
+		if normalizeValue(value) != normalizeValue(otherValue) {
+			diffs = append(diffs, FieldDiff{Field: field, Value: value, Other: otherValue})
+		}
+	}
+	compare("Console", string(r.Console), string(other.Console))
+	compare("ID", r.ID, other.ID)
+	compare("Title", r.Title, other.Title)
+	compare("InternalTitle", r.InternalTitle, other.InternalTitle)
+	compare("Region", r.Region, other.Region)
+	compare("RegionCode", string(r.RegionCode), string(other.RegionCode))
+	compare("DiscNumber", discNumberString(r.DiscNumber), discNumberString(other.DiscNumber))
+
+	keys := make([]string, 0, len(r.Metadata)+len(other.Metadata))
+	for key := range r.Metadata {
+		keys = append(keys, key)
+	}
+	for key := range other.Metadata {
+		if _, ok := r.Metadata[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		compare("Metadata."+key, r.Metadata[key], other.Metadata[key])
+	}
+
+	return len(diffs) == 0, diffs
+}
+
+// normalizeValue is the form of a result value that Equal compares.
+func normalizeValue(value string) string {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "None", "null":
+		return ""
+	}
+	if len(value) > 2 && (value[:2] == "0x" || value[:2] == "0X") {
+		return strings.ToLower(value)
+	}
+	return value
+}
+
+// resultPresence describes a possibly nil result for a FieldDiff.
+func resultPresence(r *Result) string {
+	if r == nil {
+		return "nil"
+	}
+	return "non-nil"
+}
+
+// discNumberString formats a disc number for comparison, leaving the
+// unknown disc empty.
+func discNumberString(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// writeJSONString writes s to buf as a JSON string literal.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	encoded, _ := json.Marshal(s) //nolint:errchkjson // string values always encode
+	_, _ = buf.Write(encoded)
+}
+
+// displayKey formats a snake_case metadata key for display.
+func displayKey(key string) string {
+	words := strings.Split(key, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Ensure Result implements the formatting interfaces
+var (
+	_ fmt.Stringer   = (*Result)(nil)
+	_ json.Marshaler = (*Result)(nil)
+)
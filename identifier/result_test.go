@@ -0,0 +1,305 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func newTestResult() *Result {
+	result := NewResult(ConsoleGBA)
+	result.SetMetadata("ID", "BPEE")
+	result.SetMetadata("internal_title", "POKEMON EMER")
+	result.SetMetadata("title", "Pokemon Emerald")
+	result.SetMetadata("region", "USA")
+	result.SetMetadata("maker_code", "01")
+	result.SetMetadata("software_version", "0")
+	result.SetMetadata("device_type", "0x00")
+	result.SetMetadata("main_unit_code", "0x00")
+	return result
+}
+
+func TestResult_MarshalJSON_Stable(t *testing.T) {
+	t.Parallel()
+
+	result := newTestResult()
+
+	first, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	for range 20 {
+		next, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("MarshalJSON() error = %v", err)
+		}
+		if !bytes.Equal(first, next) {
+			t.Fatalf("MarshalJSON() output differs between calls:\n%s\n%s", first, next)
+		}
+	}
+
+	want := `{"Console":"GBA","ID":"BPEE","Title":"Pokemon Emerald","InternalTitle":"POKEMON EMER",` +
+		`"Region":"USA","RegionCode":"USA","Metadata":{"ID":"BPEE","device_type":"0x00","internal_title":"POKEMON EMER",` +
+		`"main_unit_code":"0x00","maker_code":"01","region":"USA","software_version":"0",` +
+		`"title":"Pokemon Emerald"}}`
+	if string(first) != want {
+		t.Errorf("MarshalJSON() =\n%s\nwant\n%s", first, want)
+	}
+}
+
+func TestResult_MarshalJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	result := newTestResult()
+	result.SetMetadata("note", "quote \" and <tag>")
+	result.DiscNumber = 2
+	result.Path = "roms.zip"
+	result.SourcePath = "roms/game.gba"
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+
+	var decoded Result
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.ID != result.ID || decoded.Title != result.Title || decoded.Console != result.Console ||
+		decoded.DiscNumber != result.DiscNumber || decoded.Path != result.Path || decoded.SourcePath != result.SourcePath {
+		t.Errorf("decoded = %+v, want %+v", decoded, *result)
+	}
+	if decoded.Metadata["note"] != "quote \" and <tag>" {
+		t.Errorf("note = %q, want escaped value to round-trip", decoded.Metadata["note"])
+	}
+}
+
+func TestResult_MarshalJSON_OptionalFields(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(&Result{
+		Console: ConsolePSX, ID: "SLUS-00001", DiscNumber: 2,
+		Path: "games/game.cue", SourcePath: "games/game (Track 1).bin",
+	})
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	want := `{"Console":"PSX","ID":"SLUS-00001","Title":"","InternalTitle":"","Region":"","RegionCode":"",` +
+		`"Path":"games/game.cue","SourcePath":"games/game (Track 1).bin","DiscNumber":2,"Metadata":null}`
+	if string(data) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", data, want)
+	}
+}
+
+func TestResult_MarshalJSON_NilMetadata(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(&Result{Console: ConsoleNES, ID: "12345678"})
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	want := `{"Console":"NES","ID":"12345678","Title":"","InternalTitle":"","Region":"","RegionCode":"",` +
+		`"Metadata":null}`
+	if string(data) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", data, want)
+	}
+}
+
+func TestResult_String(t *testing.T) {
+	t.Parallel()
+
+	want := "Console: GBA\n" +
+		"ID: BPEE\n" +
+		"Title: Pokemon Emerald\n" +
+		"Internal Title: POKEMON EMER\n" +
+		"Region: USA\n" +
+		"\n" +
+		"Metadata:\n" +
+		"  Device Type: 0x00\n" +
+		"  Main Unit Code: 0x00\n" +
+		"  Maker Code: 01\n" +
+		"  Software Version: 0"
+	if got := newTestResult().String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestResult_String_Minimal(t *testing.T) {
+	t.Parallel()
+
+	result := NewResult(ConsoleNES)
+	result.Title = "Same"
+	result.InternalTitle = "Same"
+	if got := result.String(); got != "Console: NES\nTitle: Same" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestResult_Equal_HexCase(t *testing.T) {
+	t.Parallel()
+
+	result := newTestResult()
+	result.SetMetadata("checksum", "0x1A2B")
+	other := newTestResult()
+	other.SetMetadata("checksum", "0x1a2b")
+
+	equal, diffs := result.Equal(other)
+	if !equal || len(diffs) != 0 {
+		t.Errorf("Equal() = %v, %v, want true with no diffs", equal, diffs)
+	}
+}
+
+func TestResult_Equal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		modify    func(r *Result)
+		name      string
+		wantDiffs []FieldDiff
+	}{
+		{
+			name:   "None equals missing",
+			modify: func(r *Result) { r.Metadata["publisher"] = "None" },
+		},
+		{
+			name:   "surrounding space",
+			modify: func(r *Result) { r.Title = " Pokemon Emerald " },
+		},
+		{
+			name:      "different title",
+			modify:    func(r *Result) { r.Title = "Pokemon Ruby" },
+			wantDiffs: []FieldDiff{{Field: "Title", Value: "Pokemon Emerald", Other: "Pokemon Ruby"}},
+		},
+		{
+			name: "metadata only on one side",
+			modify: func(r *Result) {
+				r.Metadata["maker_code"] = "02"
+				r.Metadata["rom_version"] = "1"
+			},
+			wantDiffs: []FieldDiff{
+				{Field: "Metadata.maker_code", Value: "01", Other: "02"},
+				{Field: "Metadata.rom_version", Value: "", Other: "1"},
+			},
+		},
+		{
+			name: "paths are not compared",
+			modify: func(r *Result) {
+				r.Path = "elsewhere/game.zip"
+				r.SourcePath = "game.gba"
+			},
+		},
+		{
+			name:      "non-hex text keeps case",
+			modify:    func(r *Result) { r.ID = "bpee" },
+			wantDiffs: []FieldDiff{{Field: "ID", Value: "BPEE", Other: "bpee"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			other := newTestResult()
+			tt.modify(other)
+			equal, diffs := newTestResult().Equal(other)
+			if equal != (len(tt.wantDiffs) == 0) {
+				t.Errorf("Equal() = %v, want %v", equal, len(tt.wantDiffs) == 0)
+			}
+			if !slices.Equal(diffs, tt.wantDiffs) {
+				t.Errorf("Equal() diffs = %v, want %v", diffs, tt.wantDiffs)
+			}
+		})
+	}
+}
+
+func TestResult_Equal_Nil(t *testing.T) {
+	t.Parallel()
+
+	var none *Result
+	if equal, _ := none.Equal(nil); !equal {
+		t.Error("nil.Equal(nil) = false, want true")
+	}
+	if equal, diffs := newTestResult().Equal(nil); equal || len(diffs) != 1 {
+		t.Errorf("Equal(nil) = %v, %v, want false with one diff", equal, diffs)
+	}
+}
+
+func TestResult_ApplyTitleSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		dbTitle   string
+		romTitle  string
+		fileTitle string
+		want      string
+		source    TitleSource
+	}{
+		{name: "database first", source: TitleDatabaseFirst, dbTitle: "DB", romTitle: "ROM", fileTitle: "File", want: "DB"},
+		{name: "database first without database", source: TitleDatabaseFirst, romTitle: "ROM", fileTitle: "File", want: "ROM"},
+		{name: "database first with file only", source: TitleDatabaseFirst, fileTitle: "File", want: "File"},
+		{name: "rom first", source: TitleRomFirst, dbTitle: "DB", romTitle: "ROM", fileTitle: "File", want: "ROM"},
+		{name: "rom first without rom title", source: TitleRomFirst, dbTitle: "DB", fileTitle: "File", want: "DB"},
+		{name: "filename fallback", source: TitleFilenameFallback, dbTitle: "DB", romTitle: "ROM", fileTitle: "File", want: "DB"},
+		{name: "filename fallback without database", source: TitleFilenameFallback, romTitle: "ROM", fileTitle: "File", want: "File"},
+		{name: "filename fallback with rom only", source: TitleFilenameFallback, romTitle: "ROM", want: "ROM"},
+		{name: "nothing known", source: TitleRomFirst, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := NewResult(ConsoleGBA)
+			result.InternalTitle = tt.romTitle
+			result.MergeMetadata(map[string]string{"title": tt.dbTitle})
+			result.SetROMTitle(tt.romTitle)
+
+			result.ApplyTitleSource(tt.source, tt.fileTitle)
+			if result.Title != tt.want {
+				t.Errorf("Title = %q, want %q", result.Title, tt.want)
+			}
+		})
+	}
+}
+
+func TestResult_SetROMTitle(t *testing.T) {
+	t.Parallel()
+
+	// The title read from the game need not be InternalTitle, as with a
+	// GameCube banner title
+	result := NewResult(ConsoleGC)
+	result.InternalTitle = "BOOT HEADER"
+	result.SetROMTitle("Banner Title")
+	if result.Title != "Banner Title" {
+		t.Errorf("Title = %q, want %q", result.Title, "Banner Title")
+	}
+
+	result.MergeMetadata(map[string]string{"title": "Database Title"})
+	if result.Title != "Database Title" {
+		t.Errorf("Title after merge = %q, want %q", result.Title, "Database Title")
+	}
+	result.ApplyTitleSource(TitleRomFirst, "")
+	if result.Title != "Banner Title" {
+		t.Errorf("Title with TitleRomFirst = %q, want %q", result.Title, "Banner Title")
+	}
+}
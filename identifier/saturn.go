@@ -19,6 +19,7 @@
 package identifier
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -65,6 +66,11 @@ var saturnTargetAreas = map[byte]string{
 	'L': "Central and South America PAL",
 }
 
+// saturnHeaderSize is how much of the first sector is read to find the IP
+// header. Raw 2352-byte sectors place the magic word at 0x10 after the sync
+// pattern, which still leaves room for the full 0x100-byte header.
+const saturnHeaderSize = 0x110
+
 // SaturnIdentifier identifies Sega Saturn games.
 type SaturnIdentifier struct{}
 
@@ -94,14 +100,21 @@ func (s *SaturnIdentifier) Identify(reader io.ReaderAt, size int64, db Database)
 }
 
 // IdentifyFromPath identifies a Saturn game from a file path.
-//
-//nolint:gocognit,revive // CUE/CHD/ISO handling requires separate branches
+// ISO, raw BIN (via CUE) and CHD images are supported.
 func (s *SaturnIdentifier) IdentifyFromPath(path string, database Database) (*Result, error) {
-	ext := strings.ToLower(filepath.Ext(path))
+	header, err := readSaturnHeader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.identifyFromHeader(header, database)
+}
 
-	var header []byte
+// readSaturnHeader reads the start of the first data sector of a disc image.
+func readSaturnHeader(path string) ([]byte, error) {
+	header := make([]byte, saturnHeaderSize)
 
-	switch ext {
+	switch strings.ToLower(filepath.Ext(path)) {
 	case ".cue":
 		cue, err := iso9660.ParseCue(path)
 		if err != nil {
@@ -110,15 +123,7 @@ func (s *SaturnIdentifier) IdentifyFromPath(path string, database Database) (*Re
 		if len(cue.BinFiles) == 0 {
 			return nil, ErrInvalidFormat{Console: ConsoleSaturn, Reason: "no BIN files in CUE"}
 		}
-		binFile, err := os.Open(cue.BinFiles[0])
-		if err != nil {
-			return nil, fmt.Errorf("open BIN file: %w", err)
-		}
-		defer func() { _ = binFile.Close() }()
-		header = make([]byte, 0x100)
-		if _, err := binFile.Read(header); err != nil {
-			return nil, fmt.Errorf("read BIN header: %w", err)
-		}
+		return readSaturnHeaderFile(cue.BinFiles[0], header, "BIN")
 
 	case ".chd":
 		chdFile, err := chd.Open(path)
@@ -126,25 +131,31 @@ func (s *SaturnIdentifier) IdentifyFromPath(path string, database Database) (*Re
 			return nil, fmt.Errorf("open CHD: %w", err)
 		}
 		defer func() { _ = chdFile.Close() }()
-		header = make([]byte, 0x100)
-		reader := chdFile.RawSectorReader()
-		if _, err := reader.ReadAt(header, 0); err != nil {
+		n, err := chdFile.RawSectorReader().ReadAt(header, 0)
+		if err != nil && (!errors.Is(err, io.EOF) || n == 0) {
 			return nil, fmt.Errorf("read CHD header: %w", err)
 		}
+		return header[:n], nil
 
 	default:
-		isoFile, err := os.Open(path) //nolint:gosec // Path from user input is expected
-		if err != nil {
-			return nil, fmt.Errorf("open ISO file: %w", err)
-		}
-		defer func() { _ = isoFile.Close() }()
-		header = make([]byte, 0x100)
-		if _, err := isoFile.Read(header); err != nil {
-			return nil, fmt.Errorf("read ISO header: %w", err)
-		}
+		return readSaturnHeaderFile(path, header, "ISO")
 	}
+}
 
-	return s.identifyFromHeader(header, database)
+// readSaturnHeaderFile fills header from the start of a plain image file.
+// Short files are returned truncated so the magic word check can reject them.
+func readSaturnHeaderFile(path string, header []byte, kind string) ([]byte, error) {
+	file, err := os.Open(path) //nolint:gosec // Path from user input is expected
+	if err != nil {
+		return nil, fmt.Errorf("open %s file: %w", kind, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("read %s header: %w", kind, err)
+	}
+	return header[:n], nil
 }
 
 func (*SaturnIdentifier) identifyFromHeader(header []byte, db Database) (*Result, error) {
@@ -161,7 +172,7 @@ func (*SaturnIdentifier) identifyFromHeader(header []byte, db Database) (*Result
 		if end > len(header) {
 			return ""
 		}
-		return strings.TrimSpace(string(header[start:end]))
+		return binary.CleanString(header[start:end])
 	}
 
 	manufacturerID := extractString(0x10, 0x10)
@@ -182,6 +193,8 @@ func (*SaturnIdentifier) identifyFromHeader(header []byte, db Database) (*Result
 
 	// Target area (offset 0x40, 16 bytes)
 	targetArea := parseSaturnTargetArea(header, magicIdx)
+	areaSymbols := extractString(0x40, 0x10)
+	peripherals := extractString(0x50, 0x10)
 
 	// Normalize serial for database lookup
 	serial := strings.ReplaceAll(gameID, "-", "")
@@ -193,6 +206,7 @@ func (*SaturnIdentifier) identifyFromHeader(header []byte, db Database) (*Result
 	result.InternalTitle = internalTitle
 	result.SetMetadata("manufacturer_ID", manufacturerID)
 	result.SetMetadata("ID", gameID)
+	result.SetMetadata("product_number", gameID)
 	result.SetMetadata("version", version)
 	result.SetMetadata("device_info", deviceInfo)
 	result.SetMetadata("internal_title", internalTitle)
@@ -201,6 +215,14 @@ func (*SaturnIdentifier) identifyFromHeader(header []byte, db Database) (*Result
 		result.SetMetadata("release_date", releaseDate)
 	}
 
+	if areaSymbols != "" {
+		result.SetMetadata("area_symbols", areaSymbols)
+	}
+
+	if peripherals != "" {
+		result.SetMetadata("peripherals", peripherals)
+	}
+
 	if len(deviceSupport) > 0 {
 		result.SetMetadata("device_support", strings.Join(deviceSupport, " / "))
 	}
@@ -217,9 +239,7 @@ func (*SaturnIdentifier) identifyFromHeader(header []byte, db Database) (*Result
 	}
 
 	// If no title from database, use internal title
-	if result.Title == "" {
-		result.Title = result.InternalTitle
-	}
+	result.SetROMTitle(result.InternalTitle)
 
 	return result, nil
 }
@@ -266,6 +286,11 @@ func parseSaturnTargetArea(header []byte, magicIdx int) []string {
 	return targetArea
 }
 
+// Validate reports whether header, the start of a file, could be a Saturn disc.
+func (*SaturnIdentifier) Validate(header []byte) bool {
+	return ValidateSaturn(header)
+}
+
 // ValidateSaturn checks if the given data looks like a valid Saturn disc.
 func ValidateSaturn(header []byte) bool {
 	return binary.FindBytes(header, saturnMagicWord) != -1
@@ -20,6 +20,8 @@ package identifier
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -216,6 +218,143 @@ func TestSaturnIdentifier_TargetArea(t *testing.T) {
 	}
 }
 
+func TestSaturnIdentifier_HeaderFields(t *testing.T) {
+	t.Parallel()
+
+	header := createSaturnHeader("SEGA ENTERPRISES", "MK-81009  ", "V1.001", "NIGHTS")
+	copy(header[0x50:], "JA              ")
+
+	result, err := NewSaturnIdentifier().Identify(bytes.NewReader(header), int64(len(header)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+
+	want := map[string]string{
+		"manufacturer_ID": "SEGA ENTERPRISES",
+		"ID":              "MK-81009",
+		"product_number":  "MK-81009",
+		"version":         "V1.001",
+		"release_date":    "1996-11-22",
+		"device_info":     "CD-1/1",
+		"area_symbols":    "JUE",
+		"target_area":     "Japan / North America (USA, Canada) / Europe PAL",
+		"peripherals":     "JA",
+		"device_support":  "Joypad / Virtua Stick or Analog Controller",
+	}
+	for key, value := range want {
+		if got := result.Metadata[key]; got != value {
+			t.Errorf("Metadata[%q] = %q, want %q", key, got, value)
+		}
+	}
+	if !strings.HasPrefix(result.Title, "NIGHTS") {
+		t.Errorf("Title = %q, want prefix %q", result.Title, "NIGHTS")
+	}
+}
+
+func TestSaturnIdentifier_DatabaseLookup(t *testing.T) {
+	t.Parallel()
+
+	header := createSaturnHeader("SEGA ENTERPRISES", "MK-81009", "V1.001", "NIGHTS")
+	saturnDB := &mockSaturnDatabase{entries: map[string]map[string]string{
+		"MK81009": {"title": "NiGHTS into Dreams..."},
+	}}
+
+	result, err := NewSaturnIdentifier().Identify(bytes.NewReader(header), int64(len(header)), saturnDB)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+
+	if result.Title != "NiGHTS into Dreams..." {
+		t.Errorf("Title = %q, want %q", result.Title, "NiGHTS into Dreams...")
+	}
+}
+
+// mockSaturnDatabase implements Database for Saturn testing.
+type mockSaturnDatabase struct {
+	entries map[string]map[string]string // normalized serial -> entry
+}
+
+func (*mockSaturnDatabase) Lookup(_ Console, _ any) (map[string]string, bool) {
+	return nil, false
+}
+
+func (m *mockSaturnDatabase) LookupByString(console Console, key string) (map[string]string, bool) {
+	if console != ConsoleSaturn {
+		return nil, false
+	}
+	entry, found := m.entries[key]
+	return entry, found
+}
+
+func (*mockSaturnDatabase) GetIDPrefixes(_ Console) []string {
+	return nil
+}
+
+func TestSaturnIdentifier_IdentifyFromPath(t *testing.T) {
+	t.Parallel()
+
+	header := createSaturnHeader("SEGA ENTERPRISES", "MK-81009", "V1.001", "NIGHTS")
+
+	// 2048-byte ISO sector holds the IP header at offset 0
+	isoSector := make([]byte, 2048)
+	copy(isoSector, header)
+
+	// Raw 2352-byte sector: 12-byte sync, 4-byte header, then user data
+	rawSector := make([]byte, 2352)
+	copy(rawSector, []byte{0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00})
+	rawSector[15] = 0x01
+	copy(rawSector[16:], header)
+
+	dir := t.TempDir()
+	isoPath := filepath.Join(dir, "game.iso")
+	binPath := filepath.Join(dir, "game.bin")
+	cuePath := filepath.Join(dir, "game.cue")
+	cue := "FILE \"game.bin\" BINARY\n  TRACK 01 MODE1/2352\n    INDEX 01 00:00:00\n"
+	for path, data := range map[string][]byte{isoPath: isoSector, binPath: rawSector, cuePath: []byte(cue)} {
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "ISO", path: isoPath},
+		{name: "CUE", path: cuePath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewSaturnIdentifier().IdentifyFromPath(tt.path, nil)
+			if err != nil {
+				t.Fatalf("IdentifyFromPath() error = %v", err)
+			}
+			if result.ID != "MK-81009" {
+				t.Errorf("ID = %q, want %q", result.ID, "MK-81009")
+			}
+			if got := result.Metadata["area_symbols"]; got != "JUE" {
+				t.Errorf("area_symbols = %q, want %q", got, "JUE")
+			}
+		})
+	}
+}
+
+func TestSaturnIdentifier_IdentifyFromPath_ShortFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "short.iso")
+	if err := os.WriteFile(path, []byte("SEGA"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if _, err := NewSaturnIdentifier().IdentifyFromPath(path, nil); err == nil {
+		t.Error("IdentifyFromPath() expected error for short file, got nil")
+	}
+}
+
 func TestValidateSaturn(t *testing.T) {
 	t.Parallel()
 
@@ -166,7 +166,7 @@ func (s *SegaCDIdentifier) identifyFromHeader(header []byte, db Database, iso *i
 		if end > len(header) {
 			return ""
 		}
-		return strings.TrimSpace(string(header[start:end]))
+		return strings.TrimSpace(strings.TrimRight(string(header[start:end]), "\x00"))
 	}
 
 	discID := extractString(0x000, 0x10)
@@ -185,16 +185,20 @@ func (s *SegaCDIdentifier) identifyFromHeader(header []byte, db Database, iso *i
 	titleDomestic := extractString(0x120, 0x30)
 	titleOverseas := extractString(0x150, 0x30)
 
-	// Software type and ID
-	gameID := extractString(0x180, 0x10)
+	// Software type, serial and revision, e.g. "GM G-6021  -00"
+	productCode := extractString(0x180, 0x10)
+	softwareType, gameID, revision := parseSegaCDProductCode(productCode)
 
 	// Device support
 	deviceSupport := parseSegaCDDeviceSupport(header, magicIdx)
 
 	// Region support (at 0x1F0 from magic word for Genesis layout)
-	regionSupport := parseSegaCDRegionSupport(header, magicIdx)
+	var regionCode []byte
+	if magicIdx+0x1F3 <= len(header) {
+		regionCode = header[magicIdx+0x1F0 : magicIdx+0x1F3]
+	}
 
-	// Normalize serial for database lookup
+	// Normalize serial for database lookup, which stores it without dashes
 	serial := strings.ReplaceAll(gameID, "#", "")
 	serial = strings.ReplaceAll(serial, "-", "")
 	serial = strings.ReplaceAll(serial, " ", "")
@@ -217,14 +221,17 @@ func (s *SegaCDIdentifier) identifyFromHeader(header []byte, db Database, iso *i
 	result.SetMetadata("title_domestic", titleDomestic)
 	result.SetMetadata("title_overseas", titleOverseas)
 	result.SetMetadata("ID", gameID)
+	result.SetMetadata("product_code", productCode)
+	result.SetMetadata("serial", serial)
+	result.SetMetadata("revision", revision)
+	setGenesisSoftwareType(result, softwareType)
 
 	if len(deviceSupport) > 0 {
 		result.SetMetadata("device_support", strings.Join(deviceSupport, " / "))
 	}
 
-	if len(regionSupport) > 0 {
-		result.SetMetadata("region_support", strings.Join(regionSupport, " / "))
-	}
+	result.RegionCode = RegionFromGenesisCode(string(regionCode))
+	setGenesisRegionSupport(result, parseGenesisRegionSupport(regionCode))
 
 	// Add ISO metadata if available
 	if iso != nil {
@@ -240,9 +247,7 @@ func (s *SegaCDIdentifier) identifyFromHeader(header []byte, db Database, iso *i
 	}
 
 	// If no title from database, use overseas title
-	if result.Title == "" {
-		result.Title = result.InternalTitle
-	}
+	result.SetROMTitle(result.InternalTitle)
 
 	return result, nil
 }
@@ -266,6 +271,25 @@ func parseSegaCDBuildDate(raw string) string {
 	return raw
 }
 
+// parseSegaCDProductCode splits the product code field of the boot header
+// into its software type, serial and revision. Licensed discs lay it out as
+// "GM G-6021  -00"; the type and revision are optional, so a bare "G-6021"
+// is taken as the serial.
+func parseSegaCDProductCode(code string) (softwareType, serial, revision string) {
+	if len(code) > 3 && code[2] == ' ' && isUpperASCII(code[0]) && isUpperASCII(code[1]) {
+		softwareType, code = code[:2], strings.TrimSpace(code[3:])
+	}
+	if dash := strings.LastIndexByte(code, '-'); dash > 0 && len(code)-dash == 3 &&
+		isDigitASCII(code[dash+1]) && isDigitASCII(code[dash+2]) {
+		code, revision = strings.TrimSpace(code[:dash]), code[dash+1:]
+	}
+	return softwareType, code, revision
+}
+
+func isUpperASCII(b byte) bool { return b >= 'A' && b <= 'Z' }
+
+func isDigitASCII(b byte) bool { return b >= '0' && b <= '9' }
+
 // parseSegaCDDeviceSupport extracts device support codes from header.
 func parseSegaCDDeviceSupport(header []byte, magicIdx int) []string {
 	var deviceSupport []string
@@ -284,21 +308,9 @@ func parseSegaCDDeviceSupport(header []byte, magicIdx int) []string {
 	return deviceSupport
 }
 
-// parseSegaCDRegionSupport extracts region support codes from header.
-func parseSegaCDRegionSupport(header []byte, magicIdx int) []string {
-	var regionSupport []string
-	if magicIdx+0x1F3 > len(header) {
-		return regionSupport
-	}
-	for _, b := range header[magicIdx+0x1F0 : magicIdx+0x1F3] {
-		if b < '!' || b > '~' {
-			continue
-		}
-		if reg, ok := genesisRegionSupport[b]; ok {
-			regionSupport = append(regionSupport, reg)
-		}
-	}
-	return regionSupport
+// Validate reports whether header, the start of a file, could be a Sega CD disc.
+func (*SegaCDIdentifier) Validate(header []byte) bool {
+	return ValidateSegaCD(header)
 }
 
 // ValidateSegaCD checks if the given data looks like a valid Sega CD disc.
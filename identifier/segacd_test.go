@@ -259,6 +259,66 @@ func TestSegaCDIdentifier_OverseasTitlePreferred(t *testing.T) {
 	}
 }
 
+func TestSegaCDIdentifier_BootHeaderSerial(t *testing.T) {
+	t.Parallel()
+
+	header := createSegaCDHeader("SONICCD", "SEGA", "SONIC CD", "SONIC THE HEDGEHOG CD", "GM G-6021  -00")
+	copy(header[0x1F0:], "U  ")
+
+	db := newMockDatabase()
+	db.addEntry(ConsoleSegaCD, "G6021", map[string]string{"title": "Sonic CD (USA)"})
+
+	result, err := NewSegaCDIdentifier().Identify(bytes.NewReader(header), int64(len(header)), db)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+
+	want := map[string]string{
+		"ID":             "G-6021",
+		"serial":         "G6021",
+		"revision":       "00",
+		"software_type":  "Game",
+		"product_code":   "GM G-6021  -00",
+		"region":         "Americas",
+		"title_overseas": "SONIC THE HEDGEHOG CD",
+	}
+	for key, value := range want {
+		if got := result.Metadata[key]; got != value {
+			t.Errorf("Metadata[%q] = %q, want %q", key, got, value)
+		}
+	}
+	if result.ID != "G-6021" {
+		t.Errorf("ID = %q, want %q", result.ID, "G-6021")
+	}
+	if result.RegionCode != RegionUSA {
+		t.Errorf("RegionCode = %q, want %q", result.RegionCode, RegionUSA)
+	}
+	if result.Title != "Sonic CD (USA)" {
+		t.Errorf("Title = %q, want the database title", result.Title)
+	}
+}
+
+func TestParseSegaCDProductCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code, softwareType, serial, revision string
+	}{
+		{"GM G-6021  -00", "GM", "G-6021", "00"},
+		{"GM 00-2501-14", "GM", "00-2501", "14"},
+		{"GM MK-4407 -01", "GM", "MK-4407", "01"},
+		{"T-127015", "", "T-127015", ""},
+		{"G-6014", "", "G-6014", ""},
+	}
+	for _, tt := range tests {
+		softwareType, serial, revision := parseSegaCDProductCode(tt.code)
+		if softwareType != tt.softwareType || serial != tt.serial || revision != tt.revision {
+			t.Errorf("parseSegaCDProductCode(%q) = %q, %q, %q; want %q, %q, %q", tt.code,
+				softwareType, serial, revision, tt.softwareType, tt.serial, tt.revision)
+		}
+	}
+}
+
 func TestValidateSegaCD(t *testing.T) {
 	t.Parallel()
 
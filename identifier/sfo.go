@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+	"strings"
+)
+
+// PARAM.SFO layout
+const (
+	sfoHeaderSize     = 0x14
+	sfoIndexEntrySize = 0x10
+	sfoFormatUTF8S    = 0x0004 // UTF-8 without NUL termination
+	sfoFormatUTF8     = 0x0204
+	sfoFormatInt32    = 0x0404
+)
+
+var sfoMagic = []byte("\x00PSF")
+
+// parseSFO decodes a PARAM.SFO, the key/value table Sony consoles use for
+// title metadata. Integer values are returned in decimal. It reports false
+// if data is not a well-formed SFO; entries pointing outside data are skipped.
+func parseSFO(data []byte) (map[string]string, bool) {
+	if len(data) < sfoHeaderSize || !bytes.Equal(data[:4], sfoMagic) {
+		return nil, false
+	}
+	keyTable := int(binary.LittleEndian.Uint32(data[0x08:]))
+	dataTable := int(binary.LittleEndian.Uint32(data[0x0C:]))
+	count := int(binary.LittleEndian.Uint32(data[0x10:]))
+	if keyTable > len(data) || dataTable > len(data) || count > (len(data)-sfoHeaderSize)/sfoIndexEntrySize {
+		return nil, false
+	}
+
+	params := make(map[string]string, count)
+	for i := range count {
+		entry := data[sfoHeaderSize+i*sfoIndexEntrySize:]
+		keyStart := keyTable + int(binary.LittleEndian.Uint16(entry[0:]))
+		format := binary.LittleEndian.Uint16(entry[2:])
+		length := int(binary.LittleEndian.Uint32(entry[4:]))
+		valueStart := dataTable + int(binary.LittleEndian.Uint32(entry[12:]))
+		if keyStart >= len(data) || length > len(data)-valueStart {
+			continue
+		}
+
+		key, _, _ := strings.Cut(string(data[keyStart:]), "\x00")
+		value := data[valueStart : valueStart+length]
+		switch format {
+		case sfoFormatUTF8, sfoFormatUTF8S:
+			text, _, _ := strings.Cut(string(value), "\x00")
+			params[key] = strings.TrimSpace(text)
+		case sfoFormatInt32:
+			if len(value) >= 4 {
+				params[key] = strconv.FormatUint(uint64(binary.LittleEndian.Uint32(value)), 10)
+			}
+		}
+	}
+	return params, true
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// sfoParam is one PARAM.SFO entry for createSFO: a string, or an int32 when
+// value is an int.
+type sfoParam struct {
+	value any
+	key   string
+}
+
+// createSFO builds a PARAM.SFO holding params in order.
+func createSFO(params ...sfoParam) []byte {
+	var keys, values []byte
+	index := make([]byte, 0, len(params)*sfoIndexEntrySize)
+	for _, param := range params {
+		entry := make([]byte, sfoIndexEntrySize)
+		binary.LittleEndian.PutUint16(entry[0:], uint16(len(keys))) //nolint:gosec // test data
+		binary.LittleEndian.PutUint32(entry[12:], uint32(len(values)))
+		keys = append(append(keys, param.key...), 0)
+
+		var value []byte
+		switch v := param.value.(type) {
+		case int:
+			binary.LittleEndian.PutUint16(entry[2:], sfoFormatInt32)
+			value = binary.LittleEndian.AppendUint32(nil, uint32(v)) //nolint:gosec // test data
+		case string:
+			binary.LittleEndian.PutUint16(entry[2:], sfoFormatUTF8)
+			value = append([]byte(v), 0)
+		}
+		binary.LittleEndian.PutUint32(entry[4:], uint32(len(value)))   //nolint:gosec // test data
+		binary.LittleEndian.PutUint32(entry[8:], uint32(len(value)+3)) //nolint:gosec // test data
+		values = append(values, value...)
+		for len(values)%4 != 0 {
+			values = append(values, 0)
+		}
+		index = append(index, entry...)
+	}
+	for len(keys)%4 != 0 {
+		keys = append(keys, 0)
+	}
+
+	header := make([]byte, sfoHeaderSize)
+	copy(header, sfoMagic)
+	binary.LittleEndian.PutUint32(header[0x04:], 0x0101)
+	keyTable := sfoHeaderSize + len(index)
+	binary.LittleEndian.PutUint32(header[0x08:], uint32(keyTable))           //nolint:gosec // test data
+	binary.LittleEndian.PutUint32(header[0x0C:], uint32(keyTable+len(keys))) //nolint:gosec // test data
+	binary.LittleEndian.PutUint32(header[0x10:], uint32(len(params)))        //nolint:gosec // test data
+
+	sfo := append(header, index...)
+	sfo = append(sfo, keys...)
+	return append(sfo, values...)
+}
+
+func TestParseSFO(t *testing.T) {
+	t.Parallel()
+
+	sfo := createSFO(
+		sfoParam{key: "CATEGORY", value: "EG"},
+		sfoParam{key: "DISC_ID", value: "NPUG80086"},
+		sfoParam{key: "PARENTAL_LEVEL", value: 1},
+		sfoParam{key: "TITLE", value: "flOw"},
+	)
+
+	params, ok := parseSFO(sfo)
+	if !ok {
+		t.Fatal("parseSFO() = false, want true")
+	}
+	want := map[string]string{
+		"CATEGORY":       "EG",
+		"DISC_ID":        "NPUG80086",
+		"PARENTAL_LEVEL": "1",
+		"TITLE":          "flOw",
+	}
+	for key, value := range want {
+		if params[key] != value {
+			t.Errorf("params[%q] = %q, want %q", key, params[key], value)
+		}
+	}
+	if len(params) != len(want) {
+		t.Errorf("len(params) = %d, want %d", len(params), len(want))
+	}
+}
+
+func TestParseSFO_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tooMany := createSFO(sfoParam{key: "TITLE", value: "flOw"})
+	binary.LittleEndian.PutUint32(tooMany[0x10:], 1000)
+
+	outOfRange := createSFO(sfoParam{key: "TITLE", value: "flOw"})
+	binary.LittleEndian.PutUint32(outOfRange[sfoHeaderSize+12:], 0x10000)
+
+	tests := []struct {
+		name   string
+		data   []byte
+		wantOK bool
+	}{
+		{"empty", nil, false},
+		{"bad magic", make([]byte, 0x40), false},
+		{"entry count past end", tooMany, false},
+		{"value past end", outOfRange, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params, ok := parseSFO(tt.data)
+			if ok != tt.wantOK {
+				t.Fatalf("parseSFO() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if len(params) != 0 {
+				t.Errorf("parseSFO() params = %v, want none", params)
+			}
+		})
+	}
+}
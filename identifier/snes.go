@@ -19,17 +19,28 @@
 package identifier
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"math/bits"
+	"slices"
 
 	"github.com/ZaparooProject/go-gameid/internal/binary"
 )
 
 // SNES header offsets (relative to header start)
 const (
-	snesLoROMHeaderStart = 0x7FC0
-	snesHiROMHeaderStart = 0xFFC0
-	snesHeaderSize       = 32
+	snesLoROMHeaderStart   = 0x7FC0
+	snesHiROMHeaderStart   = 0xFFC0
+	snesExHiROMHeaderStart = 0x40FFC0
+	snesHeaderSize         = 32
+	snesCopierHeaderSize   = 512
+
+	// Each probe reads 16 bytes before the header (the extended header and
+	// the chip byte before it) through the end of the vector table.
+	snesWindowBefore      = 0x10
+	snesWindowSize        = snesWindowBefore + 0x40
+	snesResetVectorOffset = 0x3C
 
 	snesInternalNameOffset       = 0x00
 	snesInternalNameSize         = 21
@@ -39,6 +50,10 @@ const (
 	snesROMVersionOffset         = 0x1B // 27
 	snesChecksumComplementOffset = 0x1C // 28
 	snesChecksumOffset           = 0x1E // 30
+
+	// Satellaview (BS-X) header fields that differ from the regular layout
+	snesBSXInternalNameSize = 16
+	snesBSXMapModeOffset    = 0x18
 )
 
 // SNESIdentifier identifies Super Nintendo games.
@@ -58,42 +73,143 @@ func (*SNESIdentifier) Console() Console {
 type snesHeaderInfo struct {
 	internalNameHex string
 	internalName    []byte
-	headerStart     int
-	checksum        uint16
-	mapMode         byte
-	romType         byte
-	developerID     byte
-	romVersion      byte
+	// window holds the bytes read around the header; the header itself
+	// starts at snesWindowBefore
+	window      []byte
+	location    string
+	checksum    uint16
+	mapMode     byte
+	romType     byte
+	developerID byte
+	romVersion  byte
+	bsx         bool
+	interleaved bool
+}
+
+// snesHeaderLocation is a place in the ROM image a header may be stored.
+type snesHeaderLocation struct {
+	name  string
+	start int64
+	// mapModes are the map mode low nibbles expected for a header here
+	mapModes []byte
+}
+
+var snesHeaderLocations = []snesHeaderLocation{
+	{name: "LoROM", start: snesLoROMHeaderStart, mapModes: []byte{0x0, 0x2, 0x3}},
+	{name: "HiROM", start: snesHiROMHeaderStart, mapModes: []byte{0x1, 0xA}},
+	{name: "ExHiROM", start: snesExHiROMHeaderStart, mapModes: []byte{0x5}},
 }
 
-// snesFindHeader locates and validates the SNES header in ROM data.
-func snesFindHeader(data []byte) (snesHeaderInfo, error) {
-	for _, start := range []int{snesLoROMHeaderStart, snesHiROMHeaderStart} {
-		if start+snesHeaderSize > len(data) {
+// snesFindHeader probes every header location in a ROM of size bytes starting
+// at base and returns the best-scoring header with a valid checksum. Earlier
+// locations win ties, so plain LoROM/HiROM dumps behave as before.
+func snesFindHeader(reader io.ReaderAt, base, size int64) (snesHeaderInfo, error) {
+	var best snesHeaderInfo
+	bestScore, found := 0, false
+	for _, loc := range snesHeaderLocations {
+		windowStart := loc.start - snesWindowBefore
+		if loc.start+snesHeaderSize > size {
 			continue
 		}
+		window, err := readROM(reader, ConsoleSNES, base+windowStart, int(min(snesWindowSize, size-windowStart)))
+		if err != nil {
+			return snesHeaderInfo{}, fmt.Errorf("failed to read SNES header at 0x%X: %w", loc.start, err)
+		}
+
+		info, ok := snesParseHeader(window, loc)
+		if !ok {
+			continue
+		}
+		if score := snesScoreHeader(info, loc); !found || score > bestScore {
+			best, bestScore, found = info, score, true
+		}
+	}
+	if !found {
+		return snesHeaderInfo{}, ErrInvalidFormat{Console: ConsoleSNES, Reason: "no valid header found"}
+	}
+	return best, nil
+}
+
+// snesParseHeader parses the header in window if its checksum and complement
+// add up to 0xFFFF.
+func snesParseHeader(window []byte, loc snesHeaderLocation) (snesHeaderInfo, bool) {
+	header := window[snesWindowBefore:]
+	cs := uint16(header[snesChecksumOffset+1])<<8 | uint16(header[snesChecksumOffset])
+	csc := uint16(header[snesChecksumComplementOffset+1])<<8 | uint16(header[snesChecksumComplementOffset])
+	if cs+csc != 0xFFFF {
+		return snesHeaderInfo{}, false
+	}
+
+	info := snesHeaderInfo{
+		window:      window,
+		location:    loc.name,
+		checksum:    cs,
+		mapMode:     header[snesMapModeOffset],
+		romType:     header[snesROMTypeOffset],
+		developerID: header[snesDeveloperIDOffset],
+		romVersion:  header[snesROMVersionOffset],
+	}
+	nameSize := snesInternalNameSize
+	if snesIsBSX(header) {
+		info.bsx = true
+		info.mapMode = header[snesBSXMapModeOffset]
+		nameSize = snesBSXInternalNameSize
+	}
+	info.internalName = header[snesInternalNameOffset : snesInternalNameOffset+nameSize]
+	info.internalNameHex = snesFormatInternalNameHex(info.internalName)
+
+	// An interleaved HiROM dump moves the HiROM header into the LoROM slot
+	info.interleaved = loc.start == snesLoROMHeaderStart &&
+		!slices.Contains(loc.mapModes, info.mapMode&0x0F) &&
+		slices.ContainsFunc(snesHeaderLocations[1:], func(other snesHeaderLocation) bool {
+			return slices.Contains(other.mapModes, info.mapMode&0x0F)
+		})
+	return info, true
+}
+
+// snesIsBSX reports whether a header uses the Satellaview (BS-X) layout: a
+// 16-byte title, the map mode at 0x18 and the fixed 0x33 at 0x1A. Regular
+// headers store the SRAM size (at most 7) at 0x18.
+func snesIsBSX(header []byte) bool {
+	return header[snesDeveloperIDOffset] == 0x33 && header[snesBSXMapModeOffset]&^0x11 == 0x20
+}
+
+// snesScoreHeader rates how plausible a checksum-valid header is: a map mode
+// that matches the header's location, a reset vector pointing into ROM and a
+// printable title each add to the score.
+func snesScoreHeader(info snesHeaderInfo, loc snesHeaderLocation) int {
+	score := 0
+	if slices.Contains(loc.mapModes, info.mapMode&0x0F) {
+		score += 2
+	}
+	if resetEnd := snesWindowBefore + snesResetVectorOffset + 2; len(info.window) >= resetEnd {
+		reset := uint16(info.window[resetEnd-1])<<8 | uint16(info.window[resetEnd-2])
+		if reset >= 0x8000 {
+			score += 2
+		} else {
+			score -= 2
+		}
+	}
+	if snesTitlePrintable(info.internalName) {
+		score += 2
+	}
+	return score
+}
 
-		// Read checksum and complement
-		cs := uint16(data[start+snesChecksumOffset+1])<<8 | uint16(data[start+snesChecksumOffset])
-		csc := uint16(data[start+snesChecksumComplementOffset+1])<<8 | uint16(data[start+snesChecksumComplementOffset])
-
-		// Valid header if checksum + complement = 0xFFFF
-		if cs+csc == 0xFFFF {
-			header := data[start:]
-			internalName := header[snesInternalNameOffset : snesInternalNameOffset+snesInternalNameSize]
-			return snesHeaderInfo{
-				headerStart:     start,
-				checksum:        cs,
-				internalName:    internalName,
-				internalNameHex: snesFormatInternalNameHex(internalName),
-				mapMode:         header[snesMapModeOffset],
-				romType:         header[snesROMTypeOffset],
-				developerID:     header[snesDeveloperIDOffset],
-				romVersion:      header[snesROMVersionOffset],
-			}, nil
+// snesTitlePrintable reports whether a title holds only ASCII, JIS X 0201
+// katakana and padding, with at least one visible character.
+func snesTitlePrintable(title []byte) bool {
+	visible := false
+	for _, b := range title {
+		switch {
+		case b == 0x00 || b == ' ':
+		case b > ' ' && b <= '~', b >= 0xA1 && b <= 0xDF:
+			visible = true
+		default:
+			return false
 		}
 	}
-	return snesHeaderInfo{}, ErrInvalidFormat{Console: ConsoleSNES, Reason: "no valid header found"}
+	return visible
 }
 
 // snesFormatInternalNameHex converts internal name bytes to hex string.
@@ -156,27 +272,7 @@ func snesGetHardware(romType, mapMode byte, data []byte, headerStart int) string
 
 // Identify extracts SNES game information from the given reader.
 func (*SNESIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*Result, error) {
-	// Determine if file has SMC header (512 bytes) by checking file size
-	hasSMCHeader := size%1024 == 512
-
-	// Calculate read offset and size - we only need to read up to the HiROM header location
-	// plus header size. SMC header is at offset 0 if present.
-	readOffset := int64(0)
-	if hasSMCHeader {
-		readOffset = 512
-	}
-
-	// Only read what we need: up to HiROM header (0xFFC0) + header size (32 bytes)
-	maxNeeded := int64(snesHiROMHeaderStart + snesHeaderSize)
-	readSize := min(size-readOffset, maxNeeded)
-
-	data := make([]byte, readSize)
-	if _, err := reader.ReadAt(data, readOffset); err != nil && err != io.EOF {
-		return nil, fmt.Errorf("failed to read SNES ROM: %w", err)
-	}
-
-	// Find and parse header
-	info, err := snesFindHeader(data)
+	info, hasCopierHeader, err := snesFindHeaderWithCopier(reader, size)
 	if err != nil {
 		return nil, err
 	}
@@ -193,21 +289,88 @@ func (*SNESIdentifier) Identify(reader io.ReaderAt, size int64, db Database) (*R
 	result.SetMetadata("rom_version", fmt.Sprintf("%d", info.romVersion))
 	result.SetMetadata("checksum", fmt.Sprintf("0x%04x", info.checksum))
 
-	if hardware := snesGetHardware(info.romType, info.mapMode, data, info.headerStart); hardware != "" {
+	if info.bsx {
+		result.SetMetadata("hardware", "Satellaview (BS-X)")
+	} else if hardware := snesGetHardware(info.romType, info.mapMode, info.window, snesWindowBefore); hardware != "" {
 		result.SetMetadata("hardware", hardware)
 	}
+	if info.interleaved {
+		result.SetMetadata("interleaved", "true")
+	}
+	result.SetMetadata("has_copier_header", fmt.Sprintf("%t", hasCopierHeader))
 
 	// Database lookup
 	snesLookupDatabase(result, db, info)
 
 	// If no title from database, use internal name
-	if result.Title == "" {
-		result.Title = result.InternalTitle
-	}
+	result.SetROMTitle(result.InternalTitle)
 
 	return result, nil
 }
 
+// IdentifyHeader is Identify, which already reads only the windows around the
+// candidate header locations.
+func (s *SNESIdentifier) IdentifyHeader(reader io.ReaderAt, size int64, db Database) (*Result, error) {
+	return s.Identify(reader, size, db)
+}
+
+// snesFindHeaderWithCopier finds the SNES header, skipping a 512-byte copier
+// header when the file starts with one. The layout the header check points to
+// is probed first and the other used as a fallback, so an unrecognized copier
+// header or a ROM whose first 512 bytes merely look like one still identifies.
+func snesFindHeaderWithCopier(reader io.ReaderAt, size int64) (snesHeaderInfo, bool, error) {
+	offsets := []int64{0}
+	if size%1024 == snesCopierHeaderSize {
+		header, err := readROM(reader, ConsoleSNES, 0, snesCopierHeaderSize)
+		if err != nil {
+			return snesHeaderInfo{}, false, fmt.Errorf("failed to read SNES copier header: %w", err)
+		}
+		if snesIsCopierHeader(header) {
+			offsets = []int64{snesCopierHeaderSize, 0}
+		} else {
+			offsets = []int64{0, snesCopierHeaderSize}
+		}
+	}
+
+	var firstErr error
+	for _, offset := range offsets {
+		info, err := snesFindHeader(reader, offset, size-offset)
+		if err == nil {
+			return info, offset == snesCopierHeaderSize, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return snesHeaderInfo{}, false, firstErr
+}
+
+// snesIsCopierHeader reports whether header, the first 512 bytes of a file,
+// is a copier header: Game Doctor's magic, the Super Wild Card 0xAA 0xBB 0x04
+// split-file marker, or the Super Magicom / Pro Fighter layout of a block
+// count, a split flag and a LoROM (0x00) or HiROM (0x80) flag followed by
+// zero filler.
+func snesIsCopierHeader(header []byte) bool {
+	if len(header) < snesCopierHeaderSize {
+		return false
+	}
+	if bytes.HasPrefix(header, []byte("GAME DOCTOR SF ")) {
+		return true
+	}
+	if header[8] == 0xAA && header[9] == 0xBB && header[10] == 0x04 {
+		return true
+	}
+	if header[3] != 0x00 && header[3] != 0x80 {
+		return false
+	}
+	for _, b := range header[0x0B:snesCopierHeaderSize] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // snesLookupDatabase performs database lookup for SNES game.
 func snesLookupDatabase(result *Result, db Database, info snesHeaderInfo) {
 	if db == nil {
@@ -276,25 +439,90 @@ func snesGetExtendedCoprocessor(data []byte, headerStart int) string {
 	}
 }
 
-// ValidateSNES checks if the given data looks like a valid SNES ROM.
-func ValidateSNES(data []byte) bool {
-	// Strip SMC header if present
-	if len(data)%1024 == 512 && len(data) > 512 {
-		data = data[512:]
-	}
+// snesChecksumChunk is the read size used when summing a ROM.
+const snesChecksumChunk = 64 * 1024
 
-	for _, start := range []int{snesLoROMHeaderStart, snesHiROMHeaderStart} {
-		if start+snesHeaderSize > len(data) {
-			continue
+// ValidateStrict rejects a ROM whose computed checksum differs from the one in
+// its header. Identify only requires the header checksum and its complement
+// to agree; the ROM contents are not summed.
+func (*SNESIdentifier) ValidateStrict(reader io.ReaderAt, size int64) error {
+	info, hasCopierHeader, err := snesFindHeaderWithCopier(reader, size)
+	if err != nil {
+		return err
+	}
+	if err := checkCartridgeSize(ConsoleSNES, size); err != nil {
+		return err
+	}
+	base := int64(0)
+	if hasCopierHeader {
+		base = snesCopierHeaderSize
+	}
+	actual, err := snesROMChecksum(reader, base, size-base)
+	if err != nil {
+		return err
+	}
+	if actual != info.checksum {
+		return ErrInvalidFormat{
+			Console: ConsoleSNES,
+			Reason:  fmt.Sprintf("header checksum 0x%04x, computed 0x%04x", info.checksum, actual),
 		}
+	}
+	return nil
+}
 
-		cs := uint16(data[start+snesChecksumOffset+1])<<8 | uint16(data[start+snesChecksumOffset])
-		csc := uint16(data[start+snesChecksumComplementOffset+1])<<8 | uint16(data[start+snesChecksumComplementOffset])
+// snesROMChecksum sums the size bytes of ROM at base the way the header
+// checksum is made: a size that isn't a power of two has its remainder
+// mirrored up to the next one, so a 3 MiB ROM counts its last 1 MiB twice.
+func snesROMChecksum(reader io.ReaderAt, base, size int64) (uint16, error) {
+	if size <= 0 {
+		return 0, ErrInvalidFormat{Console: ConsoleSNES, Reason: "file too small"}
+	}
+	sum, err := snesMirroredSum(reader, base, size, 1<<bits.Len64(uint64(size-1)))
+	return uint16(sum), err //nolint:gosec // The checksum is the low 16 bits of the sum
+}
+
+// snesMirroredSum sums size bytes at offset as if mirrored to fill target
+// bytes, target being a power of two no smaller than size.
+func snesMirroredSum(reader io.ReaderAt, offset, size, target int64) (uint64, error) {
+	half := int64(1) << (bits.Len64(uint64(size)) - 1)
+	sum, err := snesSumBytes(reader, offset, half)
+	if err != nil {
+		return 0, err
+	}
+	if rest := size - half; rest > 0 {
+		restSum, err := snesMirroredSum(reader, offset+half, rest, half)
+		if err != nil {
+			return 0, err
+		}
+		sum += restSum
+		half *= 2
+	}
+	return sum * uint64(target/half), nil //nolint:gosec // target and half are positive
+}
 
-		if cs+csc == 0xFFFF {
-			return true
+// snesSumBytes adds up the size bytes at offset.
+func snesSumBytes(reader io.ReaderAt, offset, size int64) (uint64, error) {
+	var sum uint64
+	for done := int64(0); done < size; {
+		chunk, err := readROM(reader, ConsoleSNES, offset+done, int(min(snesChecksumChunk, size-done)))
+		if err != nil {
+			return 0, fmt.Errorf("failed to read SNES ROM: %w", err)
+		}
+		for _, b := range chunk {
+			sum += uint64(b)
 		}
+		done += int64(len(chunk))
 	}
+	return sum, nil
+}
+
+// Validate reports whether header, the start of a file, could be an SNES ROM.
+func (*SNESIdentifier) Validate(header []byte) bool {
+	return ValidateSNES(header)
+}
 
-	return false
+// ValidateSNES checks if the given data looks like a valid SNES ROM.
+func ValidateSNES(data []byte) bool {
+	_, _, err := snesFindHeaderWithCopier(bytes.NewReader(data), int64(len(data)))
+	return err == nil
 }
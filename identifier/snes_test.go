@@ -20,6 +20,9 @@ package identifier
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -193,6 +196,116 @@ func TestSNESIdentifier_SMCHeader(t *testing.T) {
 	}
 }
 
+func TestSNESIdentifier_CopierHeaderDetection(t *testing.T) {
+	t.Parallel()
+
+	swcHeader := make([]byte, 512)
+	swcHeader[0], swcHeader[2] = 0x04, 0x0C
+	swcHeader[8], swcHeader[9], swcHeader[10] = 0xAA, 0xBB, 0x04
+
+	figHeader := make([]byte, 512)
+	figHeader[0], figHeader[3], figHeader[4], figHeader[5] = 0x08, 0x80, 0xDD, 0x82
+
+	// LoROM reset code at the start of the image, so the first 512 bytes
+	// are not zero filler
+	headerless := createSNESHeader("TRAILING DATA", 0x01, 0, 0x4321)
+	copy(headerless, []byte{0x78, 0x18, 0xFB, 0x5C, 0x00, 0x80, 0x80})
+	for i := 0x10; i < 512; i++ {
+		headerless[i] = byte(i)
+	}
+
+	tests := []struct {
+		name      string
+		rom       []byte
+		title     string
+		wantStrip bool
+	}{
+		{
+			name:      "Super Wild Card header",
+			rom:       append(swcHeader, createSNESHeader("SWC GAME", 0x01, 0, 0x1111)...),
+			title:     "SWC GAME",
+			wantStrip: true,
+		},
+		{
+			name:      "Pro Fighter HiROM header",
+			rom:       append(figHeader, createSNESHeaderHiROM("FIG GAME", 0x01, 0, 0x2222)...),
+			title:     "FIG GAME",
+			wantStrip: true,
+		},
+		{
+			name:  "headerless with 512 trailing bytes",
+			rom:   append(slices.Clone(headerless), make([]byte, 512)...),
+			title: "TRAILING DATA",
+		},
+		{
+			name:  "headerless starting with zeros and 512 trailing bytes",
+			rom:   append(createSNESHeader("ZERO START", 0x01, 0, 0x3333), make([]byte, 512)...),
+			title: "ZERO START",
+		},
+		{
+			name:  "headerless",
+			rom:   headerless,
+			title: "TRAILING DATA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewSNESIdentifier().Identify(bytes.NewReader(tt.rom), int64(len(tt.rom)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+			if result.InternalTitle != tt.title {
+				t.Errorf("InternalTitle = %q, want %q", result.InternalTitle, tt.title)
+			}
+			want := fmt.Sprintf("%t", tt.wantStrip)
+			if got := result.Metadata["has_copier_header"]; got != want {
+				t.Errorf("has_copier_header = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestSNESIsCopierHeader(t *testing.T) {
+	t.Parallel()
+
+	withBytes := func(values map[int]byte) []byte {
+		header := make([]byte, 512)
+		for offset, value := range values {
+			header[offset] = value
+		}
+		return header
+	}
+	gameDoctor := make([]byte, 512)
+	copy(gameDoctor, "GAME DOCTOR SF 3")
+
+	tests := []struct {
+		name   string
+		header []byte
+		want   bool
+	}{
+		{name: "zero filled", header: make([]byte, 512), want: true},
+		{name: "Game Doctor", header: gameDoctor, want: true},
+		{name: "split-file marker", header: withBytes(map[int]byte{8: 0xAA, 9: 0xBB, 10: 0x04, 0x100: 0xFF}), want: true},
+		{name: "HiROM flag", header: withBytes(map[int]byte{0: 0x20, 3: 0x80}), want: true},
+		{name: "bad ROM flag", header: withBytes(map[int]byte{3: 0x42}), want: false},
+		{name: "non-zero filler", header: withBytes(map[int]byte{0x1FF: 0x01}), want: false},
+		{name: "short", header: make([]byte, 256), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := snesIsCopierHeader(tt.header); got != tt.want {
+				t.Errorf("snesIsCopierHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSNESIdentifier_InvalidChecksum(t *testing.T) {
 	t.Parallel()
 
@@ -396,3 +509,164 @@ func TestSNESIdentifier_Console(t *testing.T) {
 		t.Errorf("Console() = %v, want %v", identifier.Console(), ConsoleSNES)
 	}
 }
+
+// writeSNESHeader writes a checksum-valid header at start with the given map
+// mode and reset vector.
+func writeSNESHeader(rom []byte, start int, title string, mapMode byte, reset uint16) {
+	copy(rom[start+snesInternalNameOffset:start+snesInternalNameOffset+snesInternalNameSize],
+		append([]byte(title), bytes.Repeat([]byte{' '}, snesInternalNameSize)...))
+	rom[start+snesMapModeOffset] = mapMode
+	rom[start+snesChecksumComplementOffset] = 0xCB
+	rom[start+snesChecksumComplementOffset+1] = 0xED
+	rom[start+snesChecksumOffset] = 0x34
+	rom[start+snesChecksumOffset+1] = 0x12
+	rom[start+snesResetVectorOffset] = byte(reset)
+	rom[start+snesResetVectorOffset+1] = byte(reset >> 8)
+}
+
+func TestSNESIdentifier_ExHiROM(t *testing.T) {
+	t.Parallel()
+
+	rom := make([]byte, snesExHiROMHeaderStart+0x40)
+	writeSNESHeader(rom, snesExHiROMHeaderStart, "TALES OF PHANTASIA", 0x35, 0x8000)
+
+	result, err := NewSNESIdentifier().Identify(bytes.NewReader(rom), int64(len(rom)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	verifySNESResult(t, result, "TALES OF PHANTASIA", "ExHiROM", "FastROM")
+}
+
+func TestSNESIdentifier_ScoringPicksBestHeader(t *testing.T) {
+	t.Parallel()
+
+	// The LoROM slot happens to pass the checksum test but holds code, not a
+	// header; the HiROM header is the real one.
+	rom := make([]byte, 0x10000)
+	writeSNESHeader(rom, snesHiROMHeaderStart, "ZELDA3", 0x21, 0x8000)
+	for i := range snesInternalNameSize {
+		rom[snesLoROMHeaderStart+i] = byte(0x80 + i)
+	}
+	rom[snesLoROMHeaderStart+snesChecksumComplementOffset] = 0xFF
+	rom[snesLoROMHeaderStart+snesChecksumComplementOffset+1] = 0xFF
+
+	result, err := NewSNESIdentifier().Identify(bytes.NewReader(rom), int64(len(rom)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	verifySNESResult(t, result, "ZELDA3", "HiROM", "SlowROM")
+	if _, ok := result.Metadata["interleaved"]; ok {
+		t.Error("interleaved set for a correctly ordered HiROM dump")
+	}
+}
+
+func TestSNESIdentifier_InterleavedHiROM(t *testing.T) {
+	t.Parallel()
+
+	// Interleaved dumps swap the ROM halves, leaving the HiROM header in the
+	// LoROM slot.
+	rom := make([]byte, 0x10000)
+	writeSNESHeader(rom, snesLoROMHeaderStart, "ZELDA3", 0x21, 0x8000)
+
+	result, err := NewSNESIdentifier().Identify(bytes.NewReader(rom), int64(len(rom)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if got := result.Metadata["interleaved"]; got != "true" {
+		t.Errorf("interleaved = %q, want %q", got, "true")
+	}
+}
+
+func TestSNESIdentifier_BSX(t *testing.T) {
+	t.Parallel()
+
+	rom := make([]byte, 0x8000)
+	start := snesLoROMHeaderStart
+	writeSNESHeader(rom, start, "BS ZELDA", 0, 0x8000)
+	copy(rom[start+snesBSXInternalNameSize:], []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x00, 0x80, 0x31, 0x00})
+	rom[start+snesBSXMapModeOffset] = 0x20
+	rom[start+snesDeveloperIDOffset] = 0x33
+
+	result, err := NewSNESIdentifier().Identify(bytes.NewReader(rom), int64(len(rom)), nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	verifySNESResult(t, result, "BS ZELDA", "LoROM", "SlowROM")
+	if got := result.Metadata["hardware"]; got != "Satellaview (BS-X)" {
+		t.Errorf("hardware = %q, want %q", got, "Satellaview (BS-X)")
+	}
+}
+
+func TestSNESHeader_ValidatesOnlyAsSNES(t *testing.T) {
+	t.Parallel()
+
+	validators := []Identifier{
+		NewFDSIdentifier(), NewGBIdentifier(), NewGBAIdentifier(), NewGCIdentifier(),
+		NewGenesisIdentifier(), NewN64Identifier(), NewSaturnIdentifier(), NewSegaCDIdentifier(),
+		NewSNESIdentifier(), NewWiiIdentifier(),
+	}
+	rom := createSNESHeader("SUPER MARIO WORLD", 0x01, 0, 0x1234)
+
+	for _, id := range validators {
+		t.Run(string(id.Console()), func(t *testing.T) {
+			t.Parallel()
+
+			validator, ok := id.(Validator)
+			if !ok {
+				t.Fatalf("%T does not implement Validator", id)
+			}
+			want := id.Console() == ConsoleSNES
+			if got := validator.Validate(rom); got != want {
+				t.Errorf("Validate() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSNESIdentifier_ValidateStrict(t *testing.T) {
+	t.Parallel()
+
+	// A 48 KiB ROM isn't a power of two, so its last 16 KiB count twice
+	rom := make([]byte, 0x8000+0x4000)
+	for i := range rom {
+		rom[i] = byte(i * 7)
+	}
+	header := rom[snesLoROMHeaderStart:]
+	copy(header, "STRICT TEST")
+	header[snesMapModeOffset] = 0x20
+	setChecksum := func(checksum uint16) {
+		complement := 0xFFFF - checksum
+		header[snesChecksumComplementOffset] = byte(complement)
+		header[snesChecksumComplementOffset+1] = byte(complement >> 8)
+		header[snesChecksumOffset] = byte(checksum)
+		header[snesChecksumOffset+1] = byte(checksum >> 8)
+	}
+	setChecksum(0)
+	var sum uint16
+	for i, b := range rom {
+		sum += uint16(b)
+		if i >= 0x8000 {
+			sum += uint16(b)
+		}
+	}
+	setChecksum(sum)
+
+	identifier := NewSNESIdentifier()
+	if err := identifier.ValidateStrict(bytes.NewReader(rom), int64(len(rom))); err != nil {
+		t.Fatalf("ValidateStrict() error = %v for a correct checksum", err)
+	}
+
+	withCopier := append(make([]byte, snesCopierHeaderSize), rom...)
+	if err := identifier.ValidateStrict(bytes.NewReader(withCopier), int64(len(withCopier))); err != nil {
+		t.Errorf("ValidateStrict() error = %v with a copier header", err)
+	}
+
+	setChecksum(sum + 1)
+	var invalid ErrInvalidFormat
+	if err := identifier.ValidateStrict(bytes.NewReader(rom), int64(len(rom))); !errors.As(err, &invalid) {
+		t.Errorf("ValidateStrict() error = %v, want ErrInvalidFormat", err)
+	}
+	if _, err := identifier.Identify(bytes.NewReader(rom), int64(len(rom)), nil); err != nil {
+		t.Errorf("Identify() error = %v, want a wrong ROM checksum tolerated", err)
+	}
+}
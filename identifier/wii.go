@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	bin "github.com/ZaparooProject/go-gameid/internal/binary"
+)
+
+// Wii disc layout. The boot header shares the GameCube game ID layout but
+// carries its own magic word; everything past the partition table lives in
+// encrypted partitions.
+const (
+	wiiMagicOffset          = 0x0018
+	wiiInternalNameSize     = 0x0040
+	wiiHashDisableOffset    = 0x0060
+	wiiEncryptDisableOffset = 0x0061
+	wiiPartitionInfoOffset  = 0x40000
+	wiiPartitionGroups      = 4
+	wiiMaxPartitions        = 64
+	wiiRegionOffset         = 0x4E000
+	wiiPartitionDataOffset  = 0x02B8 // Offset (>>2) of the encrypted data within a partition
+	wiiPartitionTypeData    = 0
+)
+
+// Wii magic word at offset 0x18
+var wiiMagicWord = []byte{0x5D, 0x1C, 0x9E, 0xA3}
+
+// Wii region codes from the region setting block at 0x4E000
+var wiiRegions = map[uint32]string{
+	0: "Japan",
+	1: "USA",
+	2: "Europe",
+	4: "Korea",
+}
+
+// WiiIdentifier identifies Wii games.
+type WiiIdentifier struct{}
+
+// NewWiiIdentifier creates a new Wii identifier.
+func NewWiiIdentifier() *WiiIdentifier {
+	return &WiiIdentifier{}
+}
+
+// Console returns the console type.
+func (*WiiIdentifier) Console() Console {
+	return ConsoleWii
+}
+
+// Identify extracts Wii game information from the given reader.
+// Only the unencrypted boot header and partition table are read. There is
+// no Wii database, so the database is not consulted.
+func (*WiiIdentifier) Identify(reader io.ReaderAt, size int64, _ Database) (*Result, error) {
+	if size < gcHeaderSize {
+		return nil, ErrInvalidFormat{Console: ConsoleWii, Reason: "file too small"}
+	}
+
+	header, err := bin.ReadBytesAt(reader, 0, gcHeaderSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Wii header: %w", err)
+	}
+
+	if !ValidateWii(header) {
+		return nil, ErrInvalidFormat{Console: ConsoleWii, Reason: "invalid magic word"}
+	}
+
+	gameID := bin.CleanString(header[gcGameIDOffset : gcGameIDOffset+gcGameIDSize])
+	makerCode := bin.CleanString(header[gcMakerCodeOffset : gcMakerCodeOffset+gcMakerCodeSize])
+	internalTitle := bin.CleanString(header[gcInternalNameOffset : gcInternalNameOffset+wiiInternalNameSize])
+
+	result := NewResult(ConsoleWii)
+	result.ID = gameID
+	result.InternalTitle = internalTitle
+	result.SetMetadata("ID", gameID)
+	result.SetMetadata("maker_code", makerCode)
+	result.SetMetadata("publisher", PublisherFromMakerCode(makerCode))
+	result.SetMetadata("disk_ID", fmt.Sprintf("%d", header[gcDiskIDOffset]))
+	result.SetMetadata("version", fmt.Sprintf("%d", header[gcVersionOffset]))
+	result.SetMetadata("internal_title", internalTitle)
+	result.SetMetadata("encrypted", fmt.Sprintf("%t", header[wiiEncryptDisableOffset] == 0))
+	result.SetMetadata("hash_verification", fmt.Sprintf("%t", header[wiiHashDisableOffset] == 0))
+
+	setWiiPartitionMetadata(result, reader, size)
+
+	result.SetROMTitle(result.InternalTitle)
+
+	return result, nil
+}
+
+// IdentifyFromPath handles path-based identification for Wii discs.
+// This is needed for CHD files which require special handling.
+func (w *WiiIdentifier) IdentifyFromPath(path string, db Database) (*Result, error) {
+	if strings.ToLower(filepath.Ext(path)) == ".chd" {
+		return identifyNintendoDiscCHD(path, w, db)
+	}
+
+	// For non-CHD files, fall back to standard file reading
+	return nil, ErrNotSupported{Format: "use standard Identify for non-CHD files"}
+}
+
+// wiiPartition is an entry of the Wii partition table.
+type wiiPartition struct {
+	offset int64
+	kind   uint32
+}
+
+// setWiiPartitionMetadata records the partition layout and region setting.
+// Scrubbed or truncated images may lack these blocks, so they are optional.
+func setWiiPartitionMetadata(result *Result, reader io.ReaderAt, size int64) {
+	partitions := readWiiPartitions(reader, size)
+	if len(partitions) > 0 {
+		result.SetMetadata("partition_count", fmt.Sprintf("%d", len(partitions)))
+	}
+
+	for _, partition := range partitions {
+		if partition.kind != wiiPartitionTypeData {
+			continue
+		}
+		result.SetMetadata("data_partition_offset", fmt.Sprintf("0x%x", partition.offset))
+		// The partition header is unencrypted and points at the encrypted data
+		if dataOffset, err := bin.ReadUint32BEAt(reader, partition.offset+wiiPartitionDataOffset); err == nil {
+			encryptedStart := partition.offset + int64(dataOffset)<<2
+			result.SetMetadata("encrypted_data_offset", fmt.Sprintf("0x%x", encryptedStart))
+		}
+		break
+	}
+
+	if size >= wiiRegionOffset+4 {
+		if code, err := bin.ReadUint32BEAt(reader, wiiRegionOffset); err == nil {
+			if region, ok := wiiRegions[code]; ok {
+				result.SetMetadata("region", region)
+			}
+		}
+	}
+}
+
+// readWiiPartitions parses the four partition groups at 0x40000.
+func readWiiPartitions(reader io.ReaderAt, size int64) []wiiPartition {
+	if size < wiiPartitionInfoOffset+wiiPartitionGroups*8 {
+		return nil
+	}
+
+	groups, err := bin.ReadBytesAt(reader, wiiPartitionInfoOffset, wiiPartitionGroups*8)
+	if err != nil {
+		return nil
+	}
+
+	var partitions []wiiPartition
+	for group := range wiiPartitionGroups {
+		count := binary.BigEndian.Uint32(groups[group*8:])
+		tableOffset := int64(binary.BigEndian.Uint32(groups[group*8+4:])) << 2
+		if count == 0 || count > wiiMaxPartitions || tableOffset+int64(count)*8 > size {
+			continue
+		}
+
+		table, err := bin.ReadBytesAt(reader, tableOffset, int(count)*8)
+		if err != nil {
+			continue
+		}
+		for i := range int(count) {
+			partitions = append(partitions, wiiPartition{
+				offset: int64(binary.BigEndian.Uint32(table[i*8:])) << 2,
+				kind:   binary.BigEndian.Uint32(table[i*8+4:]),
+			})
+		}
+	}
+	return partitions
+}
+
+// Validate reports whether header, the start of a file, could be a Wii disc.
+func (*WiiIdentifier) Validate(header []byte) bool {
+	return ValidateWii(header)
+}
+
+// ValidateWii checks if the given data looks like a valid Wii disc.
+func ValidateWii(header []byte) bool {
+	if len(header) < wiiMagicOffset+len(wiiMagicWord) {
+		return false
+	}
+	return bin.BytesEqual(header[wiiMagicOffset:wiiMagicOffset+len(wiiMagicWord)], wiiMagicWord)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package identifier
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// createWiiDisc creates a Wii disc image with a boot header and, when
+// withPartitions is set, a partition table holding an update and a game
+// partition plus the region setting block.
+func createWiiDisc(gameID, makerCode, internalTitle string, withPartitions bool) []byte {
+	size := gcHeaderSize
+	if withPartitions {
+		size = wiiRegionOffset + 0x20
+	}
+	disc := make([]byte, size)
+
+	copy(disc[gcGameIDOffset:], gameID)
+	copy(disc[gcMakerCodeOffset:], makerCode)
+	disc[gcDiskIDOffset] = 0
+	disc[gcVersionOffset] = 1
+	copy(disc[wiiMagicOffset:], wiiMagicWord)
+	copy(disc[gcInternalNameOffset:], internalTitle)
+
+	if !withPartitions {
+		return disc
+	}
+
+	// One group of two partitions with its table right after the group list
+	const (
+		tableOffset     = wiiPartitionInfoOffset + 0x20
+		updateOffset    = 0x50000
+		gamePartitionAt = 0x48000
+	)
+	binary.BigEndian.PutUint32(disc[wiiPartitionInfoOffset:], 2)
+	binary.BigEndian.PutUint32(disc[wiiPartitionInfoOffset+4:], tableOffset>>2)
+	binary.BigEndian.PutUint32(disc[tableOffset:], updateOffset>>2)
+	binary.BigEndian.PutUint32(disc[tableOffset+4:], 1)
+	binary.BigEndian.PutUint32(disc[tableOffset+8:], gamePartitionAt>>2)
+	binary.BigEndian.PutUint32(disc[tableOffset+12:], wiiPartitionTypeData)
+	binary.BigEndian.PutUint32(disc[gamePartitionAt+wiiPartitionDataOffset:], 0x20000>>2)
+
+	binary.BigEndian.PutUint32(disc[wiiRegionOffset:], 2)
+
+	return disc
+}
+
+func TestWiiIdentifier_Identify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		wantMetadata   map[string]string
+		name           string
+		withPartitions bool
+	}{
+		{
+			name:           "Boot header only",
+			withPartitions: false,
+			wantMetadata: map[string]string{
+				"ID":                    "RSBP",
+				"maker_code":            "01",
+				"version":               "1",
+				"encrypted":             "true",
+				"partition_count":       "",
+				"data_partition_offset": "",
+			},
+		},
+		{
+			name:           "Partition table",
+			withPartitions: true,
+			wantMetadata: map[string]string{
+				"ID":                    "RSBP",
+				"partition_count":       "2",
+				"data_partition_offset": "0x48000",
+				"encrypted_data_offset": "0x68000",
+				"region":                "Europe",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			disc := createWiiDisc("RSBP", "01", "SUPER SMASH BROS. BRAWL", tt.withPartitions)
+			result, err := NewWiiIdentifier().Identify(bytes.NewReader(disc), int64(len(disc)), nil)
+			if err != nil {
+				t.Fatalf("Identify() error = %v", err)
+			}
+
+			if result.Console != ConsoleWii {
+				t.Errorf("Console = %v, want %v", result.Console, ConsoleWii)
+			}
+			if result.ID != "RSBP" {
+				t.Errorf("ID = %q, want %q", result.ID, "RSBP")
+			}
+			if result.Title != "SUPER SMASH BROS. BRAWL" {
+				t.Errorf("Title = %q, want %q", result.Title, "SUPER SMASH BROS. BRAWL")
+			}
+			for key, want := range tt.wantMetadata {
+				if got := result.Metadata[key]; got != want {
+					t.Errorf("Metadata[%q] = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWiiIdentifier_RejectsGameCube(t *testing.T) {
+	t.Parallel()
+
+	header := createGCHeader("GALE", "01", "Test Game", 0, 0)
+	_, err := NewWiiIdentifier().Identify(bytes.NewReader(header), int64(len(header)), nil)
+	if err == nil {
+		t.Error("expected error for GameCube magic, got nil")
+	}
+}
+
+func TestValidateWii(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header []byte
+		want   bool
+	}{
+		{name: "Valid Wii", header: createWiiDisc("RSBE", "01", "Test", false), want: true},
+		{name: "GameCube magic", header: createGCHeader("GALE", "01", "Test", 0, 0), want: false},
+		{name: "Too small", header: make([]byte, 0x10), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ValidateWii(tt.header); got != tt.want {
+				t.Errorf("ValidateWii() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
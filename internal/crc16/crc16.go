@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package crc16 implements the two 16-bit CRCs found in disc and cartridge
+// formats: CRC-16/CCITT-FALSE, which CHD V5 stores for each hunk, and
+// CRC-16/MODBUS, which the DS BIOS checks over the cartridge header. Both
+// start from 0xFFFF.
+package crc16
+
+const (
+	// ccittPoly is the CCITT polynomial x^16 + x^12 + x^5 + 1.
+	ccittPoly = 0x1021
+	// modbusPoly is the CRC-16/IBM polynomial 0x8005, bit-reversed for the
+	// reflected MODBUS computation.
+	modbusPoly = 0xA001
+	initial    = 0xFFFF
+)
+
+// ccittTable and modbusTable are the byte-at-a-time lookup tables.
+var ccittTable, modbusTable = func() (ccitt, modbus [256]uint16) {
+	for i := range 256 {
+		crc := uint16(i) << 8  //nolint:gosec // i < 256
+		reflected := uint16(i) //nolint:gosec // i < 256
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ ccittPoly
+			} else {
+				crc <<= 1
+			}
+			if reflected&1 != 0 {
+				reflected = reflected>>1 ^ modbusPoly
+			} else {
+				reflected >>= 1
+			}
+		}
+		ccitt[i], modbus[i] = crc, reflected
+	}
+	return ccitt, modbus
+}()
+
+// CCITT returns the CRC-16/CCITT-FALSE checksum of data.
+func CCITT(data []byte) uint16 {
+	return UpdateCCITT(initial, data)
+}
+
+// UpdateCCITT returns the result of adding data to a CRC-16/CCITT-FALSE
+// checksum computed so far, so a checksum can be built up piece by piece.
+func UpdateCCITT(crc uint16, data []byte) uint16 {
+	for _, b := range data {
+		crc = crc<<8 ^ ccittTable[byte(crc>>8)^b]
+	}
+	return crc
+}
+
+// Modbus returns the CRC-16/MODBUS checksum of data.
+func Modbus(data []byte) uint16 {
+	crc := uint16(initial)
+	for _, b := range data {
+		crc = crc>>8 ^ modbusTable[byte(crc)^b]
+	}
+	return crc
+}
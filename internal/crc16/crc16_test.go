@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package crc16
+
+import "testing"
+
+// The check values are the catalogued CRCs of "123456789".
+func TestCCITT(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		data string
+		want uint16
+	}{
+		{data: "", want: 0xFFFF},
+		{data: "A", want: 0xB915},
+		{data: "123456789", want: 0x29B1},
+	}
+	for _, tt := range tests {
+		if got := CCITT([]byte(tt.data)); got != tt.want {
+			t.Errorf("CCITT(%q) = 0x%04x, want 0x%04x", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateCCITT(t *testing.T) {
+	t.Parallel()
+
+	if got := UpdateCCITT(CCITT([]byte("1234")), []byte("56789")); got != 0x29B1 {
+		t.Errorf("UpdateCCITT() = 0x%04x, want 0x29b1", got)
+	}
+}
+
+func TestModbus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		data string
+		want uint16
+	}{
+		{data: "", want: 0xFFFF},
+		{data: "123456789", want: 0x4B37},
+	}
+	for _, tt := range tests {
+		if got := Modbus([]byte(tt.data)); got != tt.want {
+			t.Errorf("Modbus(%q) = 0x%04x, want 0x%04x", tt.data, got, tt.want)
+		}
+	}
+}
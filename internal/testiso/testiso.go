@@ -21,6 +21,8 @@ package testiso
 
 import (
 	"encoding/binary"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -33,12 +35,140 @@ type File struct {
 	Data []byte
 }
 
+// TreeFile describes a file at a slash-separated path, such as
+// "DATA/MOVIES/INTRO.STR;1", in a generated test ISO. Parent directories are
+// created as needed.
+type TreeFile struct {
+	Path string
+	Data []byte
+}
+
+// CreateTree returns an ISO9660 image with nested directories, each one block
+// long, and a path table listing them in level order. File data is stored
+// contiguously from the block after the last directory.
+func CreateTree(tb testing.TB, volumeID string, files []TreeFile) []byte {
+	tb.Helper()
+
+	dirs := []string{""} // Root first; level order follows from sorting by depth
+	seen := map[string]bool{"": true}
+	for _, file := range files {
+		parts := strings.Split(file.Path, "/")
+		for depth := 1; depth < len(parts); depth++ {
+			dir := strings.Join(parts[:depth], "/")
+			if !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	sort.SliceStable(dirs, func(i, j int) bool {
+		return strings.Count(dirs[i], "/")+boolInt(dirs[i] != "") < strings.Count(dirs[j], "/")+boolInt(dirs[j] != "")
+	})
+
+	dirLBA := make(map[string]int, len(dirs))
+	for idx, dir := range dirs {
+		dirLBA[dir] = 19 + idx
+	}
+	fileLBA := make([]int, len(files))
+	nextLBA := 19 + len(dirs)
+	for idx, file := range files {
+		fileLBA[idx] = nextLBA
+		nextLBA += max(1, (len(file.Data)+BlockSize-1)/BlockSize)
+	}
+	data := make([]byte, nextLBA*BlockSize)
+
+	pathTableSize := writeTreePathTable(tb, data[18*BlockSize:19*BlockSize], dirs, dirLBA)
+	writePVD(tb, data, volumeID, "SYS", "PUB", pathTableSize)
+
+	next := make(map[string]int, len(dirs)) // Next free record offset per directory
+	for _, dir := range dirs {
+		offset := dirLBA[dir] * BlockSize
+		WriteDirectoryRecord(tb, data[offset:], dirLBA[dir], BlockSize, "\x00")
+		WriteDirectoryRecord(tb, data[offset+34:], dirLBA[parentDir(dir)], BlockSize, "\x01")
+		next[dir] = offset + 68
+	}
+	addRecord := func(dir, name string, write func(record []byte)) {
+		if next[dir]+DirectoryRecordLength(name) > (dirLBA[dir]+1)*BlockSize {
+			tb.Fatalf("test ISO directory %q records exceed one block", dir)
+		}
+		write(data[next[dir]:])
+		next[dir] += DirectoryRecordLength(name)
+	}
+	for _, dir := range dirs[1:] {
+		name := dir[strings.LastIndex(dir, "/")+1:]
+		addRecord(parentDir(dir), name, func(record []byte) {
+			WriteDirectoryRecord(tb, record, dirLBA[dir], BlockSize, name)
+		})
+	}
+	for idx, file := range files {
+		dir, name := parentDir(file.Path), file.Path[strings.LastIndex(file.Path, "/")+1:]
+		addRecord(dir, name, func(record []byte) {
+			WriteFileRecord(tb, record, fileLBA[idx], len(file.Data), name)
+		})
+		copy(data[fileLBA[idx]*BlockSize:], file.Data)
+	}
+
+	return data
+}
+
+// writeTreePathTable writes a little-endian path table and returns its size.
+func writeTreePathTable(tb testing.TB, table []byte, dirs []string, dirLBA map[string]int) int {
+	tb.Helper()
+
+	index := make(map[string]int, len(dirs)) // 1-based directory numbers
+	offset := 0
+	for idx, dir := range dirs {
+		index[dir] = idx + 1
+		name := dir[strings.LastIndex(dir, "/")+1:]
+		parent := 1
+		if dir == "" {
+			name = "\x00"
+		} else {
+			parent = index[parentDir(dir)]
+		}
+		table[offset] = mustByte(tb, len(name))
+		binary.LittleEndian.PutUint32(table[offset+2:], mustUint32(tb, dirLBA[dir]))
+		binary.LittleEndian.PutUint16(table[offset+6:], uint16(parent)) //nolint:gosec // Few test directories
+		copy(table[offset+8:], name)
+		offset += 8 + len(name) + len(name)%2
+	}
+	return offset
+}
+
+// parentDir returns the directory containing a slash-separated path.
+func parentDir(path string) string {
+	if idx := strings.LastIndex(path, "/"); idx != -1 {
+		return path[:idx]
+	}
+	return ""
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // CreateMinimal returns a minimal ISO9660 image with optional root-level files.
 func CreateMinimal(tb testing.TB, volumeID, systemID, publisherID string, files []File) []byte {
 	tb.Helper()
 
 	totalBlocks := 20 + len(files)
 	data := make([]byte, totalBlocks*BlockSize)
+	writePVD(tb, data, volumeID, systemID, publisherID, 10)
+	writePathTable(data)
+	writeRootDirectory(tb, data, files)
+
+	return data
+}
+
+// writePVD writes the primary volume descriptor at block 16. The path table
+// is at block 18 and the root directory at block 19.
+func writePVD(tb testing.TB, data []byte, volumeID, systemID, publisherID string, pathTableSize int) {
+	tb.Helper()
+
+	totalBlocks := len(data) / BlockSize
 	pvdOffset := 16 * BlockSize
 
 	data[pvdOffset] = 0x01
@@ -54,17 +184,13 @@ func CreateMinimal(tb testing.TB, volumeID, systemID, publisherID string, files
 	binary.BigEndian.PutUint16(data[pvdOffset+126:], 1)
 	binary.LittleEndian.PutUint16(data[pvdOffset+128:], BlockSize)
 	binary.BigEndian.PutUint16(data[pvdOffset+130:], BlockSize)
-	binary.LittleEndian.PutUint32(data[pvdOffset+132:], 10)
-	binary.BigEndian.PutUint32(data[pvdOffset+136:], 10)
+	binary.LittleEndian.PutUint32(data[pvdOffset+132:], mustUint32(tb, pathTableSize))
+	binary.BigEndian.PutUint32(data[pvdOffset+136:], mustUint32(tb, pathTableSize))
 	binary.LittleEndian.PutUint32(data[pvdOffset+140:], 18)
 	copyBounded(data[pvdOffset+318:], publisherID, 128)
 	copy(data[pvdOffset+813:], "2024010112000000")
 
 	WriteDirectoryRecord(tb, data[pvdOffset+156:], 19, BlockSize, "\x00")
-	writePathTable(data)
-	writeRootDirectory(tb, data, files)
-
-	return data
 }
 
 func copyBounded(dst []byte, value string, maxLen int) {
@@ -132,12 +258,17 @@ func writeRecord(tb testing.TB, record []byte, lba, size int, name string) {
 	binary.BigEndian.PutUint32(record[6:], mustUint32(tb, lba))
 	binary.LittleEndian.PutUint32(record[10:], mustUint32(tb, size))
 	binary.BigEndian.PutUint32(record[14:], mustUint32(tb, size))
+	copy(record[18:25], RecordingDate[:])
 	binary.LittleEndian.PutUint16(record[28:], 1)
 	binary.BigEndian.PutUint16(record[30:], 1)
 	record[32] = mustByte(tb, len(name))
 	copy(record[33:], name)
 }
 
+// RecordingDate is the recording date written into every generated directory
+// record: 2024-01-01 12:00:00 UTC in the 7-byte directory record format.
+var RecordingDate = [7]byte{124, 1, 1, 12, 0, 0, 0}
+
 // DirectoryRecordLength returns an even-padded ISO9660 directory record length.
 func DirectoryRecordLength(name string) int {
 	recLen := 33 + len(name)
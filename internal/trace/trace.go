@@ -0,0 +1,56 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+// Package trace carries the diagnostics hook installed with gameid.SetLogger
+// to the packages that report identification decisions.
+//
+// Building the key/value arguments allocates, so call sites check Enabled
+// first:
+//
+//	if trace.Enabled() {
+//		trace.Log("iso.pvd", "offset", offset)
+//	}
+package trace
+
+import "sync/atomic"
+
+// Func receives an event name and alternating key/value pairs.
+type Func func(event string, kv ...any)
+
+var logger atomic.Pointer[Func]
+
+// Set installs fn as the hook. A nil fn disables tracing.
+func Set(fn Func) {
+	if fn == nil {
+		logger.Store(nil)
+		return
+	}
+	logger.Store(&fn)
+}
+
+// Enabled reports whether a hook is installed.
+func Enabled() bool {
+	return logger.Load() != nil
+}
+
+// Log reports an event to the installed hook, if any.
+func Log(event string, kv ...any) {
+	if fn := logger.Load(); fn != nil {
+		(*fn)(event, kv...)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package trace
+
+import (
+	"slices"
+	"testing"
+)
+
+//nolint:paralleltest // Installs the package-level hook
+func TestLog(t *testing.T) {
+	var events []string
+	Set(func(event string, kv ...any) {
+		events = append(events, event)
+		if len(kv) != 2 || kv[0] != "offset" || kv[1] != 16 {
+			t.Errorf("kv = %v, want [offset 16]", kv)
+		}
+	})
+	t.Cleanup(func() { Set(nil) })
+
+	if !Enabled() {
+		t.Fatal("Enabled() = false after Set")
+	}
+	Log("iso.pvd", "offset", 16)
+	if !slices.Equal(events, []string{"iso.pvd"}) {
+		t.Errorf("events = %v, want [iso.pvd]", events)
+	}
+
+	Set(nil)
+	Log("iso.pvd", "offset", 16)
+	if Enabled() || len(events) != 1 {
+		t.Errorf("hook still called after Set(nil): %v", events)
+	}
+}
+
+//nolint:paralleltest // Reads the package-level hook, which TestLog replaces
+func TestDisabledGuardDoesNotAllocate(t *testing.T) {
+	offset := int64(32768)
+	allocs := testing.AllocsPerRun(100, func() {
+		if Enabled() {
+			Log("iso.pvd", "offset", offset)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("disabled trace allocated %v times per call, want 0", allocs)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package iso9660
+
+import (
+	"fmt"
+
+	"github.com/ZaparooProject/go-gameid/ciso"
+)
+
+// OpenCISO opens an ISO9660 filesystem from a CISO (.cso) or ZISO (.zso)
+// compressed image, whose blocks decompress to cooked 2048-byte sectors.
+func OpenCISO(path string) (*ISO9660, error) {
+	cisoFile, err := ciso.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open CISO: %w", err)
+	}
+
+	iso, err := OpenReaderWithCloser(cisoFile, cisoFile.Size(), cisoFile)
+	if err != nil {
+		return nil, fmt.Errorf("parse ISO9660 from CISO: %w", err)
+	}
+	return iso, nil
+}
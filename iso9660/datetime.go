@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package iso9660
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PVD offsets of the 17-byte dec-datetime fields (ECMA-119 8.4.26-8.4.29).
+const (
+	pvdCreationTimeOffset     = 813
+	pvdModificationTimeOffset = 830
+	pvdExpirationTimeOffset   = 847
+	decDateTimeLen            = 17
+)
+
+// CreationTime returns the volume creation date and time from the PVD.
+// An unset field yields the zero time.Time and a nil error.
+func (iso *ISO9660) CreationTime() (time.Time, error) {
+	return iso.pvdTime(pvdCreationTimeOffset)
+}
+
+// ModificationTime returns the volume modification date and time from the PVD.
+// An unset field yields the zero time.Time and a nil error.
+func (iso *ISO9660) ModificationTime() (time.Time, error) {
+	return iso.pvdTime(pvdModificationTimeOffset)
+}
+
+// ExpirationTime returns the date and time after which the volume is
+// considered obsolete. Most discs leave it unset, yielding the zero time.Time.
+func (iso *ISO9660) ExpirationTime() (time.Time, error) {
+	return iso.pvdTime(pvdExpirationTimeOffset)
+}
+
+func (iso *ISO9660) pvdTime(offset int) (time.Time, error) {
+	if len(iso.pvd) < offset+decDateTimeLen {
+		return time.Time{}, nil
+	}
+	return parseDecDateTime(iso.pvd[offset : offset+decDateTimeLen])
+}
+
+// parseDecDateTime parses a dec-datetime: 16 ASCII digits YYYYMMDDHHMMSScc
+// followed by a signed GMT offset in 15-minute intervals. Fields that are all
+// zero digits, spaces or NULs denote "not specified".
+func parseDecDateTime(field []byte) (time.Time, error) {
+	if len(field) < decDateTimeLen {
+		return time.Time{}, fmt.Errorf("%w: field is %d bytes", ErrInvalidTime, len(field))
+	}
+	if isUnsetDecDateTime(field[:16]) {
+		return time.Time{}, nil
+	}
+
+	digits := string(field[:16])
+	parts := make([]int, 0, 7)
+	for _, span := range [][2]int{{0, 4}, {4, 6}, {6, 8}, {8, 10}, {10, 12}, {12, 14}, {14, 16}} {
+		value, err := strconv.Atoi(digits[span[0]:span[1]])
+		if err != nil || value < 0 {
+			return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTime, digits)
+		}
+		parts = append(parts, value)
+	}
+	year, month, day, hour, minute, second, centis := parts[0], parts[1], parts[2], parts[3], parts[4], parts[5], parts[6]
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTime, digits)
+	}
+
+	gmtOffset := int(int8(field[16])) // 15-minute intervals, -48 (west) to +52 (east)
+	if gmtOffset < -48 || gmtOffset > 52 {
+		return time.Time{}, fmt.Errorf("%w: GMT offset %d", ErrInvalidTime, gmtOffset)
+	}
+	loc := time.UTC
+	if gmtOffset != 0 {
+		loc = time.FixedZone("", gmtOffset*15*60)
+	}
+
+	return time.Date(year, time.Month(month), day, hour, minute, second, centis*int(10*time.Millisecond), loc), nil
+}
+
+func isUnsetDecDateTime(digits []byte) bool {
+	for _, b := range digits {
+		if b != '0' && b != ' ' && b != 0 {
+			return false
+		}
+	}
+	return true
+}
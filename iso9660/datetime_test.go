@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package iso9660
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ZaparooProject/go-gameid/internal/testiso"
+)
+
+func decDateTime(digits string, gmtOffset int8) []byte {
+	field := make([]byte, decDateTimeLen)
+	copy(field, digits)
+	field[16] = byte(gmtOffset)
+	return field
+}
+
+func TestParseDecDateTime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		want    time.Time
+		wantErr error
+		name    string
+		field   []byte
+	}{
+		{
+			name:  "noon UTC",
+			field: decDateTime("2024010112000000", 0),
+			want:  time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "hundredths and positive offset",
+			field: decDateTime("1998112723594250", 36),
+			want:  time.Date(1998, time.November, 27, 23, 59, 42, 500*int(time.Millisecond), time.FixedZone("", 9*3600)),
+		},
+		{
+			name:  "negative offset",
+			field: decDateTime("2001063008153000", -20),
+			want:  time.Date(2001, time.June, 30, 8, 15, 30, 0, time.FixedZone("", -5*3600)),
+		},
+		{name: "zero digits unset", field: decDateTime("0000000000000000", 0)},
+		{name: "NUL bytes unset", field: make([]byte, decDateTimeLen)},
+		{name: "spaces unset", field: decDateTime("                ", 0)},
+		{name: "non-digit", field: decDateTime("2024AB0112000000", 0), wantErr: ErrInvalidTime},
+		{name: "month out of range", field: decDateTime("2024130112000000", 0), wantErr: ErrInvalidTime},
+		{name: "offset out of range", field: decDateTime("2024010112000000", 60), wantErr: ErrInvalidTime},
+		{name: "short field", field: []byte("2024"), wantErr: ErrInvalidTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseDecDateTime(tt.field)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseDecDateTime() error = %v, want %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDecDateTime() = %v, want %v", got, tt.want)
+			}
+			if tt.want.IsZero() && !got.IsZero() {
+				t.Errorf("parseDecDateTime() = %v, want zero time", got)
+			}
+		})
+	}
+}
+
+func TestISO9660_VolumeTimes(t *testing.T) {
+	t.Parallel()
+
+	isoData := testiso.CreateMinimal(t, "TIMES", "SYS", "PUB", nil)
+	iso, err := OpenReader(bytes.NewReader(isoData), int64(len(isoData)))
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+
+	created, err := iso.CreationTime()
+	if err != nil {
+		t.Fatalf("CreationTime() error = %v", err)
+	}
+	if want := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC); !created.Equal(want) {
+		t.Errorf("CreationTime() = %v, want %v", created, want)
+	}
+
+	modified, err := iso.ModificationTime()
+	if err != nil {
+		t.Fatalf("ModificationTime() error = %v", err)
+	}
+	if !modified.IsZero() {
+		t.Errorf("ModificationTime() = %v, want zero time", modified)
+	}
+
+	expires, err := iso.ExpirationTime()
+	if err != nil {
+		t.Fatalf("ExpirationTime() error = %v", err)
+	}
+	if !expires.IsZero() {
+		t.Errorf("ExpirationTime() = %v, want zero time", expires)
+	}
+}
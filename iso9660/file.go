@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package iso9660
+
+import (
+	"fmt"
+	"io"
+)
+
+// OpenFile returns a reader over a file's contents that reads from the image
+// on demand, so callers can seek to and read part of a large file without
+// loading it. Reads stop at the file's recorded size.
+func (iso *ISO9660) OpenFile(path string) (io.ReadSeeker, error) {
+	info, err := iso.lookupFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return io.NewSectionReader(&fileReader{iso: iso, lba: info.LBA}, 0, int64(info.Size)), nil
+}
+
+// fileReader maps file offsets onto the image. Each logical sector holds
+// 2048 bytes of user data, which raw images store inside a larger block.
+type fileReader struct {
+	iso *ISO9660
+	lba uint32
+}
+
+// ReadAt implements io.ReaderAt. The enclosing SectionReader clamps reads to
+// the file size.
+func (fr *fileReader) ReadAt(buf []byte, off int64) (int, error) {
+	iso := fr.iso
+	if iso.blockSize == logicalSectorSize {
+		n, err := iso.reader.ReadAt(buf, iso.blockOffset+int64(fr.lba)*logicalSectorSize+off)
+		if err != nil {
+			return n, fmt.Errorf("read file data at offset %d: %w", off, err)
+		}
+		return n, nil
+	}
+
+	total := 0
+	for total < len(buf) {
+		sector, within := off/logicalSectorSize, off%logicalSectorSize
+		chunk := buf[total:min(len(buf), total+int(logicalSectorSize-within))]
+		pos := iso.blockOffset + (int64(fr.lba)+sector)*int64(iso.blockSize) + within
+		n, err := iso.reader.ReadAt(chunk, pos)
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("read file data at offset %d: %w", off, err)
+		}
+		off += int64(n)
+	}
+	return total, nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package iso9660
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FileLister is a disc whose files can be listed, such as an *ISO9660 or a
+// *MountedDisc.
+type FileLister interface {
+	IterFiles(onlyRootDir bool) ([]FileInfo, error)
+}
+
+// Fingerprint returns a stable digest of a disc's file tree, for use as a
+// fallback ID when the disc carries no reliable serial. It hashes the path
+// and size of every file, sorted by path. Paths are upper-cased and lose
+// their ";1" version suffix, so an image and the same disc mounted by the
+// operating system fingerprint alike. File contents aren't read.
+func Fingerprint(disc FileLister) (string, error) {
+	files, err := disc.IterFiles(false)
+	if err != nil {
+		return "", fmt.Errorf("list files: %w", err)
+	}
+
+	entries := make([]string, 0, len(files))
+	for _, file := range files {
+		path := strings.ToUpper(file.Path)
+		if idx := strings.LastIndexByte(path, ';'); idx != -1 {
+			path = path[:idx]
+		}
+		entries = append(entries, path+"\x00"+strconv.FormatUint(uint64(file.Size), 10))
+	}
+	slices.Sort(entries)
+
+	hash := sha256.New()
+	for _, entry := range entries {
+		_, _ = hash.Write([]byte(entry + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package iso9660
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ZaparooProject/go-gameid/internal/testiso"
+)
+
+// fingerprintTree builds an ISO from files and returns its fingerprint.
+func fingerprintTree(t *testing.T, files []testiso.TreeFile) string {
+	t.Helper()
+
+	isoData := testiso.CreateTree(t, "FPRINT", files)
+	iso, err := OpenReader(bytes.NewReader(isoData), int64(len(isoData)))
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	fingerprint, err := Fingerprint(iso)
+	if err != nil {
+		t.Fatalf("Fingerprint() error = %v", err)
+	}
+	return fingerprint
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	base := []testiso.TreeFile{
+		{Path: "BOOT.BIN;1", Data: []byte("boot code")},
+		{Path: "DATA/LEVEL1.DAT;1", Data: []byte("level one")},
+		{Path: "DATA/LEVEL2.DAT;1", Data: []byte("level two!")},
+	}
+	reordered := []testiso.TreeFile{base[2], base[0], base[1]}
+	resized := []testiso.TreeFile{base[0], base[1], {Path: "DATA/LEVEL2.DAT;1", Data: []byte("level two, longer")}}
+	renamed := []testiso.TreeFile{base[0], base[1], {Path: "DATA/LEVEL3.DAT;1", Data: []byte("level two!")}}
+
+	want := fingerprintTree(t, base)
+	if len(want) != 64 {
+		t.Errorf("Fingerprint() = %q, want a 64-character hex digest", want)
+	}
+	if got := fingerprintTree(t, base); got != want {
+		t.Errorf("identical trees: Fingerprint() = %q, want %q", got, want)
+	}
+	if got := fingerprintTree(t, reordered); got != want {
+		t.Errorf("reordered records: Fingerprint() = %q, want %q", got, want)
+	}
+	if got := fingerprintTree(t, resized); got == want {
+		t.Error("resized file: Fingerprint() unchanged")
+	}
+	if got := fingerprintTree(t, renamed); got == want {
+		t.Error("renamed file: Fingerprint() unchanged")
+	}
+
+	// The same files on a mounted disc fingerprint alike
+	dir := t.TempDir()
+	for _, file := range base {
+		name := filepath.Join(dir, filepath.FromSlash(file.Path[:len(file.Path)-2]))
+		if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(name, file.Data, 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+	mounted, err := OpenMounted(dir, "", "FPRINT")
+	if err != nil {
+		t.Fatalf("OpenMounted() error = %v", err)
+	}
+	got, err := Fingerprint(mounted)
+	if err != nil {
+		t.Fatalf("Fingerprint() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("mounted disc: Fingerprint() = %q, want %q", got, want)
+	}
+}
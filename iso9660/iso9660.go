@@ -26,7 +26,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
+
+	"github.com/ZaparooProject/go-gameid/internal/trace"
 )
 
 // Common errors
@@ -35,6 +38,7 @@ var (
 	ErrPVDNotFound  = errors.New("primary volume descriptor not found")
 	ErrInvalidBlock = errors.New("invalid block size")
 	ErrFileNotFound = errors.New("file not found")
+	ErrInvalidTime  = errors.New("invalid volume date/time")
 )
 
 // PVD magic word: 0x01 followed by "CD001"
@@ -68,6 +72,14 @@ type ISO9660 struct {
 
 // Open opens an ISO9660 disc image from a file.
 func Open(path string) (*ISO9660, error) {
+	return OpenWithBlockSize(path, 0)
+}
+
+// OpenWithBlockSize opens an ISO9660 disc image whose sector size is already
+// known (2048 or 2352), instead of guessing it from the file size. Images
+// truncated to a partial sector would otherwise be misclassified. A block
+// size of zero keeps the size-based guess.
+func OpenWithBlockSize(path string, blockSize int) (*ISO9660, error) {
 	isoFile, err := os.Open(path) //nolint:gosec // Path from user input is expected
 	if err != nil {
 		return nil, fmt.Errorf("open ISO file: %w", err)
@@ -81,9 +93,10 @@ func Open(path string) (*ISO9660, error) {
 	}
 
 	iso := &ISO9660{
-		reader: isoFile,
-		closer: isoFile,
-		size:   info.Size(),
+		reader:    isoFile,
+		closer:    isoFile,
+		size:      info.Size(),
+		blockSize: blockSize,
 	}
 
 	// Block devices (e.g. /dev/sr0) report a stat size of 0; the kernel
@@ -119,10 +132,23 @@ func OpenReader(reader io.ReaderAt, size int64) (*ISO9660, error) {
 // OpenReaderWithCloser creates an ISO9660 from an io.ReaderAt with an optional closer.
 // The closer will be called when Close() is called on the ISO9660.
 func OpenReaderWithCloser(reader io.ReaderAt, size int64, closer io.Closer) (*ISO9660, error) {
+	return openReader(reader, size, closer, 0)
+}
+
+// OpenReaderWithBlockSize is OpenWithBlockSize for an io.ReaderAt: the sector
+// size is pinned instead of guessed from size, and where the user data starts
+// within each sector is still found from the PVD. The caller is responsible
+// for closing the underlying reader if needed.
+func OpenReaderWithBlockSize(reader io.ReaderAt, size int64, blockSize int) (*ISO9660, error) {
+	return openReader(reader, size, nil, blockSize)
+}
+
+func openReader(reader io.ReaderAt, size int64, closer io.Closer, blockSize int) (*ISO9660, error) {
 	iso := &ISO9660{
-		reader: reader,
-		closer: closer,
-		size:   size,
+		reader:    reader,
+		closer:    closer,
+		size:      size,
+		blockSize: blockSize,
 	}
 
 	if err := iso.init(); err != nil {
@@ -153,7 +179,11 @@ func detectBlockSize(size int64) int {
 func (iso *ISO9660) init() error {
 	// Determine block size from file size unless the caller already knows it
 	// (block devices always expose 2048-byte sectors).
-	if iso.blockSize == 0 {
+	if iso.blockSize < 0 || (iso.blockSize > 0 && iso.blockSize < userDataSize) {
+		return fmt.Errorf("%w: %d", ErrInvalidBlock, iso.blockSize)
+	}
+	guessed := iso.blockSize == 0
+	if guessed {
 		iso.blockSize = detectBlockSize(iso.size)
 	}
 
@@ -165,8 +195,17 @@ func (iso *ISO9660) init() error {
 		return ErrPVDNotFound
 	}
 
+	// A cooked image whose length also happens to be a multiple of 2352
+	// is still cooked when its PVD sits exactly at 2048-byte block 16.
+	if guessed && pvdOffset == 16*2048 {
+		iso.blockSize = 2048
+	}
+
 	// Calculate block offset (PVD should be at block 16)
 	iso.blockOffset = pvdOffset - int64(16*iso.blockSize)
+	if trace.Enabled() {
+		trace.Log("iso.pvd", "offset", pvdOffset, "block_size", iso.blockSize, "guessed", guessed)
+	}
 
 	// Read PVD (one block)
 	iso.pvd = make([]byte, iso.blockSize)
@@ -403,6 +442,19 @@ func (iso *ISO9660) IterFiles(onlyRootDir bool) ([]FileInfo, error) {
 	return files, nil
 }
 
+// IterFilesSorted is IterFiles with the files sorted by full path, so the
+// order doesn't depend on how the disc's directory records were mastered.
+func (iso *ISO9660) IterFilesSorted(onlyRootDir bool) ([]FileInfo, error) {
+	files, err := iso.IterFiles(onlyRootDir)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(files, func(a, b FileInfo) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return files, nil
+}
+
 func fileInfoFromDirRecord(recBuf []byte, dirPath string) (FileInfo, bool) {
 	flags := recBuf[24]
 	if (flags & 0x02) != 0 {
@@ -500,39 +552,19 @@ func (iso *ISO9660) ReadFileWithLimit(info FileInfo, maxSize uint32) ([]byte, er
 	return data, nil
 }
 
-// ReadFileByPath reads a file by its path.
+// ReadFileByPath reads a file by its path. Matching is case-insensitive and
+// the ";1" version suffix is optional.
 func (iso *ISO9660) ReadFileByPath(path string) ([]byte, error) {
-	// Normalize path
-	path = strings.ToUpper(path)
-	if !strings.HasPrefix(path, "/") {
-		path = "/" + path
-	}
-
-	var found *FileInfo
-	err := iso.WalkFiles(false, func(file FileInfo) bool {
-		// ISO9660 filenames often have version suffix (;1)
-		fpath := strings.ToUpper(file.Path)
-		fpath = strings.Split(fpath, ";")[0]
-
-		if fpath == path || file.Path == path {
-			found = &file
-			return false
-		}
-		return true
-	})
+	found, err := iso.lookupFile(path)
 	if err != nil {
 		return nil, err
 	}
-	if found != nil {
-		return iso.ReadFile(*found)
-	}
-
-	return nil, ErrFileNotFound
+	return iso.ReadFile(*found)
 }
 
 // FileExists checks if a file exists at the given path.
 func (iso *ISO9660) FileExists(path string) bool {
-	_, err := iso.ReadFileByPath(path)
+	_, err := iso.lookupFile(path)
 	return err == nil
 }
 
@@ -541,6 +573,14 @@ func (iso *ISO9660) BlockSize() int {
 	return iso.blockSize
 }
 
+// BlockOffset returns where the user data of logical block 0 starts in the
+// image: 0 for cooked images, 16 for raw Mode 1 sectors and 24 for raw Mode 2
+// sectors, plus any bytes that precede the first sector. Block n's data
+// starts at BlockOffset() + n*BlockSize().
+func (iso *ISO9660) BlockOffset() int64 {
+	return iso.blockOffset
+}
+
 // Size returns the total size of the disc image.
 func (iso *ISO9660) Size() int64 {
 	return iso.size
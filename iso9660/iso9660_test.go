@@ -25,8 +25,10 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ZaparooProject/go-gameid/internal/testiso"
 )
@@ -412,6 +414,44 @@ func TestISO9660_IterFiles(t *testing.T) {
 	_ = files // We just verify it doesn't error
 }
 
+func TestISO9660_IterFilesSorted(t *testing.T) {
+	t.Parallel()
+
+	files := []testiso.File{
+		{Name: "SLUS_123.45;1", Data: []byte("exe")},
+		{Name: "ABC.DAT;1", Data: []byte("abc")},
+		{Name: "SYSTEM.CNF;1", Data: []byte("cnf")},
+	}
+	reversed := slices.Clone(files)
+	slices.Reverse(reversed)
+
+	var orders [][]string
+	for _, discFiles := range [][]testiso.File{files, reversed} {
+		isoData := testiso.CreateMinimal(t, "VOL", "SYS", "", discFiles)
+		iso, err := OpenReader(bytes.NewReader(isoData), int64(len(isoData)))
+		if err != nil {
+			t.Fatalf("OpenReader() error = %v", err)
+		}
+
+		sorted, err := iso.IterFilesSorted(false)
+		if err != nil {
+			t.Fatalf("IterFilesSorted() error = %v", err)
+		}
+		paths := make([]string, 0, len(sorted))
+		for _, file := range sorted {
+			paths = append(paths, file.Path)
+		}
+		orders = append(orders, paths)
+	}
+
+	want := []string{"/ABC.DAT;1", "/SLUS_123.45;1", "/SYSTEM.CNF;1"}
+	for i, paths := range orders {
+		if !slices.Equal(paths, want) {
+			t.Errorf("disc %d IterFilesSorted() paths = %v, want %v", i, paths, want)
+		}
+	}
+}
+
 func TestISO9660_WalkFilesStopsEarlyAndReadFileByPath(t *testing.T) {
 	t.Parallel()
 
@@ -473,6 +513,259 @@ func TestISO9660_WalkFilesReturnsDirectoryReadError(t *testing.T) {
 	}
 }
 
+func nestedTestFiles() []testiso.TreeFile {
+	return []testiso.TreeFile{
+		{Path: "SYSTEM.CNF;1", Data: []byte("BOOT = cdrom:\\SLUS_000.01;1")},
+		{Path: "DATA/INFO.TXT;1", Data: []byte("info")},
+		{Path: "DATA/MOVIES/INTRO.STR;1", Data: []byte("intro movie")},
+		{Path: "DATA/MOVIES/HD/ENDING.STR;1", Data: []byte("ending movie")},
+		{Path: "EXTRA/README.TXT;1", Data: []byte("readme")},
+	}
+}
+
+func TestISO9660_LookupMatchesWalk(t *testing.T) {
+	t.Parallel()
+
+	isoData := testiso.CreateTree(t, "NESTED", nestedTestFiles())
+	iso, err := OpenReader(bytes.NewReader(isoData), int64(len(isoData)))
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+
+	files, err := iso.IterFiles(false)
+	if err != nil {
+		t.Fatalf("IterFiles() error = %v", err)
+	}
+	if len(files) != len(nestedTestFiles()) {
+		t.Fatalf("IterFiles() returned %d files, want %d", len(files), len(nestedTestFiles()))
+	}
+
+	for _, file := range files {
+		parts := strings.Split(strings.Trim(file.Path, "/"), "/")
+		dirs, name := parts[:len(parts)-1], parts[len(parts)-1]
+
+		lba, ok := iso.pathTableDir(dirs)
+		if !ok {
+			t.Fatalf("pathTableDir(%q) not found", dirs)
+		}
+		viaTable, err := iso.findInDirectory(lba, 0, dirs, name, kindFile)
+		if err != nil {
+			t.Fatalf("findInDirectory(%s) error = %v", file.Path, err)
+		}
+		viaRecords, err := iso.walkRecords(dirs, name, kindFile)
+		if err != nil {
+			t.Fatalf("walkRecords(%s) error = %v", file.Path, err)
+		}
+		tableInfo := FileInfo{Path: viaTable.Path, LBA: viaTable.LBA, Size: viaTable.Size}
+		recordsInfo := FileInfo{Path: viaRecords.Path, LBA: viaRecords.LBA, Size: viaRecords.Size}
+		if tableInfo != file || recordsInfo != file {
+			t.Errorf("lookup %s: path table = %+v, records = %+v, want %+v", file.Path, tableInfo, recordsInfo, file)
+		}
+	}
+
+	data, err := iso.ReadFileByPath("data/movies/hd/ending.str")
+	if err != nil {
+		t.Fatalf("ReadFileByPath() error = %v", err)
+	}
+	if string(data) != "ending movie" {
+		t.Errorf("ReadFileByPath() = %q, want %q", data, "ending movie")
+	}
+	if iso.FileExists("/DATA/HD/ENDING.STR") {
+		t.Error("FileExists() found a file under the wrong directory")
+	}
+}
+
+func TestISO9660_ReadFileByPath_PathTableFallback(t *testing.T) {
+	t.Parallel()
+
+	pvdOffset := 16 * testiso.BlockSize
+	tests := []struct {
+		corrupt func(data []byte)
+		name    string
+	}{
+		{
+			name: "empty path table",
+			corrupt: func(data []byte) {
+				binary.LittleEndian.PutUint32(data[pvdOffset+132:], 0)
+				binary.BigEndian.PutUint32(data[pvdOffset+136:], 0)
+			},
+		},
+		{
+			name: "path table LBA outside image",
+			corrupt: func(data []byte) {
+				// Every non-root entry points past the end of the image
+				table := data[18*testiso.BlockSize:]
+				for pos := 0; table[pos] != 0; pos += 8 + int(table[pos]) + int(table[pos])%2 {
+					if pos > 0 {
+						binary.LittleEndian.PutUint32(table[pos+2:], 0xFFFFFF)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			isoData := testiso.CreateTree(t, "NESTED", nestedTestFiles())
+			tt.corrupt(isoData)
+			iso, err := OpenReader(bytes.NewReader(isoData), int64(len(isoData)))
+			if err != nil {
+				t.Fatalf("OpenReader() error = %v", err)
+			}
+
+			data, err := iso.ReadFileByPath("/DATA/MOVIES/INTRO.STR")
+			if err != nil {
+				t.Fatalf("ReadFileByPath() error = %v", err)
+			}
+			if string(data) != "intro movie" {
+				t.Errorf("ReadFileByPath() = %q, want %q", data, "intro movie")
+			}
+			if _, err := iso.ReadFileByPath("/DATA/MISSING.TXT"); !errors.Is(err, ErrFileNotFound) {
+				t.Errorf("ReadFileByPath(missing) error = %v, want %v", err, ErrFileNotFound)
+			}
+		})
+	}
+}
+
+func TestISO9660_Stat(t *testing.T) {
+	t.Parallel()
+
+	isoData := testiso.CreateTree(t, "NESTED", nestedTestFiles())
+	iso, err := OpenReader(bytes.NewReader(isoData), int64(len(isoData)))
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	recorded := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		path     string
+		wantPath string
+		wantSize uint32
+		wantDir  bool
+	}{
+		{"root directory", "/", "/", testiso.BlockSize, true},
+		{"root file", "system.cnf", "/SYSTEM.CNF;1", 27, false},
+		{"nested directory", "/DATA/MOVIES", "/DATA/MOVIES", testiso.BlockSize, true},
+		{"nested file", "/DATA/MOVIES/HD/ENDING.STR;1", "/DATA/MOVIES/HD/ENDING.STR;1", 12, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry, err := iso.Stat(tt.path)
+			if err != nil {
+				t.Fatalf("Stat(%q) error = %v", tt.path, err)
+			}
+			if entry.Path != tt.wantPath {
+				t.Errorf("Path = %q, want %q", entry.Path, tt.wantPath)
+			}
+			if entry.Size != tt.wantSize {
+				t.Errorf("Size = %d, want %d", entry.Size, tt.wantSize)
+			}
+			if entry.IsDir != tt.wantDir {
+				t.Errorf("IsDir = %v, want %v", entry.IsDir, tt.wantDir)
+			}
+			if entry.LBA == 0 {
+				t.Error("LBA = 0, want directory record extent")
+			}
+			if !entry.Recorded.Equal(recorded) {
+				t.Errorf("Recorded = %v, want %v", entry.Recorded, recorded)
+			}
+		})
+	}
+
+	if _, err := iso.Stat("/DATA/NOPE.TXT"); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("Stat(missing) error = %v, want %v", err, ErrFileNotFound)
+	}
+}
+
+func TestISO9660_OpenFile(t *testing.T) {
+	t.Parallel()
+
+	content := make([]byte, 3000) // Spans two logical sectors
+	for i := range content {
+		content[i] = byte(i * 13)
+	}
+	cooked := testiso.CreateTree(t, "STREAM", []testiso.TreeFile{
+		{Path: "DATA/MAIN.DOL;1", Data: content},
+	})
+
+	// Mode 1 raw image: 16-byte sync/header, 2048 bytes of user data, 288 bytes of EDC/ECC
+	raw := make([]byte, len(cooked)/testiso.BlockSize*2352)
+	for sector := range len(cooked) / testiso.BlockSize {
+		dst := raw[sector*2352:]
+		copy(dst, []byte{0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00})
+		dst[15] = 0x01
+		copy(dst[16:16+testiso.BlockSize], cooked[sector*testiso.BlockSize:])
+	}
+
+	tests := []struct {
+		name  string
+		image []byte
+	}{
+		{"cooked", cooked},
+		{"raw 2352", raw},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			iso, err := OpenReader(bytes.NewReader(tt.image), int64(len(tt.image)))
+			if err != nil {
+				t.Fatalf("OpenReader() error = %v", err)
+			}
+			file, err := iso.OpenFile("/data/main.dol")
+			if err != nil {
+				t.Fatalf("OpenFile() error = %v", err)
+			}
+
+			header := make([]byte, 64)
+			if _, err := io.ReadFull(file, header); err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if !bytes.Equal(header, content[:64]) {
+				t.Error("Read() of first 64 bytes returned wrong data")
+			}
+
+			// Seek so the read straddles the sector boundary
+			if _, err := file.Seek(2040, io.SeekStart); err != nil {
+				t.Fatalf("Seek() error = %v", err)
+			}
+			if _, err := io.ReadFull(file, header); err != nil {
+				t.Fatalf("Read() after Seek error = %v", err)
+			}
+			if !bytes.Equal(header, content[2040:2104]) {
+				t.Error("Read() across a sector boundary returned wrong data")
+			}
+
+			end, err := file.Seek(-10, io.SeekEnd)
+			if err != nil || end != int64(len(content)-10) {
+				t.Fatalf("Seek(-10, end) = %d, %v; want %d", end, err, len(content)-10)
+			}
+			tail, err := io.ReadAll(file)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if !bytes.Equal(tail, content[len(content)-10:]) {
+				t.Errorf("ReadAll() at end = %d bytes, want the last 10 bytes of the file", len(tail))
+			}
+		})
+	}
+
+	iso, err := OpenReader(bytes.NewReader(cooked), int64(len(cooked)))
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	if _, err := iso.OpenFile("/DATA/MISSING.DOL"); !errors.Is(err, ErrFileNotFound) {
+		t.Errorf("OpenFile(missing) error = %v, want %v", err, ErrFileNotFound)
+	}
+}
+
 func TestISO9660_ReadFileByPath_NotFound(t *testing.T) {
 	t.Parallel()
 
@@ -677,6 +970,82 @@ func TestInitPresetBlockSize(t *testing.T) {
 	}
 }
 
+// rawSectorImage wraps each 2048-byte block of a cooked image in a 2352-byte
+// raw sector whose user data starts at dataOffset.
+func rawSectorImage(cooked []byte, mode byte, dataOffset int) []byte {
+	raw := make([]byte, len(cooked)/2048*2352)
+	for sector := range len(cooked) / 2048 {
+		dst := raw[sector*2352:]
+		copy(dst, []byte{0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00})
+		dst[15] = mode
+		copy(dst[dataOffset:dataOffset+2048], cooked[sector*2048:])
+	}
+	return raw
+}
+
+// TestISO9660_RawSectorLayout verifies the detected or pinned layout of
+// 2352-byte sector images puts the PVD at block 16's user data.
+func TestISO9660_RawSectorLayout(t *testing.T) {
+	t.Parallel()
+
+	cooked := createMinimalISO("RAWDISC", "PLAYSTATION", "")
+
+	tests := []struct {
+		name       string
+		mode       byte
+		dataOffset int
+		truncate   int
+		blockSize  int
+	}{
+		{name: "mode 1 guessed", mode: 1, dataOffset: 16},
+		{name: "mode 2 guessed", mode: 2, dataOffset: 24},
+		// A partial trailing sector defeats the size-based guess
+		{name: "mode 2 truncated and pinned", mode: 2, dataOffset: 24, truncate: 100, blockSize: 2352},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			image := rawSectorImage(cooked, tt.mode, tt.dataOffset)
+			image = image[:len(image)-tt.truncate]
+
+			iso, err := OpenReaderWithBlockSize(bytes.NewReader(image), int64(len(image)), tt.blockSize)
+			if err != nil {
+				t.Fatalf("OpenReaderWithBlockSize() error = %v", err)
+			}
+			defer func() { _ = iso.Close() }()
+
+			if iso.BlockSize() != 2352 {
+				t.Errorf("BlockSize() = %d, want 2352", iso.BlockSize())
+			}
+			if iso.BlockOffset() != int64(tt.dataOffset) {
+				t.Errorf("BlockOffset() = %d, want %d", iso.BlockOffset(), tt.dataOffset)
+			}
+			pvdOffset := iso.BlockOffset() + 16*int64(iso.BlockSize())
+			if want := int64(16*2352 + tt.dataOffset); pvdOffset != want {
+				t.Errorf("PVD offset = %d, want %d", pvdOffset, want)
+			}
+			if !bytes.Equal(image[pvdOffset:pvdOffset+6], pvdMagicWord) {
+				t.Errorf("no PVD magic at offset %d", pvdOffset)
+			}
+			if got := strings.Trim(iso.GetVolumeID(), "\x00 "); got != "RAWDISC" {
+				t.Errorf("GetVolumeID() = %q, want %q", got, "RAWDISC")
+			}
+		})
+	}
+}
+
+func TestOpenReaderWithBlockSize_Invalid(t *testing.T) {
+	t.Parallel()
+
+	isoData := createMinimalISO("TEST", "SYS", "PUB")
+	_, err := OpenReaderWithBlockSize(bytes.NewReader(isoData), int64(len(isoData)), 1000)
+	if !errors.Is(err, ErrInvalidBlock) {
+		t.Errorf("OpenReaderWithBlockSize() error = %v, want ErrInvalidBlock", err)
+	}
+}
+
 // TestReadFileSizeCap verifies that a corrupt directory record claiming a huge
 // file size is rejected instead of allocating the claimed amount.
 func TestReadFileSizeCap(t *testing.T) {
@@ -0,0 +1,218 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package iso9660
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// logicalSectorSize is the ISO9660 logical sector size. Directory records
+// never span a logical sector boundary.
+const logicalSectorSize = 2048
+
+// maxDirectoryExtent bounds how much of a directory extent lookups will scan,
+// so a corrupt size field cannot turn one lookup into a full-image read.
+const maxDirectoryExtent = 16 * 1024 * 1024
+
+// FileEntry holds the directory record fields for a file or directory.
+type FileEntry struct {
+	Recorded time.Time // Zero if the record carries no date
+	Path     string
+	LBA      uint32
+	Size     uint32
+	IsDir    bool
+}
+
+// recordKind selects which directory records a lookup may match.
+type recordKind int
+
+const (
+	kindFile recordKind = iota
+	kindDir
+	kindAny
+)
+
+// Stat returns the directory record for a file or directory without reading
+// its contents. "/" returns the root directory.
+func (iso *ISO9660) Stat(path string) (*FileEntry, error) {
+	if strings.Trim(path, "/") == "" {
+		root := entryFromRecord(iso.pvd[157:190], "/")
+		root.Path = "/"
+		return &root, nil
+	}
+	return iso.lookup(path, kindAny)
+}
+
+// lookupFile resolves a file path to its FileInfo.
+func (iso *ISO9660) lookupFile(path string) (*FileInfo, error) {
+	entry, err := iso.lookup(path, kindFile)
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfo{Path: entry.Path, LBA: entry.LBA, Size: entry.Size}, nil
+}
+
+// lookup resolves a path. The parent directory is located through
+// the in-memory path table, so only that directory's extent is read. If the
+// path table is missing, lacks the directory, or points at unreadable data,
+// directory records are followed from the root instead.
+func (iso *ISO9660) lookup(path string, kind recordKind) (*FileEntry, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	dirs, name := parts[:len(parts)-1], parts[len(parts)-1]
+	if name == "" {
+		return nil, ErrFileNotFound
+	}
+
+	if lba, ok := iso.pathTableDir(dirs); ok {
+		found, err := iso.findInDirectory(lba, 0, dirs, name, kind)
+		if err == nil {
+			return found, nil
+		}
+		if errors.Is(err, ErrFileNotFound) {
+			return nil, err
+		}
+	}
+
+	return iso.walkRecords(dirs, name, kind)
+}
+
+// pathTableDir returns the extent LBA of the directory with the given
+// components by following parent links in the path table.
+func (iso *ISO9660) pathTableDir(dirs []string) (uint32, bool) {
+	if len(iso.pathTable) == 0 {
+		return 0, false
+	}
+
+	idx := 0
+	for _, dir := range dirs {
+		next := -1
+		// Path table entries are sorted by level, so children follow their parent
+		for childIdx := idx + 1; childIdx < len(iso.pathTable); childIdx++ {
+			entry := iso.pathTable[childIdx]
+			if entry.parentIdx == idx && strings.EqualFold(strings.TrimSuffix(entry.name, "/"), dir) {
+				next = childIdx
+				break
+			}
+		}
+		if next == -1 {
+			return 0, false
+		}
+		idx = next
+	}
+
+	return iso.pathTable[idx].lba, true
+}
+
+// walkRecords resolves a path by descending directory records from the root
+// directory record stored in the PVD.
+func (iso *ISO9660) walkRecords(dirs []string, name string, kind recordKind) (*FileEntry, error) {
+	lba := binary.LittleEndian.Uint32(iso.pvd[158:162])
+	size := binary.LittleEndian.Uint32(iso.pvd[166:170])
+
+	for _, dir := range dirs {
+		sub, err := iso.findInDirectory(lba, size, nil, dir, kindDir)
+		if err != nil {
+			return nil, err
+		}
+		lba, size = sub.LBA, sub.Size
+	}
+
+	return iso.findInDirectory(lba, size, dirs, name, kind)
+}
+
+// findInDirectory scans one directory extent for a record named name and of
+// the given kind. Names match case-insensitively, with or without their ";1"
+// version suffix. A size of 0
+// reads the extent size from the directory's own "." record.
+func (iso *ISO9660) findInDirectory(lba, size uint32, dirs []string, name string, kind recordKind) (*FileEntry, error) {
+	dirPath := "/"
+	if len(dirs) > 0 {
+		dirPath += strings.Join(dirs, "/") + "/"
+	}
+
+	sector := make([]byte, logicalSectorSize)
+	for sectorIdx := uint32(0); size == 0 || sectorIdx*logicalSectorSize < size; sectorIdx++ {
+		offset := iso.blockOffset + int64(lba+sectorIdx)*int64(iso.blockSize)
+		if _, err := iso.reader.ReadAt(sector, offset); err != nil {
+			return nil, fmt.Errorf("read directory records at offset %d: %w", offset, err)
+		}
+		if size == 0 {
+			if size = binary.LittleEndian.Uint32(sector[10:14]); size == 0 || size > maxDirectoryExtent {
+				return nil, fmt.Errorf("%w: directory at LBA %d has extent size %d", ErrInvalidISO, lba, size)
+			}
+		}
+
+		for pos := 0; pos < logicalSectorSize && sector[pos] != 0; {
+			recLen := int(sector[pos])
+			if recLen < 34 || pos+recLen > logicalSectorSize {
+				return nil, fmt.Errorf("invalid directory record length %d at offset %d", recLen, offset+int64(pos))
+			}
+			if found, ok := matchRecord(sector[pos+1:pos+recLen], dirPath, name, kind); ok {
+				return found, nil
+			}
+			pos += recLen
+		}
+	}
+
+	return nil, ErrFileNotFound
+}
+
+// matchRecord reports whether a directory record (without its length byte)
+// names the wanted file or subdirectory.
+func matchRecord(recBuf []byte, dirPath, name string, kind recordKind) (*FileEntry, bool) {
+	isDir := recBuf[24]&0x02 != 0
+	nameLen := int(recBuf[31])
+	if (kind == kindFile && isDir) || (kind == kindDir && !isDir) || nameLen == 0 || 32+nameLen > len(recBuf) {
+		return nil, false
+	}
+
+	recName := string(recBuf[32 : 32+nameLen])
+	if !strings.EqualFold(recName, name) && !strings.EqualFold(strings.Split(recName, ";")[0], name) {
+		return nil, false
+	}
+
+	entry := entryFromRecord(recBuf, dirPath+recName)
+	return &entry, true
+}
+
+// entryFromRecord decodes a directory record (without its length byte).
+func entryFromRecord(recBuf []byte, path string) FileEntry {
+	return FileEntry{
+		Path:     path,
+		LBA:      binary.LittleEndian.Uint32(recBuf[1:5]),
+		Size:     binary.LittleEndian.Uint32(recBuf[9:13]),
+		IsDir:    recBuf[24]&0x02 != 0,
+		Recorded: recordingTime(recBuf[17:24]),
+	}
+}
+
+// recordingTime decodes the 7-byte directory record date: years since 1900,
+// month, day, hour, minute, second, and a GMT offset in 15-minute steps.
+func recordingTime(date []byte) time.Time {
+	if date[1] == 0 || date[2] == 0 {
+		return time.Time{}
+	}
+	zone := time.FixedZone("", int(int8(date[6]))*15*60) //nolint:gosec // Offset is a signed byte by spec
+	return time.Date(1900+int(date[0]), time.Month(date[1]), int(date[2]),
+		int(date[3]), int(date[4]), int(date[5]), 0, zone)
+}
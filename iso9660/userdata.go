@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package iso9660
+
+import (
+	"fmt"
+	"io"
+)
+
+// userDataSize is the size of the user data area of a CD sector.
+const userDataSize = 2048
+
+// form2DataSize is the size of the user data area of a Mode 2 Form 2 sector,
+// which trades error correction for extra payload (XA audio and video).
+const form2DataSize = 2324
+
+// submodeForm2 is the Form 2 flag in the submode byte of a Mode 2 subheader.
+const submodeForm2 = 0x20
+
+// SectorForm identifies how a sector lays out its user data.
+type SectorForm int
+
+// Sector forms reported by ReadSector.
+const (
+	// SectorFormNone is a cooked or Mode 1 sector with 2048 bytes of user data.
+	SectorFormNone SectorForm = iota
+	// SectorForm1 is a Mode 2 Form 1 sector with 2048 bytes of user data.
+	SectorForm1
+	// SectorForm2 is a Mode 2 Form 2 sector with 2324 bytes of user data.
+	SectorForm2
+)
+
+// UserDataSize returns the number of user data bytes a sector of this form
+// carries.
+func (f SectorForm) UserDataSize() int {
+	if f == SectorForm2 {
+		return form2DataSize
+	}
+	return userDataSize
+}
+
+// UserDataReader presents the 2048-byte user data area of every sector in
+// a disc image as one contiguous cooked image, stripping sync patterns,
+// headers, subheaders, error correction and subchannel data. It lets a known
+// sector layout override the size-based guess used by Open. Mode 2 Form 2
+// sectors contribute only their first 2048 bytes so that logical block
+// addresses still line up; ReadSector returns their full payload.
+type UserDataReader struct {
+	reader     io.ReaderAt
+	sectorSize int64
+	dataOffset int64
+	sectors    int64
+}
+
+// NewUserDataReader wraps an image of the given size whose sectors are
+// sectorSize bytes: 2048 (cooked), 2336 (Mode 2 without sync and header),
+// 2352 (raw) or 2448 (raw plus subchannel). For raw layouts the mode byte of
+// sector 16, where the PVD lives, decides between Mode 1 and Mode 2 offsets.
+func NewUserDataReader(reader io.ReaderAt, size int64, sectorSize int) (*UserDataReader, error) {
+	udr := &UserDataReader{reader: reader, sectorSize: int64(sectorSize)}
+
+	switch sectorSize {
+	case userDataSize:
+		udr.dataOffset = 0
+	case 2336:
+		udr.dataOffset = 8 // Mode 2 subheader
+	case 2352, 2448:
+		udr.dataOffset = rawDataOffset(reader, int64(sectorSize))
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidBlock, sectorSize)
+	}
+
+	udr.sectors = size / udr.sectorSize
+	if size%udr.sectorSize >= udr.dataOffset+userDataSize {
+		udr.sectors++ // Trailing sector holds a full user data area
+	}
+	return udr, nil
+}
+
+// rawDataOffset returns where user data starts in a raw sector: 16 for
+// Mode 1, 24 for Mode 2 (after the 8-byte subheader).
+func rawDataOffset(reader io.ReaderAt, sectorSize int64) int64 {
+	for _, sector := range []int64{16, 0} {
+		mode := make([]byte, 1)
+		if _, err := reader.ReadAt(mode, sector*sectorSize+15); err == nil {
+			if mode[0] == 2 {
+				return 24
+			}
+			if mode[0] == 1 {
+				return 16
+			}
+		}
+	}
+	return 16
+}
+
+// ReadSector returns the complete user data area of a sector along with its
+// form. Mode 2 sectors (2336-byte or raw layouts) are classified by the
+// submode byte of their subheader, so Form 2 sectors yield 2324 bytes.
+func (udr *UserDataReader) ReadSector(sector int64) ([]byte, SectorForm, error) {
+	if sector < 0 || sector >= udr.sectors {
+		return nil, SectorFormNone, fmt.Errorf("%w: sector %d outside %d-sector image",
+			ErrInvalidISO, sector, udr.sectors)
+	}
+
+	start := sector * udr.sectorSize
+	form := SectorFormNone
+	if udr.mode2() {
+		subheader := make([]byte, 8)
+		if _, err := udr.reader.ReadAt(subheader, start+udr.dataOffset-8); err != nil {
+			return nil, SectorFormNone, fmt.Errorf("read sector %d subheader: %w", sector, err)
+		}
+		form = SectorForm1
+		if subheader[2]&submodeForm2 != 0 {
+			form = SectorForm2
+		}
+	}
+
+	data := make([]byte, form.UserDataSize())
+	n, err := udr.reader.ReadAt(data, start+udr.dataOffset)
+	if err != nil && !(err == io.EOF && n == len(data)) {
+		return nil, form, fmt.Errorf("read sector %d: %w", sector, err)
+	}
+	return data, form, nil
+}
+
+// mode2 reports whether sectors carry a Mode 2 subheader before user data.
+func (udr *UserDataReader) mode2() bool {
+	return udr.dataOffset == 8 || udr.dataOffset == 24
+}
+
+// Size returns the size of the cooked image in bytes.
+func (udr *UserDataReader) Size() int64 {
+	return udr.sectors * userDataSize
+}
+
+// SectorSize returns the sector size of the underlying image.
+func (udr *UserDataReader) SectorSize() int {
+	return int(udr.sectorSize)
+}
+
+// ReadAt reads cooked user data starting at off.
+func (udr *UserDataReader) ReadAt(dest []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("%w: negative offset %d", ErrInvalidISO, off)
+	}
+
+	total := 0
+	for total < len(dest) {
+		pos := off + int64(total)
+		if pos >= udr.Size() {
+			return total, io.EOF
+		}
+		sector, inSector := pos/userDataSize, pos%userDataSize
+		want := min(int64(len(dest)-total), userDataSize-inSector)
+
+		n, err := udr.reader.ReadAt(dest[total:total+int(want)], sector*udr.sectorSize+udr.dataOffset+inSector)
+		total += n
+		if err != nil {
+			if err == io.EOF && int64(n) == want {
+				continue
+			}
+			return total, err //nolint:wrapcheck // Pass through io.EOF from the underlying reader
+		}
+	}
+	return total, nil
+}
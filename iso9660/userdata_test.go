@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package iso9660
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ZaparooProject/go-gameid/internal/testiso"
+)
+
+func TestUserDataReader(t *testing.T) {
+	t.Parallel()
+
+	cooked := make([]byte, 3*2048)
+	for i := range cooked {
+		cooked[i] = byte(i / 7)
+	}
+
+	tests := []struct {
+		name       string
+		sectorSize int
+		dataOffset int
+		mode       byte
+	}{
+		{name: "cooked", sectorSize: 2048},
+		{name: "mode 2 form 1 without header", sectorSize: 2336, dataOffset: 8},
+		{name: "raw mode 1", sectorSize: 2352, dataOffset: 16, mode: 1},
+		{name: "raw mode 2", sectorSize: 2352, dataOffset: 24, mode: 2},
+		{name: "raw with subchannel", sectorSize: 2448, dataOffset: 24, mode: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			image := make([]byte, 3*tt.sectorSize)
+			for sector := range 3 {
+				dst := image[sector*tt.sectorSize:]
+				if tt.mode != 0 {
+					dst[15] = tt.mode
+				}
+				copy(dst[tt.dataOffset:tt.dataOffset+2048], cooked[sector*2048:])
+			}
+
+			udr, err := NewUserDataReader(bytes.NewReader(image), int64(len(image)), tt.sectorSize)
+			if err != nil {
+				t.Fatalf("NewUserDataReader() error = %v", err)
+			}
+			if udr.Size() != int64(len(cooked)) {
+				t.Errorf("Size() = %d, want %d", udr.Size(), len(cooked))
+			}
+
+			// A read straddling the first sector boundary
+			buf := make([]byte, 100)
+			if _, err := udr.ReadAt(buf, 2000); err != nil {
+				t.Fatalf("ReadAt() error = %v", err)
+			}
+			if !bytes.Equal(buf, cooked[2000:2100]) {
+				t.Error("ReadAt() across a sector boundary returned wrong data")
+			}
+
+			n, err := udr.ReadAt(make([]byte, 200), udr.Size()-100)
+			if n != 100 || !errors.Is(err, io.EOF) {
+				t.Errorf("ReadAt() at end = %d, %v; want 100, io.EOF", n, err)
+			}
+		})
+	}
+}
+
+func TestUserDataReader_InvalidSectorSize(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewUserDataReader(bytes.NewReader(nil), 0, 2000); !errors.Is(err, ErrInvalidBlock) {
+		t.Errorf("NewUserDataReader() error = %v, want ErrInvalidBlock", err)
+	}
+}
+
+// rawMode2Sector wraps user data in a raw Mode 2 sector whose subheader
+// carries the given submode byte.
+func rawMode2Sector(data []byte, submode byte) []byte {
+	sector := make([]byte, 2352)
+	sector[0] = 0x00
+	for i := 1; i < 11; i++ {
+		sector[i] = 0xFF
+	}
+	sector[15] = 2
+	sector[18], sector[22] = submode, submode
+	copy(sector[24:], data)
+	return sector
+}
+
+func TestUserDataReader_Mode2Forms(t *testing.T) {
+	t.Parallel()
+
+	content := bytes.Repeat([]byte("PSX XA FORM1 "), 300) // Spans two sectors
+	cooked := testiso.CreateTree(t, "PSXDISC", []testiso.TreeFile{
+		{Path: "DATA.BIN;1", Data: content},
+	})
+
+	var image []byte
+	for off := 0; off < len(cooked); off += 2048 {
+		image = append(image, rawMode2Sector(cooked[off:off+2048], 0x08)...)
+	}
+	xa := bytes.Repeat([]byte{0xA5}, 2324)
+	image = append(image, rawMode2Sector(xa, 0x24)...)
+
+	udr, err := NewUserDataReader(bytes.NewReader(image), int64(len(image)), 2352)
+	if err != nil {
+		t.Fatalf("NewUserDataReader() error = %v", err)
+	}
+
+	iso, err := OpenReader(udr, udr.Size())
+	if err != nil {
+		t.Fatalf("OpenReader() over Mode 2 Form 1 sectors error = %v", err)
+	}
+	got, err := iso.ReadFileByPath("/DATA.BIN")
+	if err != nil {
+		t.Fatalf("ReadFileByPath() error = %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Error("ReadFileByPath() returned wrong data across Mode 2 Form 1 sectors")
+	}
+
+	data, form, err := udr.ReadSector(16)
+	if err != nil {
+		t.Fatalf("ReadSector(16) error = %v", err)
+	}
+	if form != SectorForm1 || len(data) != 2048 || !bytes.Equal(data, cooked[16*2048:17*2048]) {
+		t.Errorf("ReadSector(16) = %d bytes, form %d; want the 2048-byte Form 1 PVD", len(data), form)
+	}
+
+	last := int64(len(cooked) / 2048)
+	data, form, err = udr.ReadSector(last)
+	if err != nil {
+		t.Fatalf("ReadSector(%d) error = %v", last, err)
+	}
+	if form != SectorForm2 || !bytes.Equal(data, xa) {
+		t.Errorf("ReadSector(%d) = %d bytes, form %d; want 2324 bytes of Form 2 data", last, len(data), form)
+	}
+
+	if _, _, err := udr.ReadSector(last + 1); !errors.Is(err, ErrInvalidISO) {
+		t.Errorf("ReadSector() past end error = %v, want ErrInvalidISO", err)
+	}
+}
+
+func TestUserDataReader_ReadSectorMode1(t *testing.T) {
+	t.Parallel()
+
+	image := make([]byte, 2352)
+	image[15] = 1
+	copy(image[16:], bytes.Repeat([]byte{0x5A}, 2048))
+	udr, err := NewUserDataReader(bytes.NewReader(image), int64(len(image)), 2352)
+	if err != nil {
+		t.Fatalf("NewUserDataReader() error = %v", err)
+	}
+	data, form, err := udr.ReadSector(0)
+	if err != nil {
+		t.Fatalf("ReadSector(0) error = %v", err)
+	}
+	if form != SectorFormNone || !bytes.Equal(data, image[16:16+2048]) {
+		t.Errorf("ReadSector(0) = %d bytes, form %d; want 2048 bytes of Mode 1 data", len(data), form)
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ZaparooProject/go-gameid/archive"
+	"github.com/ZaparooProject/go-gameid/identifier"
+	"github.com/ZaparooProject/go-gameid/iso9660"
+)
+
+// TitleSource is an alias for identifier.TitleSource for convenience.
+type TitleSource = identifier.TitleSource
+
+// Re-export title source constants for convenience.
+const (
+	TitleDatabaseFirst    = identifier.TitleDatabaseFirst
+	TitleRomFirst         = identifier.TitleRomFirst
+	TitleFilenameFallback = identifier.TitleFilenameFallback
+)
+
+// IdentifyOptions adjusts how IdentifyWithOptions and DetectConsoleWithOptions
+// read a file. The zero value behaves like Identify and DetectConsole.
+type IdentifyOptions struct {
+	// GenesisRegion picks which Genesis header title becomes InternalTitle
+	// and, without a database title, Title, as
+	// identifier.GenesisIdentifier.PreferredRegion does: the domestic
+	// (Japanese) title for RegionJapan and the overseas title for any other
+	// region. The zero value, RegionUnknown, matches Identify.
+	GenesisRegion identifier.Region
+
+	// SectorSize forces the sector size of a plain disc image: 2048, 2336
+	// (Mode 2 without sync and header), 2352 (raw) or 2448 (raw plus
+	// subchannel). The offset of the user data within each sector follows
+	// from it. Zero keeps the size-based guess. CUE sheets, CHD files,
+	// archives, directories and block devices describe their own layout and
+	// ignore it.
+	SectorSize int
+
+	// ErrorOnDBMiss makes IdentifyWithOptions return a GameNotFoundError,
+	// alongside the header-only result, when a database is given but has no
+	// entry for the game. Without a database it has no effect, nor for
+	// consoles the database has no table for (3DS, FDS, NDS and Wii). The
+	// result cache is bypassed, since a cached result would skip the lookup.
+	ErrorOnDBMiss bool
+
+	// CleanTitles tidies the title of GB, GBC and SNES games that the
+	// database has no entry for: the raw upper-case header title is
+	// title-cased and known run-together words are split, so "SUPER
+	// MARIOLAND" becomes "Super Mario Land". InternalTitle keeps the raw form.
+	CleanTitles bool
+
+	// Strict makes IdentifyWithOptions fail with an InvalidFormatError when
+	// a cartridge fails a header check its console would refuse to boot
+	// with, which identification otherwise tolerates: the Nintendo logo and
+	// header checksum of GB, GBC and GBA ROMs, the first word of N64 ROMs
+	// and the ROM checksum of SNES games.
+	Strict bool
+
+	// HeaderOnly makes GB, GBC, SNES and Genesis identification read only
+	// the cartridge header instead of the whole file, for quickly scanning
+	// large collections. Metadata computed over the whole ROM, such as
+	// GB's global_checksum_actual and Genesis's checksum_actual, is left
+	// out. Archive members, other consoles and a forced SectorSize are
+	// identified as usual, and results are not taken from or added to the
+	// result cache.
+	HeaderOnly bool
+
+	// TitleSource picks which title wins when a game has several: the
+	// database's, the one read from the game, or the file name without its
+	// extension. The zero value, TitleDatabaseFirst, matches Identify.
+	// CleanTitles applies afterwards to a title read from the game.
+	TitleSource TitleSource
+}
+
+// GameNotFoundError is returned by IdentifyWithOptions with ErrorOnDBMiss set
+// when the file is a valid dump for Console but the database has no entry for
+// it. ID is the game ID read from the file and may be empty.
+type GameNotFoundError struct {
+	Console identifier.Console
+	ID      string
+}
+
+func (e GameNotFoundError) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("game not found in database for console %s", e.Console)
+	}
+	return fmt.Sprintf("game %s not found in database for console %s", e.ID, e.Console)
+}
+
+// lookupRecorder wraps a database and remembers whether any lookup matched.
+type lookupRecorder struct {
+	identifier.Database
+	found bool
+}
+
+func (r *lookupRecorder) Lookup(console identifier.Console, key any) (map[string]string, bool) {
+	entry, found := r.Database.Lookup(console, key)
+	r.found = r.found || found
+	return entry, found
+}
+
+func (r *lookupRecorder) LookupByString(console identifier.Console, key string) (map[string]string, bool) {
+	entry, found := r.Database.LookupByString(console, key)
+	r.found = r.found || found
+	return entry, found
+}
+
+// forcesSectorSize reports whether the options override the sector layout
+// for path.
+func (opts IdentifyOptions) forcesSectorSize(path string) bool {
+	if opts.SectorSize == 0 || isBlockDevice(path) {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".cue", ".chd":
+		return false
+	}
+	if archivePath, err := archive.ParsePath(path); err != nil || archivePath != nil {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// IdentifyWithOptions is Identify with IdentifyOptions applied. With
+// ErrorOnDBMiss set, a database miss returns both the result and a
+// GameNotFoundError.
+func IdentifyWithOptions(path string, db *GameDatabase, opts IdentifyOptions) (*Result, error) {
+	var dbInterface identifier.Database
+	var recorder *lookupRecorder
+	if db != nil {
+		dbInterface = db
+		if opts.ErrorOnDBMiss {
+			recorder = &lookupRecorder{Database: db}
+			dbInterface = recorder
+		}
+	}
+
+	result, err := identifyWithOptions(path, dbInterface, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Strict {
+		if err := validateStrict(path, result.Console); err != nil {
+			return nil, err
+		}
+	}
+	setResultPath(result, path)
+	if opts.GenesisRegion != identifier.RegionUnknown && result.Console == ConsoleGenesis {
+		applyGenesisRegion(result, opts.GenesisRegion)
+	}
+	if opts.TitleSource != TitleDatabaseFirst {
+		result.ApplyTitleSource(opts.TitleSource, fileTitle(path))
+	}
+	if opts.CleanTitles {
+		applyCleanTitle(result)
+	}
+	if recorder != nil && !recorder.found && consoleField(result.Console) != "" {
+		return result, GameNotFoundError{Console: result.Console, ID: result.ID}
+	}
+	return result, nil
+}
+
+// applyGenesisRegion retitles a Genesis result from the header title shown
+// in region.
+func applyGenesisRegion(result *Result, region identifier.Region) {
+	result.InternalTitle = identifier.GenesisTitleForRegion(region,
+		result.Metadata["title_domestic"], result.Metadata["title_overseas"])
+	result.SetROMTitle(result.InternalTitle)
+}
+
+func identifyWithOptions(path string, dbInterface identifier.Database, opts IdentifyOptions) (*Result, error) {
+	if !opts.forcesSectorSize(path) {
+		if opts.HeaderOnly {
+			if result, handled, err := identifyHeaderOnly(path, dbInterface); handled {
+				return result, err
+			}
+		}
+		return identifyPath(path, dbInterface)
+	}
+
+	console, err := DetectConsoleWithOptions(path, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect console: %w", err)
+	}
+
+	id, ok := lookupIdentifier(console)
+	if !ok {
+		return nil, identifier.ErrNotSupported{Format: string(console)}
+	}
+
+	file, cooked, err := openUserData(path, opts.SectorSize)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	result, err := id.Identify(cooked, cooked.Size(), dbInterface)
+	if err != nil {
+		return nil, fmt.Errorf("identify: %w", err)
+	}
+	return result, nil
+}
+
+// identifyHeaderOnly identifies a plain cartridge file from its header alone
+// when its console's identifier supports it. handled is false when path
+// needs the regular identification path.
+func identifyHeaderOnly(path string, dbInterface identifier.Database) (result *Result, handled bool, err error) {
+	if isBlockDevice(path) {
+		return nil, false, nil
+	}
+	if archivePath, parseErr := archive.ParsePath(path); parseErr != nil || archivePath != nil {
+		return nil, false, nil
+	}
+
+	console, err := DetectConsole(path)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to detect console: %w", err)
+	}
+	id, ok := lookupIdentifier(console)
+	if !ok {
+		return nil, false, nil
+	}
+	headerID, ok := id.(identifier.HeaderIdentifier)
+	if !ok {
+		return nil, false, nil
+	}
+
+	file, err := os.Open(path) //nolint:gosec // Path from user input is expected
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, false, nil
+	}
+
+	result, err = headerID.IdentifyHeader(file, info.Size(), dbInterface)
+	if err != nil {
+		return nil, true, fmt.Errorf("identify: %w", err)
+	}
+	return result, true, nil
+}
+
+// validateStrict runs the strict checks of console's identifier, if it has
+// any, over the file at path or the archive member it names.
+func validateStrict(path string, console Console) error {
+	id, ok := lookupIdentifier(console)
+	if !ok {
+		return nil
+	}
+	strict, ok := id.(identifier.StrictValidator)
+	if !ok {
+		return nil
+	}
+
+	var reader io.ReaderAt
+	var size int64
+	archivePath, err := archive.ParsePath(path)
+	if err != nil {
+		return fmt.Errorf("parse archive path: %w", err)
+	}
+	if archivePath != nil {
+		member, memberSize, _, closeMember, openErr := openArchiveMember(archivePath)
+		if openErr != nil {
+			return openErr
+		}
+		defer closeMember()
+		reader, size = member, memberSize
+	} else {
+		file, openErr := os.Open(path) //nolint:gosec // Path from user input is expected
+		if openErr != nil {
+			return fmt.Errorf("open file: %w", openErr)
+		}
+		defer func() { _ = file.Close() }()
+		info, statErr := file.Stat()
+		if statErr != nil {
+			return fmt.Errorf("stat file: %w", statErr)
+		}
+		reader, size = file, info.Size()
+	}
+
+	if err := strict.ValidateStrict(reader, size); err != nil {
+		return fmt.Errorf("strict validation: %w", err)
+	}
+	return nil
+}
+
+// DetectConsoleWithOptions is DetectConsole with IdentifyOptions applied.
+func DetectConsoleWithOptions(path string, opts IdentifyOptions) (identifier.Console, error) {
+	if !opts.forcesSectorSize(path) {
+		return DetectConsole(path)
+	}
+
+	file, cooked, err := openUserData(path, opts.SectorSize)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	header := make([]byte, 0x1000)
+	bytesRead, _ := cooked.ReadAt(header, 0)
+	if console, ok := detectConsoleFromMagic(header[:bytesRead]); ok {
+		return console, nil
+	}
+
+	iso, err := iso9660.OpenReader(cooked, cooked.Size())
+	if err != nil {
+		return "", fmt.Errorf("open %d-byte sector image as ISO: %w", opts.SectorSize, err)
+	}
+	defer func() { _ = iso.Close() }()
+
+	return detectConsoleFromISO(iso)
+}
+
+// openUserData opens a disc image with a known sector size and returns a
+// cooked 2048-byte sector view of it. The caller closes the file.
+func openUserData(path string, sectorSize int) (*os.File, *iso9660.UserDataReader, error) {
+	file, err := os.Open(path) //nolint:gosec // Path from user input is expected
+	if err != nil {
+		return nil, nil, fmt.Errorf("open file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("stat file: %w", err)
+	}
+
+	cooked, err := iso9660.NewUserDataReader(file, info.Size(), sectorSize)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("sector size override: %w", err)
+	}
+	return file, cooked, nil
+}
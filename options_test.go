@@ -0,0 +1,395 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ZaparooProject/go-gameid/identifier"
+	"github.com/ZaparooProject/go-gameid/internal/testiso"
+	"github.com/ZaparooProject/go-gameid/iso9660"
+)
+
+// sectorImage lays cooked 2048-byte sectors out in sectorSize-byte sectors
+// with user data at dataOffset. Raw layouts get a sync pattern and mode byte.
+func sectorImage(cooked []byte, sectorSize, dataOffset int, mode byte) []byte {
+	sectors := (len(cooked) + 2047) / 2048
+	out := make([]byte, sectors*sectorSize)
+	for sector := range sectors {
+		dst := out[sector*sectorSize : (sector+1)*sectorSize]
+		if sectorSize >= 2352 {
+			copy(dst, []byte{0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x00})
+			dst[15] = mode
+		}
+		copy(dst[dataOffset:dataOffset+2048], cooked[sector*2048:min((sector+1)*2048, len(cooked))])
+	}
+	return out
+}
+
+func TestIdentifyWithOptions_SectorSize(t *testing.T) {
+	t.Parallel()
+
+	ps2ISO := testiso.CreateMinimal(t, "PS2GAME", "PLAYSTATION", "", []testiso.File{
+		{Name: "SYSTEM.CNF;1", Data: []byte("BOOT2 = cdrom0:\\SLUS_200.62;1\r\n")},
+	})
+
+	// Pad a raw image to a whole number of 2048-byte blocks so the size-based
+	// guess picks the wrong sector size.
+	misleading := sectorImage(ps2ISO, 2352, 24, 2)
+	misleading = append(misleading, make([]byte, 2048-len(misleading)%2048)...)
+
+	tests := []struct {
+		name       string
+		data       []byte
+		sectorSize int
+	}{
+		{name: "2352 mode 2 sized like 2048", data: misleading, sectorSize: 2352},
+		{name: "2352 mode 1", data: sectorImage(ps2ISO, 2352, 16, 1), sectorSize: 2352},
+		{name: "2336 mode 2", data: sectorImage(ps2ISO, 2336, 8, 2), sectorSize: 2336},
+		{name: "2448 with subchannel", data: sectorImage(ps2ISO, 2448, 24, 2), sectorSize: 2448},
+		{name: "2048 cooked", data: ps2ISO, sectorSize: 2048},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "game.iso")
+			if err := os.WriteFile(path, tt.data, 0o600); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			opts := IdentifyOptions{SectorSize: tt.sectorSize}
+			console, err := DetectConsoleWithOptions(path, opts)
+			if err != nil {
+				t.Fatalf("DetectConsoleWithOptions() error = %v", err)
+			}
+			if console != identifier.ConsolePS2 {
+				t.Errorf("DetectConsoleWithOptions() = %v, want %v", console, identifier.ConsolePS2)
+			}
+
+			result, err := IdentifyWithOptions(path, nil, opts)
+			if err != nil {
+				t.Fatalf("IdentifyWithOptions() error = %v", err)
+			}
+			if result.ID != "SLUS-20062" {
+				t.Errorf("IdentifyWithOptions() ID = %q, want %q", result.ID, "SLUS-20062")
+			}
+		})
+	}
+}
+
+// TestIdentifyWithOptions_GuessMisreads documents why the override exists:
+// without it the padded raw image is read as 2048-byte sectors.
+func TestIdentifyWithOptions_GuessMisreads(t *testing.T) {
+	t.Parallel()
+
+	ps2ISO := testiso.CreateMinimal(t, "PS2GAME", "PLAYSTATION", "", []testiso.File{
+		{Name: "SYSTEM.CNF;1", Data: []byte("BOOT2 = cdrom0:\\SLUS_200.62;1\r\n")},
+	})
+	data := sectorImage(ps2ISO, 2352, 24, 2)
+	data = append(data, make([]byte, 2048-len(data)%2048)...)
+
+	path := filepath.Join(t.TempDir(), "game.iso")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	iso, err := iso9660.Open(path)
+	if err == nil {
+		defer func() { _ = iso.Close() }()
+		if iso.BlockSize() != 2048 {
+			t.Fatalf("BlockSize() = %d, want the 2048 guess", iso.BlockSize())
+		}
+	}
+	if result, err := Identify(path, nil); err == nil && result.ID == "SLUS-20062" {
+		t.Error("Identify() read the raw image correctly without an override; test image no longer misleads")
+	}
+}
+
+func TestIdentifyWithOptions_InvalidSectorSize(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "game.iso")
+	if err := os.WriteFile(path, make([]byte, 4096), 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	_, err := DetectConsoleWithOptions(path, IdentifyOptions{SectorSize: 1000})
+	if !errors.Is(err, iso9660.ErrInvalidBlock) {
+		t.Errorf("DetectConsoleWithOptions() error = %v, want ErrInvalidBlock", err)
+	}
+}
+
+func TestIdentifyWithOptions_ErrorOnDBMiss(t *testing.T) {
+	t.Parallel()
+
+	path := createTestGBAFile(t, t.TempDir())
+
+	hit := NewDatabase()
+	if err := hit.AddEntry(ConsoleGBA, "ATST", map[string]string{"title": "Test Game"}); err != nil {
+		t.Fatalf("AddEntry() error = %v", err)
+	}
+	miss := NewDatabase()
+	if err := miss.AddEntry(ConsoleGBA, "AXXX", map[string]string{"title": "Other Game"}); err != nil {
+		t.Fatalf("AddEntry() error = %v", err)
+	}
+
+	tests := []struct {
+		db       *GameDatabase
+		name     string
+		opts     IdentifyOptions
+		wantMiss bool
+	}{
+		{name: "miss with flag", db: miss, opts: IdentifyOptions{ErrorOnDBMiss: true}, wantMiss: true},
+		{name: "miss without flag", db: miss, opts: IdentifyOptions{}},
+		{name: "hit with flag", db: hit, opts: IdentifyOptions{ErrorOnDBMiss: true}},
+		{name: "no database with flag", db: nil, opts: IdentifyOptions{ErrorOnDBMiss: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := IdentifyWithOptions(path, tt.db, tt.opts)
+			if result == nil || result.ID != "ATST" {
+				t.Fatalf("IdentifyWithOptions() result = %+v, want ID ATST", result)
+			}
+
+			var notFound GameNotFoundError
+			if !tt.wantMiss {
+				if err != nil {
+					t.Fatalf("IdentifyWithOptions() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.As(err, &notFound) {
+				t.Fatalf("IdentifyWithOptions() error = %v, want GameNotFoundError", err)
+			}
+			if notFound.Console != ConsoleGBA || notFound.ID != "ATST" {
+				t.Errorf("GameNotFoundError = %+v, want {GBA ATST}", notFound)
+			}
+		})
+	}
+}
+
+// TestIdentifyWithOptions_ErrorOnDBMissNoTable checks that consoles the
+// database has no table for are not reported as missing from it.
+func TestIdentifyWithOptions_ErrorOnDBMissNoTable(t *testing.T) {
+	t.Parallel()
+
+	// Wii magic is 0x5D1C9EA3 at offset 0x18
+	header := make([]byte, 0x440)
+	copy(header, "RSBE01")
+	copy(header[0x18:], []byte{0x5D, 0x1C, 0x9E, 0xA3})
+	path := filepath.Join(t.TempDir(), "game.iso")
+	if err := os.WriteFile(path, header, 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	result, err := IdentifyWithOptions(path, NewDatabase(), IdentifyOptions{ErrorOnDBMiss: true})
+	if err != nil {
+		t.Fatalf("IdentifyWithOptions() error = %v, want nil", err)
+	}
+	if result.Console != ConsoleWii {
+		t.Errorf("Console = %v, want %v", result.Console, ConsoleWii)
+	}
+}
+
+func TestIdentifyWithOptions_TitleSource(t *testing.T) {
+	t.Parallel()
+
+	path := createTestGBAFile(t, t.TempDir())
+
+	db := NewDatabase()
+	if err := db.AddEntry(ConsoleGBA, "ATST", map[string]string{"title": "Test Game"}); err != nil {
+		t.Fatalf("AddEntry() error = %v", err)
+	}
+
+	tests := []struct {
+		db     *GameDatabase
+		name   string
+		want   string
+		source TitleSource
+	}{
+		{name: "database first", db: db, source: TitleDatabaseFirst, want: "Test Game"},
+		{name: "database first without database", source: TitleDatabaseFirst, want: "TESTGAME"},
+		{name: "rom first", db: db, source: TitleRomFirst, want: "TESTGAME"},
+		{name: "filename fallback", db: db, source: TitleFilenameFallback, want: "Test Game"},
+		{name: "filename fallback without database", source: TitleFilenameFallback, want: "test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := IdentifyWithOptions(path, tt.db, IdentifyOptions{TitleSource: tt.source})
+			if err != nil {
+				t.Fatalf("IdentifyWithOptions() error = %v", err)
+			}
+			if result.Title != tt.want {
+				t.Errorf("Title = %q, want %q", result.Title, tt.want)
+			}
+			if result.InternalTitle != "TESTGAME" {
+				t.Errorf("InternalTitle = %q, want %q", result.InternalTitle, "TESTGAME")
+			}
+		})
+	}
+}
+
+func TestIdentifyWithOptions_GenesisRegion(t *testing.T) {
+	t.Parallel()
+
+	rom := make([]byte, 0x4000)
+	copy(rom[0x100:], "SEGA MEGA DRIVE ")
+	copy(rom[0x120:], fmt.Sprintf("%-48s", "PUYO PUYO"))
+	copy(rom[0x150:], fmt.Sprintf("%-48s", "DR. ROBOTNIK'S MEAN BEAN MACHINE"))
+	path := filepath.Join(t.TempDir(), "game.md")
+	if err := os.WriteFile(path, rom, 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		region identifier.Region
+		want   string
+	}{
+		{name: "japan", region: identifier.RegionJapan, want: "PUYO PUYO"},
+		{name: "usa", region: identifier.RegionUSA, want: "DR. ROBOTNIK'S MEAN BEAN MACHINE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := IdentifyWithOptions(path, nil, IdentifyOptions{GenesisRegion: tt.region})
+			if err != nil {
+				t.Fatalf("IdentifyWithOptions() error = %v", err)
+			}
+			if result.InternalTitle != tt.want {
+				t.Errorf("InternalTitle = %q, want %q", result.InternalTitle, tt.want)
+			}
+			if result.Title != tt.want {
+				t.Errorf("Title = %q, want %q", result.Title, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentifyWithOptions_ErrorOnDBMissUnsupported(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("not a game"), 0o600); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	_, err := IdentifyWithOptions(path, NewDatabase(), IdentifyOptions{ErrorOnDBMiss: true})
+	if err == nil {
+		t.Fatal("IdentifyWithOptions() error = nil, want an error")
+	}
+	var notFound GameNotFoundError
+	if errors.As(err, &notFound) {
+		t.Errorf("IdentifyWithOptions() error = %v, want a detection error, not GameNotFoundError", err)
+	}
+}
+
+func TestIdentifyWithOptions_Strict(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	valid := createTestGBAFile(t, dir)
+	rom, err := os.ReadFile(valid) //nolint:gosec // Test file in a temp dir
+	if err != nil {
+		t.Fatalf("read ROM: %v", err)
+	}
+	// Fill in the header complement check the test ROM leaves at zero
+	check := byte(0)
+	for _, b := range rom[0xA0:0xBD] {
+		check -= b
+	}
+	rom[0xBD] = check - 0x19
+	if err := os.WriteFile(valid, rom, 0o600); err != nil {
+		t.Fatalf("write ROM: %v", err)
+	}
+	rom[0x20] ^= 0xFF
+	corrupted := filepath.Join(dir, "corrupted.gba")
+	if err := os.WriteFile(corrupted, rom, 0o600); err != nil {
+		t.Fatalf("write ROM: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		strict  bool
+		wantErr bool
+	}{
+		{name: "valid strict", path: valid, strict: true},
+		{name: "corrupted logo strict", path: corrupted, strict: true, wantErr: true},
+		{name: "corrupted logo lenient", path: corrupted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := IdentifyWithOptions(tt.path, nil, IdentifyOptions{Strict: tt.strict})
+			if !tt.wantErr {
+				if err != nil || result == nil || result.ID != "ATST" {
+					t.Fatalf("IdentifyWithOptions() = %+v, %v; want ID ATST", result, err)
+				}
+				return
+			}
+			var invalid identifier.InvalidFormatError
+			if !errors.As(err, &invalid) || invalid.Console != ConsoleGBA {
+				t.Fatalf("IdentifyWithOptions() error = %v, want a GBA InvalidFormatError", err)
+			}
+			if result != nil {
+				t.Errorf("IdentifyWithOptions() result = %+v, want nil", result)
+			}
+		})
+	}
+}
+
+func TestIdentifyWithOptions_HeaderOnly(t *testing.T) {
+	t.Parallel()
+
+	const path = "testdata/Genesis/240pSuite-1.23.bin"
+	full, err := IdentifyWithOptions(path, nil, IdentifyOptions{})
+	if err != nil {
+		t.Fatalf("IdentifyWithOptions() error = %v", err)
+	}
+	header, err := IdentifyWithOptions(path, nil, IdentifyOptions{HeaderOnly: true})
+	if err != nil {
+		t.Fatalf("IdentifyWithOptions(HeaderOnly) error = %v", err)
+	}
+
+	if header.ID != full.ID || header.Title != full.Title || header.Path != path {
+		t.Errorf("HeaderOnly result = %+v, want the ID and title of %+v", header, full)
+	}
+	if _, ok := full.Metadata["checksum_actual"]; !ok {
+		t.Error("full identification is missing checksum_actual")
+	}
+	if _, ok := header.Metadata["checksum_actual"]; ok {
+		t.Error("HeaderOnly identification computed checksum_actual")
+	}
+}
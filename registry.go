@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// DetectFunc reports which console a file belongs to. Detectors registered
+// with RegisterDetector receive the path of every file with their extension.
+type DetectFunc func(path string) (Console, error)
+
+// registry holds identifiers and detectors added at runtime by packages
+// outside this module. The built-in tables are never modified.
+var registry = struct {
+	identifiers map[Console]identifier.Identifier
+	detectors   map[string]DetectFunc
+	extensions  map[string]Console
+	mu          sync.RWMutex
+}{
+	identifiers: make(map[Console]identifier.Identifier),
+	detectors:   make(map[string]DetectFunc),
+	extensions:  make(map[string]Console),
+}
+
+// RegisterIdentifier makes id available for console in Identify,
+// IdentifyWithConsole and ParseConsole. Each of extensions (".xyz";
+// case-insensitive, the leading dot is optional) is mapped to console for
+// DetectConsole, DetectConsoleFromExtension and ExtensionConsoleMap. It is
+// meant to be called from an init function, and panics if id is nil, console
+// already has an identifier or an extension is already mapped.
+func RegisterIdentifier(console Console, id identifier.Identifier, extensions ...string) {
+	if console == "" || id == nil {
+		panic("gameid: RegisterIdentifier called with an empty console or nil identifier")
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	_, builtin := identifiers[console]
+	if _, registered := registry.identifiers[console]; builtin || registered {
+		panic(fmt.Sprintf("gameid: RegisterIdentifier called twice for console %s", console))
+	}
+	normalized := make([]string, 0, len(extensions))
+	for _, ext := range extensions {
+		ext = normalizeExtension(ext)
+		_, mapped := registry.extensions[ext]
+		if ext == "." || mapped || isBuiltinExtension(ext) || slices.Contains(normalized, ext) {
+			panic(fmt.Sprintf("gameid: RegisterIdentifier called with an empty or mapped extension %q", ext))
+		}
+		normalized = append(normalized, ext)
+	}
+	registry.identifiers[console] = id
+	for _, ext := range normalized {
+		registry.extensions[ext] = console
+	}
+	detections.clear()
+}
+
+// RegisterDetector makes DetectConsole call fn for files with extension ext
+// (".xyz"; case-insensitive, the leading dot is optional). A registered
+// detector runs before the built-in detection for its extension; if it fails,
+// built-in detection is still tried. It panics if fn is nil or ext already has
+// a registered detector.
+func RegisterDetector(ext string, fn DetectFunc) {
+	ext = normalizeExtension(ext)
+	if ext == "." || fn == nil {
+		panic("gameid: RegisterDetector called with an empty extension or nil function")
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, exists := registry.detectors[ext]; exists {
+		panic("gameid: RegisterDetector called twice for extension " + ext)
+	}
+	registry.detectors[ext] = fn
+	detections.clear()
+}
+
+// lookupIdentifier returns the built-in or registered identifier for console.
+func lookupIdentifier(console Console) (identifier.Identifier, bool) {
+	if id, ok := identifiers[console]; ok {
+		return id, true
+	}
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	id, ok := registry.identifiers[console]
+	return id, ok
+}
+
+// lookupDetector returns the registered detector for ext, if any.
+func lookupDetector(ext string) (DetectFunc, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	fn, ok := registry.detectors[ext]
+	return fn, ok
+}
+
+// registeredConsoles returns the consoles added with RegisterIdentifier, sorted
+// by name.
+func registeredConsoles() []Console {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	consoles := make([]Console, 0, len(registry.identifiers))
+	for console := range registry.identifiers {
+		consoles = append(consoles, console)
+	}
+	slices.Sort(consoles)
+	return consoles
+}
+
+// normalizeExtension lower-cases ext and ensures it starts with a dot.
+func normalizeExtension(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/ZaparooProject/go-gameid/archive"
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+const consoleMyConsole Console = "MyConsole"
+
+// errNotMine is returned by the test detector registered for .srl files.
+var errNotMine = errors.New("not a MyConsole ROM")
+
+// registerTestPlugins registers the test identifier and detectors once, since
+// registrations last for the life of the process (including -count runs).
+var registerTestPlugins = sync.OnceFunc(func() {
+	RegisterIdentifier(consoleMyConsole, myConsoleIdentifier{}, "MYX")
+	RegisterDetector("MYC", func(string) (Console, error) { return consoleMyConsole, nil })
+	RegisterDetector(".srl", func(string) (Console, error) { return "", errNotMine })
+})
+
+// myConsoleIdentifier is an out-of-tree identifier that reads a 4-byte ID.
+type myConsoleIdentifier struct{}
+
+func (myConsoleIdentifier) Console() Console { return consoleMyConsole }
+
+func (myConsoleIdentifier) Identify(reader io.ReaderAt, _ int64, _ identifier.Database) (*Result, error) {
+	buf := make([]byte, 4)
+	if _, err := reader.ReadAt(buf, 0); err != nil {
+		return nil, err //nolint:wrapcheck // test identifier
+	}
+	result := identifier.NewResult(consoleMyConsole)
+	result.SetMetadata("ID", string(buf))
+	return result, nil
+}
+
+func TestRegisterIdentifier_DrivesIdentify(t *testing.T) {
+	t.Parallel()
+
+	registerTestPlugins()
+
+	path := filepath.Join(t.TempDir(), "game.myc")
+	if err := os.WriteFile(path, []byte("MYC1 rest of the rom"), 0o600); err != nil {
+		t.Fatalf("write ROM: %v", err)
+	}
+
+	result, err := Identify(path, nil)
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if result.Console != consoleMyConsole || result.ID != "MYC1" {
+		t.Errorf("Identify() = %s/%q, want %s/%q", result.Console, result.ID, consoleMyConsole, "MYC1")
+	}
+
+	console, err := ParseConsole("myconsole")
+	if err != nil || console != consoleMyConsole {
+		t.Errorf("ParseConsole(%q) = %q, %v; want %q", "myconsole", console, err, consoleMyConsole)
+	}
+	if !HasSupportedExtension("other.MYC") {
+		t.Error("HasSupportedExtension() = false for a registered extension")
+	}
+	if console, err := DetectConsoleFromExtension("game.myx"); err != nil || console != consoleMyConsole {
+		t.Errorf("DetectConsoleFromExtension() = %q, %v; want %q", console, err, consoleMyConsole)
+	}
+	if got := ConsoleExtensions(consoleMyConsole); !slices.Equal(got, []string{".myx"}) {
+		t.Errorf("ConsoleExtensions() = %v, want [.myx]", got)
+	}
+}
+
+func TestRegisterDetector_FailureFallsBackToBuiltin(t *testing.T) {
+	t.Parallel()
+
+	registerTestPlugins()
+
+	path := filepath.Join(t.TempDir(), "game.srl")
+	if err := os.WriteFile(path, make([]byte, 0xC0), 0o600); err != nil {
+		t.Fatalf("write ROM: %v", err)
+	}
+	console, err := DetectConsole(path)
+	if err != nil || console != ConsoleGBA {
+		t.Errorf("DetectConsole() = %q, %v; want %q", console, err, ConsoleGBA)
+	}
+}
+
+func TestRegisterIdentifier_PanicsOnDuplicate(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterIdentifier() did not panic for a built-in console")
+		}
+	}()
+	RegisterIdentifier(ConsoleGB, myConsoleIdentifier{})
+}
+
+func TestRegisterIdentifier_PanicsOnMappedExtension(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterIdentifier() did not panic for a built-in extension")
+		}
+	}()
+	RegisterIdentifier("OtherConsole", myConsoleIdentifier{}, ".gba")
+}
+
+// TestExtensionConsoleMap_CoversEveryIdentifier keeps the extension table in
+// step with the identifiers: every built-in or registered console must be
+// reachable from at least one extension.
+func TestExtensionConsoleMap_CoversEveryIdentifier(t *testing.T) {
+	t.Parallel()
+
+	registerTestPlugins()
+
+	extensions := ExtensionConsoleMap()
+	for _, console := range append(slices.Clone(AllConsoles), registeredConsoles()...) {
+		if len(ConsoleExtensions(console)) == 0 {
+			t.Errorf("console %s has no extension in ExtensionConsoleMap()", console)
+		}
+	}
+	for ext, consoles := range extensions {
+		for _, console := range consoles {
+			if _, ok := lookupIdentifier(console); !ok {
+				t.Errorf("extension %s maps to %s, which has no identifier", ext, console)
+			}
+		}
+		detected, err := DetectConsoleFromExtension("game" + ext)
+		switch {
+		case len(consoles) == 1 && (err != nil || detected != consoles[0]):
+			t.Errorf("DetectConsoleFromExtension(%s) = %q, %v; want %q", ext, detected, err, consoles[0])
+		case len(consoles) > 1 && err == nil:
+			t.Errorf("DetectConsoleFromExtension(%s) = %q for an ambiguous extension", ext, detected)
+		}
+	}
+
+	extensions[".gb"][0] = ConsoleNES
+	if again := ExtensionConsoleMap(); again[".gb"][0] != ConsoleGB {
+		t.Error("ExtensionConsoleMap() returned a map shared with the table")
+	}
+}
+
+func TestSupportedExtensions(t *testing.T) {
+	t.Parallel()
+
+	registerTestPlugins()
+
+	extensions := SupportedExtensions()
+	if !slices.IsSorted(extensions) || len(slices.Compact(slices.Clone(extensions))) != len(extensions) {
+		t.Errorf("SupportedExtensions() = %v, want sorted without duplicates", extensions)
+	}
+	for _, want := range []string{".gba", ".sfc", ".chd", ".cue", ".iso", ".zip", ".7z", ".rar", ".myx", ".myc"} {
+		if !slices.Contains(extensions, want) {
+			t.Errorf("SupportedExtensions() is missing %s", want)
+		}
+	}
+
+	// Stay in sync with what detection and archive handling accept
+	for ext := range ExtensionConsoleMap() {
+		if !slices.Contains(extensions, ext) {
+			t.Errorf("SupportedExtensions() is missing %s from ExtensionConsoleMap()", ext)
+		}
+	}
+	for _, ext := range extensions {
+		if !HasSupportedExtension("game"+ext) && !archive.IsArchiveExtension(ext) {
+			t.Errorf("SupportedExtensions() lists %s, which is neither detected nor an archive", ext)
+		}
+	}
+}
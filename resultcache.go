@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"crypto/sha1" //nolint:gosec // SHA-1 fingerprints file contents, not for security
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"sync"
+
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// ResultCache remembers identification results by file contents, so a scan
+// that meets the same dump again, in another archive or directory or on a
+// later run, skips identifying it. Keys are "<console>:<sha1 hex>". The
+// cache is handed copies and must return results the caller may modify.
+// Implementations must be safe for concurrent use; MemoryResultCache keeps
+// entries in memory, and a disk-backed cache can store results as JSON.
+type ResultCache interface {
+	Get(key string) (*Result, bool)
+	Put(key string, result *Result)
+}
+
+// MemoryResultCache is an unbounded, in-memory ResultCache.
+type MemoryResultCache struct {
+	entries map[string]*Result
+	mu      sync.RWMutex
+}
+
+// NewMemoryResultCache returns an empty in-memory result cache.
+func NewMemoryResultCache() *MemoryResultCache {
+	return &MemoryResultCache{entries: make(map[string]*Result)}
+}
+
+// Get returns a copy of the result stored under key.
+func (c *MemoryResultCache) Get(key string) (*Result, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return cloneResult(result), true
+}
+
+// Put stores result under key.
+func (c *MemoryResultCache) Put(key string, result *Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = result
+}
+
+// Len returns the number of cached results.
+func (c *MemoryResultCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// maxCachedSize is the largest file the result cache hashes. No cartridge is
+// bigger, and the identifiers reject oversized files without reading them.
+const maxCachedSize = 64 << 20
+
+// results is the cache installed by SetResultCache.
+var results struct {
+	cache ResultCache
+	mu    sync.RWMutex
+}
+
+// SetResultCache makes Identify, IdentifyWithConsole, DetectAndIdentify and
+// the archive and reader variants consult cache before identifying a
+// cartridge game, keyed on the console and a SHA-1 of the ROM. Disc images
+// are never cached: hashing one costs more than identifying it. Computing
+// the key reads the whole ROM, even for consoles whose identifier only needs
+// the header, so a cache pays off only when the same dumps come up again;
+// files larger than any cartridge (64 MiB) are identified without it.
+// IdentifyWithOptions with ErrorOnDBMiss also bypasses the cache, since it
+// must see the database lookups. Cached results reflect the database in use
+// when they were stored, so install a new cache after switching databases.
+// nil, the default, disables caching.
+func SetResultCache(cache ResultCache) {
+	results.mu.Lock()
+	defer results.mu.Unlock()
+	results.cache = cache
+}
+
+func currentResultCache() ResultCache {
+	results.mu.RLock()
+	defer results.mu.RUnlock()
+	return results.cache
+}
+
+// identifyCached is id.Identify through the installed result cache.
+func identifyCached(
+	id identifier.Identifier, console Console, reader io.ReaderAt, size int64, database identifier.Database,
+) (*Result, error) {
+	cache := currentResultCache()
+	_, recording := database.(*lookupRecorder)
+	if cache == nil || !IsCartridgeBased(console) || size > maxCachedSize || recording {
+		return id.Identify(reader, size, database) //nolint:wrapcheck // Callers wrap identifier errors
+	}
+
+	key, err := resultCacheKey(console, reader, size)
+	if err != nil {
+		return id.Identify(reader, size, database) //nolint:wrapcheck // Callers wrap identifier errors
+	}
+	if cached, ok := cache.Get(key); ok {
+		return cached, nil
+	}
+
+	result, err := id.Identify(reader, size, database)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Callers wrap identifier errors
+	}
+	cache.Put(key, cloneResult(result))
+	return result, nil
+}
+
+// resultCacheKey hashes the size bytes of reader into a ResultCache key.
+func resultCacheKey(console Console, reader io.ReaderAt, size int64) (string, error) {
+	hash := sha1.New() //nolint:gosec // Content fingerprint, not for security
+	if _, err := io.Copy(hash, io.NewSectionReader(reader, 0, size)); err != nil {
+		return "", fmt.Errorf("hash contents: %w", err)
+	}
+	return string(console) + ":" + hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// cloneResult returns a copy of result that shares no metadata map with it.
+func cloneResult(result *Result) *Result {
+	clone := *result
+	clone.Metadata = maps.Clone(result.Metadata)
+	return &clone
+}
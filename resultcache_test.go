@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// countingResultCache wraps a MemoryResultCache and counts hits.
+type countingResultCache struct {
+	*MemoryResultCache
+	hits int
+}
+
+func (c *countingResultCache) Get(key string) (*Result, bool) {
+	result, ok := c.MemoryResultCache.Get(key)
+	if ok {
+		c.hits++
+	}
+	return result, ok
+}
+
+// TestResultCache checks that identical bytes under another name and inside
+// an archive are served from the cache. It installs the package-level cache,
+// so it can't run in parallel.
+//
+//nolint:paralleltest // SetResultCache is global
+func TestResultCache(t *testing.T) {
+	cache := &countingResultCache{MemoryResultCache: NewMemoryResultCache()}
+	SetResultCache(cache)
+	t.Cleanup(func() { SetResultCache(nil) })
+
+	tmpDir := t.TempDir()
+	first := createTestGBAFile(t, tmpDir)
+	rom, err := os.ReadFile(first)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	second := filepath.Join(t.TempDir(), "copy.gba")
+	if err := os.WriteFile(second, rom, 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	zipped := writeTestZIP(t, map[string][]byte{"game.gba": rom})
+
+	result, err := Identify(first, nil)
+	if err != nil {
+		t.Fatalf("Identify(%s) error = %v", first, err)
+	}
+	if cache.hits != 0 || cache.Len() != 1 {
+		t.Fatalf("after first identify: hits = %d, entries = %d; want 0, 1", cache.hits, cache.Len())
+	}
+	result.Metadata["note"] = "changed by caller"
+
+	for _, path := range []string{second, zipped} {
+		cached, err := Identify(path, nil)
+		if err != nil {
+			t.Fatalf("Identify(%s) error = %v", path, err)
+		}
+		if cached.ID != "ATST" {
+			t.Errorf("Identify(%s) ID = %q, want ATST", path, cached.ID)
+		}
+		if cached.Path != path {
+			t.Errorf("Identify(%s) Path = %q, want the path it was called with", path, cached.Path)
+		}
+		if _, ok := cached.Metadata["note"]; ok {
+			t.Error("caller's change to an earlier result leaked into the cache")
+		}
+	}
+	if cache.hits != 2 || cache.Len() != 1 {
+		t.Errorf("hits = %d, entries = %d; want 2, 1", cache.hits, cache.Len())
+	}
+}
+
+// TestResultCache_ErrorOnDBMiss checks that a game found in the database is
+// still found the second time round, when a plain Identify has cached it.
+//
+//nolint:paralleltest // SetResultCache is global
+func TestResultCache_ErrorOnDBMiss(t *testing.T) {
+	SetResultCache(NewMemoryResultCache())
+	t.Cleanup(func() { SetResultCache(nil) })
+
+	path := createTestGBAFile(t, t.TempDir())
+	db := NewDatabase()
+	if err := db.AddEntry(ConsoleGBA, "ATST", map[string]string{"title": "Test Game"}); err != nil {
+		t.Fatalf("AddEntry() error = %v", err)
+	}
+	if _, err := Identify(path, db); err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+
+	for i := range 2 {
+		result, err := IdentifyWithOptions(path, db, IdentifyOptions{ErrorOnDBMiss: true})
+		if err != nil {
+			t.Fatalf("IdentifyWithOptions() call %d error = %v", i+1, err)
+		}
+		if result.Title != "Test Game" {
+			t.Errorf("IdentifyWithOptions() call %d Title = %q, want %q", i+1, result.Title, "Test Game")
+		}
+	}
+}
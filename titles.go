@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/ZaparooProject/go-gameid/identifier"
+)
+
+// titleFixups replaces words that cartridge headers run together or
+// abbreviate to fit their fixed-width title fields.
+var titleFixups = map[string]string{
+	"DONKEYKONG": "Donkey Kong",
+	"KIRBYS":     "Kirby's",
+	"MARIOKART":  "Mario Kart",
+	"MARIOLAND":  "Mario Land",
+	"MARIOWORLD": "Mario World",
+	"MEGAMAN":    "Mega Man",
+	"TETRISDX":   "Tetris DX",
+	"WARIOLAND":  "Wario Land",
+	"YOSHIS":     "Yoshi's",
+}
+
+// keepUpperWords stay upper case when a title is title-cased: roman
+// numerals and common acronyms.
+var keepUpperWords = map[string]bool{
+	"II": true, "III": true, "IV": true, "VI": true, "VII": true, "VIII": true, "IX": true, "XI": true,
+	"DX": true, "GB": true, "GBC": true, "SD": true, "NBA": true, "NFL": true, "NHL": true,
+	"WWF": true, "FIFA": true, "USA": true, "RPG": true, "TMNT": true,
+}
+
+// lowerWords are lower-cased unless they start a title.
+var lowerWords = map[string]bool{
+	"A": true, "AN": true, "AND": true, "OF": true, "THE": true, "IN": true, "ON": true, "TO": true,
+}
+
+// cleanTitlesConsoles are the consoles whose headers store titles in raw
+// upper case, which CleanTitles rewrites.
+var cleanTitlesConsoles = map[identifier.Console]bool{
+	identifier.ConsoleGB:   true,
+	identifier.ConsoleGBC:  true,
+	identifier.ConsoleSNES: true,
+}
+
+// fileTitle returns the last element of path without its extension, the
+// title TitleFilenameFallback falls back to.
+func fileTitle(path string) string {
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// applyCleanTitle replaces the title of a GB, GBC or SNES result that fell
+// back to the raw internal title with a cleaned-up version. InternalTitle is
+// left as read from the header.
+func applyCleanTitle(result *Result) {
+	if result == nil || !cleanTitlesConsoles[result.Console] {
+		return
+	}
+	if result.Title != "" && result.Title != result.InternalTitle {
+		return // Title came from the database
+	}
+	result.Title = cleanTitle(result.InternalTitle)
+}
+
+// cleanTitle title-cases an upper-case header title, splitting run-together
+// words it knows about. Roman numerals and acronyms stay upper case and
+// short connecting words are lower-cased.
+func cleanTitle(raw string) string {
+	words := strings.Fields(raw)
+	out := make([]string, 0, len(words))
+	for _, word := range words {
+		if fixed, ok := titleFixups[strings.ToUpper(word)]; ok {
+			out = append(out, fixed)
+			continue
+		}
+		upper := strings.ToUpper(word)
+		switch {
+		case keepUpperWords[upper]:
+			out = append(out, upper)
+		case lowerWords[upper] && len(out) > 0:
+			out = append(out, strings.ToLower(word))
+		default:
+			out = append(out, titleCaseWord(word))
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// titleCaseWord upper-cases the first letter of word and lower-cases the
+// rest, also capitalizing letters after a hyphen ("X-MEN" becomes "X-Men").
+// Leading digits don't use up the capital ("3D" stays "3D").
+func titleCaseWord(word string) string {
+	runes := []rune(strings.ToLower(word))
+	capitalize := true
+	for i, r := range runes {
+		if capitalize && r >= 'a' && r <= 'z' {
+			runes[i] = r - 'a' + 'A'
+		}
+		isLetter := r >= 'a' && r <= 'z'
+		capitalize = r == '-' || r == '.' || (capitalize && !isLetter)
+	}
+	return string(runes)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Niema Moshiri and The Zaparoo Project.
+// SPDX-License-Identifier: GPL-3.0-or-later
+//
+// This file is part of go-gameid.
+//
+// go-gameid is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-gameid is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-gameid.  If not, see <https://www.gnu.org/licenses/>.
+
+package gameid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCleanTitle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"SUPER MARIOLAND", "Super Mario Land"},
+		{"POKEMON RED", "Pokemon Red"},
+		{"TETRIS DX", "Tetris DX"},
+		{"LEGEND OF THE MYSTICAL NINJA", "Legend of the Mystical Ninja"},
+		{"THE LION KING", "The Lion King"},
+		{"FINAL FANTASY III", "Final Fantasy III"},
+		{"X-MEN", "X-Men"},
+		{"3D POOL", "3D Pool"},
+		{"KIRBYS DREAMLAND", "Kirby's Dreamland"},
+		{"  ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := cleanTitle(tt.raw); got != tt.want {
+			t.Errorf("cleanTitle(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestIdentifyWithOptions_CleanTitles(t *testing.T) {
+	t.Parallel()
+
+	rom := make([]byte, 0x8000)
+	copy(rom[0x134:], "SUPER MARIO")
+	path := filepath.Join(t.TempDir(), "game.gb")
+	if err := os.WriteFile(path, rom, 0o600); err != nil {
+		t.Fatalf("Failed to write test ROM: %v", err)
+	}
+
+	raw, err := IdentifyWithOptions(path, nil, IdentifyOptions{})
+	if err != nil {
+		t.Fatalf("IdentifyWithOptions() error = %v", err)
+	}
+	if raw.Title != "SUPER MARIO" {
+		t.Errorf("Title without CleanTitles = %q, want the raw header title", raw.Title)
+	}
+
+	cleaned, err := IdentifyWithOptions(path, nil, IdentifyOptions{CleanTitles: true})
+	if err != nil {
+		t.Fatalf("IdentifyWithOptions() error = %v", err)
+	}
+	if cleaned.Title != "Super Mario" || cleaned.InternalTitle != "SUPER MARIO" {
+		t.Errorf("Title, InternalTitle = %q, %q; want %q, %q",
+			cleaned.Title, cleaned.InternalTitle, "Super Mario", "SUPER MARIO")
+	}
+
+	fromDB := &Result{Console: ConsoleSNES, Title: "Super Mario World", InternalTitle: "SUPER MARIOWORLD"}
+	applyCleanTitle(fromDB)
+	if fromDB.Title != "Super Mario World" {
+		t.Errorf("Title from the database = %q, want it unchanged", fromDB.Title)
+	}
+	other := &Result{Console: ConsoleGBA, Title: "ZELDA", InternalTitle: "ZELDA"}
+	applyCleanTitle(other)
+	if other.Title != "ZELDA" {
+		t.Errorf("GBA Title = %q, want it unchanged", other.Title)
+	}
+}